@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/config"
 	configAPI "github.com/newrelic/newrelic-cli/internal/config/api"
 	diagnose "github.com/newrelic/newrelic-cli/internal/diagnose"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
 	"github.com/newrelic/newrelic-cli/internal/output"
 	"github.com/newrelic/newrelic-cli/internal/split"
 	"github.com/newrelic/newrelic-cli/internal/utils"
@@ -19,6 +22,8 @@ import (
 var (
 	outputFormat string
 	outputPlain  bool
+	spinnerStyle string
+	spinnerFreq  time.Duration
 )
 
 // Command represents the base command when called without any subcommands
@@ -89,10 +94,13 @@ func init() {
 	Command.PersistentFlags().BoolVar(&outputPlain, "plain", false, "output compact text")
 	Command.PersistentFlags().BoolVar(&config.FlagDebug, "debug", false, "debug level logging")
 	Command.PersistentFlags().BoolVar(&config.FlagTrace, "trace", false, "trace level logging")
+	Command.PersistentFlags().StringVar(&spinnerStyle, "spinner", "", "the style of progress spinners, as a character set number or none to print progress as plain lines. Can be set with "+ux.EnvSpinner)
+	Command.PersistentFlags().DurationVar(&spinnerFreq, "spinnerInterval", 0, "how often progress spinners are redrawn, such as 250ms. Can be set with "+ux.EnvSpinnerInterval)
 	Command.PersistentFlags().IntVarP(&config.FlagAccountID, "accountId", "a", 0, "the account ID to use. Can be overridden by setting NEW_RELIC_ACCOUNT_ID")
 }
 
 func initConfig() {
 	utils.LogIfError(output.SetFormat(output.ParseFormat(outputFormat)))
 	utils.LogIfError(output.SetPrettyPrint(!outputPlain))
+	utils.LogIfError(ux.ConfigureSpinner(spinnerStyle, spinnerFreq))
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	log "github.com/sirupsen/logrus"
@@ -25,6 +26,7 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/nerdstorage"
 	"github.com/newrelic/newrelic-cli/internal/nrql"
 	"github.com/newrelic/newrelic-cli/internal/profile"
+	"github.com/newrelic/newrelic-cli/internal/recipes"
 	"github.com/newrelic/newrelic-cli/internal/reporting"
 	"github.com/newrelic/newrelic-cli/internal/synthetics"
 	"github.com/newrelic/newrelic-cli/internal/utils"
@@ -52,6 +54,7 @@ func init() {
 	Command.AddCommand(nerdstorage.Command)
 	Command.AddCommand(nrql.Command)
 	Command.AddCommand(profile.Command)
+	Command.AddCommand(recipes.Command)
 	Command.AddCommand(reporting.Command)
 	Command.AddCommand(utils.Command)
 	Command.AddCommand(workload.Command)
@@ -64,6 +67,12 @@ func init() {
 func main() {
 	if err := Execute(); err != nil {
 		if err != flag.ErrHelp {
+			var exitErr *install.ExitError
+			if errors.As(err, &exitErr) {
+				log.Error(err)
+				os.Exit(exitErr.ExitCode())
+			}
+
 			log.Fatal(err)
 		}
 	}
@@ -474,6 +474,13 @@ func TestGetConfigFieldDefinition(t *testing.T) {
 	require.Equal(t, config.TernaryValues.Unknown, fd.Default)
 	require.Equal(t, "NEW_RELIC_CLI_SENDUSAGEDATA", fd.EnvVar)
 	require.Contains(t, getFunctionName(fd.SetValidationFunc), "IsTernary")
+
+	fd = GetConfigFieldDefinition(config.InstallTelemetry)
+	require.NotNil(t, fd)
+	require.Equal(t, config.InstallTelemetry, fd.Key)
+	require.Equal(t, config.TernaryValues.Unknown, fd.Default)
+	require.Equal(t, "NEW_RELIC_CLI_INSTALLTELEMETRY", fd.EnvVar)
+	require.Contains(t, getFunctionName(fd.SetValidationFunc), "IsTernary")
 }
 
 func TestForEachConfigFieldDefinition(t *testing.T) {
@@ -483,7 +490,7 @@ func TestForEachConfigFieldDefinition(t *testing.T) {
 	}
 
 	ForEachConfigFieldDefinition(fn)
-	require.Equal(t, 4, count)
+	require.Equal(t, 5, count)
 }
 
 func TestForEachProfileFieldDefinition(t *testing.T) {
@@ -19,6 +19,7 @@ const (
 	PluginDir          FieldKey = "plugindir"
 	PreReleaseFeatures FieldKey = "prereleasefeatures"
 	SendUsageData      FieldKey = "sendUsageData"
+	InstallTelemetry   FieldKey = "installTelemetry"
 
 	DefaultProfileName = "default"
 
@@ -123,6 +124,12 @@ func InitializeConfigStore() {
 				SetValidationFunc: IsTernary(),
 				Default:           TernaryValues.Unknown,
 			},
+			FieldDefinition{
+				Key:               InstallTelemetry,
+				EnvVar:            "NEW_RELIC_CLI_INSTALLTELEMETRY",
+				SetValidationFunc: IsTernary(),
+				Default:           TernaryValues.Unknown,
+			},
 		),
 	)
 
@@ -2,10 +2,13 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -16,11 +19,15 @@ const (
 
 	// DefaultLogFile is the default log file
 	DefaultLogFile = "newrelic-cli.log"
+
+	// installLogTimeFormat is the timestamp format used in install log file names
+	installLogTimeFormat = "20060102-150405"
 )
 
 var (
 	fileHookConfigured = false
 	Logger             = log.StandardLogger()
+	installLog         *InstallLogHook
 )
 
 func InitLogger(logger *log.Logger, logLevel string) {
@@ -120,6 +127,71 @@ func (hook *LogrusFileHook) Levels() []log.Level {
 	}
 }
 
+// InstallLogHook writes log entries, and any output written to it, to the log file of a
+// single install so it can be shared as one artifact when asking for support.
+type InstallLogHook struct {
+	mu        sync.Mutex
+	file      *os.File
+	formatter *log.JSONFormatter
+}
+
+// InitInstallLogFile creates a log file for the current install in the CLI's base path,
+// and returns its path. Log entries are captured at the file log level.
+func InitInstallLogFile() (string, error) {
+	if installLog != nil {
+		return installLog.file.Name(), nil
+	}
+
+	if err := os.MkdirAll(BasePath, 0750); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(BasePath, fmt.Sprintf("install-%s.log", time.Now().Format(installLogTimeFormat)))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return "", err
+	}
+
+	installLog = &InstallLogHook{
+		file:      file,
+		formatter: &log.JSONFormatter{},
+	}
+	log.StandardLogger().Hooks.Add(installLog)
+
+	return path, nil
+}
+
+// InstallLogWriter returns a writer to the current install's log file, or one that
+// discards its output when no install log has been created.
+func InstallLogWriter() io.Writer {
+	if installLog == nil {
+		return ioutil.Discard
+	}
+
+	return installLog
+}
+
+func (hook *InstallLogHook) Write(p []byte) (int, error) {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+
+	return hook.file.Write(p)
+}
+
+func (hook *InstallLogHook) Fire(entry *log.Entry) error {
+	line, err := hook.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = hook.Write(line)
+	return err
+}
+
+func (hook *InstallLogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
 func getLevelFromString(logLevel string, defaultLevel log.Level) log.Level {
 	switch level := strings.ToUpper(logLevel); level {
 	case "TRACE":
@@ -0,0 +1,66 @@
+package install
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/accounts"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	configAPI "github.com/newrelic/newrelic-cli/internal/config/api"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// accountLister lists the accounts the profile's user API key has access to.
+type accountLister interface {
+	ListAccounts(params accounts.ListAccountsParams) ([]accounts.AccountOutline, error)
+}
+
+// selectAccount asks which account to install into when the profile's user API key has access to
+// more than one, unless the account is given with --accountId or NEW_RELIC_ACCOUNT_ID, or the
+// install doesn't prompt. The license key, the recipes' variables and the links to the installed
+// entities are then those of the selected account.
+func selectAccount(ic types.InstallerContext, lister accountLister, prompter Prompter) error {
+	if ic.AssumeYes || config.FlagAccountID != 0 || os.Getenv("NEW_RELIC_ACCOUNT_ID") != "" {
+		return nil
+	}
+
+	list, err := lister.ListAccounts(accounts.ListAccountsParams{
+		Scope: &accounts.RegionScopeTypes.IN_REGION,
+	})
+	if err != nil {
+		log.Debugf("could not list the accounts of the user API key: %s", err)
+		return nil
+	}
+
+	if len(list) < 2 {
+		return nil
+	}
+
+	profileAccountID := configAPI.GetActiveProfileAccountID()
+	options := []string{}
+	defaultOption := ""
+	for _, a := range list {
+		option := fmt.Sprintf("%s (%d)", a.Name, a.ID)
+		options = append(options, option)
+		if a.ID == profileAccountID {
+			defaultOption = option
+		}
+	}
+
+	choice, err := prompter.Select("Which account should the data be sent to?", options, defaultOption)
+	if err != nil {
+		return err
+	}
+
+	for i, option := range options {
+		if option == choice {
+			config.FlagAccountID = list[i].ID
+			log.Debugf("installing into account %d", list[i].ID)
+		}
+	}
+
+	return nil
+}
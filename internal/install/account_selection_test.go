@@ -0,0 +1,62 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/accounts"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+type mockAccountLister struct {
+	accounts []accounts.AccountOutline
+}
+
+func (l *mockAccountLister) ListAccounts(params accounts.ListAccountsParams) ([]accounts.AccountOutline, error) {
+	return l.accounts, nil
+}
+
+func TestSelectAccountShouldSetSelectedAccount(t *testing.T) {
+	config.Init(t.TempDir())
+	t.Setenv("NEW_RELIC_ACCOUNT_ID", "")
+	defer func() {
+		config.FlagAccountID = 0
+	}()
+	lister := &mockAccountLister{accounts: []accounts.AccountOutline{{ID: 1, Name: "Production"}, {ID: 2, Name: "Staging"}}}
+	prompter := ux.NewMockPrompter()
+	prompter.PromptSelectVals["Which account should the data be sent to?"] = "Staging (2)"
+
+	err := selectAccount(types.InstallerContext{}, lister, prompter)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, config.FlagAccountID)
+}
+
+func TestSelectAccountShouldNotPromptForSingleAccount(t *testing.T) {
+	config.Init(t.TempDir())
+	t.Setenv("NEW_RELIC_ACCOUNT_ID", "")
+	lister := &mockAccountLister{accounts: []accounts.AccountOutline{{ID: 1, Name: "Production"}}}
+	prompter := ux.NewMockPrompter()
+
+	err := selectAccount(types.InstallerContext{}, lister, prompter)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptSelectCallCount)
+	assert.Equal(t, 0, config.FlagAccountID)
+}
+
+func TestSelectAccountShouldNotPromptWhenAccountIsGiven(t *testing.T) {
+	t.Setenv("NEW_RELIC_ACCOUNT_ID", "1")
+	lister := &mockAccountLister{accounts: []accounts.AccountOutline{{ID: 1, Name: "Production"}, {ID: 2, Name: "Staging"}}}
+	prompter := ux.NewMockPrompter()
+
+	err := selectAccount(types.InstallerContext{}, lister, prompter)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptSelectCallCount)
+}
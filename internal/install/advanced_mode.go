@@ -0,0 +1,132 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const (
+	defaultInfraAgentVersion           = "latest"
+	defaultInfraAgentConfigFile        = "/etc/newrelic-infra.yml"
+	defaultWindowsInfraAgentConfigFile = `C:\Program Files\New Relic\newrelic-infra\newrelic-infra.yml`
+)
+
+var infraAgentModes = []string{"ROOT", "PRIVILEGED", "UNPRIVILEGED"}
+
+// promptAdvancedOptions asks for the settings the guided install otherwise chooses itself: how the
+// infrastructure agent is installed, and how long and how many times each recipe is attempted.
+// Anything already provided with flags, variables or the environment isn't asked for.
+func (i *RecipeInstall) promptAdvancedOptions(m *types.DiscoveryManifest, availableRecipes recipes.RecipeDetectionResults) error {
+	if !i.Advanced || i.AssumeYes {
+		return nil
+	}
+
+	fmt.Println("\n  Advanced options")
+
+	if _, ok := availableRecipes.GetRecipeDetection(types.InfraAgentRecipeName); ok {
+		if err := i.promptInfraAgentOptions(m); err != nil {
+			return err
+		}
+	}
+
+	if i.RecipeTimeout == 0 {
+		answer, err := i.prompter.Input("Maximum time each recipe may take to install, e.g. 10m (leave empty for the recipe's own)", "")
+		if err != nil {
+			return err
+		}
+
+		if answer = strings.TrimSpace(answer); answer != "" {
+			timeout, err := time.ParseDuration(answer)
+			if err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid recipe timeout %q, expected a duration such as 10m", answer)
+			}
+			i.RecipeTimeout = timeout
+		}
+	}
+
+	if i.MaxRetries == 0 {
+		answer, err := i.prompter.Input("Number of times to retry a recipe that fails to install", "0")
+		if err != nil {
+			return err
+		}
+
+		retries, err := strconv.Atoi(strings.TrimSpace(answer))
+		if err != nil || retries < 0 {
+			return fmt.Errorf("invalid number of retries %q", answer)
+		}
+		i.MaxRetries = retries
+	}
+
+	fmt.Println()
+
+	return nil
+}
+
+func (i *RecipeInstall) promptInfraAgentOptions(m *types.DiscoveryManifest) error {
+	if !i.isRecipeVarProvided(execution.EnvInfraAgentVersion) {
+		version, err := i.prompter.Input("Infrastructure agent version to install", defaultInfraAgentVersion)
+		if err != nil {
+			return err
+		}
+		setAdvancedRecipeVar(execution.EnvInfraAgentVersion, version)
+	}
+
+	if !i.isRecipeVarProvided(execution.EnvNriaConfigFile) {
+		defaultConfigFile := defaultInfraAgentConfigFile
+		if m.OS == "windows" {
+			defaultConfigFile = defaultWindowsInfraAgentConfigFile
+		}
+
+		configFile, err := i.prompter.Input("Infrastructure agent configuration file", defaultConfigFile)
+		if err != nil {
+			return err
+		}
+		setAdvancedRecipeVar(execution.EnvNriaConfigFile, configFile)
+	}
+
+	// The agent always runs as a service account on Windows.
+	if m.OS != "windows" && !i.isRecipeVarProvided(execution.EnvNriaMode) {
+		mode, err := i.prompter.Select("User the infrastructure agent runs as", infraAgentModes, infraAgentModes[0])
+		if err != nil {
+			return err
+		}
+		setAdvancedRecipeVar(execution.EnvNriaMode, mode)
+	}
+
+	return nil
+}
+
+func (i *RecipeInstall) isRecipeVarProvided(name string) bool {
+	if _, ok := i.RecipeVars[name]; ok {
+		return true
+	}
+
+	if _, ok := i.RecipeFileVars[name]; ok {
+		return true
+	}
+
+	return os.Getenv(name) != ""
+}
+
+func setAdvancedRecipeVar(name string, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"name":  name,
+		"value": value,
+	}).Debug("using advanced option")
+
+	types.RecipeVariables[name] = value
+}
@@ -0,0 +1,93 @@
+package install
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+func TestPromptAdvancedOptionsShouldSetInfraAgentVars(t *testing.T) {
+	defer resetRecipeVariables()
+	infra := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.Advanced = true
+	prompter := ux.NewMockPrompter()
+	prompter.PromptInputVals["Infrastructure agent version to install"] = "1.40.0"
+	prompter.PromptSelectVals["User the infrastructure agent runs as"] = "UNPRIVILEGED"
+	prompter.PromptInputVals["Maximum time each recipe may take to install, e.g. 10m (leave empty for the recipe's own)"] = "5m"
+	prompter.PromptInputVals["Number of times to retry a recipe that fails to install"] = "2"
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptAdvancedOptions(&types.DiscoveryManifest{OS: "linux"}, recipes.RecipeDetectionResults{infra})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1.40.0", types.RecipeVariables[execution.EnvInfraAgentVersion])
+	assert.Equal(t, defaultInfraAgentConfigFile, types.RecipeVariables[execution.EnvNriaConfigFile])
+	assert.Equal(t, "UNPRIVILEGED", types.RecipeVariables[execution.EnvNriaMode])
+	assert.Equal(t, 5*time.Minute, recipeInstall.RecipeTimeout)
+	assert.Equal(t, 2, recipeInstall.MaxRetries)
+}
+
+func TestPromptAdvancedOptionsShouldNotAskForProvidedSettings(t *testing.T) {
+	defer resetRecipeVariables()
+	infra := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.Advanced = true
+	recipeInstall.RecipeVars = map[string]string{
+		execution.EnvInfraAgentVersion: "1.40.0",
+		execution.EnvNriaConfigFile:    "/opt/newrelic-infra.yml",
+		execution.EnvNriaMode:          "ROOT",
+	}
+	recipeInstall.RecipeTimeout = time.Minute
+	recipeInstall.MaxRetries = 1
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptAdvancedOptions(&types.DiscoveryManifest{OS: "linux"}, recipes.RecipeDetectionResults{infra})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptInputCallCount)
+	assert.Equal(t, 0, prompter.PromptSelectCallCount)
+}
+
+func TestPromptAdvancedOptionsShouldOnlyAskInAdvancedMode(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptAdvancedOptions(&types.DiscoveryManifest{}, recipes.RecipeDetectionResults{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptInputCallCount)
+}
+
+func TestPromptAdvancedOptionsShouldFailForInvalidTimeout(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.Advanced = true
+	prompter := ux.NewMockPrompter()
+	prompter.PromptInputVals["Maximum time each recipe may take to install, e.g. 10m (leave empty for the recipe's own)"] = "soon"
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptAdvancedOptions(&types.DiscoveryManifest{}, recipes.RecipeDetectionResults{})
+
+	assert.Error(t, err)
+}
+
+func resetRecipeVariables() {
+	for k := range types.RecipeVariables {
+		delete(types.RecipeVariables, k)
+	}
+}
@@ -0,0 +1,445 @@
+package install
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+)
+
+const (
+	ansibleAllGroup       = "all"
+	ansibleUngroupedGroup = "ungrouped"
+	// ansibleGroupsVar is the recipe variable listing the inventory groups of the host being installed.
+	ansibleGroupsVar = "ANSIBLE_GROUPS"
+)
+
+// AnsibleInventory is an Ansible INI inventory, used to select the hosts to install onto.
+type AnsibleInventory struct {
+	groups map[string]*ansibleGroup
+	hosts  map[string]map[string]string
+	// hostNames are in the order hosts appear in the inventory.
+	hostNames []string
+}
+
+type ansibleGroup struct {
+	hosts    []string
+	children []string
+	vars     map[string]string
+}
+
+// LoadAnsibleInventory reads an Ansible INI inventory with its groups, child groups,
+// group variables and host variables.
+func LoadAnsibleInventory(inventoryPath string) (*AnsibleInventory, error) {
+	file, err := os.Open(inventoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Ansible inventory %s: %w", inventoryPath, err)
+	}
+	defer file.Close()
+
+	inv := &AnsibleInventory{
+		groups: map[string]*ansibleGroup{},
+		hosts:  map[string]map[string]string{},
+	}
+
+	groupName, section := ansibleUngroupedGroup, "hosts"
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			groupName, section = line[1:len(line)-1], "hosts"
+			if i := strings.Index(groupName, ":"); i >= 0 {
+				groupName, section = groupName[:i], groupName[i+1:]
+			}
+
+			if section != "hosts" && section != "vars" && section != "children" {
+				return nil, fmt.Errorf("invalid section [%s] on line %d of %s", line[1:len(line)-1], lineNumber, inventoryPath)
+			}
+
+			inv.group(groupName)
+			continue
+		}
+
+		g := inv.group(groupName)
+		switch section {
+		case "children":
+			g.children = append(g.children, line)
+			inv.group(line)
+		case "vars":
+			key, value, ok := parseAnsibleVar(line)
+			if !ok {
+				return nil, fmt.Errorf("invalid variable on line %d of %s", lineNumber, inventoryPath)
+			}
+			g.vars[key] = value
+		default:
+			fields := strings.Fields(line)
+			hostVars := map[string]string{}
+			for _, field := range fields[1:] {
+				key, value, ok := parseAnsibleVar(field)
+				if !ok {
+					return nil, fmt.Errorf("invalid host variable %q on line %d of %s", field, lineNumber, inventoryPath)
+				}
+				hostVars[key] = value
+			}
+
+			names, err := expandAnsibleHostRange(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid host on line %d of %s: %w", lineNumber, inventoryPath, err)
+			}
+
+			for _, name := range names {
+				inv.addHost(g, name, hostVars)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read Ansible inventory %s: %w", inventoryPath, err)
+	}
+
+	return inv, nil
+}
+
+func (inv *AnsibleInventory) group(name string) *ansibleGroup {
+	g, ok := inv.groups[name]
+	if !ok {
+		g = &ansibleGroup{vars: map[string]string{}}
+		inv.groups[name] = g
+	}
+
+	return g
+}
+
+func (inv *AnsibleInventory) addHost(g *ansibleGroup, name string, hostVars map[string]string) {
+	vars, ok := inv.hosts[name]
+	if !ok {
+		vars = map[string]string{}
+		inv.hosts[name] = vars
+		inv.hostNames = append(inv.hostNames, name)
+	}
+
+	for k, v := range hostVars {
+		vars[k] = v
+	}
+
+	g.hosts = append(g.hosts, name)
+}
+
+// Targets returns the hosts matching limit, as Ansible's --limit would select them, with each host's
+// variables as recipe variables. The host's user defaults to defaultUser without an ansible_user.
+func (inv *AnsibleInventory) Targets(limit string, defaultUser string) ([]FleetTarget, error) {
+	selected := inv.selectHosts(limit)
+
+	targets := []FleetTarget{}
+	for _, name := range inv.hostNames {
+		if !selected[name] {
+			continue
+		}
+
+		target, err := inv.target(name, defaultUser)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no hosts in the Ansible inventory match %q", limit)
+	}
+
+	return targets, nil
+}
+
+func (inv *AnsibleInventory) target(name string, defaultUser string) (FleetTarget, error) {
+	groups := inv.hostGroups(name)
+
+	// Variables of parent groups are overridden by those of their children, then by the host's own.
+	vars := map[string]string{}
+	for _, g := range append([]string{ansibleAllGroup}, groups...) {
+		if group, ok := inv.groups[g]; ok {
+			for k, v := range group.vars {
+				vars[k] = v
+			}
+		}
+	}
+	for k, v := range inv.hosts[name] {
+		vars[k] = v
+	}
+
+	t := remote.Target{
+		User: firstNonEmpty(vars["ansible_user"], vars["ansible_ssh_user"], defaultUser),
+		Host: firstNonEmpty(vars["ansible_host"], vars["ansible_ssh_host"], name),
+		Port: 22,
+	}
+
+	if t.User == "" {
+		return FleetTarget{}, fmt.Errorf("no ansible_user set for host %s", name)
+	}
+
+	if port := firstNonEmpty(vars["ansible_port"], vars["ansible_ssh_port"]); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return FleetTarget{}, fmt.Errorf("invalid ansible_port %q for host %s", port, name)
+		}
+		t.Port = p
+	}
+
+	args := []string{}
+	if key := vars["ansible_ssh_private_key_file"]; key != "" {
+		args = append(args, "--sshKey="+key)
+	}
+
+	keys := []string{}
+	for k := range vars {
+		if !strings.HasPrefix(k, "ansible_") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		args = append(args, fmt.Sprintf("--var=%s=%s", strings.ToUpper(k), vars[k]))
+	}
+
+	memberOf := []string{}
+	for _, g := range groups {
+		if g != ansibleUngroupedGroup {
+			memberOf = append(memberOf, g)
+		}
+	}
+	if len(memberOf) > 0 {
+		args = append(args, fmt.Sprintf("--var=%s=%s", ansibleGroupsVar, strings.Join(memberOf, ",")))
+	}
+
+	return FleetTarget{Target: t.String(), Args: args}, nil
+}
+
+// hostGroups returns the groups the host belongs to, directly or through child groups,
+// with parent groups before their children.
+func (inv *AnsibleInventory) hostGroups(host string) []string {
+	depths := map[string]int{}
+	for name := range inv.groups {
+		if name != ansibleAllGroup && inv.groupHosts(name, map[string]bool{})[host] {
+			depths[name] = inv.groupDepth(name, map[string]bool{})
+		}
+	}
+
+	groups := make([]string, 0, len(depths))
+	for name := range depths {
+		groups = append(groups, name)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if depths[groups[i]] != depths[groups[j]] {
+			return depths[groups[i]] < depths[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+
+	return groups
+}
+
+// groupDepth is how many parent groups a group is nested under.
+func (inv *AnsibleInventory) groupDepth(name string, visited map[string]bool) int {
+	visited[name] = true
+
+	depth := 0
+	for parentName, parent := range inv.groups {
+		if visited[parentName] {
+			continue
+		}
+
+		for _, child := range parent.children {
+			if child == name {
+				if d := inv.groupDepth(parentName, visited) + 1; d > depth {
+					depth = d
+				}
+			}
+		}
+	}
+
+	return depth
+}
+
+// groupHosts returns the hosts of a group, including those of its child groups.
+func (inv *AnsibleInventory) groupHosts(name string, visited map[string]bool) map[string]bool {
+	hosts := map[string]bool{}
+	if name == ansibleAllGroup {
+		for _, h := range inv.hostNames {
+			hosts[h] = true
+		}
+		return hosts
+	}
+
+	g, ok := inv.groups[name]
+	if !ok || visited[name] {
+		return hosts
+	}
+	visited[name] = true
+
+	for _, h := range g.hosts {
+		hosts[h] = true
+	}
+
+	for _, child := range g.children {
+		for h := range inv.groupHosts(child, visited) {
+			hosts[h] = true
+		}
+	}
+
+	return hosts
+}
+
+// selectHosts returns the hosts matching a limit of patterns separated by commas or colons. Patterns
+// are group names, host names or wildcards, and may be prefixed with ! to exclude or & to intersect.
+// Without a pattern that isn't prefixed, the exclusions and intersections apply to all the hosts.
+func (inv *AnsibleInventory) selectHosts(limit string) map[string]bool {
+	if strings.TrimSpace(limit) == "" {
+		limit = ansibleAllGroup
+	}
+
+	selected := map[string]bool{}
+	excluded := map[string]bool{}
+	var intersect map[string]bool
+	positive := false
+
+	for _, pattern := range strings.FieldsFunc(limit, func(r rune) bool { return r == ',' || r == ':' }) {
+		pattern = strings.TrimSpace(pattern)
+
+		switch {
+		case strings.HasPrefix(pattern, "!"):
+			for h := range inv.matchHosts(pattern[1:]) {
+				excluded[h] = true
+			}
+		case strings.HasPrefix(pattern, "&"):
+			matched := inv.matchHosts(pattern[1:])
+			if intersect == nil {
+				intersect = matched
+				continue
+			}
+			for h := range intersect {
+				if !matched[h] {
+					delete(intersect, h)
+				}
+			}
+		default:
+			positive = true
+			for h := range inv.matchHosts(pattern) {
+				selected[h] = true
+			}
+		}
+	}
+
+	// A limit of only exclusions and intersections applies to all the hosts, as it does in Ansible.
+	if !positive {
+		selected = inv.matchHosts(ansibleAllGroup)
+	}
+
+	for h := range selected {
+		if excluded[h] || (intersect != nil && !intersect[h]) {
+			delete(selected, h)
+		}
+	}
+
+	return selected
+}
+
+func (inv *AnsibleInventory) matchHosts(pattern string) map[string]bool {
+	if pattern == "*" {
+		pattern = ansibleAllGroup
+	}
+
+	if _, ok := inv.groups[pattern]; ok || pattern == ansibleAllGroup {
+		return inv.groupHosts(pattern, map[string]bool{})
+	}
+
+	matched := map[string]bool{}
+	for _, h := range inv.hostNames {
+		if ok, _ := path.Match(pattern, h); ok {
+			matched[h] = true
+		}
+	}
+
+	for name := range inv.groups {
+		if ok, _ := path.Match(pattern, name); ok {
+			for h := range inv.groupHosts(name, map[string]bool{}) {
+				matched[h] = true
+			}
+		}
+	}
+
+	return matched
+}
+
+func parseAnsibleVar(s string) (string, string, bool) {
+	parts := strings.SplitN(s, "=", 2)
+	key := strings.TrimSpace(parts[0])
+	if len(parts) != 2 || key == "" {
+		return "", "", false
+	}
+
+	return key, unquoteAnsibleValue(strings.TrimSpace(parts[1])), true
+}
+
+func unquoteAnsibleValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
+
+// expandAnsibleHostRange expands numeric host ranges such as web[01:03].example.com.
+func expandAnsibleHostRange(host string) ([]string, error) {
+	start := strings.Index(host, "[")
+	end := strings.Index(host, "]")
+	if start < 0 || end < start {
+		return []string{host}, nil
+	}
+
+	bounds := strings.SplitN(host[start+1:end], ":", 2)
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid host range %s", host)
+	}
+
+	from, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid host range %s", host)
+	}
+
+	to, err := strconv.Atoi(bounds[1])
+	if err != nil || to < from {
+		return nil, fmt.Errorf("invalid host range %s", host)
+	}
+
+	hosts := []string{}
+	for n := from; n <= to; n++ {
+		hosts = append(hosts, fmt.Sprintf("%s%0*d%s", host[:start], len(bounds[0]), n, host[end+1:]))
+	}
+
+	return hosts, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,136 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAnsibleInventory = `
+bastion.example.com ansible_user=admin
+
+[webservers]
+web[01:02].example.com
+web-canary ansible_host=10.0.0.9 ansible_port=2222
+
+[dbservers]
+db1.example.com mysql_port=3307 ansible_ssh_private_key_file=~/.ssh/db.pem
+
+[dbservers:vars]
+mysql_port=3306
+mysql_username="newrelic"
+
+[production:children]
+webservers
+dbservers
+
+[production:vars]
+environment=production
+
+[all:vars]
+ansible_user=ubuntu
+`
+
+func writeTestAnsibleInventory(t *testing.T) *AnsibleInventory {
+	path := filepath.Join(t.TempDir(), "inventory.ini")
+	require.NoError(t, os.WriteFile(path, []byte(testAnsibleInventory), 0600))
+
+	inv, err := LoadAnsibleInventory(path)
+	require.NoError(t, err)
+
+	return inv
+}
+
+func TestAnsibleInventoryShouldSelectAllHostsByDefault(t *testing.T) {
+	inv := writeTestAnsibleInventory(t)
+
+	targets, err := inv.Targets("", "")
+
+	require.NoError(t, err)
+	require.Len(t, targets, 5)
+	assert.Equal(t, "admin@bastion.example.com", targets[0].Target)
+	assert.Empty(t, targets[0].Args)
+	assert.Equal(t, "ubuntu@web01.example.com", targets[1].Target)
+	assert.Equal(t, "ubuntu@web02.example.com", targets[2].Target)
+	assert.Equal(t, "ubuntu@10.0.0.9:2222", targets[3].Target)
+}
+
+func TestAnsibleInventoryShouldMapVariables(t *testing.T) {
+	inv := writeTestAnsibleInventory(t)
+
+	targets, err := inv.Targets("dbservers", "")
+
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "ubuntu@db1.example.com", targets[0].Target)
+	assert.Equal(t, []string{
+		"--sshKey=~/.ssh/db.pem",
+		"--var=ENVIRONMENT=production",
+		"--var=MYSQL_PORT=3307",
+		"--var=MYSQL_USERNAME=newrelic",
+		"--var=ANSIBLE_GROUPS=production,dbservers",
+	}, targets[0].Args)
+}
+
+func TestAnsibleInventoryShouldApplyLimitPatterns(t *testing.T) {
+	inv := writeTestAnsibleInventory(t)
+
+	targets, err := inv.Targets("production,!web-canary", "")
+	require.NoError(t, err)
+	assert.Len(t, targets, 3)
+
+	targets, err = inv.Targets("web*:&production", "")
+	require.NoError(t, err)
+	assert.Len(t, targets, 3)
+
+	targets, err = inv.Targets("bastion.example.com", "")
+	require.NoError(t, err)
+	assert.Len(t, targets, 1)
+
+	_, err = inv.Targets("nothing", "")
+	assert.Error(t, err)
+}
+
+func TestAnsibleInventoryShouldApplyExclusionsAndIntersectionsToAllHosts(t *testing.T) {
+	inv := writeTestAnsibleInventory(t)
+
+	targets, err := inv.Targets("!production", "")
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "admin@bastion.example.com", targets[0].Target)
+
+	targets, err = inv.Targets("&dbservers", "")
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "ubuntu@db1.example.com", targets[0].Target)
+
+	targets, err = inv.Targets("&production:!web-canary", "")
+	require.NoError(t, err)
+	assert.Len(t, targets, 3)
+}
+
+func TestAnsibleInventoryShouldUseDefaultUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.ini")
+	require.NoError(t, os.WriteFile(path, []byte("[web]\nweb1\n"), 0600))
+	inv, err := LoadAnsibleInventory(path)
+	require.NoError(t, err)
+
+	targets, err := inv.Targets("web", "deploy")
+	require.NoError(t, err)
+	assert.Equal(t, "deploy@web1", targets[0].Target)
+
+	_, err = inv.Targets("web", "")
+	assert.Error(t, err)
+}
+
+func TestLoadAnsibleInventoryShouldFailForInvalidSections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.ini")
+	require.NoError(t, os.WriteFile(path, []byte("[web:unknown]\nweb1\n"), 0600))
+
+	_, err := LoadAnsibleInventory(path)
+
+	assert.Error(t, err)
+}
@@ -3,13 +3,17 @@ package install
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/i18n"
 	"github.com/newrelic/newrelic-cli/internal/install/recipes"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
 )
 
 type StatusReporter interface {
@@ -23,6 +27,9 @@ type BundleInstaller struct {
 	statusReporter   StatusReporter
 	recipeInstaller  RecipeInstaller
 	prompter         Prompter
+	maxConcurrency   int
+	stepCounter      *ux.StepCounter
+	mu               sync.Mutex
 }
 
 func NewBundleInstaller(ctx context.Context, manifest *types.DiscoveryManifest, recipeInstallerInterface RecipeInstaller, statusReporter StatusReporter) *BundleInstaller {
@@ -37,6 +44,18 @@ func NewBundleInstaller(ctx context.Context, manifest *types.DiscoveryManifest,
 	}
 }
 
+// WithMaxConcurrency sets how many additional recipes may be installed at the same time.
+func (bi *BundleInstaller) WithMaxConcurrency(maxConcurrency int) *BundleInstaller {
+	bi.maxConcurrency = maxConcurrency
+	return bi
+}
+
+// WithStepCounter counts the recipes each bundle will install as steps of the install's progress.
+func (bi *BundleInstaller) WithStepCounter(stepCounter *ux.StepCounter) *BundleInstaller {
+	bi.stepCounter = stepCounter
+	return bi
+}
+
 func NewPrompter() *ux.PromptUIPrompter {
 	return ux.NewPromptUIPrompter()
 }
@@ -50,6 +69,8 @@ func (bi *BundleInstaller) InstallStopOnError(bundle *recipes.Bundle, assumeYes
 		return nil
 	}
 
+	bi.addSteps(installableBundleRecipes)
+
 	for _, br := range installableBundleRecipes {
 		err := bi.InstallBundleRecipe(br, assumeYes)
 
@@ -70,37 +91,124 @@ func (bi *BundleInstaller) InstallContinueOnError(bundle *recipes.Bundle, assume
 	}
 
 	if !assumeYes && bundle.IsAdditionalGuided() {
-		fmt.Println("\nWe've detected additional monitoring that can be configured by installing the following:")
-
-		for _, bundleRecipe := range installableBundleRecipes {
-			fmt.Printf("  %s\n", bundleRecipe.Recipe.DisplayName)
+		installableBundleRecipes = bi.promptForRecipeSelection(installableBundleRecipes)
+		if len(installableBundleRecipes) == 0 {
+			return
 		}
+	}
 
-		fmt.Println()
-		prompter := ux.NewPromptUIPrompter()
-		msg := "Continue installing? "
-		isConfirmed, err := prompter.PromptYesNo(msg)
+	bi.addSteps(installableBundleRecipes)
 
+	// Recipes may prompt for input, so they are only installed concurrently when no prompting is possible.
+	if assumeYes && bi.maxConcurrency > 1 {
+		bi.installConcurrently(installableBundleRecipes, assumeYes)
+		return
+	}
+
+	for _, additionalRecipe := range installableBundleRecipes {
+		err := bi.InstallBundleRecipe(additionalRecipe, assumeYes)
 		if err != nil {
-			log.Debug(err)
-			isConfirmed = false
+			log.Debugf("error installing recipe %v: %v", additionalRecipe.Recipe.Name, err)
 		}
+	}
+}
+
+// installConcurrently installs the given recipes using a pool of up to maxConcurrency workers.
+// Dependencies are installed serially beforehand, since several recipes may share them.
+func (bi *BundleInstaller) installConcurrently(bundleRecipes []*recipes.BundleRecipe, assumeYes bool) {
+	ready := []*recipes.BundleRecipe{}
 
-		if !isConfirmed {
-			for _, additionalRecipe := range installableBundleRecipes {
-				skippedEvent := execution.NewRecipeStatusEvent(additionalRecipe.Recipe)
-				bi.statusReporter.ReportStatus(execution.RecipeStatusTypes.SKIPPED, skippedEvent)
+	for _, br := range bundleRecipes {
+		var depErr error
+		for _, dr := range br.Dependencies {
+			if depErr = bi.InstallBundleRecipe(dr, assumeYes); depErr != nil {
+				break
 			}
-			return
 		}
+
+		if depErr != nil {
+			log.Debugf("error installing dependencies for recipe %v: %v", br.Recipe.Name, depErr)
+			continue
+		}
+
+		ready = append(ready, br)
 	}
 
-	for _, additionalRecipe := range installableBundleRecipes {
-		err := bi.InstallBundleRecipe(additionalRecipe, assumeYes)
-		if err != nil {
-			log.Debugf("error installing recipe %v: %v", additionalRecipe.Recipe.Name, err)
+	queue := make(chan *recipes.BundleRecipe)
+	var wg sync.WaitGroup
+
+	for w := 0; w < utils.MinOf(bi.maxConcurrency, len(ready)); w++ {
+		wg.Add(1)
+
+		go func(ri RecipeInstaller) {
+			defer wg.Done()
+
+			for br := range queue {
+				if err := bi.installRecipe(ri, br, assumeYes); err != nil {
+					log.Debugf("error installing recipe %v: %v", br.Recipe.Name, err)
+				}
+			}
+		}(bi.recipeInstaller.cloneForConcurrentInstall())
+	}
+
+	for _, br := range ready {
+		queue <- br
+	}
+	close(queue)
+
+	wg.Wait()
+}
+
+// promptForRecipeSelection lets the user choose which of the detected recipes to install.
+// Recipes left unselected are reported as skipped.
+func (bi *BundleInstaller) promptForRecipeSelection(bundleRecipes []*recipes.BundleRecipe) []*recipes.BundleRecipe {
+	fmt.Printf("\n%s\n", i18n.T("We've detected additional monitoring that can be configured by installing the following:"))
+
+	options := []string{}
+	byOption := map[string]*recipes.BundleRecipe{}
+	for _, br := range bundleRecipes {
+		fmt.Printf("  %s\n", br.Recipe.DisplayName)
+		if tasks := getRecipeTaskNames(br.Recipe); len(tasks) > 0 {
+			fmt.Printf("    changes: runs %s\n", strings.Join(tasks, ", "))
+		}
+
+		option := recipeSelectionOption(br.Recipe)
+		options = append(options, option)
+		byOption[option] = br
+	}
+	fmt.Println()
+
+	selectedOptions, err := bi.prompter.MultiSelect(i18n.T("Select the integrations to install:"), options)
+	if err != nil {
+		log.Debug(err)
+		selectedOptions = []string{}
+	}
+
+	isSelected := map[string]bool{}
+	for _, o := range selectedOptions {
+		isSelected[o] = true
+	}
+
+	selected := []*recipes.BundleRecipe{}
+	for _, o := range options {
+		if isSelected[o] {
+			selected = append(selected, byOption[o])
+			continue
 		}
+
+		skippedEvent := execution.NewRecipeStatusEvent(byOption[o].Recipe)
+		bi.statusReporter.ReportStatus(execution.RecipeStatusTypes.SKIPPED, skippedEvent)
+	}
+
+	return selected
+}
+
+func recipeSelectionOption(r *types.OpenInstallationRecipe) string {
+	if r.Description == "" {
+		return recipeDisplayName(r)
 	}
+
+	return fmt.Sprintf("%s - %s", recipeDisplayName(r), r.Description)
 }
 
 func (bi *BundleInstaller) reportBundleStatus(bundle *recipes.Bundle) {
@@ -115,6 +223,26 @@ func (bi *BundleInstaller) reportBundleStatus(bundle *recipes.Bundle) {
 	}
 }
 
+// addSteps counts each recipe that is yet to be installed, including dependencies, as a step.
+func (bi *BundleInstaller) addSteps(bundleRecipes []*recipes.BundleRecipe) {
+	counted := map[string]bool{}
+
+	var count func(brs []*recipes.BundleRecipe)
+	count = func(brs []*recipes.BundleRecipe) {
+		for _, br := range brs {
+			count(br.Dependencies)
+
+			name := br.Recipe.Name
+			if !counted[name] && !bi.isInstalled(name) {
+				counted[name] = true
+			}
+		}
+	}
+	count(bundleRecipes)
+
+	bi.stepCounter.AddSteps(len(counted))
+}
+
 func (bi *BundleInstaller) InstalledRecipesCount() int {
 	return len(bi.installedRecipes)
 }
@@ -129,8 +257,12 @@ func (bi *BundleInstaller) InstallBundleRecipe(bundleRecipe *recipes.BundleRecip
 		}
 	}
 
+	return bi.installRecipe(bi.recipeInstaller, bundleRecipe, assumeYes)
+}
+
+func (bi *BundleInstaller) installRecipe(ri RecipeInstaller, bundleRecipe *recipes.BundleRecipe, assumeYes bool) error {
 	recipeName := bundleRecipe.Recipe.Name
-	if bi.installedRecipes[bundleRecipe.Recipe.Name] {
+	if bi.isInstalled(recipeName) {
 		return nil
 	}
 
@@ -138,18 +270,27 @@ func (bi *BundleInstaller) InstallBundleRecipe(bundleRecipe *recipes.BundleRecip
 		"name": recipeName,
 	}).Debug("installing recipe")
 
-	_, err = bi.recipeInstaller.executeAndValidateWithProgress(bi.ctx, bi.manifest, bundleRecipe.Recipe, assumeYes)
+	_, err := ri.executeAndValidateWithProgress(bi.ctx, bi.manifest, bundleRecipe.Recipe, assumeYes)
 	if err != nil {
 		log.Debugf("Failed while executing and validating with progress for recipe name %s, detail:%s", recipeName, err)
 		return err
 	}
 
+	bi.mu.Lock()
 	bi.installedRecipes[recipeName] = true
+	bi.mu.Unlock()
 	log.Debugf("Done executing and validating with progress for recipe name %s.", recipeName)
 
 	return nil
 }
 
+func (bi *BundleInstaller) isInstalled(recipeName string) bool {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	return bi.installedRecipes[recipeName]
+}
+
 func (bi *BundleInstaller) getInstallableBundleRecipes(bundle *recipes.Bundle) []*recipes.BundleRecipe {
 	var bundleRecipes []*recipes.BundleRecipe
 
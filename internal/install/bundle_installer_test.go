@@ -70,6 +70,39 @@ func TestInstallContinueOnErrorInstallAllWhenErroring(t *testing.T) {
 	test.mockRecipeInstaller.AssertNumberOfCalls(t, "executeAndValidateWithProgress", 2)
 }
 
+func TestInstallContinueOnErrorInstallsOnlySelectedRecipesWhenGuided(t *testing.T) {
+	test := createBundleInstallerTest().withPrompterMultiSelectVal([]string{"recipe2"}).withRecipeInstallerSuccess()
+	test.bundle.Type = recipes.BundleTypes.ADDITIONALGUIDED
+	test.addRecipeToBundle("recipe1", execution.RecipeStatusTypes.AVAILABLE)
+	test.addRecipeToBundle("recipe2", execution.RecipeStatusTypes.AVAILABLE)
+
+	test.BundleInstaller.InstallContinueOnError(test.bundle, false)
+
+	assert.Equal(t, 1, test.mockPrompter.PromptMultiSelectCallCount)
+	test.mockRecipeInstaller.AssertNumberOfCalls(t, "executeAndValidateWithProgress", 1)
+	test.mockStatusReporter.AssertCalled(t, "ReportStatus", execution.RecipeStatusTypes.SKIPPED, mock.Anything)
+}
+
+func TestInstallContinueOnErrorSkipsAllWhenNoRecipesSelected(t *testing.T) {
+	test := createBundleInstallerTest().withPrompterMultiSelectVal([]string{}).withRecipeInstallerSuccess()
+	test.bundle.Type = recipes.BundleTypes.ADDITIONALGUIDED
+	test.addRecipeToBundle("recipe1", execution.RecipeStatusTypes.AVAILABLE)
+	test.addRecipeToBundle("recipe2", execution.RecipeStatusTypes.AVAILABLE)
+
+	test.BundleInstaller.InstallContinueOnError(test.bundle, false)
+
+	test.mockRecipeInstaller.AssertNumberOfCalls(t, "executeAndValidateWithProgress", 0)
+	test.mockStatusReporter.AssertNumberOfCalls(t, "ReportStatus", 4)
+}
+
+func TestRecipeSelectionOptionShouldIncludeDescription(t *testing.T) {
+	r := recipes.NewRecipeBuilder().Name("recipe1").Build()
+	r.DisplayName = "Recipe One"
+	r.Description = "Monitors recipe one"
+
+	assert.Equal(t, "Recipe One - Monitors recipe one", recipeSelectionOption(r))
+}
+
 func TestInstallStopsOnErrorActuallyErrors(t *testing.T) {
 	expectedError := errors.New("Kaboom " + time.Now().String())
 	test := createBundleInstallerTest().withRecipeInstallerErrorWithMessage(expectedError)
@@ -221,6 +254,45 @@ func TestInstallShouldnotInstallAnyWhenDependenciesNotAvailable(t *testing.T) {
 	assert.False(t, test.BundleInstaller.installedRecipes["d2"])
 }
 
+func TestInstallContinueOnErrorShouldInstallConcurrentlyWithDependencies(t *testing.T) {
+	test := createBundleInstallerTest().withRecipeInstallerSuccess()
+	test.BundleInstaller.WithMaxConcurrency(2)
+
+	d := &recipes.BundleRecipe{
+		Recipe: recipes.NewRecipeBuilder().Name("x").Build(),
+	}
+	d.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	test.addRecipeToBundle("recipe1", execution.RecipeStatusTypes.AVAILABLE)
+	test.addRecipeToBundle("recipe2", execution.RecipeStatusTypes.AVAILABLE)
+	test.addRecipeToBundle("recipe3", execution.RecipeStatusTypes.AVAILABLE)
+	test.bundle.BundleRecipes[0].Dependencies = append(test.bundle.BundleRecipes[0].Dependencies, d)
+	test.bundle.BundleRecipes[1].Dependencies = append(test.bundle.BundleRecipes[1].Dependencies, d)
+
+	test.BundleInstaller.InstallContinueOnError(test.bundle, true)
+
+	test.mockRecipeInstaller.AssertNumberOfCalls(t, "executeAndValidateWithProgress", 4)
+	assert.Equal(t, 4, test.BundleInstaller.InstalledRecipesCount())
+}
+
+func TestInstallContinueOnErrorShouldCountRecipesAndDependenciesAsSteps(t *testing.T) {
+	test := createBundleInstallerTest().withRecipeInstallerSuccess()
+	stepCounter := ux.NewStepCounter()
+	test.BundleInstaller.WithStepCounter(stepCounter)
+
+	d := &recipes.BundleRecipe{
+		Recipe: recipes.NewRecipeBuilder().Name("x").Build(),
+	}
+	d.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	test.addRecipeToBundle("recipe1", execution.RecipeStatusTypes.AVAILABLE)
+	test.addRecipeToBundle("recipe2", execution.RecipeStatusTypes.AVAILABLE)
+	test.bundle.BundleRecipes[0].Dependencies = append(test.bundle.BundleRecipes[0].Dependencies, d)
+	test.bundle.BundleRecipes[1].Dependencies = append(test.bundle.BundleRecipes[1].Dependencies, d)
+
+	test.BundleInstaller.InstallContinueOnError(test.bundle, true)
+
+	assert.Equal(t, "[1/3] ", stepCounter.Next())
+}
+
 type BundleInstallerTest struct {
 	BundleInstaller     *BundleInstaller
 	mockStatusReporter  *mockStatusReporter
@@ -283,3 +355,9 @@ func (bi *BundleInstallerTest) withPrompterYesNoVal(val bool) *BundleInstallerTe
 	bi.mockPrompter.PromptYesNoVal = val
 	return bi
 }
+
+func (bi *BundleInstallerTest) withPrompterMultiSelectVal(val []string) *BundleInstallerTest {
+	bi.mockPrompter.PromptMultiSelectAll = false
+	bi.mockPrompter.PromptMultiSelectVal = val
+	return bi
+}
@@ -1,31 +1,113 @@
 package install
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/term"
 
 	"github.com/newrelic/newrelic-cli/internal/client"
 	"github.com/newrelic/newrelic-cli/internal/config"
 	configAPI "github.com/newrelic/newrelic-cli/internal/config/api"
+	"github.com/newrelic/newrelic-cli/internal/install/discovery"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/i18n"
 	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
 	"github.com/newrelic/newrelic-cli/internal/install/segment"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
 	"github.com/newrelic/newrelic-cli/internal/utils"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
 	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
 	nrRegion "github.com/newrelic/newrelic-client-go/v2/pkg/region"
 )
 
 var (
-	assumeYes    bool
-	localRecipes string
-	recipeNames  []string
-	recipePaths  []string
-	testMode     bool
-	tags         []string
+	acceptAllLogs  bool
+	advanced       bool
+	allowSudo      bool
+	ansibleInv     string
+	asciiMode      bool
+	assumeYes      bool
+	awsRoleArn     string
+	ciMode         bool
+	clusterName    string
+	configFile     string
+	customAttrs    []string
+	discExclude    []string
+	discInclude    []string
+	discMatchers   string
+	discTimeout    time.Duration
+	continueOnErr  bool
+	dryRun         bool
+	excludeLogs    []string
+	helmValues     string
+	hooksFile      string
+	kubernetes     bool
+	parallelHosts  int
+	licenseKey     string
+	limit          string
+	localRecipes   string
+	locale         string
+	lockFile       string
+	manifestIn     string
+	manifestOut    string
+	maxConcurrency int
+	maxRetries     int
+	nice           bool
+	noRollback     bool
+	noTelemetry    bool
+	notifySlack    string
+	notifyWebhook  string
+	offline        bool
+	outputFormat   string
+	postHook       string
+	preHook        string
+	proxy          string
+	quiet          bool
+	recipeBundle   string
+	recipeNames    []string
+	recipeOrder    []string
+	recipePaths    []string
+	recipeSha256   []string
+	recipeSources  []string
+	recipeTimeout  time.Duration
+	refreshDisc    bool
+	renderOnly     bool
+	requireSigned  bool
+	resume         bool
+	review         bool
+	sendLogs       bool
+	skipPreflight  bool
+	skipRecipes    []string
+	sourceHeaders  []string
+	sshKey         string
+	statusFile     string
+	target         string
+	targetsFile    string
+	testMode       bool
+	ui             bool
+	uninstall      bool
+	upgrade        bool
+	varFile        string
+	tags           []string
+	vars           []string
+)
+
+// The formats of --output.
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
 )
 
 // Command represents the install command.
@@ -34,68 +116,411 @@ var Command = &cobra.Command{
 	Short:  "Install New Relic.",
 	PreRun: client.RequireClient,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ic := types.InstallerContext{
-			AssumeYes:    assumeYes,
-			LocalRecipes: localRecipes,
-			RecipeNames:  recipeNames,
-			RecipePaths:  recipePaths,
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
 		}
-		ic.SetTags(tags)
-
-		logLevel := configAPI.GetLogLevel()
-		config.InitFileLogger(logLevel)
 
-		sg := initSegment()
-		sg.Track(types.EventTypes.InstallStarted)
+		if locale == "" {
+			locale = i18n.DetectLocale()
+		}
+		if err := i18n.SetLocale(locale); err != nil {
+			return NewExitError(err)
+		}
 
-		detailErr := validateProfile(config.DefaultMaxTimeoutSeconds, sg)
-		if detailErr != nil {
-			log.Fatal(detailErr)
+		if ciMode {
+			if ic.UI {
+				return NewExitError(errors.New("--ci can't be used with --ui"))
+			}
+			ux.EnableCIMode()
 		}
 
-		// Reinitialize client, overriding fetched values
-		c, _ := client.NewClient(configAPI.GetActiveProfileName())
-		client.NRClient = c
+		if asciiMode && ic.UI {
+			return NewExitError(errors.New("--ascii can't be used with --ui"))
+		}
 
-		i := NewRecipeInstaller(ic, c, sg)
+		if asciiMode || (!ic.UI && ux.IsASCIITerminal()) {
+			ux.EnableASCIIMode()
+		}
 
-		// Run the install.
-		if err := i.Install(); err != nil {
-			if err == types.ErrInterrupt {
-				return nil
+		switch outputFormat {
+		case outputFormatText:
+		case outputFormatJSON:
+			if ic.UI {
+				return NewExitError(errors.New("--output json can't be used with --ui"))
 			}
+			// Prompts would be written among the events, so a JSON install never prompts.
+			ic.AssumeYes = true
+			ic.JSONOutput = true
+			ux.EnableJSONMode()
+		default:
+			return NewExitError(fmt.Errorf("invalid --output %q, expected %s or %s", outputFormat, outputFormatText, outputFormatJSON))
+		}
 
-			if _, ok := err.(*types.UpdateRequiredError); ok {
-				return nil
+		if quiet {
+			if ic.UI {
+				return NewExitError(errors.New("--quiet can't be used with --ui"))
 			}
+			ux.EnableQuietMode()
+		}
 
-			if e, ok := err.(*nrErrors.PaymentRequiredError); ok {
-				return e
-			}
+		if limit != "" && ansibleInv == "" {
+			return NewExitError(errors.New("--limit requires --ansibleInventory"))
+		}
 
-			fallbackErrorMsg := fmt.Sprintf("\nWe encountered an issue during the installation: %s.", err)
-			fallbackHelpMsg := "If this problem persists, visit the documentation and support page for additional help here at https://docs.newrelic.com/docs/infrastructure/install-infrastructure-agent/get-started/requirements-infrastructure-agent/"
+		if targetsFile != "" || ansibleInv != "" {
+			return runFleetInstall(cmd, ic)
+		}
 
-			// In the extremely rare case we run into an uncaught error (e.g. no recipes found),
-			// we need to output something to user to sinc we probably haven't displayed anything yet.
-			fmt.Println(fallbackErrorMsg)
-			fmt.Println(fallbackHelpMsg)
-			fmt.Print("\n\n")
+		if err := validateTarget(ic); err != nil {
+			return NewExitError(err)
+		}
 
-			log.Debug(fallbackErrorMsg)
+		if err := validateAdvanced(ic); err != nil {
+			return NewExitError(err)
 		}
 
-		return nil
+		if err := validateManifest(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		if err := validateUI(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		if err := validateKubernetes(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		return runInstall(ic, nil)
 	},
 }
 
+// newInstallerContext returns the installer context for the install flags.
+func newInstallerContext() (types.InstallerContext, error) {
+	ic := types.InstallerContext{
+		AcceptAllLogs:       acceptAllLogs,
+		Advanced:            advanced,
+		AllowSudo:           allowSudo,
+		AssumeYes:           assumeYes,
+		AWSRoleARN:          awsRoleArn,
+		ClusterName:         clusterName,
+		ContinueOnError:     continueOnErr,
+		DiscoveryExclude:    discExclude,
+		DiscoveryInclude:    discInclude,
+		DiscoveryTimeout:    discTimeout,
+		DryRun:              dryRun || renderOnly,
+		ExcludeLogs:         excludeLogs,
+		HelmValuesFile:      helmValues,
+		Kubernetes:          kubernetes,
+		LocalRecipes:        localRecipes,
+		ManifestIn:          manifestIn,
+		ManifestOut:         manifestOut,
+		MaxConcurrency:      maxConcurrency,
+		MaxRetries:          maxRetries,
+		Nice:                nice,
+		NotifySlack:         notifySlack,
+		NoRollback:          noRollback,
+		NoTelemetry:         noTelemetry,
+		NotifyWebhook:       notifyWebhook,
+		Offline:             offline,
+		RecipeBundle:        recipeBundle,
+		RecipePaths:         recipePaths,
+		RecipeSHA256:        recipeSha256,
+		RenderOnly:          renderOnly,
+		RecipeOrder:         recipeOrder,
+		RecipeTimeout:       recipeTimeout,
+		RefreshDiscovery:    refreshDisc,
+		RequireSigned:       requireSigned,
+		Resume:              resume,
+		Review:              review,
+		SendInstallLogs:     sendLogs,
+		SkipPreflightChecks: skipPreflight,
+		SkipRecipes:         skipRecipes,
+		SSHKey:              sshKey,
+		StatusFile:          statusFile,
+		Target:              target,
+		UI:                  ui,
+		Uninstall:           uninstall,
+		Upgrade:             upgrade,
+	}
+
+	var cfg *InstallConfig
+	names := recipeNames
+	if configFile != "" {
+		var err error
+		if cfg, err = LoadInstallConfig(configFile); err != nil {
+			return ic, err
+		}
+
+		// An unattended install never prompts.
+		ic.AssumeYes = true
+		if len(names) == 0 {
+			names = cfg.Recipes
+		}
+	}
+
+	if err := ic.SetRecipeNames(names); err != nil {
+		return ic, err
+	}
+
+	for _, c := range ic.RecipeSHA256 {
+		if !recipes.IsSHA256Checksum(c) {
+			return ic, fmt.Errorf("invalid --recipeSha256 %q, expected a SHA-256 checksum in hex", c)
+		}
+	}
+
+	for _, p := range ic.ExcludeLogs {
+		if _, err := filepath.Match(p, ""); err != nil {
+			return ic, fmt.Errorf("invalid --excludeLogs pattern %q: %s", p, err)
+		}
+	}
+
+	if _, err := recipes.NewProcessFilter(ic.DiscoveryInclude, ic.DiscoveryExclude); err != nil {
+		return ic, err
+	}
+
+	if ic.DiscoveryTimeout < 0 {
+		return ic, fmt.Errorf("invalid --discoveryTimeout %s, expected a positive duration", ic.DiscoveryTimeout)
+	}
+
+	if ic.MaxRetries < 0 {
+		return ic, fmt.Errorf("invalid --maxRetries %d, expected a positive number", ic.MaxRetries)
+	}
+
+	lockPath := lockFile
+	if _, err := os.Stat(DefaultRecipeLockFile); err == nil && lockPath == "" {
+		lockPath = DefaultRecipeLockFile
+	}
+
+	if lockPath != "" {
+		lock, err := LoadRecipeLock(lockPath)
+		if err != nil {
+			return ic, err
+		}
+
+		log.Debugf("Using the recipe versions of %s", lockPath)
+		applyRecipeLock(&ic, lock)
+	}
+
+	if err := setRecipeSource(&ic); err != nil {
+		return ic, err
+	}
+
+	if err := setInstallHooks(&ic); err != nil {
+		return ic, err
+	}
+
+	if err := setDiscoveryMatchers(&ic); err != nil {
+		return ic, err
+	}
+
+	if err := validateNotifyWebhooks(ic); err != nil {
+		return ic, err
+	}
+
+	recipeVars, err := execution.ParseRecipeVars(vars)
+	if err != nil {
+		return ic, err
+	}
+	ic.RecipeVars = recipeVars
+
+	if varFile != "" {
+		ic.RecipeFileVars, err = execution.LoadRecipeVarFile(varFile)
+		if err != nil {
+			return ic, err
+		}
+	}
+	ic.SetTags(tags)
+
+	customAttributes, err := parseCustomAttributes(customAttrs)
+	if err != nil {
+		return ic, err
+	}
+	if err := ic.SetCustomAttributes(customAttributes); err != nil {
+		return ic, err
+	}
+
+	// A license key given with --licenseKey is used instead of an install configuration's, or fetching
+	// the account's.
+	if licenseKey != "" {
+		os.Setenv("NEW_RELIC_LICENSE_KEY", licenseKey)
+		log.Debugf("using license key %s", utils.Obfuscate(licenseKey))
+	}
+
+	if cfg != nil {
+		if err := applyInstallConfig(&ic, cfg); err != nil {
+			return ic, err
+		}
+	}
+
+	return ic, nil
+}
+
+// runInstall runs the install, or uninstall, of the installer context and reports its outcome.
+// When planOutput is set, the install plan is written to it instead of installing anything.
+func runInstall(ic types.InstallerContext, planOutput io.Writer) error {
+	if proxy != "" {
+		if err := ConfigureProxy(proxy); err != nil {
+			return NewExitError(err)
+		}
+	}
+
+	logLevel := configAPI.GetLogLevel()
+	config.InitFileLogger(logLevel)
+	installLogPath := initInstallLog()
+
+	if installTelemetryDisallowed() {
+		ic.NoTelemetry = true
+	}
+
+	if !ic.Offline && client.NRClient != nil {
+		if err := selectAccount(ic, &client.NRClient.Accounts, ux.NewPromptUIPrompter()); err != nil {
+			return NewExitError(err)
+		}
+	}
+
+	sg := segment.NewNoOp()
+	if !ic.NoTelemetry {
+		sg = initSegment()
+	}
+	sg.Track(types.EventTypes.InstallStarted)
+
+	if ic.Offline {
+		if err := validateOffline(ic); err != nil {
+			return NewExitError(err)
+		}
+	} else {
+		detailErr := validateProfile(config.DefaultMaxTimeoutSeconds, sg)
+		if detailErr != nil {
+			return NewExitError(detailErr)
+		}
+	}
+
+	// Reinitialize client, overriding fetched values
+	c, _ := client.NewClient(configAPI.GetActiveProfileName())
+	client.NRClient = c
+
+	// The plan is written to STDOUT, so the outcome of planning is printed to STDERR.
+	var out io.Writer = os.Stdout
+	if planOutput != nil {
+		out = os.Stderr
+	}
+
+	// Run the install.
+	if err := newInstallRun(ic, c, sg, planOutput, installLogPath)(); err != nil {
+		// An interrupted install exits with the code of a process ended by Ctrl-C.
+		if errors.Is(err, types.ErrInterrupt) {
+			return NewExitError(err)
+		}
+
+		if _, ok := err.(*types.UpdateRequiredError); ok {
+			return nil
+		}
+
+		if e, ok := err.(*nrErrors.PaymentRequiredError); ok {
+			return e
+		}
+
+		// Failed checks have already been reported in the pre-flight report.
+		if _, ok := err.(*types.PreflightError); ok {
+			return NewExitError(err)
+		}
+
+		// Failed recipes have already been reported in the installation summary.
+		if _, ok := err.(*types.RecipeFailuresError); ok {
+			printInstallLogPath(out, installLogPath)
+			return NewExitError(err)
+		}
+
+		fallbackErrorMsg := fmt.Sprintf("\nWe encountered an issue during the installation: %s.", err)
+		fallbackHelpMsg := "If this problem persists, visit the documentation and support page for additional help here at https://docs.newrelic.com/docs/infrastructure/install-infrastructure-agent/get-started/requirements-infrastructure-agent/"
+
+		// In the extremely rare case we run into an uncaught error (e.g. no recipes found),
+		// we need to output something to user to sinc we probably haven't displayed anything yet.
+		fmt.Fprintln(out, fallbackErrorMsg)
+		fmt.Fprintln(out, fallbackHelpMsg)
+		fmt.Fprint(out, "\n\n")
+		printInstallLogPath(out, installLogPath)
+
+		log.Debug(fallbackErrorMsg)
+
+		return NewExitError(err)
+	}
+
+	return nil
+}
+
 func init() {
-	Command.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to a recipe file to install")
-	Command.Flags().StringSliceVarP(&recipeNames, "recipe", "n", []string{}, "the name of a recipe to install")
+	Command.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to a recipe file, directory or glob pattern of recipe files to install")
+	Command.Flags().StringSliceVarP(&recipeSha256, "recipeSha256", "", []string{}, "the SHA-256 checksums that recipe files fetched with --recipePath from a URL must have")
+	Command.Flags().StringSliceVarP(&recipeNames, "recipe", "n", []string{}, "the name of a recipe to install, or name@version to install the recipe released in that version of the recipe library")
+	Command.Flags().StringVarP(&lockFile, "lockfile", "", "", "the path of a lockfile created with \"newrelic install lock generate\" to pin recipe versions with, instead of "+DefaultRecipeLockFile+" in the working directory")
+	Command.Flags().StringVarP(&configFile, "config", "", "", "the path of a "+DefaultInstallConfigFile+" file describing an unattended install, with its license key source, recipes, variables, tags and log paths, which never prompts")
 	Command.Flags().BoolVarP(&testMode, "testMode", "t", false, "fakes operations for UX testing")
 	Command.Flags().BoolVarP(&assumeYes, "assumeYes", "y", false, "use \"yes\" for all questions during install")
+	Command.Flags().BoolVarP(&allowSudo, "allowSudo", "", false, "allow the recipes of an unattended install to run commands with sudo, or as root when the install runs as root, which are otherwise previewed and confirmed")
+	Command.Flags().BoolVarP(&advanced, "advanced", "", false, "skip the welcome and ask for the agent version, configuration file, run-as user, recipe timeout and retries instead of using defaults")
+	Command.Flags().BoolVarP(&review, "review", "", false, "print the commands of each recipe before it runs, to install it, skip it or edit its variables")
+	Command.Flags().BoolVarP(&dryRun, "dryRun", "", false, "print the recipes, tasks and variables the install would use without executing them")
+	Command.Flags().BoolVarP(&renderOnly, "renderOnly", "", false, "print the shell the tasks of the recipes would run, with their variables rendered, without executing them")
+	Command.Flags().BoolVarP(&uninstall, "uninstall", "", false, "reverse previously installed recipes using their uninstall steps")
+	Command.Flags().BoolVarP(&upgrade, "upgrade", "", false, "upgrade recipes that are already installed at an older version using their upgrade steps, instead of skipping them")
+	Command.Flags().BoolVarP(&resume, "resume", "", false, "skip recipes already installed by a previous install that did not complete")
+	Command.Flags().IntVarP(&maxConcurrency, "maxConcurrency", "", 1, "the number of integration recipes to install at the same time, requires --assumeYes")
+	Command.Flags().BoolVarP(&nice, "nice", "", false, "run the recipes with a lower CPU and I/O priority, and one package manager at a time, so the install doesn't compete with the workloads of the host")
+	Command.Flags().DurationVarP(&recipeTimeout, "recipeTimeout", "", 0, "the maximum time each recipe may take to install, e.g. 10m, unless the recipe defines its own")
+	Command.Flags().IntVarP(&maxRetries, "maxRetries", "", 0, "the number of times to retry a recipe that failed to install, waiting longer between each attempt")
+	Command.Flags().StringVarP(&statusFile, "statusFile", "", "", "the path of a JSON file to write the result, error, entity GUID and duration of each attempted recipe to")
+	Command.Flags().StringSliceVarP(&recipeOrder, "order", "", []string{}, "the names of recipes to install first, in order, before the infrastructure agent and the other recipes. Example: --order firewall-open,infrastructure-agent-installer")
+	Command.Flags().BoolVarP(&sendLogs, "sendInstallLogs", "", false, "send the install log, with the output of recipes and any errors, to New Relic Logs in the account, tagged with the hostname and install ID")
+	Command.Flags().BoolVarP(&acceptAllLogs, "acceptAllLogs", "", false, "forward every log file discovered for the logs integration without prompting for them, also when --assumeYes is set")
+	Command.Flags().StringArrayVarP(&excludeLogs, "excludeLogs", "", []string{}, "a glob of log files never to offer or forward with the logs integration, can be multiple. Example: --excludeLogs '/var/log/secure*'")
+	Command.Flags().BoolVarP(&noRollback, "noRollback", "", false, "leave the changes of a recipe that failed partway through its install in place, instead of running its uninstall steps or restoring the configuration files it changed")
+	Command.Flags().BoolVarP(&noTelemetry, "noTelemetry", "", false, "don't report the status of the install and its recipes to New Relic, for every install when the installTelemetry config value is DISALLOW")
+	Command.Flags().StringSliceVarP(&skipRecipes, "skipRecipes", "", []string{}, "the names of recipes to exclude from the install, can be multiple. Example: --skipRecipes mysql-open-source-integration,nginx-open-source-integration")
+	Command.Flags().BoolVarP(&continueOnErr, "continueOnError", "", false, "install the remaining integrations when the infrastructure agent or logs integration fail to install")
+	Command.Flags().BoolVarP(&skipPreflight, "skipPreflightChecks", "", false, "install without first checking network access, privileges, disk space and the prerequisites of recipes")
+	Command.Flags().BoolVarP(&requireSigned, "requireSignedRecipes", "", false, "only install recipes fetched from a URL or the recipe library when they have a cosign or GPG signature from a key added with \"newrelic install trust add\"")
+	Command.Flags().BoolVarP(&offline, "offline", "", false, "install without contacting New Relic, using the recipes and agent packages from --recipeBundle")
+	Command.Flags().StringVarP(&recipeBundle, "recipeBundle", "", "", "the path of a recipe archive created with \"newrelic install bundle\" to load recipes and agent packages from")
+	Command.Flags().StringVarP(&proxy, "proxy", "", "", "the URL of an HTTP proxy to install through, which is also configured for the infrastructure agent. Hosts in NO_PROXY are not proxied")
+	Command.Flags().StringVarP(&licenseKey, "licenseKey", "", "", "the license key to install with, instead of fetching the license key of the profile's account with its user API key")
+	Command.Flags().StringSliceVarP(&recipeSources, "recipeSource", "", []string{}, "the URL of a mirror of the open install library to fetch recipes from, instead of the recipes included with the CLI, can be multiple. Each is tried in order, then the recipe cache and the included recipes. Defaults to NEW_RELIC_RECIPE_SOURCE")
+	Command.Flags().StringArrayVarP(&sourceHeaders, "recipeSourceHeader", "", []string{}, "a header to send with requests to each --recipeSource, can be multiple. Example: --recipeSourceHeader \"Authorization: Bearer $TOKEN\"")
+	Command.Flags().StringVarP(&preHook, "preInstallHook", "", "", "the path of a script to run once the recipes to install are known, with the discovered host and the recipes in NEW_RELIC_* environment variables. The install stops when it fails")
+	Command.Flags().StringVarP(&postHook, "postInstallHook", "", "", "the path of a script to run once the install completes, with its status and the installed, failed and skipped recipes in NEW_RELIC_* environment variables")
+	Command.Flags().StringArrayVarP(&discInclude, "discoveryInclude", "", []string{}, "a regular expression of the command lines of the processes to scope discovery to, can be multiple. Example: --discoveryInclude 'mysqld|nginx'")
+	Command.Flags().StringArrayVarP(&discExclude, "discoveryExclude", "", []string{}, "a regular expression of the command lines of the processes discovery ignores, can be multiple. Example: --discoveryExclude '^/usr/bin/python3 /opt/jobs/'")
+	Command.Flags().DurationVarP(&discTimeout, "discoveryTimeout", "", 0, "the maximum time the discovery of the host may take, e.g. 2m, after which the probes that haven't completed are skipped, each probe being limited to "+discovery.DefaultProbeTimeout.String()+" regardless")
+	Command.Flags().BoolVarP(&refreshDisc, "refreshDiscovery", "", false, "discover the host again instead of reusing the discovery of a run of the CLI in the last "+discovery.DefaultManifestCacheTTL.String())
+	Command.Flags().StringVarP(&discMatchers, "discoveryMatchers", "", "", "the path of a YAML file of matchers that recommend custom recipes for in-house services by processName, port, configFile, package or runtime, instead of "+recipes.DefaultDiscoveryMatchersFile+" in the configuration directory")
+	Command.Flags().StringVarP(&hooksFile, "hooksFile", "", "", "the path of a YAML file of preInstall and postInstall hooks, and the hooks of individual recipes under recipes, instead of "+execution.DefaultInstallHooksFile+" in the configuration directory")
+	Command.Flags().StringVarP(&notifyWebhook, "notifyWebhook", "", "", "a URL to POST the JSON summary of the install to once it completes, retrying on failure. Requests are signed in the "+execution.WebhookSignatureHeader+" header when "+types.EnvNotifyWebhookSecret+" is set")
+	Command.Flags().StringVarP(&notifySlack, "notifySlackWebhook", "", "", "the URL of a Slack incoming webhook to send a message with the host, the outcome of the install and its failed recipes to once it completes")
 	Command.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
-	Command.Flags().StringSliceVarP(&tags, "tag", "", []string{}, "the tags to add during install, can be multiple. Example: --tag tag1:test,tag2:test")
+	Command.Flags().StringArrayVarP(&vars, "var", "", []string{}, "a recipe variable to set instead of prompting for it, can be multiple. Example: --var MYSQL_PORT=3306 --var MYSQL_USERNAME=newrelic")
+	Command.Flags().StringVarP(&varFile, "varFile", "", "", "the path of a .env or .yaml file of recipe variables to set instead of prompting for them, which --var takes precedence over")
+	Command.Flags().StringVarP(&target, "target", "", "", "a remote host to install onto over SSH instead of this one, as user@host[:port], requires --assumeYes")
+	Command.Flags().StringVarP(&sshKey, "sshKey", "", "", "the path of the private key to connect to --target with, instead of the SSH agent and configuration")
+	Command.Flags().StringVarP(&manifestOut, "manifestOut", "", "", "the path to export the discovery manifest of the host to, with its running processes, to resolve its recipes elsewhere with --manifestIn")
+	Command.Flags().StringVarP(&manifestIn, "manifestIn", "", "", "the path of a discovery manifest exported with --manifestOut to resolve the recipes of its host with, instead of discovering this one, requires --dryRun")
+	Command.Flags().StringVarP(&targetsFile, "targetsFile", "", "", "the path of a file of remote hosts to install onto at the same time, one user@host[:port] per line, requires --assumeYes")
+	Command.Flags().StringVarP(&ansibleInv, "ansibleInventory", "", "", "the path of an Ansible INI inventory of remote hosts to install onto, whose host and group variables are set as recipe variables, requires --assumeYes")
+	Command.Flags().StringVarP(&limit, "limit", "", "", "the groups or hosts of --ansibleInventory to install onto, as with Ansible's --limit. Example: --limit webservers,!staging")
+	Command.Flags().IntVarP(&parallelHosts, "hostConcurrency", "", 10, "the number of hosts from --targetsFile to install onto at the same time")
+	Command.Flags().StringVarP(&awsRoleArn, "awsRoleArn", "", "", "the ARN of the IAM role to link the AWS account of an EC2 host to New Relic with once the install completes, instead of prompting for it")
+	Command.Flags().BoolVarP(&kubernetes, "kubernetes", "", false, "install the Kubernetes integration onto the cluster of the current kubeconfig with the nri-bundle Helm chart, instead of the host agents. Offered when Kubernetes is detected")
+	Command.Flags().StringVarP(&clusterName, "clusterName", "", "", "the name of the Kubernetes cluster to report its data as, instead of prompting for it")
+	Command.Flags().StringVarP(&helmValues, "helmValuesFile", "", "", "the path to write the nri-bundle Helm chart values to, instead of installing the chart onto the cluster")
+	Command.Flags().StringVarP(&locale, "locale", "", "", "the language to show prompts and messages in, one of en, de, es or ja, detected from LANG when not set")
+	Command.Flags().StringVarP(&outputFormat, "output", "", outputFormatText, "the format to write the install's output in, text, or json to write its events and summary as JSON lines on stdout")
+	Command.Flags().BoolVarP(&quiet, "quiet", "q", false, "print only warnings, errors and the installation summary, without the welcome or the progress of each step")
+	Command.Flags().BoolVarP(&asciiMode, "ascii", "", false, "write output as plain ASCII lines without icons, emoji or spinners, for terminals that can't show them and for screen readers. Enabled for dumb and non UTF-8 terminals")
+	Command.Flags().BoolVarP(&ciMode, "ci", "", false, "write output for CI logs, with one timestamped line per step and no spinners, colors or unicode icons")
+	Command.Flags().BoolVarP(&ui, "ui", "", false, "show a full-screen view of the install with the output of each recipe and a results screen, requires --assumeYes")
+	Command.Flags().StringArrayVarP(&customAttrs, "customAttributes", "", []string{}, "a custom attribute the infrastructure agent reports the host with, written to custom_attributes in newrelic-infra.yml, can be multiple. Example: --customAttributes team=payments --customAttributes environment=production")
+	Command.Flags().StringSliceVarP(&tags, "tag", "", []string{}, "the tags to add during install and to the entities it creates, can be multiple. Example: --tag tag1:test,tag2:test")
 }
 
 func initSegment() *segment.Segment {
@@ -111,6 +536,50 @@ func initSegment() *segment.Segment {
 	return segment.New(writeKey, accountID, region, isProxyConfigured)
 }
 
+// parseCustomAttributes parses the key=value pairs of --customAttributes.
+func parseCustomAttributes(pairs []string) (map[string]string, error) {
+	attributes := map[string]string{}
+	for _, p := range pairs {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid custom attribute %q, expected key=value", p)
+		}
+
+		attributes[strings.TrimSpace(key)] = value
+	}
+
+	return attributes, nil
+}
+
+// newInstallRun returns the install, or the uninstall, of the installer of the context.
+var newInstallRun = func(ic types.InstallerContext, c *newrelic.NewRelic, sg *segment.Segment, planOutput io.Writer, installLogPath string) func() error {
+	i := NewRecipeInstaller(ic, c, sg)
+	if planOutput != nil {
+		// Everything else the install prints goes to STDERR, so the plan can be redirected to a file.
+		i.WithPlanOutput(planOutput).WithOutput(os.Stderr)
+	}
+
+	run := i.Install
+	if ic.Uninstall {
+		run = i.Uninstall
+	}
+
+	return func() error {
+		if ic.SendInstallLogs {
+			defer i.sendInstallLog(installLogPath)
+		}
+
+		return run()
+	}
+}
+
+// installTelemetryDisallowed returns whether telemetry is disabled for every install, with
+// newrelic config set --key installTelemetry --value DISALLOW.
+func installTelemetryDisallowed() bool {
+	t := configAPI.GetConfigTernary(config.InstallTelemetry)
+	return strings.EqualFold(t.String(), config.TernaryValues.Disallow.String())
+}
+
 func validateProfile(maxTimeoutSeconds int, sg *segment.Segment) *types.DetailError {
 	accountID := configAPI.GetActiveProfileAccountID()
 	APIKey := configAPI.GetActiveProfileString(config.APIKey)
@@ -137,9 +606,8 @@ func validateProfile(maxTimeoutSeconds int, sg *segment.Segment) *types.DetailEr
 		return detailErr
 	}
 
-	if !utils.IsValidUserAPIKeyFormat(APIKey) {
+	if detailErr = checkUserAPIKey(APIKey); detailErr != nil {
 		errorOccured = true
-		detailErr = types.NewDetailError(types.EventTypes.InvalidUserAPIKeyFormat, `Invalid user API key format detected. Please provide a valid user API key. User API keys usually have a prefix of "NRAK-" or "NRAA-".`)
 		return detailErr
 	}
 
@@ -151,7 +619,7 @@ func validateProfile(maxTimeoutSeconds int, sg *segment.Segment) *types.DetailEr
 
 	if _, err := nrRegion.Parse(region); err != nil {
 		errorOccured = true
-		detailErr = types.NewDetailError(types.EventTypes.InvalidRegion, `Invalid region provided. Valid regions are "US" or "EU".`)
+		detailErr = types.NewDetailError(types.EventTypes.InvalidRegion, `Invalid region provided. Valid regions are "US", "EU" or "Staging".`)
 		return detailErr
 	}
 
@@ -161,17 +629,322 @@ func validateProfile(maxTimeoutSeconds int, sg *segment.Segment) *types.DetailEr
 		return detailErr
 	}
 
-	licenseKey, err := client.FetchLicenseKey(accountID, config.FlagProfileName, &maxTimeoutSeconds)
+	if client.NRClient != nil {
+		if detailErr = checkAccountAccess(&client.NRClient.Accounts, accountID); detailErr != nil {
+			errorOccured = true
+			return detailErr
+		}
+	}
+
+	// The license key of --licenseKey or an install configuration is used instead of fetching the
+	// account's with the profile's user API key.
+	if licenseKey != "" {
+		if detailErr = checkLicenseKey(licenseKey, region); detailErr != nil {
+			errorOccured = true
+			return detailErr
+		}
+
+		return nil
+	}
+
+	fetchedKey, err := client.FetchLicenseKey(accountID, config.FlagProfileName, &maxTimeoutSeconds)
 	if err != nil {
 		errorOccured = true
-		message := fmt.Sprintf("could not fetch license key for account %d:, license key: %v %s", accountID, utils.Obfuscate(licenseKey), err)
+		message := fmt.Sprintf("could not fetch license key for account %d:, license key: %v %s", accountID, utils.Obfuscate(fetchedKey), err)
 		log.Debug(message)
 		detailErr = types.NewDetailError(types.EventTypes.UnableToFetchLicenseKey, fmt.Sprintf("%s", err))
 		return detailErr
 	}
 
-	os.Setenv("NEW_RELIC_LICENSE_KEY", licenseKey)
-	log.Debugf("using license key %s", utils.Obfuscate(licenseKey))
+	os.Setenv("NEW_RELIC_LICENSE_KEY", fetchedKey)
+	log.Debugf("using license key %s", utils.Obfuscate(fetchedKey))
+
+	return nil
+}
+
+// setRecipeSource sets the mirrors of the recipe library given with --recipeSource, or the comma
+// separated NEW_RELIC_RECIPE_SOURCE environment variable, along with the headers to send to them.
+// An Authorization header can be set with NEW_RELIC_RECIPE_SOURCE_AUTH instead, to keep it out
+// of the command line.
+func setRecipeSource(ic *types.InstallerContext) error {
+	ic.RecipeSources = append([]string{}, recipeSources...)
+	if len(ic.RecipeSources) == 0 && os.Getenv(types.EnvRecipeSource) != "" {
+		ic.RecipeSources = strings.Split(os.Getenv(types.EnvRecipeSource), ",")
+	}
+
+	if len(ic.RecipeSources) == 0 {
+		if len(sourceHeaders) > 0 {
+			return errors.New("--recipeSourceHeader requires --recipeSource")
+		}
+		return nil
+	}
+
+	for idx, s := range ic.RecipeSources {
+		s = strings.TrimSpace(s)
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid recipe source %q, expected an http or https URL", s)
+		}
+		ic.RecipeSources[idx] = s
+	}
+
+	ic.RecipeSourceHeaders = map[string]string{}
+	if auth := os.Getenv(types.EnvRecipeSourceAuth); auth != "" {
+		ic.RecipeSourceHeaders["Authorization"] = auth
+	}
+
+	for _, h := range sourceHeaders {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return fmt.Errorf("invalid recipe source header %q, expected Name: value", h)
+		}
+
+		ic.RecipeSourceHeaders[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return nil
+}
+
+// setInstallHooks sets the hooks of the --hooksFile, or of the default hooks file when it exists,
+// with the --preInstallHook and --postInstallHook flags taking precedence over the file.
+func setInstallHooks(ic *types.InstallerContext) error {
+	path := hooksFile
+	defaultPath := filepath.Join(config.BasePath, execution.DefaultInstallHooksFile)
+	if _, err := os.Stat(defaultPath); err == nil && path == "" {
+		path = defaultPath
+	}
+
+	if path != "" {
+		hooks, err := execution.LoadInstallHooks(path)
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("Using the install hooks of %s", path)
+		ic.Hooks = hooks
+	}
+
+	if preHook != "" {
+		ic.Hooks.PreInstall = preHook
+	}
+	if postHook != "" {
+		ic.Hooks.PostInstall = postHook
+	}
+
+	return nil
+}
+
+// setDiscoveryMatchers loads the discovery matchers of --discoveryMatchers, or of the default matchers
+// file in the configuration directory when it exists.
+func setDiscoveryMatchers(ic *types.InstallerContext) error {
+	path := discMatchers
+	defaultPath := filepath.Join(config.BasePath, recipes.DefaultDiscoveryMatchersFile)
+	if _, err := os.Stat(defaultPath); err == nil && path == "" {
+		path = defaultPath
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	matchers, err := recipes.LoadDiscoveryMatchers(path, recipes.NewMatcherRegistry())
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Using the %d discovery matchers of %s", len(matchers), path)
+	ic.DiscoveryMatchers = matchers
+	return nil
+}
+
+func validateNotifyWebhooks(ic types.InstallerContext) error {
+	webhooks := map[string]string{
+		"notifyWebhook":      ic.NotifyWebhook,
+		"notifySlackWebhook": ic.NotifySlack,
+	}
+
+	for flag, webhook := range webhooks {
+		if webhook == "" {
+			continue
+		}
+
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid --%s %q, expected an http or https URL", flag, webhook)
+		}
+	}
+
+	return nil
+}
+
+// runFleetInstall installs onto each host of the --targetsFile or --ansibleInventory, and reports the combined result.
+func runFleetInstall(cmd *cobra.Command, ic types.InstallerContext) error {
+	if err := validateFleet(ic); err != nil {
+		return NewExitError(err)
+	}
+
+	targets, err := loadFleetTargets()
+	if err != nil {
+		return NewExitError(err)
+	}
+
+	fi, err := NewFleetInstall(targets, FleetArgs(cmd.Flags()), parallelHosts)
+	if err != nil {
+		return NewExitError(err)
+	}
+
+	results, err := fi.Install(utils.SignalCtx)
+
+	return fleetInstallError(ic.StatusFile, results, err)
+}
+
+func loadFleetTargets() ([]FleetTarget, error) {
+	if targetsFile != "" {
+		return LoadTargetsFile(targetsFile)
+	}
+
+	inv, err := LoadAnsibleInventory(ansibleInv)
+	if err != nil {
+		return nil, err
+	}
+
+	return inv.Targets(limit, os.Getenv("USER"))
+}
+
+func validateFleet(ic types.InstallerContext) error {
+	if targetsFile != "" && ansibleInv != "" {
+		return errors.New("--targetsFile can't be used with --ansibleInventory")
+	}
+
+	source := "--targetsFile"
+	if ansibleInv != "" {
+		source = "--ansibleInventory"
+	}
+
+	if ic.Target != "" {
+		return fmt.Errorf("%s can't be used with --target", source)
+	}
+
+	if ic.UI {
+		return fmt.Errorf("%s can't be used with --ui", source)
+	}
+
+	if !ic.AssumeYes {
+		return fmt.Errorf("%s requires --assumeYes", source)
+	}
+
+	if ic.Offline {
+		return fmt.Errorf("%s can't be used with --offline", source)
+	}
+
+	return nil
+}
+
+func validateTarget(ic types.InstallerContext) error {
+	if ic.Target == "" {
+		if ic.SSHKey != "" {
+			return errors.New("--sshKey requires --target")
+		}
+		return nil
+	}
+
+	if _, err := remote.ParseTarget(ic.Target); err != nil {
+		return err
+	}
+
+	// Recipes run over a non-interactive SSH session, so their questions can't be answered.
+	if !ic.AssumeYes {
+		return errors.New("--target requires --assumeYes")
+	}
+
+	if ic.Offline {
+		return errors.New("--target can't be used with --offline")
+	}
+
+	return nil
+}
+
+func validateAdvanced(ic types.InstallerContext) error {
+	if ic.Advanced && ic.AssumeYes {
+		return errors.New("--advanced asks for each setting, and can't be used with --assumeYes")
+	}
+
+	if ic.Review && ic.AssumeYes {
+		return errors.New("--review asks to approve each recipe, and can't be used with --assumeYes")
+	}
+
+	return nil
+}
+
+func validateManifest(ic types.InstallerContext) error {
+	if ic.ManifestIn == "" {
+		return nil
+	}
+
+	// The recipes are resolved for the host of the manifest, so they can't be installed onto this one.
+	if !ic.DryRun {
+		return errors.New("--manifestIn resolves the recipes of the host the manifest was discovered on, and requires --dryRun")
+	}
+
+	if ic.Target != "" {
+		return errors.New("--manifestIn can't be used with --target")
+	}
+
+	return nil
+}
+
+func validateKubernetes(ic types.InstallerContext) error {
+	if !ic.Kubernetes && ic.HelmValuesFile == "" {
+		return nil
+	}
+
+	// Helm applies the chart with the local kubeconfig, so there's no host to install onto.
+	if ic.Target != "" {
+		return errors.New("--kubernetes can't be used with --target")
+	}
+
+	if ic.RecipeNamesProvided() {
+		return errors.New("--kubernetes can't be used with --recipe")
+	}
+
+	return nil
+}
+
+func validateUI(ic types.InstallerContext) error {
+	if !ic.UI {
+		return nil
+	}
+
+	// Prompts and recipes running side by side can't be shown in the full-screen view.
+	if !ic.AssumeYes {
+		return errors.New("--ui requires --assumeYes")
+	}
+
+	if ic.MaxConcurrency > 1 {
+		return errors.New("--ui can't be used with --maxConcurrency")
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("--ui requires a terminal")
+	}
+
+	return nil
+}
+
+// validateOffline checks that an offline install has everything it needs, since the
+// profile's license key can't be fetched without contacting New Relic.
+func validateOffline(ic types.InstallerContext) error {
+	if ic.RecipeBundle == "" {
+		return errors.New("--offline requires a recipe archive provided with --recipeBundle")
+	}
+
+	if os.Getenv("NEW_RELIC_LICENSE_KEY") == "" {
+		return errors.New("--offline requires a license key provided with --licenseKey or the NEW_RELIC_LICENSE_KEY environment variable")
+	}
+
+	if ic.SendInstallLogs {
+		return errors.New("--sendInstallLogs can't be used with --offline")
+	}
 
 	return nil
 }
@@ -0,0 +1,48 @@
+package install
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+var (
+	bundleOutput   string
+	bundlePackages string
+)
+
+var cmdBundle = &cobra.Command{
+	Use:   "bundle",
+	Short: "Create a recipe archive for offline installs.",
+	Long: `Create a recipe archive for offline installs
+
+The bundle command writes the recipes used by the install command, along with
+any agent packages in the --packages directory, to a single archive. Copy the
+archive to a machine without access to New Relic and install from it with
+"newrelic install --offline --recipeBundle <archive>".
+`,
+	Example: "newrelic install bundle --output newrelic-recipes.tar.gz --packages ./packages",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Create(bundleOutput)
+		if err != nil {
+			return fmt.Errorf("could not create recipe archive: %w", err)
+		}
+		defer f.Close()
+
+		if err := recipes.WriteRecipeArchive(f, bundlePackages); err != nil {
+			return fmt.Errorf("could not write recipe archive: %w", err)
+		}
+
+		fmt.Printf("Recipe archive written to %s\n", bundleOutput)
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdBundle)
+	cmdBundle.Flags().StringVarP(&bundleOutput, "output", "o", "newrelic-recipes.tar.gz", "the path to write the recipe archive to")
+	cmdBundle.Flags().StringVarP(&bundlePackages, "packages", "p", "", "a directory of agent packages to include in the archive")
+}
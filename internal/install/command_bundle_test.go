@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestBundleCommand(t *testing.T) {
+	assert.Equal(t, "bundle", cmdBundle.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdBundle)
+	testcobra.CheckCobraRequiredFlags(t, cmdBundle, []string{})
+}
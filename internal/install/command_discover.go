@@ -0,0 +1,61 @@
+package install
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/segment"
+)
+
+var discoverOutput string
+
+var cmdDiscover = &cobra.Command{
+	Use:   "discover",
+	Short: "Print what discovery finds on this host, and the recipes it would recommend.",
+	Long: `Print what discovery finds on this host, and the recipes it would recommend
+
+The discover command runs the discovery of an install without installing
+anything. It prints the OS, platform and environment of the host, the processes
+running on it, and for each recipe whether it would be recommended and why, like
+the process that matched its integration or the install target the host doesn't
+match. Use --output json for the full discovery manifest.
+
+The discovery manifest can be exported with --manifestOut, and the
+recommendations of its host printed on another host with --manifestIn. The host
+discovered by a run of the CLI in the last few minutes is reused, unless
+--refreshDiscovery is set.
+`,
+	Example: "newrelic install discover --output json",
+	PreRun:  client.RequireClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		i := NewRecipeInstaller(ic, client.NRClient, segment.NewNoOp())
+		if err := i.PrintDiscovery(os.Stdout, discoverOutput); err != nil {
+			return NewExitError(err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdDiscover)
+	cmdDiscover.Flags().StringVarP(&discoverOutput, "output", "o", discoveryOutputTable, "the format to print the discovery results in, table or json")
+	cmdDiscover.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to a recipe file, directory or glob pattern of recipe files to discover")
+	cmdDiscover.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
+	cmdDiscover.Flags().StringVarP(&manifestOut, "manifestOut", "", "", "the path to export the discovery manifest of the host to, with its running processes")
+	cmdDiscover.Flags().StringVarP(&manifestIn, "manifestIn", "", "", "the path of a discovery manifest exported with --manifestOut to print the recommendations of its host for, instead of discovering this one")
+	cmdDiscover.Flags().StringVarP(&discMatchers, "discoveryMatchers", "", "", "the path of a YAML file of matchers that recommend custom recipes for in-house services, instead of "+recipes.DefaultDiscoveryMatchersFile+" in the configuration directory")
+	cmdDiscover.Flags().StringArrayVarP(&discInclude, "discoveryInclude", "", []string{}, "a regular expression of the command lines of the processes to scope discovery to, can be multiple")
+	cmdDiscover.Flags().StringArrayVarP(&discExclude, "discoveryExclude", "", []string{}, "a regular expression of the command lines of the processes discovery ignores, can be multiple")
+	cmdDiscover.Flags().DurationVarP(&discTimeout, "discoveryTimeout", "", 0, "the maximum time the discovery of the host may take, e.g. 2m, after which the probes that haven't completed are skipped")
+	cmdDiscover.Flags().BoolVarP(&refreshDisc, "refreshDiscovery", "", false, "discover the host again instead of reusing the discovery of a recent run of the CLI")
+	cmdDiscover.Flags().StringSliceVarP(&skipRecipes, "skipRecipes", "", []string{}, "the names of recipes to exclude from the recommendations, can be multiple")
+}
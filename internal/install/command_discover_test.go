@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestDiscoverCommand(t *testing.T) {
+	assert.Equal(t, "discover", cmdDiscover.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdDiscover)
+	testcobra.CheckCobraRequiredFlags(t, cmdDiscover, []string{})
+}
@@ -0,0 +1,118 @@
+package install
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	lockfilePath string
+	lockRecipes  []string
+)
+
+var cmdLock = &cobra.Command{
+	Use:   "lock",
+	Short: "Generate and verify recipe lockfiles.",
+	Long: `Generate and verify recipe lockfiles
+
+A recipe lockfile pins recipes to the version of the recipe library they're
+installed from, along with a checksum of each recipe. Installs run with the
+newrelic-recipes.lock in the working directory, or the --lockfile given, so
+repeated installs use exactly the same recipes.
+`,
+	Example: "newrelic install lock generate --recipe mysql-open-source-integration@0.120.0",
+}
+
+var cmdLockGenerate = &cobra.Command{
+	Use:   "generate",
+	Short: "Write a lockfile of the current recipe versions.",
+	Long: `Write a lockfile of the current recipe versions
+
+The generate command locks the recipes given with --recipe, or every recipe when
+none are given, to the version of the recipe library they're fetched from.
+Recipes given as name@version are locked to that version instead.
+`,
+	Example: "newrelic install lock generate --recipe infrastructure-agent-installer,logs-integration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ic := types.InstallerContext{}
+		if err := ic.SetRecipeNames(lockRecipes); err != nil {
+			return NewExitError(err)
+		}
+
+		if err := setRecipeSource(&ic); err != nil {
+			return NewExitError(err)
+		}
+
+		lock, err := GenerateRecipeLock(utils.SignalCtx, newRecipeFetcher(ic), ic.RecipeNames, ic.RecipeVersions)
+		if err != nil {
+			return NewExitError(fmt.Errorf("could not generate recipe lockfile: %w", err))
+		}
+
+		f, err := os.Create(lockfilePath)
+		if err != nil {
+			return NewExitError(fmt.Errorf("could not create recipe lockfile: %w", err))
+		}
+		defer f.Close()
+
+		if err := lock.Write(f); err != nil {
+			return NewExitError(fmt.Errorf("could not write recipe lockfile: %w", err))
+		}
+
+		fmt.Printf("Locked %d recipes in %s\n", len(lock.Recipes), lockfilePath)
+		return nil
+	},
+}
+
+var cmdLockVerify = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that the locked recipes are unchanged.",
+	Long: `Check that the locked recipes are unchanged
+
+The verify command fetches each recipe of the lockfile at its locked version, and
+fails when any of them no longer matches its checksum.
+`,
+	Example: "newrelic install lock verify --lockfile newrelic-recipes.lock",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lock, err := LoadRecipeLock(lockfilePath)
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		ic := types.InstallerContext{RecipeVersions: lock.Versions()}
+		if err := setRecipeSource(&ic); err != nil {
+			return NewExitError(err)
+		}
+
+		mismatched, err := VerifyRecipeLock(utils.SignalCtx, newRecipeFetcher(ic), lock)
+		if err != nil {
+			return NewExitError(fmt.Errorf("could not verify recipe lockfile: %w", err))
+		}
+
+		for _, name := range mismatched {
+			fmt.Printf("  %s %s does not match the lockfile\n", ux.IconError, name)
+		}
+
+		if len(mismatched) > 0 {
+			return NewExitError(fmt.Errorf("%d of %d locked recipes do not match %s", len(mismatched), len(lock.Recipes), lockfilePath))
+		}
+
+		fmt.Printf("  %s All %d locked recipes match %s\n", ux.IconSuccess, len(lock.Recipes), lockfilePath)
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdLock)
+	cmdLock.PersistentFlags().StringVarP(&lockfilePath, "lockfile", "", DefaultRecipeLockFile, "the path of the recipe lockfile")
+
+	cmdLock.AddCommand(cmdLockGenerate)
+	cmdLockGenerate.Flags().StringSliceVarP(&lockRecipes, "recipe", "n", []string{}, "the name of a recipe to lock, or name@version to lock it to that version of the recipe library")
+
+	cmdLock.AddCommand(cmdLockVerify)
+}
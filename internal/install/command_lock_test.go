@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestLockCommand(t *testing.T) {
+	assert.Equal(t, "lock", cmdLock.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdLock)
+	testcobra.CheckCobraRequiredFlags(t, cmdLock, []string{})
+}
@@ -0,0 +1,134 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+)
+
+var (
+	planOutput string
+)
+
+var cmdPlan = &cobra.Command{
+	Use:   "plan",
+	Short: "Write the install plan to a file that can be reviewed and applied.",
+	Long: `Write the install plan to a file that can be reviewed and applied
+
+The plan command resolves the recipes an install would run on this host, along
+with the version of each recipe and the variables it would use, and writes them
+as YAML without installing anything. Secrets such as the license key are never
+written to the plan. Review the plan, then install exactly what it contains on
+any host with "newrelic install apply".
+`,
+	Example: "newrelic install plan --recipe mysql-open-source-integration --var MYSQL_PORT=3306 > plan.yaml",
+	PreRun:  client.RequireClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		// A plan never prompts, and is built without executing any recipe.
+		ic.DryRun = true
+		ic.AssumeYes = true
+
+		if err := validateTarget(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		if err := validateManifest(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		var out io.Writer = os.Stdout
+		if planOutput != "" {
+			f, err := os.Create(planOutput)
+			if err != nil {
+				return NewExitError(fmt.Errorf("could not create install plan: %w", err))
+			}
+			defer f.Close()
+			out = f
+		}
+
+		return runInstall(ic, out)
+	},
+}
+
+var cmdApply = &cobra.Command{
+	Use:   "apply <plan>",
+	Short: "Install the recipes of an install plan.",
+	Long: `Install the recipes of an install plan
+
+The apply command installs the recipes of a plan written by "newrelic install
+plan", using the variables it contains. Variables set with --var take precedence
+over those of the plan. The install stops before anything is installed when any
+recipe has changed since the plan was written.
+`,
+	Example: "newrelic install apply plan.yaml --assumeYes",
+	Args:    cobra.ExactArgs(1),
+	PreRun:  client.RequireClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := LoadInstallPlan(args[0])
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		inputs, err := plan.Inputs()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		for k, v := range ic.RecipeVars {
+			inputs[k] = v
+		}
+		ic.RecipeVars = inputs
+		ic.RecipeNames = plan.RecipeNames()
+		ic.RecipeVersions = plan.Versions()
+		ic.RecipeChecksums = plan.Checksums()
+
+		if err := validateTarget(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		return runInstall(ic, nil)
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdPlan)
+	cmdPlan.Flags().StringVarP(&planOutput, "output", "o", "", "the path to write the install plan to, instead of STDOUT")
+	cmdPlan.Flags().StringSliceVarP(&recipeNames, "recipe", "n", []string{}, "the name of a recipe to plan, or name@version to plan the recipe released in that version of the recipe library")
+	cmdPlan.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to a recipe file, directory or glob pattern of recipe files to plan")
+	cmdPlan.Flags().StringSliceVarP(&skipRecipes, "skipRecipes", "", []string{}, "the names of recipes to exclude from the plan, can be multiple")
+	cmdPlan.Flags().StringArrayVarP(&discInclude, "discoveryInclude", "", []string{}, "a regular expression of the command lines of the processes to scope discovery to, can be multiple")
+	cmdPlan.Flags().StringArrayVarP(&discExclude, "discoveryExclude", "", []string{}, "a regular expression of the command lines of the processes discovery ignores, can be multiple")
+	cmdPlan.Flags().DurationVarP(&discTimeout, "discoveryTimeout", "", 0, "the maximum time the discovery of the host may take, e.g. 2m, after which the probes that haven't completed are skipped")
+	cmdPlan.Flags().BoolVarP(&refreshDisc, "refreshDiscovery", "", false, "discover the host again instead of reusing the discovery of a recent run of the CLI")
+	cmdPlan.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
+	cmdPlan.Flags().StringArrayVarP(&vars, "var", "", []string{}, "a recipe variable to set in the plan, can be multiple. Example: --var MYSQL_PORT=3306")
+	cmdPlan.Flags().StringVarP(&varFile, "varFile", "", "", "the path of a .env or .yaml file of recipe variables to set in the plan, which --var takes precedence over")
+	cmdPlan.Flags().StringVarP(&target, "target", "", "", "a remote host to plan the install of over SSH instead of this one, as user@host[:port]")
+	cmdPlan.Flags().StringVarP(&manifestOut, "manifestOut", "", "", "the path to export the discovery manifest of the host to, with its running processes")
+	cmdPlan.Flags().StringVarP(&manifestIn, "manifestIn", "", "", "the path of a discovery manifest exported with --manifestOut to plan the install of its host with, instead of discovering this one")
+	cmdPlan.Flags().StringVarP(&sshKey, "sshKey", "", "", "the path of the private key to connect to --target with, instead of the SSH agent and configuration")
+	cmdPlan.Flags().StringVarP(&proxy, "proxy", "", "", "the URL of an HTTP proxy to connect through")
+
+	Command.AddCommand(cmdApply)
+	cmdApply.Flags().BoolVarP(&assumeYes, "assumeYes", "y", false, "use \"yes\" for all questions during install")
+	cmdApply.Flags().StringArrayVarP(&vars, "var", "", []string{}, "a recipe variable to set instead of the plan's, can be multiple. Example: --var MYSQL_PORT=3306")
+	cmdApply.Flags().StringVarP(&statusFile, "statusFile", "", "", "the path of a JSON file to write the result, error, entity GUID and duration of each attempted recipe to")
+	cmdApply.Flags().StringVarP(&target, "target", "", "", "a remote host to apply the plan to over SSH instead of this one, as user@host[:port], requires --assumeYes")
+	cmdApply.Flags().StringVarP(&sshKey, "sshKey", "", "", "the path of the private key to connect to --target with, instead of the SSH agent and configuration")
+	cmdApply.Flags().StringVarP(&proxy, "proxy", "", "", "the URL of an HTTP proxy to install through, which is also configured for the infrastructure agent")
+	cmdApply.Flags().StringSliceVarP(&tags, "tag", "", []string{}, "the tags to add during install and to the entities it creates, can be multiple. Example: --tag tag1:test,tag2:test")
+}
@@ -0,0 +1,25 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestPlanCommand(t *testing.T) {
+	assert.Equal(t, "plan", cmdPlan.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdPlan)
+	testcobra.CheckCobraRequiredFlags(t, cmdPlan, []string{})
+}
+
+func TestApplyCommand(t *testing.T) {
+	assert.Equal(t, "apply", cmdApply.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdApply)
+	testcobra.CheckCobraRequiredFlags(t, cmdApply, []string{})
+}
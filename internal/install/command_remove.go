@@ -0,0 +1,61 @@
+package install
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	removeRecipe string
+)
+
+var cmdRemove = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a single integration installed by a recipe.",
+	Long: `Remove a single integration installed by a recipe
+
+The remove command runs the uninstall steps of one recipe, removing the
+configuration and package of its integration. The infrastructure agent and the
+other integrations on the host are left as they are. Use "newrelic install
+--uninstall" to remove everything that was installed instead.
+`,
+	Example: "newrelic install remove --recipeName nginx-open-source-integration",
+	PreRun:  client.RequireClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if removeRecipe == types.InfraAgentRecipeName {
+			return NewExitError(fmt.Errorf("the integrations rely on the infrastructure agent, use \"newrelic install --uninstall\" to remove it with them"))
+		}
+
+		recipeNames = []string{removeRecipe}
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		ic.Uninstall = true
+
+		if err := validateTarget(ic); err != nil {
+			return NewExitError(err)
+		}
+
+		return runInstall(ic, nil)
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdRemove)
+	cmdRemove.Flags().StringVarP(&removeRecipe, "recipeName", "n", "", "the name of the recipe whose integration to remove")
+	cmdRemove.Flags().BoolVarP(&assumeYes, "assumeYes", "y", false, "use \"yes\" for all questions during the removal")
+	cmdRemove.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to the recipe file of the integration to remove, instead of the recipe library")
+	cmdRemove.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
+	cmdRemove.Flags().StringArrayVarP(&vars, "var", "", []string{}, "a recipe variable to set, can be multiple. Example: --var MYSQL_PORT=3306")
+	cmdRemove.Flags().StringVarP(&target, "target", "", "", "a remote host to remove the integration from over SSH instead of this one, as user@host[:port], requires --assumeYes")
+	cmdRemove.Flags().StringVarP(&sshKey, "sshKey", "", "", "the path of the private key to connect to --target with, instead of the SSH agent and configuration")
+	cmdRemove.Flags().StringVarP(&proxy, "proxy", "", "", "the URL of an HTTP proxy to connect through")
+	utils.LogIfError(cmdRemove.MarkFlagRequired("recipeName"))
+}
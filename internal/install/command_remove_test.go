@@ -0,0 +1,30 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestRemoveCommand(t *testing.T) {
+	assert.Equal(t, "remove", cmdRemove.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdRemove)
+	testcobra.CheckCobraRequiredFlags(t, cmdRemove, []string{"recipeName"})
+}
+
+func TestRemoveCommandShouldNotRemoveInfraAgent(t *testing.T) {
+	removeRecipe = types.InfraAgentRecipeName
+	defer func() { removeRecipe = "" }()
+
+	err := cmdRemove.RunE(cmdRemove, []string{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--uninstall")
+}
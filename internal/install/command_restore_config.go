@@ -0,0 +1,96 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreBackup string
+	restoreList   bool
+)
+
+var cmdRestoreConfig = &cobra.Command{
+	Use:   "restore-config",
+	Short: "Restore the configuration files a recipe changed.",
+	Long: `Restore the configuration files a recipe changed
+
+Before a recipe changes the configuration of the infrastructure agent or the
+logs integration, the files are backed up. The restore-config command reverts
+the files of the most recent backup, or of the backup given with --backup, to
+how they were before the recipe ran, and removes the files the recipe created.
+Use --list to list the backups. Restart the infrastructure agent afterwards for
+the restored configuration to take effect.
+`,
+	Example: "newrelic install restore-config --backup 20231204-101500-logs-integration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backupPath := GetDefaultConfigBackupPath()
+		backups, err := ListConfigBackups(backupPath)
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		if restoreList {
+			printConfigBackups(backups)
+			return nil
+		}
+
+		var backup *ConfigBackup
+		if restoreBackup != "" {
+			if backup, err = LoadConfigBackup(backupPath, restoreBackup); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return NewExitError(fmt.Errorf("no configuration backup is named %s", restoreBackup))
+				}
+				return NewExitError(err)
+			}
+		} else if len(backups) > 0 {
+			backup = backups[0]
+		}
+
+		if backup == nil {
+			fmt.Println("No configuration files were backed up.")
+			return nil
+		}
+
+		if err := RestoreConfigBackup(backupPath, backup); err != nil {
+			return NewExitError(fmt.Errorf("could not restore configuration backup %s: %w", backup.Name, err))
+		}
+
+		fmt.Printf("Restored the configuration files %s changed:\n", backup.Recipe)
+		for _, f := range backup.Files {
+			action := "restored"
+			if !f.Existed {
+				action = "removed"
+			}
+			fmt.Printf("  %s (%s)\n", f.Path, action)
+		}
+		fmt.Println("Restart the infrastructure agent for the restored configuration to take effect.")
+
+		return nil
+	},
+}
+
+func printConfigBackups(backups []*ConfigBackup) {
+	if len(backups) == 0 {
+		fmt.Println("No configuration files were backed up.")
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Backup", "Recipe", "Files"})
+	for _, b := range backups {
+		t.AppendRow(table.Row{b.Name, b.Recipe, len(b.Files)})
+	}
+	t.Render()
+}
+
+func init() {
+	Command.AddCommand(cmdRestoreConfig)
+	cmdRestoreConfig.Flags().StringVarP(&restoreBackup, "backup", "", "", "the name of the backup to restore, instead of the most recent")
+	cmdRestoreConfig.Flags().BoolVarP(&restoreList, "list", "", false, "list the backups of configuration files instead of restoring one")
+}
@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestRestoreConfigCommand(t *testing.T) {
+	assert.Equal(t, "restore-config", cmdRestoreConfig.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdRestoreConfig)
+	testcobra.CheckCobraRequiredFlags(t, cmdRestoreConfig, []string{})
+}
@@ -0,0 +1,44 @@
+package install
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/install/segment"
+)
+
+var cmdStatus = &cobra.Command{
+	Use:   "status",
+	Short: "List the New Relic instrumentation installed on this host.",
+	Long: `List the New Relic instrumentation installed on this host
+
+The status command inspects this host for the infrastructure agent, the logs
+integration and the integrations installed by recipes, using the files, packages
+and services each recipe installs. It prints the installed version of each
+component, when known, and whether its services are running. Nothing is
+installed or changed.
+`,
+	Example: "newrelic install status",
+	PreRun:  client.RequireClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		i := NewRecipeInstaller(ic, client.NRClient, segment.NewNoOp())
+		if err := i.PrintHostStatus(os.Stdout); err != nil {
+			return NewExitError(err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdStatus)
+	cmdStatus.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to a recipe file, directory or glob pattern of recipe files to check for")
+	cmdStatus.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
+}
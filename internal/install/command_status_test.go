@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestStatusCommand(t *testing.T) {
+	assert.Equal(t, "status", cmdStatus.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdStatus)
+	testcobra.CheckCobraRequiredFlags(t, cmdStatus, []string{})
+}
@@ -3,17 +3,22 @@
 package install
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/newrelic/newrelic-cli/internal/config"
 	"github.com/newrelic/newrelic-cli/internal/install/segment"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/testcobra"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
 )
 
 func TestInstallCommand(t *testing.T) {
@@ -87,3 +92,150 @@ func getAccountIDAsInt(accountID string) int {
 	i, _ := strconv.Atoi(accountID)
 	return i
 }
+
+func TestValidateTarget(t *testing.T) {
+	assert.NoError(t, validateTarget(types.InstallerContext{}))
+	assert.NoError(t, validateTarget(types.InstallerContext{Target: "ubuntu@10.0.0.1:2222", SSHKey: "id_rsa", AssumeYes: true}))
+
+	assert.Error(t, validateTarget(types.InstallerContext{SSHKey: "id_rsa"}))
+	assert.Error(t, validateTarget(types.InstallerContext{Target: "10.0.0.1", AssumeYes: true}))
+	assert.Error(t, validateTarget(types.InstallerContext{Target: "ubuntu@10.0.0.1"}))
+	assert.Error(t, validateTarget(types.InstallerContext{Target: "ubuntu@10.0.0.1", AssumeYes: true, Offline: true}))
+}
+
+func TestValidateKubernetes(t *testing.T) {
+	assert.NoError(t, validateKubernetes(types.InstallerContext{Target: "ubuntu@10.0.0.1"}))
+	assert.NoError(t, validateKubernetes(types.InstallerContext{Kubernetes: true, ClusterName: "prod"}))
+
+	assert.Error(t, validateKubernetes(types.InstallerContext{Kubernetes: true, Target: "ubuntu@10.0.0.1"}))
+	assert.Error(t, validateKubernetes(types.InstallerContext{HelmValuesFile: "values.yaml", RecipeNames: []string{"mysql-open-source-integration"}}))
+}
+
+func TestValidateAdvancedShouldRejectAssumeYes(t *testing.T) {
+	err := validateAdvanced(types.InstallerContext{Advanced: true, AssumeYes: true})
+	assert.Error(t, err)
+
+	err = validateAdvanced(types.InstallerContext{Advanced: true})
+	assert.NoError(t, err)
+
+	err = validateAdvanced(types.InstallerContext{Review: true, AssumeYes: true})
+	assert.Error(t, err)
+
+	err = validateAdvanced(types.InstallerContext{Review: true})
+	assert.NoError(t, err)
+}
+
+func TestValidateManifestShouldRequireDryRun(t *testing.T) {
+	assert.NoError(t, validateManifest(types.InstallerContext{ManifestOut: "manifest.json"}))
+	assert.NoError(t, validateManifest(types.InstallerContext{ManifestIn: "manifest.json", DryRun: true}))
+
+	assert.Error(t, validateManifest(types.InstallerContext{ManifestIn: "manifest.json"}))
+	assert.Error(t, validateManifest(types.InstallerContext{ManifestIn: "manifest.json", DryRun: true, Target: "ubuntu@10.0.0.1"}))
+}
+
+func TestValidateOfflineShouldRequireRecipeBundle(t *testing.T) {
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "0123456789abcdef")
+
+	err := validateOffline(types.InstallerContext{Offline: true})
+	assert.Error(t, err)
+
+	err = validateOffline(types.InstallerContext{Offline: true, RecipeBundle: "bundle.tar.gz"})
+	assert.NoError(t, err)
+
+	err = validateOffline(types.InstallerContext{Offline: true, RecipeBundle: "bundle.tar.gz", SendInstallLogs: true})
+	assert.Error(t, err)
+}
+
+func TestNewInstallerContextShouldRejectNegativeMaxRetries(t *testing.T) {
+	defer func(v int) { maxRetries = v }(maxRetries)
+	maxRetries = -1
+
+	_, err := newInstallerContext()
+
+	assert.EqualError(t, err, "invalid --maxRetries -1, expected a positive number")
+}
+
+func TestRunInstallShouldExitWithCanceledCodeWhenInterrupted(t *testing.T) {
+	config.Init(t.TempDir())
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "0123456789abcdef")
+	// The install log wraps the default transport of the process.
+	defer func(transport http.RoundTripper) { http.DefaultTransport = transport }(http.DefaultTransport)
+	defer func(run func(types.InstallerContext, *newrelic.NewRelic, *segment.Segment, io.Writer, string) func() error) {
+		newInstallRun = run
+	}(newInstallRun)
+	newInstallRun = func(types.InstallerContext, *newrelic.NewRelic, *segment.Segment, io.Writer, string) func() error {
+		return func() error {
+			return fmt.Errorf("installing recipe: %w", types.ErrInterrupt)
+		}
+	}
+
+	err := runInstall(types.InstallerContext{Offline: true, RecipeBundle: "bundle.tar.gz", NoTelemetry: true}, nil)
+
+	exitErr, ok := err.(*ExitError)
+	assert.True(t, ok)
+	assert.Equal(t, ExitCodeCanceled, exitErr.ExitCode())
+}
+
+func TestInstallTelemetryDisallowedShouldUseConfig(t *testing.T) {
+	config.Init(t.TempDir())
+
+	t.Setenv("NEW_RELIC_CLI_INSTALLTELEMETRY", "")
+	assert.False(t, installTelemetryDisallowed())
+
+	t.Setenv("NEW_RELIC_CLI_INSTALLTELEMETRY", "ALLOW")
+	assert.False(t, installTelemetryDisallowed())
+
+	t.Setenv("NEW_RELIC_CLI_INSTALLTELEMETRY", "disallow")
+	assert.True(t, installTelemetryDisallowed())
+}
+
+func TestParseCustomAttributes(t *testing.T) {
+	attributes, err := parseCustomAttributes([]string{"team=payments", "owner=a=b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "payments", "owner": "a=b"}, attributes)
+
+	_, err = parseCustomAttributes([]string{"payments"})
+	assert.Error(t, err)
+}
+
+func TestSetRecipeSourceShouldUseEnvironmentAndHeaders(t *testing.T) {
+	t.Setenv(types.EnvRecipeSource, "https://mirror.example.com/open-install-library")
+	t.Setenv(types.EnvRecipeSourceAuth, "Bearer token")
+	sourceHeaders = []string{"X-Team: observability"}
+	defer func() {
+		sourceHeaders = nil
+	}()
+
+	ic := types.InstallerContext{}
+	err := setRecipeSource(&ic)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://mirror.example.com/open-install-library"}, ic.RecipeSources)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token", "X-Team": "observability"}, ic.RecipeSourceHeaders)
+}
+
+func TestSetRecipeSourceShouldRejectInvalidSource(t *testing.T) {
+	t.Setenv(types.EnvRecipeSource, "mirror.example.com")
+
+	err := setRecipeSource(&types.InstallerContext{})
+	assert.Error(t, err)
+}
+
+func TestSetInstallHooksShouldPreferFlagsOverHooksFile(t *testing.T) {
+	dir := t.TempDir()
+	hooksFile = filepath.Join(dir, "hooks.yml")
+	preHook = "/opt/hooks/create-ticket.sh"
+	defer func() {
+		hooksFile = ""
+		preHook = ""
+	}()
+	content := "preInstall: pre.sh\npostInstall: post.sh\n"
+	assert.NoError(t, os.WriteFile(hooksFile, []byte(content), 0600))
+
+	ic := types.InstallerContext{}
+	err := setInstallHooks(&ic)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/opt/hooks/create-ticket.sh", ic.Hooks.PreInstall)
+	assert.Equal(t, filepath.Join(dir, "post.sh"), ic.Hooks.PostInstall)
+}
@@ -0,0 +1,105 @@
+package install
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+var cmdTrust = &cobra.Command{
+	Use:   "trust",
+	Short: "Manage the keys recipe signatures are verified with.",
+	Long: `Manage the keys recipe signatures are verified with
+
+Recipes fetched from a URL or the recipe library are verified with the detached
+cosign (.sig) or GPG (.asc) signature published next to them, using the keys of
+the trust store. A recipe with a signature that doesn't match a trusted key is
+never installed. Use "newrelic install --requireSignedRecipes" to also reject
+recipes that aren't signed.
+`,
+	Example: "newrelic install trust add recipes-team ./cosign.pub",
+}
+
+var cmdTrustAdd = &cobra.Command{
+	Use:   "add <name> <keyFile>",
+	Short: "Trust a cosign or GPG public key.",
+	Long: `Trust a cosign or GPG public key
+
+The add command adds a cosign PEM public key, or an armored GPG public key, to
+the trust store under the given name, replacing any key with the same name.
+`,
+	Example: "newrelic install trust add recipes-team ./recipes-team.asc",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return NewExitError(fmt.Errorf("could not read key %s: %w", args[1], err))
+		}
+
+		key, err := recipes.NewTrustStore(recipes.GetDefaultTrustStorePath()).Add(args[0], data)
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		fmt.Printf("Added %s key %s with fingerprint %s\n", key.Type, key.Name, key.Fingerprint)
+		return nil
+	},
+}
+
+var cmdTrustList = &cobra.Command{
+	Use:     "list",
+	Short:   "List the trusted keys.",
+	Long:    "List the name, type and fingerprint of each key of the trust store\n",
+	Example: "newrelic install trust list",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys, err := recipes.NewTrustStore(recipes.GetDefaultTrustStorePath()).List()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		if len(keys) == 0 {
+			fmt.Println("No keys are trusted, add one with \"newrelic install trust add\".")
+			return nil
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"Name", "Type", "Fingerprint"})
+		for _, k := range keys {
+			t.AppendRow(table.Row{k.Name, k.Type, k.Fingerprint})
+		}
+		t.Render()
+
+		return nil
+	},
+}
+
+var cmdTrustRemove = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Stop trusting a key.",
+	Long:    "Remove the key with the given name from the trust store\n",
+	Example: "newrelic install trust remove recipes-team",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := recipes.NewTrustStore(recipes.GetDefaultTrustStorePath()).Remove(args[0]); err != nil {
+			if os.IsNotExist(err) {
+				return NewExitError(fmt.Errorf("no trusted key is named %s", args[0]))
+			}
+			return NewExitError(err)
+		}
+
+		fmt.Printf("Removed key %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdTrust)
+	cmdTrust.AddCommand(cmdTrustAdd)
+	cmdTrust.AddCommand(cmdTrustList)
+	cmdTrust.AddCommand(cmdTrustRemove)
+}
@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestTrustCommand(t *testing.T) {
+	assert.Equal(t, "trust", cmdTrust.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdTrust)
+	testcobra.CheckCobraRequiredFlags(t, cmdTrust, []string{})
+}
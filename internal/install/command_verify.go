@@ -0,0 +1,47 @@
+package install
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/client"
+	"github.com/newrelic/newrelic-cli/internal/install/segment"
+)
+
+var cmdVerify = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that the New Relic instrumentation installed on this host is reporting data.",
+	Long: `Check that the New Relic instrumentation installed on this host is reporting data
+
+The verify command finds the components the recipes installed on this host, like
+the status command, and runs the validation query of each component's recipe to
+check that its data is still reaching New Relic. It can be run any time after an
+install, to find out which integrations stopped reporting. The command exits
+with an error when any of the components isn't reporting data. Nothing is
+installed or changed.
+`,
+	Example: "newrelic install verify",
+	PreRun:  client.RequireClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ic, err := newInstallerContext()
+		if err != nil {
+			return NewExitError(err)
+		}
+
+		i := NewRecipeInstaller(ic, client.NRClient, segment.NewNoOp())
+		if err := i.VerifyHostStatus(os.Stdout); err != nil {
+			return NewExitError(err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(cmdVerify)
+	cmdVerify.Flags().StringSliceVarP(&recipePaths, "recipePath", "c", []string{}, "the path to a recipe file, directory or glob pattern of recipe files to check for")
+	cmdVerify.Flags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to load instead of service other fetching")
+	cmdVerify.Flags().BoolVarP(&refreshDisc, "refreshDiscovery", "", false, "discover the host again instead of reusing the discovery of a recent run of the CLI")
+	cmdVerify.Flags().StringArrayVarP(&vars, "var", "", []string{}, "a recipe variable to run the validation queries with, can be multiple. Example: --var MYSQL_PORT=3306")
+}
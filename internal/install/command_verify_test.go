@@ -0,0 +1,18 @@
+//go:build unit
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestVerifyCommand(t *testing.T) {
+	assert.Equal(t, "verify", cmdVerify.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdVerify)
+	testcobra.CheckCobraRequiredFlags(t, cmdVerify, []string{})
+}
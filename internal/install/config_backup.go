@@ -0,0 +1,334 @@
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+)
+
+const (
+	// DefaultConfigBackupDir is the directory, beneath the CLI's configuration, the configuration files
+	// recipes change are backed up to.
+	DefaultConfigBackupDir   = "config-backups"
+	configBackupManifestFile = "backup.json"
+)
+
+// agentConfigFilePatterns are the configuration files of the infrastructure agent and the logs
+// integration that are backed up before recipes change them, by OS.
+var agentConfigFilePatterns = map[string][]string{
+	"linux": {
+		"/etc/newrelic-infra.yml",
+		"/etc/newrelic-infra/logging.d/*",
+	},
+	"darwin": {
+		"/usr/local/etc/newrelic-infra/newrelic-infra.yml",
+		"/usr/local/etc/newrelic-infra/logging.d/*",
+	},
+	"windows": {
+		`C:\Program Files\New Relic\newrelic-infra\newrelic-infra.yml`,
+		`C:\Program Files\New Relic\newrelic-infra\logging.d\*`,
+	},
+}
+
+// sensitiveConfigLineRegex matches the settings of configuration files whose values aren't printed
+// in the diffs of the changes recipes make to them.
+var sensitiveConfigLineRegex = regexp.MustCompile(`(?i)^([-+ ]\s*[\w.-]*(license_key|api_key|password|passwd|secret|token)[\w.-]*\s*[:=]\s*)(\S.*)$`)
+
+// ConfigBackup is a backup of the configuration files a recipe changed, as they were before it ran.
+type ConfigBackup struct {
+	Name      string             `json:"-"`
+	Recipe    string             `json:"recipe"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Files     []ConfigBackupFile `json:"files"`
+	// Diff is the unified diff of the changes the recipe made to the files.
+	Diff string `json:"-"`
+}
+
+// ConfigBackupFile is a configuration file a recipe changed. Files the recipe created are removed
+// when the backup is restored.
+type ConfigBackupFile struct {
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+	Backup  string `json:"backup,omitempty"`
+}
+
+// configSnapshot is the contents of the configuration files before a recipe runs, by path.
+type configSnapshot map[string][]byte
+
+// configChanges are the backups of the configuration files changed by each recipe of the install.
+type configChanges struct {
+	mu      sync.Mutex
+	backups map[string]*ConfigBackup
+}
+
+func GetDefaultConfigBackupPath() string {
+	return filepath.Join(config.BasePath, DefaultConfigBackupDir)
+}
+
+// snapshotConfigFiles reads the configuration files recipes may change, so the ones they change can
+// be backed up afterwards. Recipes installed onto a target change the files of the target instead.
+func (i *RecipeInstall) snapshotConfigFiles() configSnapshot {
+	if len(i.configFilePatterns) == 0 || i.Target != "" {
+		return nil
+	}
+
+	return readConfigFiles(i.configFilePatterns)
+}
+
+// backupChangedConfigFiles backs up the configuration files the recipe changed since the snapshot,
+// as they were before it ran, with the diff of the changes to print once the recipe completes.
+func (i *RecipeInstall) backupChangedConfigFiles(recipeName string, before configSnapshot) {
+	if before == nil {
+		return
+	}
+
+	after := readConfigFiles(i.configFilePatterns)
+
+	paths := []string{}
+	for p := range before {
+		paths = append(paths, p)
+	}
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	backup := &ConfigBackup{
+		Name:      fmt.Sprintf("%s-%s", time.Now().Format("20060102-150405"), recipeName),
+		Recipe:    recipeName,
+		CreatedAt: time.Now(),
+		Files:     []ConfigBackupFile{},
+	}
+	diffs := []string{}
+	for _, p := range paths {
+		original, existed := before[p]
+		changed, exists := after[p]
+		if existed == exists && string(original) == string(changed) {
+			continue
+		}
+
+		f := ConfigBackupFile{Path: p, Existed: existed}
+		if existed {
+			f.Backup = strconv.Itoa(len(backup.Files))
+		}
+		backup.Files = append(backup.Files, f)
+		diffs = append(diffs, configDiff(p, original, changed))
+	}
+
+	if len(backup.Files) == 0 {
+		return
+	}
+	backup.Diff = strings.Join(diffs, "")
+
+	if err := saveConfigBackup(i.configBackupPath, backup, before); err != nil {
+		log.Warnf("Could not back up the configuration files %s changed: %s", recipeName, err)
+		return
+	}
+
+	log.Debugf("backed up the configuration files changed by %s to %s", recipeName, backup.Name)
+	i.configChanges.mu.Lock()
+	defer i.configChanges.mu.Unlock()
+	i.configChanges.backups[recipeName] = backup
+}
+
+// printConfigChanges prints the changes the recipe made to the configuration files, and how to
+// restore them.
+func (i *RecipeInstall) printConfigChanges(w io.Writer, recipeName string) {
+	if i.configChanges == nil {
+		return
+	}
+
+	i.configChanges.mu.Lock()
+	backup, ok := i.configChanges.backups[recipeName]
+	i.configChanges.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	fmt.Fprintf(w, "\n  %s changed these configuration files:\n\n", recipeName)
+	for _, line := range strings.Split(strings.TrimRight(backup.Diff, "\n"), "\n") {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+	fmt.Fprintf(w, "\n  The original files were backed up, restore them with \"newrelic install restore-config --backup %s\".\n", backup.Name)
+}
+
+// restoreChangedConfigFiles restores the configuration files the recipe changed to how they were
+// before it ran, returning whether it changed any.
+func (i *RecipeInstall) restoreChangedConfigFiles(recipeName string) (bool, error) {
+	if i.configChanges == nil {
+		return false, nil
+	}
+
+	i.configChanges.mu.Lock()
+	backup, ok := i.configChanges.backups[recipeName]
+	i.configChanges.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+
+	return true, RestoreConfigBackup(i.configBackupPath, backup)
+}
+
+func readConfigFiles(patterns []string) configSnapshot {
+	files := configSnapshot{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			log.Debugf("invalid configuration file pattern %s: %s", pattern, err)
+			continue
+		}
+
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil || info.IsDir() {
+				continue
+			}
+
+			data, err := os.ReadFile(m)
+			if err != nil {
+				log.Debugf("could not read configuration file %s: %s", m, err)
+				continue
+			}
+			files[m] = data
+		}
+	}
+
+	return files
+}
+
+// configDiff returns the unified diff of the changes to a configuration file, without the values of
+// its sensitive settings.
+func configDiff(path string, before []byte, after []byte) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Sprintf("could not diff %s: %s\n", path, err)
+	}
+
+	lines := strings.Split(diff, "\n")
+	for idx, line := range lines {
+		lines[idx] = sensitiveConfigLineRegex.ReplaceAllString(line, "${1}<redacted>")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func saveConfigBackup(backupPath string, backup *ConfigBackup, before configSnapshot) error {
+	dir := filepath.Join(backupPath, backup.Name)
+	// The configuration files have the license key, so only the user may read their backups.
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	for _, f := range backup.Files {
+		if !f.Existed {
+			continue
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, f.Backup), before[f.Path], 0600); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, configBackupManifestFile), data, 0600)
+}
+
+// ListConfigBackups returns the backups of configuration files, the most recent first.
+func ListConfigBackups(backupPath string) ([]*ConfigBackup, error) {
+	entries, err := os.ReadDir(backupPath)
+	if os.IsNotExist(err) {
+		return []*ConfigBackup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []*ConfigBackup{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		b, err := LoadConfigBackup(backupPath, e.Name())
+		if err != nil {
+			log.Debugf("skipping configuration backup %s: %s", e.Name(), err)
+			continue
+		}
+		backups = append(backups, b)
+	}
+
+	sort.SliceStable(backups, func(a, b int) bool {
+		return backups[a].CreatedAt.After(backups[b].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// LoadConfigBackup loads the backup of configuration files with the given name.
+func LoadConfigBackup(backupPath string, name string) (*ConfigBackup, error) {
+	data, err := os.ReadFile(filepath.Join(backupPath, name, configBackupManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &ConfigBackup{}
+	if err := json.Unmarshal(data, backup); err != nil {
+		return nil, fmt.Errorf("could not read configuration backup %s: %s", name, err)
+	}
+	backup.Name = name
+
+	return backup, nil
+}
+
+// RestoreConfigBackup reverts the configuration files of the backup to how they were before its
+// recipe ran, removing the files the recipe created.
+func RestoreConfigBackup(backupPath string, backup *ConfigBackup) error {
+	for _, f := range backup.Files {
+		if !f.Existed {
+			if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(backupPath, backup.Name, f.Backup))
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(f.Path, data, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package install
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupChangedConfigFilesShouldRestoreOriginals(t *testing.T) {
+	configDir := t.TempDir()
+	agentConfig := filepath.Join(configDir, "newrelic-infra.yml")
+	loggingConfig := filepath.Join(configDir, "logging.d", "logging.yml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(loggingConfig), 0755))
+	require.NoError(t, os.WriteFile(agentConfig, []byte("license_key: 0123456789abcdef\nlog_level: info\n"), 0600))
+
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.configFilePatterns = []string{agentConfig, filepath.Join(configDir, "logging.d", "*")}
+	recipeInstall.configBackupPath = t.TempDir()
+
+	snapshot := recipeInstall.snapshotConfigFiles()
+	require.NoError(t, os.WriteFile(agentConfig, []byte("license_key: fedcba9876543210\nlog_level: debug\n"), 0600))
+	require.NoError(t, os.WriteFile(loggingConfig, []byte("logs:\n- name: nginx\n"), 0600))
+	recipeInstall.backupChangedConfigFiles("logs-integration", snapshot)
+
+	var out bytes.Buffer
+	recipeInstall.printConfigChanges(&out, "logs-integration")
+	assert.Contains(t, out.String(), "+log_level: debug")
+	assert.Contains(t, out.String(), "+license_key: <redacted>")
+	assert.NotContains(t, out.String(), "fedcba9876543210")
+	assert.Contains(t, out.String(), "+- name: nginx")
+
+	backups, err := ListConfigBackups(recipeInstall.configBackupPath)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "logs-integration", backups[0].Recipe)
+
+	require.NoError(t, RestoreConfigBackup(recipeInstall.configBackupPath, backups[0]))
+
+	data, err := os.ReadFile(agentConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "license_key: 0123456789abcdef\nlog_level: info\n", string(data))
+	assert.NoFileExists(t, loggingConfig)
+}
+
+func TestBackupChangedConfigFilesShouldNotBackUpUnchangedFiles(t *testing.T) {
+	configDir := t.TempDir()
+	agentConfig := filepath.Join(configDir, "newrelic-infra.yml")
+	require.NoError(t, os.WriteFile(agentConfig, []byte("log_level: info\n"), 0600))
+
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.configFilePatterns = []string{agentConfig}
+	recipeInstall.configBackupPath = t.TempDir()
+
+	recipeInstall.backupChangedConfigFiles("mysql-open-source-integration", recipeInstall.snapshotConfigFiles())
+
+	backups, err := ListConfigBackups(recipeInstall.configBackupPath)
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+}
@@ -0,0 +1,106 @@
+package install
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/accounts"
+	nrRegion "github.com/newrelic/newrelic-client-go/v2/pkg/region"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+const (
+	licenseKeyLength   = 40
+	licenseKeySuffix   = "NRAL"
+	euLicenseKeyPrefix = "eu"
+)
+
+// apiKeyKinds are the kinds of New Relic keys that can be told apart by the prefix of the key.
+var apiKeyKinds = map[string]string{
+	"NRAK": "a USER key",
+	"NRAA": "an admin key",
+	"NRJS": "a browser key",
+	"NRII": "an insights insert key",
+	"NRIQ": "an insights query key",
+	"NRRA": "a REST API key",
+}
+
+// apiKeyKind returns the kind of key the key is, going by its prefix or suffix, or an empty
+// string when it can't be told.
+func apiKeyKind(key string) string {
+	if strings.HasSuffix(key, licenseKeySuffix) {
+		return "a license key"
+	}
+
+	if prefix, _, found := strings.Cut(key, "-"); found {
+		return apiKeyKinds[prefix]
+	}
+
+	return ""
+}
+
+// checkUserAPIKey checks that the profile's API key is a user API key, naming the kind of key it
+// is otherwise.
+func checkUserAPIKey(key string) *types.DetailError {
+	if utils.IsValidUserAPIKeyFormat(key) {
+		return nil
+	}
+
+	if kind := apiKeyKind(key); kind != "" {
+		return types.NewDetailError(types.EventTypes.InvalidUserAPIKeyFormat, fmt.Sprintf(`The profile's API key is %s, a USER key is required. User API keys have a prefix of "NRAK-".`, kind))
+	}
+
+	return types.NewDetailError(types.EventTypes.InvalidUserAPIKeyFormat, `Invalid user API key format detected. Please provide a valid user API key. User API keys usually have a prefix of "NRAK-" or "NRAA-".`)
+}
+
+// checkLicenseKey checks that the license key to install with is an ingest license key, and that
+// it's for the profile's region.
+func checkLicenseKey(key string, region string) *types.DetailError {
+	if kind := apiKeyKind(key); kind != "" && !strings.HasSuffix(key, licenseKeySuffix) {
+		return types.NewDetailError(types.EventTypes.InvalidIngestKey, fmt.Sprintf("The license key is %s, an INGEST - LICENSE key is required.", kind))
+	}
+
+	if len(key) != licenseKeyLength {
+		return types.NewDetailError(types.EventTypes.InvalidIngestKey, fmt.Sprintf("The license key is %d characters long, INGEST - LICENSE keys are %d.", len(key), licenseKeyLength))
+	}
+
+	name, err := nrRegion.Parse(region)
+	if err != nil || name == nrRegion.Staging || name == nrRegion.Local {
+		return nil
+	}
+
+	keyRegion := nrRegion.US
+	if strings.HasPrefix(key, euLicenseKeyPrefix) {
+		keyRegion = nrRegion.EU
+	}
+
+	if keyRegion != name {
+		return types.NewDetailError(types.EventTypes.InvalidIngestKey, fmt.Sprintf("The license key is for the %s region, but the profile's region is %s.", keyRegion, name))
+	}
+
+	return nil
+}
+
+// checkAccountAccess checks that the profile's user API key has access to the account to install
+// into. The check is skipped when the accounts can't be listed.
+func checkAccountAccess(lister accountLister, accountID int) *types.DetailError {
+	list, err := lister.ListAccounts(accounts.ListAccountsParams{
+		Scope: &accounts.RegionScopeTypes.IN_REGION,
+	})
+	if err != nil {
+		log.Debugf("could not list the accounts of the user API key: %s", err)
+		return nil
+	}
+
+	for _, a := range list {
+		if a.ID == accountID {
+			return nil
+		}
+	}
+
+	return types.NewDetailError(types.EventTypes.InaccessibleAccount, fmt.Sprintf("The user API key doesn't have access to account %d in the profile's region. Check the account ID and region of the profile.", accountID))
+}
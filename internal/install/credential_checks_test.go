@@ -0,0 +1,58 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/accounts"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestCheckUserAPIKeyShouldNameKeyKind(t *testing.T) {
+	assert.Nil(t, checkUserAPIKey("NRAK-0123456789ABCDEFGHIJKLMNOPQ"))
+
+	detailErr := checkUserAPIKey("NRJS-0123456789abcdef0123")
+
+	require.NotNil(t, detailErr)
+	assert.Equal(t, types.EventTypes.InvalidUserAPIKeyFormat, detailErr.EventName)
+	assert.Contains(t, detailErr.Details, "is a browser key, a USER key is required")
+}
+
+func TestCheckLicenseKeyShouldRejectOtherKeys(t *testing.T) {
+	assert.Nil(t, checkLicenseKey("0123456789abcdef0123456789abcdef0123NRAL", "US"))
+
+	detailErr := checkLicenseKey("NRII-0123456789abcdef0123456789abcdef", "US")
+
+	require.NotNil(t, detailErr)
+	assert.Equal(t, types.EventTypes.InvalidIngestKey, detailErr.EventName)
+	assert.Contains(t, detailErr.Details, "is an insights insert key")
+
+	detailErr = checkLicenseKey("0123456789abcdefNRAL", "US")
+
+	require.NotNil(t, detailErr)
+	assert.Contains(t, detailErr.Details, "20 characters long")
+}
+
+func TestCheckLicenseKeyShouldRejectKeyOfOtherRegion(t *testing.T) {
+	assert.Nil(t, checkLicenseKey("eu01xx6789abcdef0123456789abcdef0123NRAL", "eu"))
+	assert.Nil(t, checkLicenseKey("0123456789abcdef0123456789abcdef0123NRAL", "Staging"))
+
+	detailErr := checkLicenseKey("eu01xx6789abcdef0123456789abcdef0123NRAL", "US")
+
+	require.NotNil(t, detailErr)
+	assert.Contains(t, detailErr.Details, "for the EU region, but the profile's region is US")
+}
+
+func TestCheckAccountAccess(t *testing.T) {
+	lister := &mockAccountLister{accounts: []accounts.AccountOutline{{ID: 1, Name: "Production"}}}
+
+	assert.Nil(t, checkAccountAccess(lister, 1))
+
+	detailErr := checkAccountAccess(lister, 2)
+
+	require.NotNil(t, detailErr)
+	assert.Equal(t, types.EventTypes.InaccessibleAccount, detailErr.EventName)
+}
@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const (
+	awsMetadataURL   = "http://169.254.169.254"
+	gcpMetadataURL   = "http://metadata.google.internal"
+	azureMetadataURL = "http://169.254.169.254"
+
+	// cloudMetadataTimeout bounds how long discovery waits on hosts that aren't cloud instances,
+	// where the metadata services can't be reached.
+	cloudMetadataTimeout = 2 * time.Second
+)
+
+// CloudDetector reads the instance the host runs on from the AWS, GCP and Azure instance metadata
+// services, which are queried at the same time.
+type CloudDetector struct {
+	awsURL   string
+	gcpURL   string
+	azureURL string
+	client   *http.Client
+}
+
+func NewCloudDetector() *CloudDetector {
+	return &CloudDetector{
+		awsURL:   awsMetadataURL,
+		gcpURL:   gcpMetadataURL,
+		azureURL: azureMetadataURL,
+		client:   &http.Client{},
+	}
+}
+
+// Detect returns the cloud instance, or nil when the host isn't one.
+func (d *CloudDetector) Detect(ctx context.Context) *types.CloudHost {
+	ctx, cancel := context.WithTimeout(ctx, cloudMetadataTimeout)
+	defer cancel()
+
+	detectors := []func(context.Context) (*types.CloudHost, error){d.detectAWS, d.detectGCP, d.detectAzure}
+	results := make(chan *types.CloudHost, len(detectors))
+	for _, detect := range detectors {
+		go func(detect func(context.Context) (*types.CloudHost, error)) {
+			c, err := detect(ctx)
+			if err != nil {
+				log.Tracef("cloud metadata not available: %s", err)
+			}
+			results <- c
+		}(detect)
+	}
+
+	for range detectors {
+		if c := <-results; c != nil {
+			log.Debugf("discovered cloud instance %+v", *c)
+			return c
+		}
+	}
+
+	return nil
+}
+
+// detectAWS reads the EC2 instance identity document with an IMDSv2 session token.
+func (d *CloudDetector) detectAWS(ctx context.Context) (*types.CloudHost, error) {
+	token, err := d.request(ctx, http.MethodPut, d.awsURL+"/latest/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "60",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := d.request(ctx, http.MethodGet, d.awsURL+"/latest/dynamic/instance-identity/document", map[string]string{
+		"X-aws-ec2-metadata-token": string(token),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doc := struct {
+		InstanceID string `json:"instanceId"`
+		Region     string `json:"region"`
+		AccountID  string `json:"accountId"`
+	}{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	return &types.CloudHost{Provider: "aws", InstanceID: doc.InstanceID, Region: doc.Region, AccountID: doc.AccountID}, nil
+}
+
+func (d *CloudDetector) detectGCP(ctx context.Context) (*types.CloudHost, error) {
+	headers := map[string]string{"Metadata-Flavor": "Google"}
+
+	values := map[string]string{}
+	for _, p := range []string{"instance/id", "instance/zone", "project/project-id"} {
+		body, err := d.request(ctx, http.MethodGet, d.gcpURL+"/computeMetadata/v1/"+p, headers)
+		if err != nil {
+			return nil, err
+		}
+		values[p] = strings.TrimSpace(string(body))
+	}
+
+	// The zone is given as projects/<number>/zones/<zone>, and its region is the zone without
+	// its last part, such as us-central1 for us-central1-a.
+	region := path.Base(values["instance/zone"])
+	if i := strings.LastIndex(region, "-"); i > 0 {
+		region = region[:i]
+	}
+
+	return &types.CloudHost{Provider: "gcp", InstanceID: values["instance/id"], Region: region, AccountID: values["project/project-id"]}, nil
+}
+
+func (d *CloudDetector) detectAzure(ctx context.Context) (*types.CloudHost, error) {
+	body, err := d.request(ctx, http.MethodGet, d.azureURL+"/metadata/instance/compute?api-version=2021-02-01", map[string]string{
+		"Metadata": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	compute := struct {
+		VMID           string `json:"vmId"`
+		Location       string `json:"location"`
+		SubscriptionID string `json:"subscriptionId"`
+	}{}
+	if err := json.Unmarshal(body, &compute); err != nil {
+		return nil, err
+	}
+
+	return &types.CloudHost{Provider: "azure", InstanceID: compute.VMID, Region: compute.Location, AccountID: compute.SubscriptionID}, nil
+}
+
+func (d *CloudDetector) request(ctx context.Context, method string, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: received non-2xx Status code %d", method, url, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
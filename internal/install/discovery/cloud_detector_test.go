@@ -0,0 +1,87 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// newTestCloudDetector points every provider at server, which answers for the provider under test.
+func newTestCloudDetector(server *httptest.Server) *CloudDetector {
+	d := NewCloudDetector()
+	d.awsURL = server.URL
+	d.gcpURL = server.URL
+	d.azureURL = server.URL
+
+	return d
+}
+
+func TestCloudDetectorShouldDetectAWS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			w.Write([]byte("imds-token"))
+		case r.URL.Path == "/latest/dynamic/instance-identity/document" && r.Header.Get("X-aws-ec2-metadata-token") == "imds-token":
+			w.Write([]byte(`{"instanceId":"i-0abc","region":"eu-west-1","accountId":"123456789012"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestCloudDetector(server).Detect(context.Background())
+
+	require.Equal(t, &types.CloudHost{Provider: "aws", InstanceID: "i-0abc", Region: "eu-west-1", AccountID: "123456789012"}, c)
+}
+
+func TestCloudDetectorShouldDetectGCP(t *testing.T) {
+	values := map[string]string{
+		"/computeMetadata/v1/instance/id":        "4520031799277581759",
+		"/computeMetadata/v1/instance/zone":      "projects/863413861059/zones/us-central1-a",
+		"/computeMetadata/v1/project/project-id": "observability-prod",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v, ok := values[r.URL.Path]; ok && r.Header.Get("Metadata-Flavor") == "Google" {
+			w.Write([]byte(v))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := newTestCloudDetector(server).Detect(context.Background())
+
+	require.Equal(t, &types.CloudHost{Provider: "gcp", InstanceID: "4520031799277581759", Region: "us-central1", AccountID: "observability-prod"}, c)
+}
+
+func TestCloudDetectorShouldDetectAzure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metadata/instance/compute" && r.Header.Get("Metadata") == "true" {
+			w.Write([]byte(`{"vmId":"02aab8a4-74ef-476e","location":"westeurope","subscriptionId":"8d10da13-8125"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := newTestCloudDetector(server).Detect(context.Background())
+
+	require.Equal(t, &types.CloudHost{Provider: "azure", InstanceID: "02aab8a4-74ef-476e", Region: "westeurope", AccountID: "8d10da13-8125"}, c)
+}
+
+func TestCloudDetectorShouldNotDetectOutsideClouds(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	c := newTestCloudDetector(server).Detect(context.Background())
+
+	require.Nil(t, c)
+}
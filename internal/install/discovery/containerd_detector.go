@@ -0,0 +1,112 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const defaultContainerdSocket = "/run/containerd/containerd.sock"
+
+// containerPortRegex matches the ports of the containers nerdctl lists, like 80/tcp in
+// 0.0.0.0:8080->80/tcp.
+var containerPortRegex = regexp.MustCompile(`(\d+)/(?:tcp|udp)`)
+
+// ContainerdDetector finds the containers containerd runs without Docker, by listing them with
+// nerdctl. The containers of Docker, and of Kubernetes, are in other containerd namespaces.
+type ContainerdDetector struct {
+	socketPath string
+	listFunc   func(ctx context.Context) ([]byte, error)
+}
+
+func NewContainerdDetector() *ContainerdDetector {
+	return &ContainerdDetector{
+		socketPath: defaultContainerdSocket,
+		listFunc:   listNerdctlContainers,
+	}
+}
+
+// Detect returns the containerd host, or nil when containerd isn't running or its containers can't
+// be listed.
+func (d *ContainerdDetector) Detect(ctx context.Context) *types.ContainerdHost {
+	if _, err := os.Stat(d.socketPath); err != nil {
+		return nil
+	}
+
+	out, err := d.listFunc(ctx)
+	if err != nil {
+		log.Debugf("containerd socket %s found but its containers could not be listed: %s", d.socketPath, err)
+		return nil
+	}
+
+	h := &types.ContainerdHost{
+		Containers: []types.DockerContainer{},
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		c := struct {
+			ID     string `json:"ID"`
+			Names  string `json:"Names"`
+			Image  string `json:"Image"`
+			Ports  string `json:"Ports"`
+			Labels string `json:"Labels"`
+		}{}
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			continue
+		}
+
+		container := types.DockerContainer{ID: c.ID, Name: c.Names, Image: c.Image}
+		for _, m := range containerPortRegex.FindAllStringSubmatch(c.Ports, -1) {
+			if port, err := strconv.ParseUint(m[1], 10, 16); err == nil {
+				container.Ports = appendPort(container.Ports, uint32(port))
+			}
+		}
+		container.Labels = parseContainerLabels(c.Labels)
+		h.Containers = append(h.Containers, container)
+	}
+
+	if len(h.Containers) == 0 {
+		return nil
+	}
+
+	log.Debugf("discovered %d containers running with containerd", len(h.Containers))
+
+	return h
+}
+
+// parseContainerLabels parses the labels of the containers nerdctl lists, as key=value pairs
+// separated by commas.
+func parseContainerLabels(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+
+	labels := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if ok && key != "" {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
+
+func listNerdctlContainers(ctx context.Context) ([]byte, error) {
+	if _, err := exec.LookPath("nerdctl"); err != nil {
+		return nil, err
+	}
+
+	return exec.CommandContext(ctx, "nerdctl", "ps", "--format", "{{json .}}").Output()
+}
@@ -0,0 +1,64 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func givenContainerdSocket(t *testing.T) string {
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	require.NoError(t, os.WriteFile(socket, []byte{}, 0600))
+
+	return socket
+}
+
+func TestContainerdDetectorShouldDetectRunningContainers(t *testing.T) {
+	d := &ContainerdDetector{
+		socketPath: givenContainerdSocket(t),
+		listFunc: func(context.Context) ([]byte, error) {
+			return []byte(`{"ID":"7f3a","Image":"docker.io/library/redis:7","Names":"cache","Ports":"0.0.0.0:6379->6379/tcp","Labels":"com.newrelic.recipe=redis-open-source-integration,tier=backend"}
+{"ID":"1e8b","Image":"ghcr.io/acme/billing:2.1","Names":"billing","Ports":"","Labels":""}
+`), nil
+		},
+	}
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Equal(t, []types.DockerContainer{
+		{ID: "7f3a", Name: "cache", Image: "docker.io/library/redis:7", Ports: []uint32{6379}, Labels: map[string]string{"com.newrelic.recipe": "redis-open-source-integration", "tier": "backend"}},
+		{ID: "1e8b", Name: "billing", Image: "ghcr.io/acme/billing:2.1"},
+	}, h.Containers)
+}
+
+func TestContainerdDetectorShouldNotDetectWithoutSocket(t *testing.T) {
+	d := &ContainerdDetector{
+		socketPath: filepath.Join(t.TempDir(), "containerd.sock"),
+		listFunc: func(context.Context) ([]byte, error) {
+			return []byte(`{"ID":"7f3a","Image":"redis:7","Names":"cache"}`), nil
+		},
+	}
+
+	require.Nil(t, d.Detect(context.Background()))
+}
+
+func TestContainerdDetectorShouldNotDetectWhenContainersCantBeListed(t *testing.T) {
+	d := &ContainerdDetector{
+		socketPath: givenContainerdSocket(t),
+		listFunc: func(context.Context) ([]byte, error) {
+			return nil, errors.New(`exec: "nerdctl": executable file not found in $PATH`)
+		},
+	}
+
+	require.Nil(t, d.Detect(context.Background()))
+}
@@ -0,0 +1,267 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// maxConfigIncludeDepth bounds the !include directives of MySQL option files followed from the
+// main one, as they may include each other.
+const maxConfigIncludeDepth = 4
+
+// databaseConfigFile is where the configuration of a database service is looked for, and how it's
+// read. Its paths may be glob patterns, and the first one found is the one read.
+type databaseConfigFile struct {
+	service string
+	paths   []string
+	parse   func(path string, data []byte, c *types.DatabaseConfig, depth int)
+}
+
+// defaultDatabaseConfigFiles are the paths the packages of Linux distributions and Homebrew install
+// the configuration files of database servers at.
+var defaultDatabaseConfigFiles = []databaseConfigFile{
+	{
+		service: types.DatabaseServiceMySQL,
+		paths: []string{
+			"/etc/mysql/my.cnf",
+			"/etc/my.cnf",
+			"/usr/local/etc/my.cnf",
+			"/opt/homebrew/etc/my.cnf",
+		},
+		parse: parseMySQLConfig,
+	},
+	{
+		service: types.DatabaseServicePostgres,
+		paths: []string{
+			"/etc/postgresql/*/main/postgresql.conf",
+			"/var/lib/pgsql/data/postgresql.conf",
+			"/var/lib/pgsql/*/data/postgresql.conf",
+			"/var/lib/postgresql/data/postgresql.conf",
+			"/usr/local/var/postgres/postgresql.conf",
+			"/opt/homebrew/var/postgresql@*/postgresql.conf",
+		},
+		parse: parsePostgresConfig,
+	},
+	{
+		service: types.DatabaseServiceRedis,
+		paths: []string{
+			"/etc/redis/redis.conf",
+			"/etc/redis.conf",
+			"/usr/local/etc/redis.conf",
+			"/opt/homebrew/etc/redis.conf",
+		},
+		parse: parseRedisConfig,
+	},
+}
+
+// DatabaseConfigDetector reads the configuration files of the database servers installed on the
+// host, for the ports, sockets and data directories their integrations would otherwise prompt for.
+type DatabaseConfigDetector struct {
+	files []databaseConfigFile
+}
+
+func NewDatabaseConfigDetector() *DatabaseConfigDetector {
+	return &DatabaseConfigDetector{
+		files: defaultDatabaseConfigFiles,
+	}
+}
+
+// Detect returns the configuration of each database service whose configuration file was found,
+// or nil when none was.
+func (d *DatabaseConfigDetector) Detect() []types.DatabaseConfig {
+	var configs []types.DatabaseConfig
+
+	for _, f := range d.files {
+		path := findConfigFile(f.paths)
+		if path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Debugf("could not read the %s configuration file %s: %s", f.service, path, err)
+			continue
+		}
+
+		c := types.DatabaseConfig{Service: f.service, ConfigPath: path}
+		f.parse(path, data, &c, 0)
+		configs = append(configs, c)
+	}
+
+	log.Debugf("discovered %d database configuration files", len(configs))
+
+	return configs
+}
+
+// findConfigFile returns the first file found at the paths, or an empty string when there's none.
+func findConfigFile(patterns []string) string {
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+
+		// Of several versions of a server, the latest is more likely the one running.
+		sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+				return m
+			}
+		}
+	}
+
+	return ""
+}
+
+// parseMySQLConfig reads the options of the server groups of a MySQL or MariaDB option file, and of
+// the files it includes. Options read later replace those read before, as they do for the server.
+func parseMySQLConfig(path string, data []byte, c *types.DatabaseConfig, depth int) {
+	group := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "!include") {
+			if depth < maxConfigIncludeDepth {
+				parseMySQLIncludes(path, line, c, depth+1)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			group = strings.ToLower(strings.Trim(line, "[] "))
+			continue
+		}
+
+		if group != "mysqld" && group != "server" && group != "mariadb" && group != "mariadbd" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, "=")
+		value = unquoteConfigValue(stripConfigComment(value))
+		switch strings.ReplaceAll(strings.TrimSpace(key), "-", "_") {
+		case "port":
+			c.Port = value
+		case "socket":
+			c.Socket = value
+		case "datadir":
+			c.DataDir = value
+		}
+	}
+}
+
+// parseMySQLIncludes reads the option files of an !include or !includedir directive.
+func parseMySQLIncludes(path string, line string, c *types.DatabaseConfig, depth int) {
+	directive, target, _ := strings.Cut(line, " ")
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return
+	}
+
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+
+	paths := []string{target}
+	if directive == "!includedir" {
+		// The server reads the .cnf files of the directory in the order of their names.
+		paths, _ = filepath.Glob(filepath.Join(target, "*.cnf"))
+		sort.Strings(paths)
+	}
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		parseMySQLConfig(p, data, c, depth)
+	}
+}
+
+// parsePostgresConfig reads the settings of a postgresql.conf file, whose values may be single quoted.
+func parsePostgresConfig(_ string, data []byte, c *types.DatabaseConfig, _ int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := stripConfigComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			key, value, _ = strings.Cut(line, " ")
+		}
+		value = unquoteConfigValue(value)
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "port":
+			c.Port = value
+		case "unix_socket_directories", "unix_socket_directory":
+			// The server listens on a socket in each directory, the first of which clients use.
+			dir, _, _ := strings.Cut(value, ",")
+			c.Socket = strings.TrimSpace(dir)
+		case "data_directory":
+			c.DataDir = value
+		}
+	}
+}
+
+// parseRedisConfig reads the directives of a redis.conf file, whose arguments may be double quoted.
+func parseRedisConfig(_ string, data []byte, c *types.DatabaseConfig, _ int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		value := unquoteConfigValue(fields[1])
+		switch strings.ToLower(fields[0]) {
+		case "port":
+			c.Port = value
+		case "unixsocket":
+			c.Socket = value
+		case "dir":
+			c.DataDir = value
+		}
+	}
+}
+
+// stripConfigComment returns a line of a configuration file without its trailing # comment. A #
+// within quotes isn't the start of a comment.
+func stripConfigComment(line string) string {
+	quote := rune(0)
+	for i, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '\'' || r == '"'):
+			quote = r
+		case quote == 0 && r == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+
+	return strings.TrimSpace(line)
+}
+
+func unquoteConfigValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
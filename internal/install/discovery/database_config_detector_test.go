@@ -0,0 +1,95 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func writeConfigFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDatabaseConfigDetectorShouldReadMySQLOptionFiles(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "my.cnf"), "[client]\nport = 1234\n\n!includedir "+filepath.Join(root, "conf.d")+"/\n")
+	writeConfigFile(t, filepath.Join(root, "conf.d", "10-server.cnf"), "[mysqld]\nport = 3306\nsocket = /run/mysqld/mysqld.sock\ndatadir = \"/var/lib/mysql\" # data\n")
+	writeConfigFile(t, filepath.Join(root, "conf.d", "20-override.cnf"), "[mysqld]\nport=3307\n")
+	d := &DatabaseConfigDetector{
+		files: []databaseConfigFile{
+			{service: types.DatabaseServiceMySQL, paths: []string{filepath.Join(root, "missing.cnf"), filepath.Join(root, "my.cnf")}, parse: parseMySQLConfig},
+		},
+	}
+
+	configs := d.Detect()
+
+	require.Equal(t, []types.DatabaseConfig{
+		{
+			Service:    types.DatabaseServiceMySQL,
+			ConfigPath: filepath.Join(root, "my.cnf"),
+			Port:       "3307",
+			Socket:     "/run/mysqld/mysqld.sock",
+			DataDir:    "/var/lib/mysql",
+		},
+	}, configs)
+}
+
+func TestDatabaseConfigDetectorShouldReadLatestPostgresConfig(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "12", "main", "postgresql.conf"), "port = 5432\n")
+	writeConfigFile(t, filepath.Join(root, "14", "main", "postgresql.conf"), "data_directory = '/var/lib/postgresql/14/main'\t# use data in another directory\n#port = 5432\nport = 5433\nunix_socket_directories = '/var/run/postgresql, /tmp'\n")
+	d := &DatabaseConfigDetector{
+		files: []databaseConfigFile{
+			{service: types.DatabaseServicePostgres, paths: []string{filepath.Join(root, "*", "main", "postgresql.conf")}, parse: parsePostgresConfig},
+		},
+	}
+
+	configs := d.Detect()
+
+	require.Len(t, configs, 1)
+	require.Equal(t, filepath.Join(root, "14", "main", "postgresql.conf"), configs[0].ConfigPath)
+	require.Equal(t, "5433", configs[0].Port)
+	require.Equal(t, "/var/run/postgresql", configs[0].Socket)
+	require.Equal(t, "/var/lib/postgresql/14/main", configs[0].DataDir)
+}
+
+func TestDatabaseConfigDetectorShouldReadRedisConfig(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "redis.conf"), "# port 6379\nport 6380\nunixsocket /run/redis/redis-server.sock\ndir \"/var/lib/redis\"\n")
+	d := &DatabaseConfigDetector{
+		files: []databaseConfigFile{
+			{service: types.DatabaseServiceMySQL, paths: []string{filepath.Join(root, "my.cnf")}, parse: parseMySQLConfig},
+			{service: types.DatabaseServiceRedis, paths: []string{filepath.Join(root, "redis.conf")}, parse: parseRedisConfig},
+		},
+	}
+
+	configs := d.Detect()
+
+	require.Equal(t, []types.DatabaseConfig{
+		{
+			Service:    types.DatabaseServiceRedis,
+			ConfigPath: filepath.Join(root, "redis.conf"),
+			Port:       "6380",
+			Socket:     "/run/redis/redis-server.sock",
+			DataDir:    "/var/lib/redis",
+		},
+	}, configs)
+}
+
+func TestDatabaseConfigDetectorShouldReturnNilWithoutConfigFiles(t *testing.T) {
+	d := &DatabaseConfigDetector{
+		files: []databaseConfigFile{
+			{service: types.DatabaseServiceRedis, paths: []string{filepath.Join(t.TempDir(), "redis.conf")}, parse: parseRedisConfig},
+		},
+	}
+
+	require.Nil(t, d.Detect())
+}
@@ -0,0 +1,144 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const (
+	defaultDockerSocket = "/var/run/docker.sock"
+	defaultCgroupRoot   = "/sys/fs/cgroup"
+	dockerAPITimeout    = 5 * time.Second
+)
+
+// DockerDetector finds the Docker Engine on the host through its API socket, and the containers
+// running on it.
+type DockerDetector struct {
+	socketPath string
+	cgroupRoot string
+	client     *http.Client
+}
+
+func NewDockerDetector() *DockerDetector {
+	return newDockerDetector(defaultDockerSocket, defaultCgroupRoot)
+}
+
+func newDockerDetector(socketPath string, cgroupRoot string) *DockerDetector {
+	return &DockerDetector{
+		socketPath: socketPath,
+		cgroupRoot: cgroupRoot,
+		client: &http.Client{
+			Timeout: dockerAPITimeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// Detect returns the Docker host, or nil when the Docker Engine isn't running.
+func (d *DockerDetector) Detect(ctx context.Context) *types.DockerHost {
+	if _, err := os.Stat(d.socketPath); err != nil {
+		return nil
+	}
+
+	version := struct {
+		APIVersion string `json:"ApiVersion"`
+	}{}
+	if err := d.get(ctx, "/version", &version); err != nil {
+		log.Debugf("Docker socket %s found but the Docker Engine could not be reached: %s", d.socketPath, err)
+		return nil
+	}
+
+	containers := []struct {
+		ID     string            `json:"Id"`
+		Names  []string          `json:"Names"`
+		Image  string            `json:"Image"`
+		Labels map[string]string `json:"Labels"`
+		Ports  []struct {
+			PrivatePort uint32 `json:"PrivatePort"`
+		} `json:"Ports"`
+	}{}
+	if err := d.get(ctx, "/containers/json", &containers); err != nil {
+		log.Debugf("could not list Docker containers: %s", err)
+	}
+
+	h := &types.DockerHost{
+		APIVersion:    version.APIVersion,
+		CgroupVersion: d.cgroupVersion(),
+		Containers:    []types.DockerContainer{},
+	}
+	for _, c := range containers {
+		name := ""
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+
+		container := types.DockerContainer{ID: c.ID, Name: name, Image: c.Image}
+		for _, p := range c.Ports {
+			container.Ports = appendPort(container.Ports, p.PrivatePort)
+		}
+		if len(c.Labels) > 0 {
+			container.Labels = c.Labels
+		}
+		h.Containers = append(h.Containers, container)
+	}
+
+	log.Debugf("discovered Docker Engine %s with %d running containers on cgroup v%d", h.APIVersion, len(h.Containers), h.CgroupVersion)
+
+	return h
+}
+
+// appendPort adds the port to the ports, unless it's already one of them, as ports published on both
+// IPv4 and IPv6 are listed twice.
+func appendPort(ports []uint32, port uint32) []uint32 {
+	for _, p := range ports {
+		if p == port {
+			return ports
+		}
+	}
+
+	return append(ports, port)
+}
+
+// cgroupVersion returns 2 when the unified cgroup v2 hierarchy is mounted, and 1 otherwise.
+func (d *DockerDetector) cgroupVersion() int {
+	if _, err := os.Stat(filepath.Join(d.cgroupRoot, "cgroup.controllers")); err == nil {
+		return 2
+	}
+
+	return 1
+}
+
+func (d *DockerDetector) get(ctx context.Context, path string, v interface{}) error {
+	// The host is ignored, as requests are sent over the socket.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-2xx Status code %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,71 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// serveDockerAPI serves a fake Docker Engine API on a unix socket, returning the socket's path.
+func serveDockerAPI(t *testing.T) string {
+	// Unix socket paths are limited to ~100 characters, which t.TempDir() may exceed.
+	dir, err := os.MkdirTemp("", "docker")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "docker.sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Version":"24.0.5","ApiVersion":"1.43"}`))
+	})
+	mux.HandleFunc("/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"Id":"4a1b","Names":["/web"],"Image":"nginx:1.25"},{"Id":"9c2d","Names":["/db"],"Image":"postgres:15","Labels":{"com.example.team":"payments"},"Ports":[{"IP":"0.0.0.0","PrivatePort":5432,"PublicPort":15432,"Type":"tcp"},{"IP":"::","PrivatePort":5432,"PublicPort":15432,"Type":"tcp"}]}]`))
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(l)
+	t.Cleanup(func() { server.Close() })
+
+	return socket
+}
+
+func TestDockerDetectorShouldDetectRunningContainers(t *testing.T) {
+	cgroupRoot := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("cpu memory"), 0644))
+
+	h := newDockerDetector(serveDockerAPI(t), cgroupRoot).Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Equal(t, "1.43", h.APIVersion)
+	require.Equal(t, 2, h.CgroupVersion)
+	require.Equal(t, []types.DockerContainer{
+		{ID: "4a1b", Name: "web", Image: "nginx:1.25"},
+		{ID: "9c2d", Name: "db", Image: "postgres:15", Ports: []uint32{5432}, Labels: map[string]string{"com.example.team": "payments"}},
+	}, h.Containers)
+}
+
+func TestDockerDetectorShouldDetectCgroupV1(t *testing.T) {
+	h := newDockerDetector(serveDockerAPI(t), t.TempDir()).Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Equal(t, 1, h.CgroupVersion)
+}
+
+func TestDockerDetectorShouldNotDetectWithoutSocket(t *testing.T) {
+	h := newDockerDetector(filepath.Join(t.TempDir(), "docker.sock"), t.TempDir()).Detect(context.Background())
+
+	require.Nil(t, h)
+}
@@ -0,0 +1,153 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const (
+	defaultPCIDevicesDir = "/sys/bus/pci/devices"
+	// nvidiaPCIVendorID is the PCI vendor ID of NVIDIA's cards.
+	nvidiaPCIVendorID = "0x10de"
+)
+
+// nvidiaSMIQuery is the nvidia-smi query of the model, PCI address, driver version and memory of
+// each GPU, as CSV lines without units.
+var nvidiaSMIQuery = []string{"--query-gpu=name,pci.bus_id,driver_version,memory.total", "--format=csv,noheader,nounits"}
+
+// GPUDetector finds the NVIDIA GPUs of the host with the nvidia-smi of their driver, or on the PCI
+// bus when the driver isn't installed.
+type GPUDetector struct {
+	lookPath      func(file string) (string, error)
+	run           func(ctx context.Context, path string, args ...string) ([]byte, error)
+	pciDevicesDir string
+}
+
+func NewGPUDetector() *GPUDetector {
+	return &GPUDetector{
+		lookPath:      exec.LookPath,
+		run:           runCommand,
+		pciDevicesDir: defaultPCIDevicesDir,
+	}
+}
+
+// Detect returns the GPU host, or nil when the host has no NVIDIA GPU.
+func (d *GPUDetector) Detect(ctx context.Context) *types.GPUHost {
+	h := &types.GPUHost{}
+
+	if path, err := d.lookPath("nvidia-smi"); err == nil {
+		h.NvidiaSMIPath = path
+		h.GPUs = d.nvidiaSMIGPUs(ctx, path)
+	}
+
+	if len(h.GPUs) == 0 {
+		h.GPUs = d.pciGPUs()
+	}
+
+	if len(h.GPUs) == 0 {
+		return nil
+	}
+
+	log.Debugf("discovered %d NVIDIA GPUs", len(h.GPUs))
+
+	return h
+}
+
+// nvidiaSMIGPUs returns the GPUs nvidia-smi reports, or nil when it fails, such as when its
+// driver isn't loaded.
+func (d *GPUDetector) nvidiaSMIGPUs(ctx context.Context, path string) []types.GPU {
+	out, err := d.run(ctx, path, nvidiaSMIQuery...)
+	if err != nil {
+		log.Debugf("could not list the GPUs with nvidia-smi: %s", err)
+		return nil
+	}
+
+	var gpus []types.GPU
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ",")
+		if len(fields) < 4 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		g := types.GPU{
+			Vendor:        types.GPUVendorNVIDIA,
+			Model:         fields[0],
+			BusID:         normalizePCIAddress(fields[1]),
+			DriverVersion: fields[2],
+		}
+		if mb, err := strconv.ParseUint(fields[3], 10, 64); err == nil {
+			g.MemoryMB = mb
+		}
+		gpus = append(gpus, g)
+	}
+
+	return gpus
+}
+
+// pciGPUs returns the NVIDIA display controllers of the PCI bus, as the kernel lists them in sysfs.
+func (d *GPUDetector) pciGPUs() []types.GPU {
+	devices, err := filepath.Glob(filepath.Join(d.pciDevicesDir, "*"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(devices)
+
+	var gpus []types.GPU
+	for _, dir := range devices {
+		if readPCIAttribute(dir, "vendor") != nvidiaPCIVendorID {
+			continue
+		}
+
+		// The display controllers are of class 0x03, such as VGA (0x0300) and 3D (0x0302) ones.
+		if !strings.HasPrefix(readPCIAttribute(dir, "class"), "0x03") {
+			continue
+		}
+
+		gpus = append(gpus, types.GPU{
+			Vendor:   types.GPUVendorNVIDIA,
+			BusID:    filepath.Base(dir),
+			DeviceID: readPCIAttribute(dir, "device"),
+		})
+	}
+
+	return gpus
+}
+
+func readPCIAttribute(dir string, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(string(data)))
+}
+
+// normalizePCIAddress returns a PCI address as sysfs names it, such as 0000:3b:00.0 for the
+// 00000000:3B:00.0 of nvidia-smi.
+func normalizePCIAddress(addr string) string {
+	addr = strings.ToLower(addr)
+	if domain, rest, ok := strings.Cut(addr, ":"); ok && len(domain) == 8 {
+		return domain[4:] + ":" + rest
+	}
+
+	return addr
+}
+
+func runCommand(ctx context.Context, path string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, path, args...).Output()
+}
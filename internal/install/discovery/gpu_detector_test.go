@@ -0,0 +1,91 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func newTestGPUDetector(t *testing.T, nvidiaSMI string) *GPUDetector {
+	return &GPUDetector{
+		lookPath: func(file string) (string, error) {
+			if nvidiaSMI == "" {
+				return "", errors.New("not found")
+			}
+			return "/usr/bin/nvidia-smi", nil
+		},
+		run: func(ctx context.Context, path string, args ...string) ([]byte, error) {
+			require.Equal(t, "/usr/bin/nvidia-smi", path)
+			require.Equal(t, nvidiaSMIQuery, args)
+			return []byte(nvidiaSMI), nil
+		},
+		pciDevicesDir: t.TempDir(),
+	}
+}
+
+func writePCIDevice(t *testing.T, dir string, addr string, vendor string, class string, device string) {
+	deviceDir := filepath.Join(dir, addr)
+	require.NoError(t, os.MkdirAll(deviceDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(deviceDir, "vendor"), []byte(vendor+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(deviceDir, "class"), []byte(class+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(deviceDir, "device"), []byte(device+"\n"), 0600))
+}
+
+func TestGPUDetectorShouldDetectGPUsWithNvidiaSMI(t *testing.T) {
+	d := newTestGPUDetector(t, "Tesla T4, 00000000:3B:00.0, 535.104.05, 15360\nNVIDIA A10G, 00000000:00:1E.0, 535.104.05, 23028\n")
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Equal(t, "/usr/bin/nvidia-smi", h.NvidiaSMIPath)
+	require.Equal(t, []types.GPU{
+		{Vendor: types.GPUVendorNVIDIA, Model: "Tesla T4", BusID: "0000:3b:00.0", DriverVersion: "535.104.05", MemoryMB: 15360},
+		{Vendor: types.GPUVendorNVIDIA, Model: "NVIDIA A10G", BusID: "0000:00:1e.0", DriverVersion: "535.104.05", MemoryMB: 23028},
+	}, h.GPUs)
+}
+
+func TestGPUDetectorShouldDetectGPUsOnPCIBusWithoutDriver(t *testing.T) {
+	d := newTestGPUDetector(t, "")
+	writePCIDevice(t, d.pciDevicesDir, "0000:3b:00.0", "0x10de", "0x030200", "0x1EB8")
+	writePCIDevice(t, d.pciDevicesDir, "0000:3b:00.1", "0x10de", "0x040300", "0x10fa")
+	writePCIDevice(t, d.pciDevicesDir, "0000:00:02.0", "0x8086", "0x030000", "0x3e92")
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Empty(t, h.NvidiaSMIPath)
+	require.Equal(t, []types.GPU{{Vendor: types.GPUVendorNVIDIA, BusID: "0000:3b:00.0", DeviceID: "0x1eb8"}}, h.GPUs)
+}
+
+func TestGPUDetectorShouldFallBackToPCIBusWhenNvidiaSMIFails(t *testing.T) {
+	d := newTestGPUDetector(t, "")
+	d.lookPath = func(file string) (string, error) {
+		return "/usr/bin/nvidia-smi", nil
+	}
+	d.run = func(ctx context.Context, path string, args ...string) ([]byte, error) {
+		return nil, errors.New("NVIDIA-SMI has failed because it couldn't communicate with the NVIDIA driver")
+	}
+	writePCIDevice(t, d.pciDevicesDir, "0000:3b:00.0", "0x10de", "0x030000", "0x1eb8")
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Equal(t, "/usr/bin/nvidia-smi", h.NvidiaSMIPath)
+	require.Len(t, h.GPUs, 1)
+}
+
+func TestGPUDetectorShouldReturnNilWithoutGPUs(t *testing.T) {
+	d := newTestGPUDetector(t, "")
+	writePCIDevice(t, d.pciDevicesDir, "0000:00:02.0", "0x8086", "0x030000", "0x3e92")
+
+	require.Nil(t, d.Detect(context.Background()))
+}
@@ -0,0 +1,150 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// hostDetails is what HostDetector finds of the host, beside what its OS reports.
+type hostDetails struct {
+	FQDN             string
+	IPAddresses      []string
+	TotalMemoryBytes uint64
+	CPUs             int
+}
+
+// HostDetector finds the network identity and hardware of the host, for recipes to size and name
+// what they install with.
+type HostDetector struct {
+	lookupHost     func(ctx context.Context, host string) ([]string, error)
+	lookupAddr     func(ctx context.Context, addr string) ([]string, error)
+	interfaceAddrs func() ([]net.Addr, error)
+	totalMemory    func(ctx context.Context) (uint64, error)
+	cpus           func(ctx context.Context) (int, error)
+}
+
+func NewHostDetector() *HostDetector {
+	return &HostDetector{
+		lookupHost:     net.DefaultResolver.LookupHost,
+		lookupAddr:     net.DefaultResolver.LookupAddr,
+		interfaceAddrs: net.InterfaceAddrs,
+		totalMemory: func(ctx context.Context) (uint64, error) {
+			v, err := mem.VirtualMemoryWithContext(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return v.Total, nil
+		},
+		cpus: func(ctx context.Context) (int, error) {
+			return cpu.CountsWithContext(ctx, true)
+		},
+	}
+}
+
+// Detect returns the details of the host of the hostname. The details that can't be found are
+// left empty.
+func (d *HostDetector) Detect(ctx context.Context, hostname string) *hostDetails {
+	h := &hostDetails{
+		FQDN:        d.fqdn(ctx, hostname),
+		IPAddresses: d.ipAddresses(),
+	}
+
+	if total, err := d.totalMemory(ctx); err == nil {
+		h.TotalMemoryBytes = total
+	} else {
+		log.Debugf("could not read the memory of the host: %s", err)
+	}
+
+	if n, err := d.cpus(ctx); err == nil {
+		h.CPUs = n
+	} else {
+		log.Debugf("could not count the CPUs of the host: %s", err)
+	}
+
+	return h
+}
+
+// fqdn returns the fully qualified name of the hostname, as hostname -f does: the name an address
+// of the hostname resolves back to.
+func (d *HostDetector) fqdn(ctx context.Context, hostname string) string {
+	if hostname == "" {
+		return ""
+	}
+
+	if strings.Contains(hostname, ".") {
+		return hostname
+	}
+
+	addrs, err := d.lookupHost(ctx, hostname)
+	if err != nil {
+		log.Debugf("could not resolve the hostname %s: %s", hostname, err)
+		return ""
+	}
+
+	for _, addr := range addrs {
+		names, err := d.lookupAddr(ctx, addr)
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			name = strings.TrimSuffix(name, ".")
+			if strings.HasPrefix(name, hostname+".") {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// ipAddresses returns the addresses of the network interfaces, without those of loopback and the
+// IPv6 link-local ones, which are only reachable from the same network link.
+func (d *HostDetector) ipAddresses() []string {
+	addrs, err := d.interfaceAddrs()
+	if err != nil {
+		log.Debugf("could not list the addresses of the host: %s", err)
+		return nil
+	}
+
+	ips := []string{}
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		ips = append(ips, ipNet.IP.String())
+	}
+
+	return ips
+}
+
+// normalizeVirtualization returns the virtualization system reported for a host, or an empty
+// string for bare metal, which systemd-detect-virt reports as none.
+func normalizeVirtualization(system string) string {
+	system = strings.ToLower(strings.TrimSpace(system))
+	if system == "none" {
+		return ""
+	}
+
+	return system
+}
+
+// setHostDetails sets the details found of the host on its manifest.
+func setHostDetails(m *types.DiscoveryManifest, h *hostDetails) {
+	if h == nil {
+		return
+	}
+
+	m.FQDN = h.FQDN
+	m.IPAddresses = h.IPAddresses
+	m.TotalMemoryBytes = h.TotalMemoryBytes
+	m.CPUs = h.CPUs
+}
@@ -0,0 +1,112 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func newTestHostDetector() *HostDetector {
+	return &HostDetector{
+		lookupHost: func(ctx context.Context, host string) ([]string, error) {
+			return []string{"10.0.0.5"}, nil
+		},
+		lookupAddr: func(ctx context.Context, addr string) ([]string, error) {
+			return []string{"web-1.example.com."}, nil
+		},
+		interfaceAddrs: func() ([]net.Addr, error) {
+			return []net.Addr{
+				&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+				&net.IPNet{IP: net.ParseIP("10.0.0.5"), Mask: net.CIDRMask(24, 32)},
+				&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+				&net.IPNet{IP: net.ParseIP("2001:db8::5"), Mask: net.CIDRMask(64, 128)},
+			}, nil
+		},
+		totalMemory: func(ctx context.Context) (uint64, error) {
+			return 8 * 1024 * 1024 * 1024, nil
+		},
+		cpus: func(ctx context.Context) (int, error) {
+			return 4, nil
+		},
+	}
+}
+
+func TestHostDetectorShouldDetectHostDetails(t *testing.T) {
+	h := newTestHostDetector().Detect(context.Background(), "web-1")
+
+	require.Equal(t, "web-1.example.com", h.FQDN)
+	require.Equal(t, []string{"10.0.0.5", "2001:db8::5"}, h.IPAddresses)
+	require.Equal(t, uint64(8*1024*1024*1024), h.TotalMemoryBytes)
+	require.Equal(t, 4, h.CPUs)
+}
+
+func TestHostDetectorShouldKeepQualifiedHostname(t *testing.T) {
+	d := newTestHostDetector()
+	d.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		t.Fatal("the hostname should not be resolved")
+		return nil, nil
+	}
+
+	h := d.Detect(context.Background(), "db-1.internal.example.com")
+
+	require.Equal(t, "db-1.internal.example.com", h.FQDN)
+}
+
+func TestHostDetectorShouldIgnoreNamesOfOtherHosts(t *testing.T) {
+	d := newTestHostDetector()
+	d.lookupAddr = func(ctx context.Context, addr string) ([]string, error) {
+		return []string{"proxy.example.com."}, nil
+	}
+
+	h := d.Detect(context.Background(), "web-1")
+
+	require.Empty(t, h.FQDN)
+}
+
+func TestHostDetectorShouldLeaveUnknownDetailsEmpty(t *testing.T) {
+	d := newTestHostDetector()
+	d.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		return nil, errors.New("no such host")
+	}
+	d.interfaceAddrs = func() ([]net.Addr, error) {
+		return nil, errors.New("not permitted")
+	}
+	d.totalMemory = func(ctx context.Context) (uint64, error) {
+		return 0, errors.New("not implemented")
+	}
+	d.cpus = func(ctx context.Context) (int, error) {
+		return 0, errors.New("not implemented")
+	}
+
+	h := d.Detect(context.Background(), "web-1")
+
+	require.Empty(t, h.FQDN)
+	require.Empty(t, h.IPAddresses)
+	require.Zero(t, h.TotalMemoryBytes)
+	require.Zero(t, h.CPUs)
+}
+
+func TestNormalizeVirtualizationShouldReportBareMetalAsEmpty(t *testing.T) {
+	require.Equal(t, "", normalizeVirtualization("none"))
+	require.Equal(t, "kvm", normalizeVirtualization(" KVM\n"))
+}
+
+func TestSetHostDetailsShouldSetManifest(t *testing.T) {
+	m := types.DiscoveryManifest{}
+
+	setHostDetails(&m, &hostDetails{FQDN: "web-1.example.com", IPAddresses: []string{"10.0.0.5"}, TotalMemoryBytes: 1024, CPUs: 2})
+	setHostDetails(&m, nil)
+
+	require.Equal(t, "web-1.example.com", m.FQDN)
+	require.Equal(t, []string{"10.0.0.5"}, m.IPAddresses)
+	require.Equal(t, uint64(1024), m.TotalMemoryBytes)
+	require.Equal(t, 2, m.CPUs)
+}
@@ -0,0 +1,234 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// jmxDialTimeout is how long a JMX remote port may take to accept a connection before it's deemed
+// not listening.
+const jmxDialTimeout = time.Second
+
+// The JVM options, and the properties of their management.properties file, that enable the JMX
+// remote port.
+const (
+	jmxPortProperty         = "com.sun.management.jmxremote.port"
+	jmxHostProperty         = "com.sun.management.jmxremote.host"
+	jmxSSLProperty          = "com.sun.management.jmxremote.ssl"
+	jmxAuthenticateProperty = "com.sun.management.jmxremote.authenticate"
+	jmxConfigFileProperty   = "com.sun.management.config.file"
+	rmiHostnameProperty     = "java.rmi.server.hostname"
+)
+
+// javaProcess is a running process of a JVM, with its command line.
+type javaProcess struct {
+	pid  int32
+	args []string
+}
+
+// JMXDetector finds the JMX remote ports the Java processes of the host enable, and checks that
+// they accept connections.
+type JMXDetector struct {
+	processes func(ctx context.Context) ([]javaProcess, error)
+	dial      func(ctx context.Context, address string) error
+}
+
+func NewJMXDetector() *JMXDetector {
+	return &JMXDetector{
+		processes: localJavaProcesses,
+		dial:      dialTCP,
+	}
+}
+
+// Detect returns the JMX endpoints of the Java processes that listen on their JMX remote port, or
+// nil when there's none.
+func (d *JMXDetector) Detect(ctx context.Context) []types.JMXEndpoint {
+	processes, err := d.processes(ctx)
+	if err != nil {
+		log.Debugf("could not list the Java processes: %s", err)
+		return nil
+	}
+
+	var endpoints []types.JMXEndpoint
+	for _, p := range processes {
+		e := jmxEndpoint(p)
+		if e == nil {
+			continue
+		}
+
+		address := net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+		if err := d.dial(ctx, address); err != nil {
+			log.Debugf("the JMX remote port %s of pid %d doesn't accept connections: %s", address, p.pid, err)
+			continue
+		}
+		endpoints = append(endpoints, *e)
+	}
+
+	log.Debugf("discovered %d JMX endpoints", len(endpoints))
+
+	return endpoints
+}
+
+// jmxEndpoint returns the JMX remote port of a Java process, or nil when its options don't enable
+// one.
+func jmxEndpoint(p javaProcess) *types.JMXEndpoint {
+	props := map[string]string{}
+	options := javaSystemProperties(p.args)
+
+	// The options of the command line replace the properties of the management.properties file.
+	if path := options[jmxConfigFileProperty]; path != "" {
+		for k, v := range readJavaProperties(path) {
+			props[k] = v
+		}
+	}
+	for k, v := range options {
+		props[k] = v
+	}
+
+	port, err := strconv.Atoi(props[jmxPortProperty])
+	if err != nil || port <= 0 || port > 65535 {
+		return nil
+	}
+
+	host := props[jmxHostProperty]
+	if host == "" {
+		host = props[rmiHostnameProperty]
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+
+	return &types.JMXEndpoint{
+		PID:          p.pid,
+		Name:         javaMainName(p.args),
+		Host:         host,
+		Port:         port,
+		SSL:          !strings.EqualFold(props[jmxSSLProperty], "false"),
+		Authenticate: !strings.EqualFold(props[jmxAuthenticateProperty], "false"),
+	}
+}
+
+// javaSystemProperties returns the -D system properties of the command line of a JVM.
+func javaSystemProperties(args []string) map[string]string {
+	props := map[string]string{}
+	for _, a := range javaOptions(args) {
+		if !strings.HasPrefix(a, "-D") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(strings.TrimPrefix(a, "-D"), "=")
+		props[key] = strings.Trim(value, `"'`)
+	}
+
+	return props
+}
+
+// javaOptions returns the options of the command line of a JVM, which come before its main class
+// or jar, after which the arguments are the application's.
+func javaOptions(args []string) []string {
+	for i := 1; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == "-jar":
+			return args[1:i]
+		case a == "-cp" || a == "-classpath" || a == "--class-path" || a == "-p" || a == "--module-path":
+			i++
+		case !strings.HasPrefix(a, "-"):
+			return args[1:i]
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	return args[1:]
+}
+
+// javaMainName returns the name of the jar or main class of the command line of a JVM.
+func javaMainName(args []string) string {
+	options := javaOptions(args)
+	if len(options)+1 >= len(args) {
+		return ""
+	}
+
+	next := args[len(options)+1]
+	if next == "-jar" {
+		if len(options)+2 < len(args) {
+			return filepath.Base(args[len(options)+2])
+		}
+		return ""
+	}
+
+	return next
+}
+
+// readJavaProperties returns the key=value properties of a Java properties file.
+func readJavaProperties(path string) map[string]string {
+	props := map[string]string{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Debugf("could not read the management properties file %s: %s", path, err)
+		return props
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			key, value, _ = strings.Cut(line, ":")
+		}
+		props[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return props
+}
+
+func localJavaProcesses(ctx context.Context) ([]javaProcess, error) {
+	processes, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var java []javaProcess
+	for _, p := range processes {
+		name, err := p.NameWithContext(ctx)
+		if err != nil || (name != "java" && name != "java.exe") {
+			continue
+		}
+
+		args, err := p.CmdlineSliceWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		java = append(java, javaProcess{pid: p.Pid, args: args})
+	}
+
+	return java, nil
+}
+
+func dialTCP(ctx context.Context, address string) error {
+	conn, err := (&net.Dialer{Timeout: jmxDialTimeout}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
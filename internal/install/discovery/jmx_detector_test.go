@@ -0,0 +1,72 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func newTestJMXDetector(processes []javaProcess, listening ...string) *JMXDetector {
+	return &JMXDetector{
+		processes: func(ctx context.Context) ([]javaProcess, error) {
+			return processes, nil
+		},
+		dial: func(ctx context.Context, address string) error {
+			for _, l := range listening {
+				if l == address {
+					return nil
+				}
+			}
+			return errors.New("connection refused")
+		},
+	}
+}
+
+func TestJMXDetectorShouldDetectListeningJMXPorts(t *testing.T) {
+	d := newTestJMXDetector([]javaProcess{
+		{pid: 1200, args: []string{"java", "-Xmx1g", "-Dcom.sun.management.jmxremote.port=9010", "-Dcom.sun.management.jmxremote.ssl=false", "-Dcom.sun.management.jmxremote.authenticate=false", "-jar", "/opt/billing/billing-2.1.0.jar"}},
+		{pid: 1300, args: []string{"java", "-cp", "/opt/orders/lib/*", "-Dcom.sun.management.jmxremote.port=9011", "-Djava.rmi.server.hostname=orders.internal", "com.acme.orders.Main", "-Dcom.sun.management.jmxremote.port=1"}},
+		{pid: 1400, args: []string{"java", "-Dcom.sun.management.jmxremote.port=9012", "-jar", "stopped.jar"}},
+		{pid: 1500, args: []string{"java", "-jar", "nojmx.jar"}},
+	}, "localhost:9010", "orders.internal:9011")
+
+	endpoints := d.Detect(context.Background())
+
+	require.Equal(t, []types.JMXEndpoint{
+		{PID: 1200, Name: "billing-2.1.0.jar", Host: "localhost", Port: 9010, SSL: false, Authenticate: false},
+		{PID: 1300, Name: "com.acme.orders.Main", Host: "orders.internal", Port: 9011, SSL: true, Authenticate: true},
+	}, endpoints)
+}
+
+func TestJMXDetectorShouldReadManagementPropertiesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "management.properties")
+	require.NoError(t, os.WriteFile(path, []byte("# JMX\ncom.sun.management.jmxremote.port=9020\ncom.sun.management.jmxremote.host = 0.0.0.0\ncom.sun.management.jmxremote.ssl=false\n"), 0600))
+
+	d := newTestJMXDetector([]javaProcess{
+		{pid: 1200, args: []string{"java", "-Dcom.sun.management.config.file=" + path, "-Dcom.sun.management.jmxremote.authenticate=false", "com.acme.Main"}},
+	}, "localhost:9020")
+
+	endpoints := d.Detect(context.Background())
+
+	require.Equal(t, []types.JMXEndpoint{
+		{PID: 1200, Name: "com.acme.Main", Host: "localhost", Port: 9020, SSL: false, Authenticate: false},
+	}, endpoints)
+}
+
+func TestJMXDetectorShouldReturnNilWhenProcessesCantBeListed(t *testing.T) {
+	d := newTestJMXDetector(nil)
+	d.processes = func(ctx context.Context) ([]javaProcess, error) {
+		return nil, errors.New("not permitted")
+	}
+
+	require.Nil(t, d.Detect(context.Background()))
+}
@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// kubeletConfigPaths are where kubeadm and most distributions write the kubelet's configuration.
+var kubeletConfigPaths = []string{
+	"/var/lib/kubelet/config.yaml",
+	"/etc/kubernetes/kubelet.conf",
+}
+
+// KubernetesDetector finds whether the host is a Kubernetes node, runs in a pod, or has a
+// kubeconfig giving access to a cluster.
+type KubernetesDetector struct {
+	getenv  func(string) string
+	homeDir func() (string, error)
+	stat    func(string) (os.FileInfo, error)
+	read    func(string) ([]byte, error)
+}
+
+func NewKubernetesDetector() *KubernetesDetector {
+	return &KubernetesDetector{
+		getenv:  os.Getenv,
+		homeDir: os.UserHomeDir,
+		stat:    os.Stat,
+		read:    os.ReadFile,
+	}
+}
+
+// Detect returns the Kubernetes host, or nil when neither a kubelet, a pod nor a kubeconfig is found.
+func (d *KubernetesDetector) Detect() *types.KubernetesHost {
+	k := types.KubernetesHost{
+		InCluster: d.getenv("KUBERNETES_SERVICE_HOST") != "",
+	}
+
+	for _, path := range kubeletConfigPaths {
+		if _, err := d.stat(path); err == nil {
+			k.Kubelet = true
+			break
+		}
+	}
+
+	if path := d.kubeconfigPath(); path != "" {
+		if _, err := d.stat(path); err == nil {
+			k.Kubeconfig = path
+			k.Context = d.currentContext(path)
+		}
+	}
+
+	if !k.Kubelet && !k.InCluster && k.Kubeconfig == "" {
+		return nil
+	}
+
+	log.Debugf("discovered Kubernetes %+v", k)
+
+	return &k
+}
+
+// kubeconfigPath returns the kubeconfig kubectl would use: the first of KUBECONFIG, or ~/.kube/config.
+func (d *KubernetesDetector) kubeconfigPath() string {
+	if paths := d.getenv("KUBECONFIG"); paths != "" {
+		return strings.Split(paths, string(os.PathListSeparator))[0]
+	}
+
+	home, err := d.homeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".kube", "config")
+}
+
+func (d *KubernetesDetector) currentContext(path string) string {
+	data, err := d.read(path)
+	if err != nil {
+		return ""
+	}
+
+	kubeconfig := struct {
+		CurrentContext string `yaml:"current-context"`
+	}{}
+	if err := yaml.Unmarshal(data, &kubeconfig); err != nil {
+		log.Debugf("could not parse kubeconfig %s: %s", path, err)
+		return ""
+	}
+
+	return kubeconfig.CurrentContext
+}
@@ -0,0 +1,58 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func newTestKubernetesDetector(env map[string]string, home string) *KubernetesDetector {
+	d := NewKubernetesDetector()
+	d.getenv = func(k string) string { return env[k] }
+	d.homeDir = func() (string, error) { return home, nil }
+	d.stat = func(path string) (os.FileInfo, error) {
+		for _, kubelet := range kubeletConfigPaths {
+			if path == kubelet {
+				if env["KUBELET"] != "" {
+					return nil, nil
+				}
+				return nil, os.ErrNotExist
+			}
+		}
+		return os.Stat(path)
+	}
+
+	return d
+}
+
+func TestKubernetesDetectorShouldReadKubeconfigContext(t *testing.T) {
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".kube"), 0755))
+	kubeconfig := filepath.Join(home, ".kube", "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1\ncurrent-context: prod-eu\n"), 0600))
+
+	k := newTestKubernetesDetector(map[string]string{}, home).Detect()
+
+	require.Equal(t, &types.KubernetesHost{Kubeconfig: kubeconfig, Context: "prod-eu"}, k)
+}
+
+func TestKubernetesDetectorShouldDetectKubeletAndPods(t *testing.T) {
+	env := map[string]string{"KUBELET": "1", "KUBERNETES_SERVICE_HOST": "10.96.0.1"}
+
+	k := newTestKubernetesDetector(env, t.TempDir()).Detect()
+
+	require.Equal(t, &types.KubernetesHost{Kubelet: true, InCluster: true}, k)
+}
+
+func TestKubernetesDetectorShouldNotDetectWithoutKubernetes(t *testing.T) {
+	k := newTestKubernetesDetector(map[string]string{}, t.TempDir()).Detect()
+
+	require.Nil(t, k)
+}
@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const (
+	// DefaultManifestCacheFile is the file, in the directory of the CLI's configuration, the last
+	// discovery manifest of the host is kept in.
+	DefaultManifestCacheFile = "discovery-manifest.json"
+	// DefaultManifestCacheTTL is how long a discovery manifest is reused for, so the commands run
+	// one after the other, like plan then install then verify, discover the host once.
+	DefaultManifestCacheTTL = 5 * time.Minute
+)
+
+type manifestDiscoverer interface {
+	Discover(context.Context) (*types.DiscoveryManifest, error)
+}
+
+func GetDefaultManifestCachePath() string {
+	return filepath.Join(config.BasePath, DefaultManifestCacheFile)
+}
+
+// CachingDiscoverer reuses the manifest of the host discovered by a recent run of the CLI, and
+// keeps the manifest it discovers otherwise for the runs that follow. The processes running on the
+// host aren't kept, as they change from one run to the next.
+type CachingDiscoverer struct {
+	discoverer manifestDiscoverer
+	path       string
+	ttl        time.Duration
+	refresh    bool
+	timeout    time.Duration
+	now        func() time.Time
+	hostname   func() (string, error)
+}
+
+func NewCachingDiscoverer(discoverer manifestDiscoverer, path string, ttl time.Duration) *CachingDiscoverer {
+	return &CachingDiscoverer{
+		discoverer: discoverer,
+		path:       path,
+		ttl:        ttl,
+		now:        time.Now,
+		hostname:   os.Hostname,
+	}
+}
+
+// WithRefresh discovers the host again when refresh is set, whatever the age of the kept manifest.
+func (d *CachingDiscoverer) WithRefresh(refresh bool) *CachingDiscoverer {
+	d.refresh = refresh
+	return d
+}
+
+// WithDiscoveryTimeout sets the timeout of the discoverer, so a manifest discovered with another
+// timeout isn't reused.
+func (d *CachingDiscoverer) WithDiscoveryTimeout(timeout time.Duration) *CachingDiscoverer {
+	d.timeout = timeout
+	return d
+}
+
+func (d *CachingDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManifest, error) {
+	if m := d.cached(); m != nil {
+		return m, nil
+	}
+
+	m, err := d.discoverer.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The manifest of a discovery that skipped probes misses what they'd have found.
+	if len(m.SkippedProbes) > 0 {
+		log.Debugf("not keeping the discovery manifest, the discovery of %s didn't complete", strings.Join(m.SkippedProbes, ", "))
+		return m, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(d.path), 0750); err != nil {
+		log.Debugf("could not keep the discovery manifest: %s", err)
+		return m, nil
+	}
+
+	f := NewManifestFile(m, nil)
+	f.DiscoveryTimeout = d.timeout
+	if err := f.Write(d.path); err != nil {
+		log.Debugf("could not keep the discovery manifest: %s", err)
+	}
+
+	return m, nil
+}
+
+// cached returns the kept manifest, or nil when there's none recent enough for this host.
+func (d *CachingDiscoverer) cached() *types.DiscoveryManifest {
+	if d.refresh {
+		return nil
+	}
+
+	f, err := LoadManifestFile(d.path)
+	if err != nil {
+		return nil
+	}
+
+	if f.DiscoveryTimeout != d.timeout {
+		return nil
+	}
+
+	age := d.now().Sub(f.DiscoveredAt)
+	if age < 0 || age >= d.ttl {
+		return nil
+	}
+
+	// The configuration directory may have been copied from another host, like in a machine image.
+	if hostname, err := d.hostname(); err != nil || hostname != f.Manifest.Hostname {
+		return nil
+	}
+
+	log.Debugf("using the discovery manifest of %s discovered %s ago", d.path, age.Round(time.Second))
+
+	return f.Manifest
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+type countingDiscoverer struct {
+	manifest *types.DiscoveryManifest
+	err      error
+	calls    int
+}
+
+func (d *countingDiscoverer) Discover(context.Context) (*types.DiscoveryManifest, error) {
+	d.calls++
+	return d.manifest, d.err
+}
+
+func givenCachingDiscoverer(t *testing.T) (*CachingDiscoverer, *countingDiscoverer) {
+	inner := &countingDiscoverer{manifest: &types.DiscoveryManifest{Hostname: "web-1", OS: "linux"}}
+	d := NewCachingDiscoverer(inner, filepath.Join(t.TempDir(), "config", DefaultManifestCacheFile), time.Minute)
+	d.hostname = func() (string, error) {
+		return "web-1", nil
+	}
+
+	return d, inner
+}
+
+func TestCachingDiscovererShouldReuseRecentManifest(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+
+	first, err := d.Discover(context.Background())
+	require.NoError(t, err)
+	second, err := d.Discover(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, inner.calls)
+	require.Equal(t, first, second)
+}
+
+func TestCachingDiscovererShouldDiscoverAgainWhenManifestExpired(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+	_, err := d.Discover(context.Background())
+	require.NoError(t, err)
+
+	d.now = func() time.Time {
+		return time.Now().Add(2 * time.Minute)
+	}
+	_, err = d.Discover(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 2, inner.calls)
+}
+
+func TestCachingDiscovererShouldDiscoverAgainWhenRefreshed(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+	_, err := d.Discover(context.Background())
+	require.NoError(t, err)
+
+	_, err = d.WithRefresh(true).Discover(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 2, inner.calls)
+}
+
+func TestCachingDiscovererShouldNotReuseManifestOfAnotherHost(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+	_, err := d.Discover(context.Background())
+	require.NoError(t, err)
+
+	d.hostname = func() (string, error) {
+		return "web-2", nil
+	}
+	_, err = d.Discover(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 2, inner.calls)
+}
+
+func TestCachingDiscovererShouldNotKeepFailedDiscovery(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+	inner.err = errors.New("discovery failed")
+
+	_, err := d.Discover(context.Background())
+	require.Error(t, err)
+
+	inner.err = nil
+	_, err = d.Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, inner.calls)
+}
+
+func TestCachingDiscovererShouldNotKeepManifestWithSkippedProbes(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+	inner.manifest.SkippedProbes = []string{"docker"}
+
+	_, err := d.Discover(context.Background())
+	require.NoError(t, err)
+
+	inner.manifest = &types.DiscoveryManifest{Hostname: "web-1", OS: "linux"}
+	_, err = d.Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, inner.calls)
+}
+
+func TestCachingDiscovererShouldDiscoverAgainWhenTimeoutChanges(t *testing.T) {
+	d, inner := givenCachingDiscoverer(t)
+	_, err := d.Discover(context.Background())
+	require.NoError(t, err)
+
+	d.WithDiscoveryTimeout(time.Minute)
+	_, err = d.Discover(context.Background())
+	require.NoError(t, err)
+	_, err = d.Discover(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 2, inner.calls)
+}
@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ManifestFile is a discovery manifest exported with the processes running on its host, so the
+// recipes of the host can be resolved on another one.
+type ManifestFile struct {
+	DiscoveredAt time.Time                `json:"discoveredAt"`
+	Manifest     *types.DiscoveryManifest `json:"manifest"`
+	Processes    []ManifestProcess        `json:"processes"`
+	// DiscoveryTimeout is the --discoveryTimeout the manifest was discovered with, when it's kept
+	// for the runs that follow.
+	DiscoveryTimeout time.Duration `json:"discoveryTimeout,omitempty"`
+}
+
+// ManifestProcess is a process running on the host of an exported manifest.
+type ManifestProcess struct {
+	ProcessID   int32  `json:"pid"`
+	ProcessName string `json:"name"`
+	Command     string `json:"command"`
+}
+
+// NewManifestFile returns the manifest file of a discovered host and the processes running on it.
+func NewManifestFile(m *types.DiscoveryManifest, processes []types.GenericProcess) *ManifestFile {
+	f := &ManifestFile{
+		DiscoveredAt: time.Now(),
+		Manifest:     m,
+		Processes:    []ManifestProcess{},
+	}
+
+	for _, p := range processes {
+		name, err := p.Name()
+		if err != nil {
+			continue
+		}
+		cmd, _ := p.Cmd()
+
+		f.Processes = append(f.Processes, ManifestProcess{ProcessID: p.PID(), ProcessName: name, Command: cmd})
+	}
+
+	return f
+}
+
+// Write writes the manifest file to the path. Process command lines can have secrets in them, so
+// only the user may read it.
+func (f *ManifestFile) Write(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadManifestFile reads a manifest file written by Write.
+func LoadManifestFile(path string) (*ManifestFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read discovery manifest: %w", err)
+	}
+
+	f := &ManifestFile{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("could not read discovery manifest %s: %s", path, err)
+	}
+
+	if f.Manifest == nil || f.Manifest.OS == "" {
+		return nil, fmt.Errorf("could not read discovery manifest %s: %w", path, errors.New("the manifest has no OS"))
+	}
+
+	return f, nil
+}
+
+// GenericProcesses returns the processes of the manifest file to match recipes against.
+func (f *ManifestFile) GenericProcesses() []types.GenericProcess {
+	processes := []types.GenericProcess{}
+	for _, p := range f.Processes {
+		processes = append(processes, manifestProcess(p))
+	}
+
+	return processes
+}
+
+// manifestProcess is a process of a manifest file, as a GenericProcess.
+type manifestProcess ManifestProcess
+
+func (p manifestProcess) Name() (string, error) {
+	return p.ProcessName, nil
+}
+
+func (p manifestProcess) Cmd() (string, error) {
+	return p.Command, nil
+}
+
+func (p manifestProcess) PID() int32 {
+	return p.ProcessID
+}
+
+// FileDiscoverer discovers the host of a manifest file, instead of the host the CLI runs on.
+type FileDiscoverer struct {
+	path string
+	once sync.Once
+	file *ManifestFile
+	err  error
+}
+
+func NewFileDiscoverer(path string) *FileDiscoverer {
+	return &FileDiscoverer{
+		path: path,
+	}
+}
+
+func (d *FileDiscoverer) Discover(context.Context) (*types.DiscoveryManifest, error) {
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+
+	m := *d.file.Manifest
+	return &m, nil
+}
+
+// Processes returns the processes that ran on the host of the manifest file.
+func (d *FileDiscoverer) Processes(context.Context) []types.GenericProcess {
+	if err := d.load(); err != nil {
+		return []types.GenericProcess{}
+	}
+
+	return d.file.GenericProcesses()
+}
+
+func (d *FileDiscoverer) load() error {
+	d.once.Do(func() {
+		d.file, d.err = LoadManifestFile(d.path)
+	})
+
+	return d.err
+}
@@ -0,0 +1,59 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestFileDiscovererShouldDiscoverExportedManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	m := &types.DiscoveryManifest{Hostname: "web-1", OS: "linux", Platform: "ubuntu", PlatformVersion: "22.04"}
+	processes := []types.GenericProcess{
+		manifestProcess{ProcessID: 42, ProcessName: "mysqld", Command: "/usr/sbin/mysqld --port 3306"},
+	}
+
+	require.NoError(t, NewManifestFile(m, processes).Write(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	d := NewFileDiscoverer(path)
+	discovered, err := d.Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, m, discovered)
+
+	loaded := d.Processes(context.Background())
+	require.Len(t, loaded, 1)
+	name, _ := loaded[0].Name()
+	cmd, _ := loaded[0].Cmd()
+	require.Equal(t, "mysqld", name)
+	require.Equal(t, "/usr/sbin/mysqld --port 3306", cmd)
+	require.Equal(t, int32(42), loaded[0].PID())
+}
+
+func TestFileDiscovererShouldRejectManifestWithoutOS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"manifest": {"hostname": "web-1"}}`), 0600))
+
+	_, err := NewFileDiscoverer(path).Discover(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the manifest has no OS")
+}
+
+func TestFileDiscovererShouldFailForMissingFile(t *testing.T) {
+	d := NewFileDiscoverer(filepath.Join(t.TempDir(), "missing.json"))
+
+	_, err := d.Discover(context.Background())
+	require.Error(t, err)
+	require.Empty(t, d.Processes(context.Background()))
+}
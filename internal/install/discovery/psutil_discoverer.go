@@ -2,8 +2,10 @@ package discovery
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/shirou/gopsutil/v3/host"
 	log "github.com/sirupsen/logrus"
@@ -11,14 +13,60 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
 
-type PSUtilDiscoverer struct{}
+// DefaultProbeTimeout is how long each probe of the host's discovery, like listing its containers
+// or services, may take before discovery goes on without what it would have found.
+const DefaultProbeTimeout = 30 * time.Second
+
+type PSUtilDiscoverer struct {
+	host         *HostDetector
+	docker       *DockerDetector
+	containerd   *ContainerdDetector
+	systemd      *SystemdDetector
+	windows      *WindowsDetector
+	databases    *DatabaseConfigDetector
+	webServers   *WebServerDetector
+	gpu          *GPUDetector
+	jmx          *JMXDetector
+	kubernetes   *KubernetesDetector
+	cloud        *CloudDetector
+	timeout      time.Duration
+	probeTimeout time.Duration
+}
 
 func NewPSUtilDiscoverer() *PSUtilDiscoverer {
-	return &PSUtilDiscoverer{}
+	return &PSUtilDiscoverer{
+		host:         NewHostDetector(),
+		docker:       NewDockerDetector(),
+		containerd:   NewContainerdDetector(),
+		systemd:      NewSystemdDetector(),
+		windows:      NewWindowsDetector(),
+		databases:    NewDatabaseConfigDetector(),
+		webServers:   NewWebServerDetector(),
+		gpu:          NewGPUDetector(),
+		jmx:          NewJMXDetector(),
+		kubernetes:   NewKubernetesDetector(),
+		cloud:        NewCloudDetector(),
+		probeTimeout: DefaultProbeTimeout,
+	}
+}
+
+// WithTimeout sets how long the whole discovery may take, after which the probes that haven't
+// completed are skipped. There's no limit besides the probes' own when it's zero.
+func (p *PSUtilDiscoverer) WithTimeout(timeout time.Duration) *PSUtilDiscoverer {
+	p.timeout = timeout
+	return p
 }
 
 func (p *PSUtilDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManifest, error) {
-	i, err := host.InfoWithContext(ctx)
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	i, err := probe(ctx, "host", p.probeTimeout, func(ctx context.Context) (*host.InfoStat, error) {
+		return host.InfoWithContext(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +74,7 @@ func (p *PSUtilDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManife
 	m := types.DiscoveryManifest{
 		Hostname:        i.Hostname,
 		KernelArch:      i.KernelArch,
+		Arch:            types.NormalizeArch(i.KernelArch),
 		KernelVersion:   i.KernelVersion,
 		OS:              i.OS,
 		Platform:        i.Platform,
@@ -33,6 +82,63 @@ func (p *PSUtilDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManife
 		PlatformVersion: i.PlatformVersion,
 	}
 
+	if m.VirtualizationSystem = normalizeVirtualization(i.VirtualizationSystem); m.VirtualizationSystem != "" {
+		m.VirtualizationRole = i.VirtualizationRole
+	}
+
+	if p.host != nil {
+		hostname := m.Hostname
+		setHostDetails(&m, detect(ctx, &m, "host details", p.probeTimeout, func(ctx context.Context) *hostDetails {
+			return p.host.Detect(ctx, hostname)
+		}))
+	}
+
+	if m.OS == "linux" && p.docker != nil {
+		m.Docker = detect(ctx, &m, "docker", p.probeTimeout, p.docker.Detect)
+	}
+
+	if m.OS == "linux" && p.containerd != nil {
+		m.Containerd = detect(ctx, &m, "containerd", p.probeTimeout, p.containerd.Detect)
+	}
+
+	if m.OS == "linux" && p.systemd != nil {
+		m.Systemd = detect(ctx, &m, "systemd", p.probeTimeout, p.systemd.Detect)
+	}
+
+	if m.OS == "windows" && p.windows != nil {
+		m.Windows = detect(ctx, &m, "windows services", p.probeTimeout, p.windows.Detect)
+	}
+
+	if (m.OS == "linux" || m.OS == "darwin") && p.databases != nil {
+		m.Databases = detect(ctx, &m, "database configuration", p.probeTimeout, func(context.Context) []types.DatabaseConfig {
+			return p.databases.Detect()
+		})
+	}
+
+	if (m.OS == "linux" || m.OS == "darwin") && p.webServers != nil {
+		m.WebServers = detect(ctx, &m, "web server configuration", p.probeTimeout, func(context.Context) []types.WebServerConfig {
+			return p.webServers.Detect()
+		})
+	}
+
+	if p.jmx != nil {
+		m.JMXEndpoints = detect(ctx, &m, "jmx", p.probeTimeout, p.jmx.Detect)
+	}
+
+	if (m.OS == "linux" || m.OS == "windows") && p.gpu != nil {
+		m.GPU = detect(ctx, &m, "gpu", p.probeTimeout, p.gpu.Detect)
+	}
+
+	if p.kubernetes != nil {
+		m.Kubernetes = detect(ctx, &m, "kubernetes", p.probeTimeout, func(context.Context) *types.KubernetesHost {
+			return p.kubernetes.Detect()
+		})
+	}
+
+	if p.cloud != nil {
+		m.Cloud = detect(ctx, &m, "cloud", p.probeTimeout, p.cloud.Detect)
+	}
+
 	log.Debugf("discovered manifest %+v", m)
 
 	m = filterValues(m)
@@ -54,6 +160,46 @@ func filterValues(m types.DiscoveryManifest) types.DiscoveryManifest {
 	return m
 }
 
+// detect returns what a probe of the host found, or nothing when it didn't complete in time, in
+// which case the probe is recorded as skipped in the manifest.
+func detect[T any](ctx context.Context, m *types.DiscoveryManifest, name string, timeout time.Duration, d func(context.Context) T) T {
+	result, err := probe(ctx, name, timeout, func(ctx context.Context) (T, error) {
+		return d(ctx), nil
+	})
+	if err != nil {
+		log.Warnf("Skipping the discovery of %s: %s", name, err)
+		m.SkippedProbes = append(m.SkippedProbes, name)
+	}
+
+	return result
+}
+
+// probe runs a probe of the host with the timeout, and returns an error when neither it nor the
+// discovery completes in time. A probe that doesn't stop when its context is done, like one blocked
+// reading a file, is left to complete in the background.
+func probe[T any](ctx context.Context, name string, timeout time.Duration, p func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+	results := make(chan result, 1)
+	go func() {
+		v, err := p(ctx)
+		results <- result{value: v, err: err}
+	}()
+
+	select {
+	case r := <-results:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, fmt.Errorf("the %s probe did not complete in time: %w", name, ctx.Err())
+	}
+}
+
 func isValidOpenInstallationPlatform(platform string) bool {
 	s := reflect.ValueOf(&types.OpenInstallationPlatformTypes).Elem()
 
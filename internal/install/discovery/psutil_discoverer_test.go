@@ -4,8 +4,11 @@
 package discovery
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -47,3 +50,50 @@ func TestFilterValues_InvalidPlatform(t *testing.T) {
 	require.Empty(t, m.Platform)
 	require.Empty(t, m.PlatformFamily)
 }
+
+func TestProbeShouldReturnResultOfProbe(t *testing.T) {
+	value, err := probe(context.Background(), "test", time.Second, func(ctx context.Context) (string, error) {
+		return "found", nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "found", value)
+
+	_, err = probe(context.Background(), "test", time.Second, func(ctx context.Context) (string, error) {
+		return "", errors.New("failed")
+	})
+	require.EqualError(t, err, "failed")
+}
+
+func TestProbeShouldNotWaitForSlowProbe(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	start := time.Now()
+	value, err := probe(context.Background(), "test", 10*time.Millisecond, func(ctx context.Context) (string, error) {
+		// Like a read of a hung file system, which doesn't stop when its context is done.
+		<-blocked
+		return "found", nil
+	})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "the test probe did not complete in time")
+	require.Empty(t, value)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestDetectShouldSkipProbeWhenDiscoveryTimesOut(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m := &types.DiscoveryManifest{}
+	h := detect(ctx, m, "test", time.Minute, func(ctx context.Context) *types.SystemdHost {
+		<-blocked
+		return &types.SystemdHost{}
+	})
+
+	require.Nil(t, h)
+	require.Equal(t, []string{"test"}, m.SkippedProbes)
+}
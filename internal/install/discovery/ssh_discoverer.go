@@ -0,0 +1,108 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// sshDiscoveryScript prints the host information as KEY=VALUE lines, followed by /etc/os-release,
+// or the macOS version on Macs, which have no os-release.
+const sshDiscoveryScript = `echo "HOSTNAME=$(hostname)"
+echo "KERNEL_NAME=$(uname -s)"
+echo "KERNEL_ARCH=$(uname -m)"
+echo "KERNEL_VERSION=$(uname -r)"
+echo "FQDN=$(hostname -f 2>/dev/null)"
+echo "IP_ADDRESSES=$(hostname -I 2>/dev/null)"
+echo "VIRTUALIZATION=$(systemd-detect-virt 2>/dev/null)"
+echo "CPUS=$(nproc 2>/dev/null || sysctl -n hw.ncpu 2>/dev/null)"
+echo "MEMORY_KB=$(awk '/^MemTotal:/ {print $2}' /proc/meminfo 2>/dev/null)"
+cat /etc/os-release 2>/dev/null || echo "VERSION_ID=$(sw_vers -productVersion 2>/dev/null)"
+`
+
+// platformFamilies maps os-release IDs to the platform families gopsutil reports for local installs.
+var platformFamilies = map[string]string{
+	"ubuntu":   "debian",
+	"debian":   "debian",
+	"raspbian": "debian",
+	"centos":   "rhel",
+	"rhel":     "rhel",
+	"amazon":   "rhel",
+	"ol":       "rhel",
+	"rocky":    "rhel",
+	"fedora":   "fedora",
+	"sles":     "suse",
+	"opensuse": "suse",
+}
+
+type SSHDiscoverer struct {
+	client remote.Client
+}
+
+func NewSSHDiscoverer(client remote.Client) *SSHDiscoverer {
+	return &SSHDiscoverer{
+		client: client,
+	}
+}
+
+func (s *SSHDiscoverer) Discover(ctx context.Context) (*types.DiscoveryManifest, error) {
+	out, err := remote.Output(ctx, s.client, sshDiscoveryScript)
+	if err != nil {
+		return nil, fmt.Errorf("could not discover %s: %w", s.client.Target(), err)
+	}
+
+	values := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(parts) == 2 {
+			values[parts[0]] = strings.Trim(parts[1], `"'`)
+		}
+	}
+
+	platform := values["ID"]
+	if platform == "amzn" {
+		platform = "amazon"
+	}
+
+	m := types.DiscoveryManifest{
+		Hostname:        values["HOSTNAME"],
+		KernelArch:      values["KERNEL_ARCH"],
+		Arch:            types.NormalizeArch(values["KERNEL_ARCH"]),
+		KernelVersion:   values["KERNEL_VERSION"],
+		OS:              strings.ToLower(values["KERNEL_NAME"]),
+		Platform:        platform,
+		PlatformFamily:  platformFamilies[platform],
+		PlatformVersion: values["VERSION_ID"],
+		IPAddresses:     strings.Fields(values["IP_ADDRESSES"]),
+	}
+
+	if fqdn := values["FQDN"]; strings.Contains(fqdn, ".") {
+		m.FQDN = fqdn
+	}
+
+	if m.VirtualizationSystem = normalizeVirtualization(values["VIRTUALIZATION"]); m.VirtualizationSystem != "" {
+		m.VirtualizationRole = "guest"
+	}
+
+	if cpus, err := strconv.Atoi(values["CPUS"]); err == nil {
+		m.CPUs = cpus
+	}
+
+	if kb, err := strconv.ParseUint(values["MEMORY_KB"], 10, 64); err == nil {
+		m.TotalMemoryBytes = kb * 1024
+	}
+
+	log.Debugf("discovered manifest of %s %+v", s.client.Target(), m)
+
+	m = filterValues(m)
+
+	log.Debugf("filtered manifest %+v", m)
+
+	return &m, nil
+}
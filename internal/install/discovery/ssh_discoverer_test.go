@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+)
+
+func TestSSHDiscovererShouldDiscoverRemoteHost(t *testing.T) {
+	c := remote.NewMockClient()
+	c.Outputs["uname"] = `HOSTNAME=web-1
+KERNEL_NAME=Linux
+KERNEL_ARCH=x86_64
+KERNEL_VERSION=5.15.0-1019-aws
+FQDN=web-1.example.com
+IP_ADDRESSES=10.0.0.5 172.17.0.1
+VIRTUALIZATION=kvm
+CPUS=4
+MEMORY_KB=8000000
+NAME="Ubuntu"
+VERSION_ID="22.04"
+ID=ubuntu
+ID_LIKE=debian
+`
+
+	m, err := NewSSHDiscoverer(c).Discover(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "web-1", m.Hostname)
+	require.Equal(t, "linux", m.OS)
+	require.Equal(t, "x86_64", m.KernelArch)
+	require.Equal(t, "amd64", m.Arch)
+	require.Equal(t, "5.15.0-1019-aws", m.KernelVersion)
+	require.Equal(t, "ubuntu", m.Platform)
+	require.Equal(t, "debian", m.PlatformFamily)
+	require.Equal(t, "22.04", m.PlatformVersion)
+	require.Equal(t, "web-1.example.com", m.FQDN)
+	require.Equal(t, []string{"10.0.0.5", "172.17.0.1"}, m.IPAddresses)
+	require.Equal(t, "kvm", m.VirtualizationSystem)
+	require.Equal(t, "guest", m.VirtualizationRole)
+	require.Equal(t, 4, m.CPUs)
+	require.Equal(t, uint64(8000000*1024), m.TotalMemoryBytes)
+}
+
+func TestSSHDiscovererShouldMapAmazonLinux(t *testing.T) {
+	c := remote.NewMockClient()
+	c.Outputs["uname"] = "KERNEL_NAME=Linux\nID=\"amzn\"\nVERSION_ID=\"2\"\n"
+
+	m, err := NewSSHDiscoverer(c).Discover(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "amazon", m.Platform)
+	require.Equal(t, "rhel", m.PlatformFamily)
+}
+
+func TestSSHDiscovererShouldDiscoverMacOS(t *testing.T) {
+	c := remote.NewMockClient()
+	c.Outputs["uname"] = "KERNEL_NAME=Darwin\nKERNEL_ARCH=arm64\nVERSION_ID=13.4.1\n"
+
+	m, err := NewSSHDiscoverer(c).Discover(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "darwin", m.OS)
+	require.Equal(t, "arm64", m.KernelArch)
+	require.Equal(t, "13.4.1", m.PlatformVersion)
+}
+
+func TestSSHDiscovererShouldFailWhenHostIsUnreachable(t *testing.T) {
+	c := remote.NewMockClient()
+	c.Errors["uname"] = errors.New("exit status 255")
+
+	_, err := NewSSHDiscoverer(c).Discover(context.Background())
+
+	require.Error(t, err)
+}
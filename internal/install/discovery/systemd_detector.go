@@ -0,0 +1,177 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// systemdRuntimeDir exists when systemd is the init system of the host, as sd_booted checks.
+const systemdRuntimeDir = "/run/systemd/system"
+
+// defaultSystemdUnitDirs are the directories of the system's unit files, in the order systemd
+// loads them, so the first unit file of a name is the one in effect.
+var defaultSystemdUnitDirs = []string{
+	"/etc/systemd/system",
+	"/run/systemd/system",
+	"/usr/local/lib/systemd/system",
+	"/usr/lib/systemd/system",
+	"/lib/systemd/system",
+}
+
+// runnableUnitFileStates are the states of the unit files of services that can start without being
+// enabled first, so they're discovered while idle.
+var runnableUnitFileStates = map[string]bool{
+	"enabled":         true,
+	"enabled-runtime": true,
+	"static":          true,
+	"indirect":        true,
+	"generated":       true,
+}
+
+// SystemdDetector finds the service units installed on the host, with their states, so services
+// that aren't running at the time of discovery are found too.
+type SystemdDetector struct {
+	runtimeDir string
+	unitDirs   []string
+	systemctl  func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+func NewSystemdDetector() *SystemdDetector {
+	return &SystemdDetector{
+		runtimeDir: systemdRuntimeDir,
+		unitDirs:   defaultSystemdUnitDirs,
+		systemctl:  runSystemctl,
+	}
+}
+
+// Detect returns the service units of the host that are running or can start, or nil when systemd
+// doesn't manage the host's services.
+func (d *SystemdDetector) Detect(ctx context.Context) *types.SystemdHost {
+	if _, err := os.Stat(d.runtimeDir); err != nil {
+		return nil
+	}
+
+	units := d.readUnitFiles()
+	activeStates := d.unitStates(ctx, 2, "list-units", "--type=service", "--all", "--no-legend", "--no-pager", "--plain")
+	fileStates := d.unitStates(ctx, 1, "list-unit-files", "--type=service", "--no-legend", "--no-pager")
+
+	h := &types.SystemdHost{
+		Units: []types.SystemdUnit{},
+	}
+	for _, u := range units {
+		u.ActiveState = activeStates[u.Name]
+		u.UnitFileState = fileStates[u.Name]
+
+		// Without systemctl the states aren't known, and every unit is kept.
+		known := len(activeStates) > 0 || len(fileStates) > 0
+		if known && !runnableUnitFileStates[u.UnitFileState] && u.ActiveState != "active" && u.ActiveState != "activating" && u.ActiveState != "reloading" {
+			continue
+		}
+		h.Units = append(h.Units, u)
+	}
+
+	log.Debugf("discovered %d systemd service units", len(h.Units))
+
+	return h
+}
+
+// readUnitFiles reads the name and start command of the service unit files, by name.
+func (d *SystemdDetector) readUnitFiles() []types.SystemdUnit {
+	seen := map[string]bool{}
+	units := []types.SystemdUnit{}
+
+	for _, dir := range d.unitDirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.service"))
+		if err != nil {
+			continue
+		}
+
+		for _, p := range paths {
+			name := filepath.Base(p)
+			// Templates only run as instances, whose units are named after them.
+			if seen[name] || strings.HasSuffix(name, "@.service") {
+				continue
+			}
+			seen[name] = true
+
+			data, err := os.ReadFile(p)
+			if err != nil || len(data) == 0 {
+				// Masked units are links to /dev/null.
+				continue
+			}
+
+			units = append(units, types.SystemdUnit{Name: name, ExecStart: parseExecStart(data)})
+		}
+	}
+
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name < units[j].Name
+	})
+
+	return units
+}
+
+// unitStates returns the state in the given column of the units systemctl lists, by unit name.
+func (d *SystemdDetector) unitStates(ctx context.Context, column int, args ...string) map[string]string {
+	states := map[string]string{}
+
+	out, err := d.systemctl(ctx, args...)
+	if err != nil {
+		log.Debugf("could not list systemd units: %s", err)
+		return states
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > column {
+			states[fields[0]] = fields[column]
+		}
+	}
+
+	return states
+}
+
+// parseExecStart returns the command line of the last ExecStart of the [Service] section of a unit
+// file, without the prefixes that change how systemd runs it.
+func parseExecStart(data []byte) string {
+	execStart := ""
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			section = line
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || section != "[Service]" || strings.TrimSpace(key) != "ExecStart" {
+			continue
+		}
+
+		// An empty ExecStart resets those before it, as in drop-in files.
+		execStart = strings.TrimLeft(strings.TrimSpace(value), "-@:+!")
+	}
+
+	return execStart
+}
+
+func runSystemctl(ctx context.Context, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil, err
+	}
+
+	return exec.CommandContext(ctx, "systemctl", args...).Output()
+}
@@ -0,0 +1,99 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func givenSystemdUnitDirs(t *testing.T) (string, []string) {
+	root := t.TempDir()
+	etc := filepath.Join(root, "etc")
+	lib := filepath.Join(root, "lib")
+	for _, dir := range []string{etc, lib} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+
+	units := map[string]string{
+		filepath.Join(lib, "mysql.service"):        "[Unit]\nDescription=MySQL\n\n[Service]\nExecStart=/usr/sbin/mysqld\n",
+		filepath.Join(etc, "redis-server.service"): "[Service]\nExecStart=-/usr/bin/redis-server /etc/redis/redis.conf\n",
+		filepath.Join(lib, "redis-server.service"): "[Service]\nExecStart=/usr/bin/redis-server\n",
+		filepath.Join(lib, "cups.service"):         "[Service]\nExecStart=/usr/sbin/cupsd -l\n",
+		filepath.Join(lib, "getty@.service"):       "[Service]\nExecStart=-/sbin/agetty %I\n",
+		filepath.Join(lib, "masked.service"):       "",
+	}
+	for path, content := range units {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return root, []string{etc, lib}
+}
+
+func TestSystemdDetectorShouldDetectRunnableUnits(t *testing.T) {
+	root, dirs := givenSystemdUnitDirs(t)
+	d := &SystemdDetector{
+		runtimeDir: root,
+		unitDirs:   dirs,
+		systemctl: func(ctx context.Context, args ...string) ([]byte, error) {
+			if args[0] == "list-units" {
+				return []byte("mysql.service loaded inactive dead MySQL\nredis-server.service loaded active running Redis\ncups.service loaded inactive dead CUPS\n"), nil
+			}
+			return []byte("mysql.service enabled enabled\nredis-server.service disabled enabled\ncups.service disabled enabled\n"), nil
+		},
+	}
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Equal(t, []types.SystemdUnit{
+		{Name: "mysql.service", ExecStart: "/usr/sbin/mysqld", ActiveState: "inactive", UnitFileState: "enabled"},
+		{Name: "redis-server.service", ExecStart: "/usr/bin/redis-server /etc/redis/redis.conf", ActiveState: "active", UnitFileState: "disabled"},
+	}, h.Units)
+}
+
+func TestSystemdDetectorShouldKeepEveryUnitWithoutSystemctl(t *testing.T) {
+	root, dirs := givenSystemdUnitDirs(t)
+	d := &SystemdDetector{
+		runtimeDir: root,
+		unitDirs:   dirs,
+		systemctl: func(ctx context.Context, args ...string) ([]byte, error) {
+			return nil, errors.New(`exec: "systemctl": executable file not found in $PATH`)
+		},
+	}
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Len(t, h.Units, 3)
+}
+
+func TestSystemdDetectorShouldNotDetectWithoutSystemd(t *testing.T) {
+	d := &SystemdDetector{
+		runtimeDir: filepath.Join(t.TempDir(), "missing"),
+	}
+
+	require.Nil(t, d.Detect(context.Background()))
+}
+
+func TestParseExecStartShouldReadTheServiceSection(t *testing.T) {
+	unit := strings.Join([]string{
+		"[Unit]",
+		"ExecStart=/not/a/service",
+		"[Service]",
+		"ExecStart=/usr/bin/old",
+		"ExecStart=",
+		"ExecStart=+/usr/bin/kafka-server-start.sh /etc/kafka/server.properties",
+	}, "\n")
+
+	require.Equal(t, "/usr/bin/kafka-server-start.sh /etc/kafka/server.properties", parseExecStart([]byte(unit)))
+}
@@ -0,0 +1,417 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// webServerConfigFile is where the configuration of a web server is looked for, and how it's read.
+// Its paths may be glob patterns, and the first one found is the one read.
+type webServerConfigFile struct {
+	service string
+	paths   []string
+	parse   func(path string, data []byte, c *types.WebServerConfig)
+}
+
+// defaultWebServerConfigFiles are the paths the packages of Linux distributions and Homebrew
+// install the main configuration files of web servers at.
+var defaultWebServerConfigFiles = []webServerConfigFile{
+	{
+		service: types.WebServerNginx,
+		paths: []string{
+			"/etc/nginx/nginx.conf",
+			"/usr/local/etc/nginx/nginx.conf",
+			"/opt/homebrew/etc/nginx/nginx.conf",
+		},
+		parse: parseNginxConfig,
+	},
+	{
+		service: types.WebServerApache,
+		paths: []string{
+			"/etc/apache2/apache2.conf",
+			"/etc/httpd/conf/httpd.conf",
+			"/etc/apache2/httpd.conf",
+			"/usr/local/etc/httpd/httpd.conf",
+			"/opt/homebrew/etc/httpd/httpd.conf",
+		},
+		parse: parseApacheConfig,
+	},
+	{
+		service: types.WebServerHAProxy,
+		paths: []string{
+			"/etc/haproxy/haproxy.cfg",
+			"/usr/local/etc/haproxy/haproxy.cfg",
+			"/opt/homebrew/etc/haproxy.cfg",
+		},
+		parse: parseHAProxyConfig,
+	},
+}
+
+// WebServerDetector reads the configuration files of the web servers installed on the host, for
+// the status pages their integrations read their metrics from.
+type WebServerDetector struct {
+	files []webServerConfigFile
+}
+
+func NewWebServerDetector() *WebServerDetector {
+	return &WebServerDetector{
+		files: defaultWebServerConfigFiles,
+	}
+}
+
+// Detect returns the configuration of each web server whose configuration file was found, or nil
+// when none was.
+func (d *WebServerDetector) Detect() []types.WebServerConfig {
+	var configs []types.WebServerConfig
+
+	for _, f := range d.files {
+		path := findConfigFile(f.paths)
+		if path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Debugf("could not read the %s configuration file %s: %s", f.service, path, err)
+			continue
+		}
+
+		c := types.WebServerConfig{Service: f.service, ConfigPath: path}
+		f.parse(path, data, &c)
+		configs = append(configs, c)
+	}
+
+	log.Debugf("discovered %d web server configuration files", len(configs))
+
+	return configs
+}
+
+// nginxBlock is a block directive of an nginx configuration, such as a server or a location.
+type nginxBlock struct {
+	name string
+	args []string
+	// listen and statusPaths are the first listen directive and the stub_status locations of a
+	// server block.
+	listen      []string
+	statusPaths []string
+}
+
+// nginxParser reads the directives of an nginx configuration, and of the files it includes, within
+// the blocks they're in.
+type nginxParser struct {
+	root   string
+	c      *types.WebServerConfig
+	blocks []*nginxBlock
+}
+
+// parseNginxConfig reads the first stub_status location of the server blocks of an nginx
+// configuration.
+func parseNginxConfig(path string, data []byte, c *types.WebServerConfig) {
+	p := &nginxParser{root: filepath.Dir(path), c: c}
+	p.parse(data, 0)
+}
+
+func (p *nginxParser) parse(data []byte, depth int) {
+	words := []string{}
+	word := strings.Builder{}
+	quote := rune(0)
+	comment := false
+
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range string(data) {
+		switch {
+		case comment:
+			comment = r != '\n'
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '#':
+			flush()
+			comment = true
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		case r == ';':
+			flush()
+			p.directive(words, depth)
+			words = []string{}
+		case r == '{':
+			flush()
+			if len(words) > 0 {
+				p.blocks = append(p.blocks, &nginxBlock{name: words[0], args: words[1:]})
+			}
+			words = []string{}
+		case r == '}':
+			flush()
+			p.closeBlock()
+			words = []string{}
+		default:
+			word.WriteRune(r)
+		}
+	}
+}
+
+func (p *nginxParser) directive(words []string, depth int) {
+	if len(words) == 0 {
+		return
+	}
+
+	switch words[0] {
+	case "include":
+		if len(words) < 2 || depth >= maxConfigIncludeDepth {
+			return
+		}
+		for _, path := range includedFiles(p.root, words[1]) {
+			if data, err := os.ReadFile(path); err == nil {
+				p.parse(data, depth+1)
+			}
+		}
+	case "listen":
+		if server := p.innermost("server"); server != nil && server.listen == nil {
+			server.listen = words[1:]
+		}
+	case "stub_status":
+		location, server := p.innermost("location"), p.innermost("server")
+		if location != nil && server != nil && len(location.args) > 0 {
+			server.statusPaths = append(server.statusPaths, location.args[len(location.args)-1])
+		}
+	}
+}
+
+// closeBlock ends the innermost block, setting the status URL of the configuration when it's the
+// first server block with a stub_status location.
+func (p *nginxParser) closeBlock() {
+	if len(p.blocks) == 0 {
+		return
+	}
+
+	b := p.blocks[len(p.blocks)-1]
+	p.blocks = p.blocks[:len(p.blocks)-1]
+
+	if b.name != "server" || len(b.statusPaths) == 0 || p.c.StatusURL != "" {
+		return
+	}
+
+	address := "80"
+	if len(b.listen) > 0 {
+		address = b.listen[0]
+	}
+	p.c.StatusURL = localStatusURL(address, contains(b.listen, "ssl"), b.statusPaths[0])
+}
+
+func (p *nginxParser) innermost(name string) *nginxBlock {
+	for i := len(p.blocks) - 1; i >= 0; i-- {
+		if p.blocks[i].name == name {
+			return p.blocks[i]
+		}
+	}
+
+	return nil
+}
+
+// parseApacheConfig reads the first server-status location of an Apache configuration, and of the
+// files it includes.
+func parseApacheConfig(path string, data []byte, c *types.WebServerConfig) {
+	p := &apacheParser{root: filepath.Dir(path)}
+	p.parse(data, 0)
+
+	if p.statusPath == "" {
+		return
+	}
+
+	address := "80"
+	if len(p.listen) > 0 {
+		address = p.listen[0]
+	}
+	c.StatusURL = localStatusURL(address, contains(p.listen, "https"), p.statusPath)
+}
+
+// apacheParser reads the directives of an Apache configuration, whose includes are relative to its
+// ServerRoot.
+type apacheParser struct {
+	root       string
+	listen     []string
+	location   string
+	statusPath string
+}
+
+func (p *apacheParser) parse(data []byte, depth int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		directive := strings.ToLower(fields[0])
+		args := fields[1:]
+		for i := range args {
+			args[i] = unquoteConfigValue(strings.TrimSuffix(args[i], ">"))
+		}
+
+		switch {
+		case directive == "<location" && len(args) > 0:
+			p.location = args[len(args)-1]
+		case directive == "</location>":
+			p.location = ""
+		case directive == "sethandler" && len(args) > 0 && strings.EqualFold(args[0], "server-status"):
+			if p.location != "" && p.statusPath == "" {
+				p.statusPath = p.location
+			}
+		case directive == "listen" && len(args) > 0 && p.listen == nil:
+			p.listen = args
+		case directive == "serverroot" && len(args) > 0:
+			p.root = args[0]
+		case (directive == "include" || directive == "includeoptional") && len(args) > 0:
+			if depth >= maxConfigIncludeDepth {
+				continue
+			}
+			for _, path := range includedFiles(p.root, args[0]) {
+				if data, err := os.ReadFile(path); err == nil {
+					p.parse(data, depth+1)
+				}
+			}
+		}
+	}
+}
+
+// haproxySections are the keywords that start the sections of an HAProxy configuration.
+var haproxySections = map[string]bool{
+	"global":    true,
+	"defaults":  true,
+	"frontend":  true,
+	"backend":   true,
+	"listen":    true,
+	"userlist":  true,
+	"peers":     true,
+	"resolvers": true,
+	"mailers":   true,
+	"program":   true,
+	"cache":     true,
+	"ring":      true,
+}
+
+// parseHAProxyConfig reads the stats page of the first frontend or listen section of an HAProxy
+// configuration that serves one.
+func parseHAProxyConfig(_ string, data []byte, c *types.WebServerConfig) {
+	var bind []string
+	statsURI := ""
+
+	done := func() {
+		if c.StatusURL == "" && statsURI != "" && len(bind) > 0 {
+			c.StatusURL = localStatusURL(bind[0], contains(bind, "ssl"), statsURI)
+		}
+		bind, statsURI = nil, ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(stripConfigComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case haproxySections[fields[0]]:
+			done()
+		case fields[0] == "bind" && len(fields) > 1 && bind == nil:
+			bind = fields[1:]
+		case fields[0] == "stats" && len(fields) > 2 && fields[1] == "uri":
+			statsURI = fields[2]
+		}
+	}
+
+	done()
+}
+
+// includedFiles returns the files of an include directive, whose relative patterns are relative to
+// the root directory of the configuration. A directory includes all its files.
+func includedFiles(root string, pattern string) []string {
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(root, pattern)
+	}
+
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		pattern = filepath.Join(pattern, "*")
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(matches)
+
+	files := []string{}
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+			files = append(files, m)
+		}
+	}
+
+	return files
+}
+
+// localStatusURL returns the URL, from the host itself, of a status page served on a listen address
+// such as 8080, 127.0.0.1:8080 or *:80. Servers listening on all interfaces are reached on the
+// loopback address.
+func localStatusURL(address string, tls bool, path string) string {
+	if strings.HasPrefix(address, "unix:") || strings.HasPrefix(address, "/") {
+		return ""
+	}
+
+	host, port := "", address
+	if h, p, err := net.SplitHostPort(address); err == nil {
+		host, port = h, p
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		// A listen address without a port, such as a hostname.
+		host, port = address, ""
+	}
+	if host == "" || host == "*" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+
+	scheme, defaultPort := "http", "80"
+	if tls {
+		scheme, defaultPort = "https", "443"
+	}
+	if port != "" && port != defaultPort {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return scheme + "://" + host + path
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
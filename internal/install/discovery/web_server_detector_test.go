@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func detectWebServer(t *testing.T, service string, path string, parse func(string, []byte, *types.WebServerConfig)) []types.WebServerConfig {
+	d := &WebServerDetector{
+		files: []webServerConfigFile{
+			{service: service, paths: []string{filepath.Join(t.TempDir(), "missing.conf"), path}, parse: parse},
+		},
+	}
+
+	return d.Detect()
+}
+
+func TestWebServerDetectorShouldReadNginxStubStatusOfIncludedServers(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "nginx.conf"), "user www-data;\nhttp {\n    # include disabled.conf;\n    include sites-enabled/*;\n}\n")
+	writeConfigFile(t, filepath.Join(root, "sites-enabled", "default"), "server {\n    listen 80 default_server;\n    location / { try_files $uri $uri/ =404; }\n}\n")
+	writeConfigFile(t, filepath.Join(root, "sites-enabled", "status"), "server {\n    location = /nginx_status {\n        stub_status;\n        allow 127.0.0.1;\n    }\n    listen 127.0.0.1:8080;\n}\n")
+
+	configs := detectWebServer(t, types.WebServerNginx, filepath.Join(root, "nginx.conf"), parseNginxConfig)
+
+	require.Equal(t, []types.WebServerConfig{
+		{Service: types.WebServerNginx, ConfigPath: filepath.Join(root, "nginx.conf"), StatusURL: "http://127.0.0.1:8080/nginx_status"},
+	}, configs)
+}
+
+func TestWebServerDetectorShouldReadNginxWithoutStubStatus(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "nginx.conf"), "http {\n    server {\n        listen 443 ssl;\n        location /status { return 200 'stub_status;'; }\n    }\n}\n")
+
+	configs := detectWebServer(t, types.WebServerNginx, filepath.Join(root, "nginx.conf"), parseNginxConfig)
+
+	require.Len(t, configs, 1)
+	require.Empty(t, configs[0].StatusURL)
+}
+
+func TestWebServerDetectorShouldReadApacheServerStatusOfIncludedModules(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "apache2.conf"), "ServerRoot \""+root+"\"\nInclude ports.conf\nIncludeOptional mods-enabled/*.conf\n")
+	writeConfigFile(t, filepath.Join(root, "ports.conf"), "Listen 8081\n<IfModule ssl_module>\n\tListen 443\n</IfModule>\n")
+	writeConfigFile(t, filepath.Join(root, "mods-enabled", "status.conf"), "<IfModule mod_status.c>\n\t<Location \"/server-status\">\n\t\tSetHandler server-status\n\t\tRequire local\n\t</Location>\n</IfModule>\n")
+
+	configs := detectWebServer(t, types.WebServerApache, filepath.Join(root, "apache2.conf"), parseApacheConfig)
+
+	require.Equal(t, []types.WebServerConfig{
+		{Service: types.WebServerApache, ConfigPath: filepath.Join(root, "apache2.conf"), StatusURL: "http://127.0.0.1:8081/server-status"},
+	}, configs)
+}
+
+func TestWebServerDetectorShouldReadHAProxyStatsPage(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "haproxy.cfg"), "global\n    stats socket /run/haproxy/admin.sock mode 660\n\nfrontend web\n    bind *:80\n    default_backend app\n\nlisten stats\n    bind :8404\n    stats enable\n    stats uri /stats # the stats page\n")
+
+	configs := detectWebServer(t, types.WebServerHAProxy, filepath.Join(root, "haproxy.cfg"), parseHAProxyConfig)
+
+	require.Equal(t, []types.WebServerConfig{
+		{Service: types.WebServerHAProxy, ConfigPath: filepath.Join(root, "haproxy.cfg"), StatusURL: "http://127.0.0.1:8404/stats"},
+	}, configs)
+}
+
+func TestWebServerDetectorShouldReturnNilWithoutConfigFiles(t *testing.T) {
+	require.Nil(t, detectWebServer(t, types.WebServerNginx, filepath.Join(t.TempDir(), "nginx.conf"), parseNginxConfig))
+}
+
+func TestLocalStatusURLShouldReachListenAddressesFromHost(t *testing.T) {
+	require.Equal(t, "http://127.0.0.1/status", localStatusURL("80", false, "/status"))
+	require.Equal(t, "http://127.0.0.1:8080/status", localStatusURL("*:8080", false, "status"))
+	require.Equal(t, "http://127.0.0.1/status", localStatusURL("[::]:80", false, "/status"))
+	require.Equal(t, "https://10.0.0.5:8443/status", localStatusURL("10.0.0.5:8443", true, "/status"))
+	require.Equal(t, "https://127.0.0.1/status", localStatusURL("443", true, "/status"))
+	require.Equal(t, "http://localhost/status", localStatusURL("localhost", false, "/status"))
+	require.Empty(t, localStatusURL("unix:/run/nginx.sock", false, "/status"))
+}
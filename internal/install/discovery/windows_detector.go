@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// windowsDiscoveryScript prints the services of the host, the websites of IIS when its
+// WebAdministration module is installed, and the SQL Server instances of the registry, as JSON.
+const windowsDiscoveryScript = `$ErrorActionPreference = 'SilentlyContinue'
+$services = @(Get-CimInstance Win32_Service | ForEach-Object { [pscustomobject]@{ name = $_.Name; displayName = $_.DisplayName; state = $_.State; startMode = $_.StartMode; pathName = $_.PathName } })
+$sites = @()
+if (Get-Module -ListAvailable -Name WebAdministration) {
+  Import-Module WebAdministration
+  $sites = @(Get-Website | ForEach-Object { [pscustomobject]@{ name = $_.Name; state = $_.State; bindings = @($_.Bindings.Collection | ForEach-Object { $_.protocol + ' ' + $_.bindingInformation }) } })
+}
+$sql = @()
+$key = 'HKLM:\SOFTWARE\Microsoft\Microsoft SQL Server\Instance Names\SQL'
+if (Test-Path $key) { $sql = @((Get-Item $key).GetValueNames()) }
+ConvertTo-Json -Compress -Depth 4 @{ services = $services; iisSites = $sites; sqlServerInstances = $sql }
+`
+
+// WindowsDetector finds the services installed on a Windows host, including those that are stopped,
+// with the websites of IIS and the instances of SQL Server.
+type WindowsDetector struct {
+	run func(ctx context.Context, script string) ([]byte, error)
+}
+
+func NewWindowsDetector() *WindowsDetector {
+	return &WindowsDetector{
+		run: runPowerShell,
+	}
+}
+
+// Detect returns the Windows host, or nil when its services can't be listed.
+func (d *WindowsDetector) Detect(ctx context.Context) *types.WindowsHost {
+	out, err := d.run(ctx, windowsDiscoveryScript)
+	if err != nil {
+		log.Debugf("could not list the Windows services: %s", err)
+		return nil
+	}
+
+	h := &types.WindowsHost{}
+	if err := json.Unmarshal(out, h); err != nil {
+		log.Debugf("could not read the Windows services: %s", err)
+		return nil
+	}
+
+	if h.Services == nil {
+		h.Services = []types.WindowsService{}
+	}
+
+	log.Debugf("discovered %d Windows services, %d IIS sites and %d SQL Server instances", len(h.Services), len(h.IISSites), len(h.SQLServerInstances))
+
+	return h
+}
+
+func runPowerShell(ctx context.Context, script string) ([]byte, error) {
+	return exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-NonInteractive", "-Command", script).Output()
+}
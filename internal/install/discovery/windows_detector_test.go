@@ -0,0 +1,41 @@
+//go:build unit
+// +build unit
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestWindowsDetectorShouldDetectServicesSitesAndInstances(t *testing.T) {
+	d := &WindowsDetector{
+		run: func(context.Context, string) ([]byte, error) {
+			return []byte(`{"services":[{"name":"W3SVC","displayName":"World Wide Web Publishing Service","state":"Running","startMode":"Auto","pathName":"C:\\Windows\\system32\\svchost.exe -k iissvcs"},{"name":"MSSQLSERVER","displayName":"SQL Server (MSSQLSERVER)","state":"Stopped","startMode":"Manual","pathName":"\"C:\\Program Files\\Microsoft SQL Server\\MSSQL16.MSSQLSERVER\\MSSQL\\Binn\\sqlservr.exe\" -sMSSQLSERVER"}],"iisSites":[{"name":"Default Web Site","state":"Started","bindings":["http *:80:"]}],"sqlServerInstances":["MSSQLSERVER"]}`), nil
+		},
+	}
+
+	h := d.Detect(context.Background())
+
+	require.NotNil(t, h)
+	require.Len(t, h.Services, 2)
+	require.Equal(t, "MSSQLSERVER", h.Services[1].Name)
+	require.Equal(t, "Stopped", h.Services[1].State)
+	require.Equal(t, []types.IISSite{{Name: "Default Web Site", State: "Started", Bindings: []string{"http *:80:"}}}, h.IISSites)
+	require.Equal(t, []string{"MSSQLSERVER"}, h.SQLServerInstances)
+}
+
+func TestWindowsDetectorShouldNotDetectWhenServicesCantBeListed(t *testing.T) {
+	d := &WindowsDetector{
+		run: func(context.Context, string) ([]byte, error) {
+			return nil, errors.New(`exec: "powershell.exe": executable file not found in %PATH%`)
+		},
+	}
+
+	require.Nil(t, d.Detect(context.Background()))
+}
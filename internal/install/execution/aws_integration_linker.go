@@ -0,0 +1,86 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+)
+
+// awsCloudFormationTemplateURL is the CloudFormation template creating the IAM role New Relic reads
+// the AWS account's telemetry with.
+const awsCloudFormationTemplateURL = "https://nr-downloads-main.s3.amazonaws.com/cloud_integrations/aws/cloudformation/newrelic-cloudformation-link.yaml"
+
+// AWSIntegrationLinker links the AWS account of an EC2 instance to the New Relic account, so the
+// account's cloud telemetry lands next to the instance's infrastructure data.
+type AWSIntegrationLinker struct {
+	client    CloudLinkingClient
+	accountID int
+}
+
+// NewAWSIntegrationLinker returns a linker of AWS accounts to the New Relic account accountID.
+func NewAWSIntegrationLinker(client CloudLinkingClient, accountID int) *AWSIntegrationLinker {
+	return &AWSIntegrationLinker{
+		client:    client,
+		accountID: accountID,
+	}
+}
+
+// IsLinked returns whether the AWS account is already linked to the New Relic account.
+func (l *AWSIntegrationLinker) IsLinked(ctx context.Context, awsAccountID string) (bool, error) {
+	accounts, err := l.client.GetLinkedAccountsWithContext(ctx, "aws")
+	if err != nil {
+		return false, fmt.Errorf("could not list linked AWS accounts: %w", err)
+	}
+
+	if accounts == nil {
+		return false, nil
+	}
+
+	for _, a := range *accounts {
+		if a.ExternalId == awsAccountID && !a.Disabled {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Link links the AWS account New Relic reads with the IAM role roleARN, named name in New Relic.
+func (l *AWSIntegrationLinker) Link(ctx context.Context, roleARN string, name string) error {
+	if !strings.HasPrefix(roleARN, "arn:aws") || !strings.Contains(roleARN, ":role/") {
+		return fmt.Errorf("invalid IAM role ARN %q, expected arn:aws:iam::<account>:role/<name>", roleARN)
+	}
+
+	result, err := l.client.CloudLinkAccountWithContext(ctx, l.accountID, cloud.CloudLinkCloudAccountsInput{
+		Aws: []cloud.CloudAwsLinkAccountInput{{Arn: roleARN, Name: name}},
+	})
+	if err != nil {
+		return fmt.Errorf("could not link AWS account: %w", err)
+	}
+
+	if result != nil && len(result.Errors) > 0 {
+		messages := []string{}
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+
+		return fmt.Errorf("could not link AWS account: %s", strings.Join(messages, ", "))
+	}
+
+	return nil
+}
+
+// CloudFormationURL returns the AWS console link creating the IAM role to link with, for the AWS
+// region the instance runs in.
+func (l *AWSIntegrationLinker) CloudFormationURL(region string) string {
+	q := url.Values{}
+	q.Set("templateURL", awsCloudFormationTemplateURL)
+	q.Set("stackName", "NewRelicIntegration")
+	q.Set("param_NewRelicAccountId", strconv.Itoa(l.accountID))
+
+	return fmt.Sprintf("https://console.aws.amazon.com/cloudformation/home?region=%s#/stacks/quickcreate?%s", url.QueryEscape(region), q.Encode())
+}
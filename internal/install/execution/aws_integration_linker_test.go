@@ -0,0 +1,65 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSIntegrationLinkerShouldFindLinkedAccount(t *testing.T) {
+	c := NewMockCloudLinkingClient()
+	c.LinkedAccounts = []cloud.CloudLinkedAccount{{ExternalId: "111111111111"}, {ExternalId: "123456789012", Disabled: true}}
+	l := NewAWSIntegrationLinker(c, 12345)
+
+	linked, err := l.IsLinked(context.Background(), "111111111111")
+	require.NoError(t, err)
+	require.True(t, linked)
+
+	linked, err = l.IsLinked(context.Background(), "123456789012")
+	require.NoError(t, err)
+	require.False(t, linked, "disabled accounts are linked again")
+}
+
+func TestAWSIntegrationLinkerShouldLinkAccount(t *testing.T) {
+	c := NewMockCloudLinkingClient()
+	l := NewAWSIntegrationLinker(c, 12345)
+
+	err := l.Link(context.Background(), "arn:aws:iam::123456789012:role/NewRelicInfrastructure-Integrations", "AWS 123456789012")
+
+	require.NoError(t, err)
+	require.Equal(t, []cloud.CloudLinkCloudAccountsInput{{
+		Aws: []cloud.CloudAwsLinkAccountInput{{Arn: "arn:aws:iam::123456789012:role/NewRelicInfrastructure-Integrations", Name: "AWS 123456789012"}},
+	}}, c.LinkAccountsInputs)
+}
+
+func TestAWSIntegrationLinkerShouldRejectInvalidRoleARN(t *testing.T) {
+	c := NewMockCloudLinkingClient()
+	l := NewAWSIntegrationLinker(c, 12345)
+
+	err := l.Link(context.Background(), "NewRelicInfrastructure-Integrations", "AWS 123456789012")
+
+	require.Error(t, err)
+	require.Equal(t, 0, c.LinkAccountCount)
+}
+
+func TestAWSIntegrationLinkerShouldReturnMutationErrors(t *testing.T) {
+	c := NewMockCloudLinkingClient()
+	c.LinkAccountVal = &cloud.CloudLinkAccountPayload{Errors: []cloud.CloudAccountMutationError{{Message: "role can't be assumed"}}}
+	l := NewAWSIntegrationLinker(c, 12345)
+
+	err := l.Link(context.Background(), "arn:aws:iam::123456789012:role/NewRelic", "AWS 123456789012")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "role can't be assumed")
+}
+
+func TestAWSIntegrationLinkerCloudFormationURL(t *testing.T) {
+	l := NewAWSIntegrationLinker(NewMockCloudLinkingClient(), 12345)
+
+	u := l.CloudFormationURL("eu-west-1")
+
+	require.Contains(t, u, "region=eu-west-1#/stacks/quickcreate?")
+	require.Contains(t, u, "param_NewRelicAccountId=12345")
+}
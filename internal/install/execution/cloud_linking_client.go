@@ -0,0 +1,12 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+)
+
+type CloudLinkingClient interface {
+	GetLinkedAccountsWithContext(ctx context.Context, provider string) (*[]cloud.CloudLinkedAccount, error)
+	CloudLinkAccountWithContext(ctx context.Context, accountID int, accounts cloud.CloudLinkCloudAccountsInput) (*cloud.CloudLinkAccountPayload, error)
+}
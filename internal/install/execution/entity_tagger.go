@@ -0,0 +1,85 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// EntityTagger attaches the tags provided with --tag to the entities created by recipes.
+type EntityTagger struct {
+	client EntityTaggingClient
+	tags   []entities.TaggingTagInput
+}
+
+// NewEntityTagger returns a new instance of EntityTagger for the given key:value tags.
+func NewEntityTagger(client EntityTaggingClient, tags []string) *EntityTagger {
+	return &EntityTagger{
+		client: client,
+		tags:   assembleTagsInput(tags),
+	}
+}
+
+// AddTags adds key:value tags to the ones added to each entity, such as those of the cloud instance
+// found by discovery.
+func (t *EntityTagger) AddTags(tags []string) {
+	t.tags = append(t.tags, assembleTagsInput(tags)...)
+}
+
+// TagEntity adds the tags to the entity. It does nothing when there are no tags to add.
+func (t *EntityTagger) TagEntity(ctx context.Context, entityGUID string) error {
+	if len(t.tags) == 0 || entityGUID == "" {
+		return nil
+	}
+
+	result, err := t.client.TaggingAddTagsToEntityWithContext(ctx, common.EntityGUID(entityGUID), t.tags)
+	if err != nil {
+		return fmt.Errorf("could not tag entity %s: %w", entityGUID, err)
+	}
+
+	if result != nil && len(result.Errors) > 0 {
+		messages := []string{}
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+
+		return fmt.Errorf("could not tag entity %s: %s", entityGUID, strings.Join(messages, ", "))
+	}
+
+	return nil
+}
+
+func assembleTagsInput(tags []string) []entities.TaggingTagInput {
+	tagValues := map[string][]string{}
+
+	for _, tag := range tags {
+		parts := strings.SplitN(tag, types.TagSeparator, 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+
+		tagValues[parts[0]] = append(tagValues[parts[0]], parts[1])
+	}
+
+	keys := make([]string, 0, len(tagValues))
+	for k := range tagValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	input := []entities.TaggingTagInput{}
+	for _, k := range keys {
+		input = append(input, entities.TaggingTagInput{
+			Key:    k,
+			Values: tagValues[k],
+		})
+	}
+
+	return input
+}
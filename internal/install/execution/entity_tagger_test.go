@@ -0,0 +1,72 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+)
+
+func TestEntityTaggerShouldAddTagsToEntity(t *testing.T) {
+	c := NewMockEntityTaggingClient()
+	tagger := NewEntityTagger(c, []string{"team:payments", "env:prod", "env:eu"})
+
+	err := tagger.TagEntity(context.Background(), "MTIzNDU2")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"MTIzNDU2"}, c.TaggedEntityGUIDs)
+	require.Equal(t, []entities.TaggingTagInput{
+		{Key: "env", Values: []string{"prod", "eu"}},
+		{Key: "team", Values: []string{"payments"}},
+	}, c.Tags)
+}
+
+func TestEntityTaggerShouldAddDiscoveredTags(t *testing.T) {
+	c := NewMockEntityTaggingClient()
+	tagger := NewEntityTagger(c, []string{})
+	tagger.AddTags([]string{"cloud.provider:aws", "cloud.region:eu-west-1"})
+
+	err := tagger.TagEntity(context.Background(), "MTIzNDU2")
+
+	require.NoError(t, err)
+	require.Equal(t, []entities.TaggingTagInput{
+		{Key: "cloud.provider", Values: []string{"aws"}},
+		{Key: "cloud.region", Values: []string{"eu-west-1"}},
+	}, c.Tags)
+}
+
+func TestEntityTaggerShouldNotCallClientWithoutTags(t *testing.T) {
+	c := NewMockEntityTaggingClient()
+	tagger := NewEntityTagger(c, []string{})
+
+	err := tagger.TagEntity(context.Background(), "MTIzNDU2")
+
+	require.NoError(t, err)
+	require.Equal(t, 0, c.AddTagsCallCount)
+}
+
+func TestEntityTaggerShouldReturnMutationErrors(t *testing.T) {
+	c := NewMockEntityTaggingClient()
+	c.AddTagsVal = &entities.TaggingMutationResult{
+		Errors: []entities.TaggingMutationError{{Message: "too many tags"}},
+	}
+	tagger := NewEntityTagger(c, []string{"team:payments"})
+
+	err := tagger.TagEntity(context.Background(), "MTIzNDU2")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too many tags")
+}
+
+func TestEntityTaggerShouldReturnClientErrors(t *testing.T) {
+	c := NewMockEntityTaggingClient()
+	c.AddTagsErr = errors.New("unauthorized")
+	tagger := NewEntityTagger(c, []string{"team:payments"})
+
+	err := tagger.TagEntity(context.Background(), "MTIzNDU2")
+
+	require.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+)
+
+type EntityTaggingClient interface {
+	TaggingAddTagsToEntityWithContext(context.Context, common.EntityGUID, []entities.TaggingTagInput) (*entities.TaggingMutationResult, error)
+}
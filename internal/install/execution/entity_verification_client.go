@@ -0,0 +1,9 @@
+package execution
+
+import (
+	"context"
+)
+
+type EntityVerificationClient interface {
+	QueryWithResponseAndContext(ctx context.Context, query string, variables map[string]interface{}, respBody interface{}) error
+}
@@ -0,0 +1,224 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+const (
+	// entityVerificationBatchSize is the most entities NerdGraph returns for one query.
+	entityVerificationBatchSize = 25
+	entityVerificationTimeout   = 30 * time.Second
+)
+
+const entityVerificationQuery = `query($guids: [EntityGuid]!) { actor { entities(guids: $guids) {
+	guid
+	name
+	reporting
+	permalink
+	goldenMetrics { metrics { title } }
+} } }`
+
+type entityVerificationResponse struct {
+	Actor struct {
+		Entities []EntityVerification `json:"entities"`
+	} `json:"actor"`
+}
+
+// EntityVerification is the state of an entity created by an install, as reported by the entity platform.
+type EntityVerification struct {
+	GUID          string `json:"guid"`
+	Name          string `json:"name"`
+	Reporting     bool   `json:"reporting"`
+	Permalink     string `json:"permalink"`
+	GoldenMetrics struct {
+		Metrics []struct {
+			Title string `json:"title"`
+		} `json:"metrics"`
+	} `json:"goldenMetrics"`
+}
+
+// EntityVerificationReporter confirms, once an install completes, that the entities created by
+// the installed recipes are known to New Relic and reporting data.
+type EntityVerificationReporter struct {
+	client EntityVerificationClient
+	out    io.Writer
+}
+
+// NewEntityVerificationReporter is an implementation of the ExecutionStatusReporter interface that
+// reports the state of the installed entities to STDOUT.
+func NewEntityVerificationReporter(client EntityVerificationClient) *EntityVerificationReporter {
+	r := EntityVerificationReporter{
+		client: client,
+		out:    os.Stdout,
+	}
+
+	return &r
+}
+
+func (r *EntityVerificationReporter) InstallComplete(status *InstallStatus) error {
+	recipeNames := map[string]string{}
+	guids := []string{}
+	for _, s := range status.Statuses {
+		if s.Status != RecipeStatusTypes.INSTALLED || s.EntityGUID == "" {
+			continue
+		}
+
+		if _, ok := recipeNames[s.EntityGUID]; !ok {
+			guids = append(guids, s.EntityGUID)
+			recipeNames[s.EntityGUID] = s.DisplayName
+		}
+	}
+
+	if len(guids) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), entityVerificationTimeout)
+	defer cancel()
+
+	verifications, err := r.verify(ctx, guids)
+	if err != nil {
+		fmt.Fprintf(r.out, "  %s  Could not verify the installed entities, they may take a few minutes to appear in New Relic.\n\n", ux.IconExclamation)
+		return fmt.Errorf("could not verify installed entities: %w", err)
+	}
+
+	r.print(status, guids, recipeNames, verifications)
+
+	return nil
+}
+
+func (r *EntityVerificationReporter) verify(ctx context.Context, guids []string) (map[string]EntityVerification, error) {
+	verifications := map[string]EntityVerification{}
+
+	for start := 0; start < len(guids); start += entityVerificationBatchSize {
+		end := start + entityVerificationBatchSize
+		if end > len(guids) {
+			end = len(guids)
+		}
+
+		resp := entityVerificationResponse{}
+		vars := map[string]interface{}{
+			"guids": guids[start:end],
+		}
+
+		if err := r.client.QueryWithResponseAndContext(ctx, entityVerificationQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, e := range resp.Actor.Entities {
+			verifications[e.GUID] = e
+		}
+	}
+
+	return verifications, nil
+}
+
+func (r *EntityVerificationReporter) print(status *InstallStatus, guids []string, recipeNames map[string]string, verifications map[string]EntityVerification) {
+	fmt.Fprintln(r.out, "  Entity verification")
+	fmt.Fprintln(r.out)
+
+	for _, guid := range guids {
+		e, found := verifications[guid]
+		if !found {
+			fmt.Fprintf(r.out, "  %s  %s: entity %s not found yet, it may take a few minutes to appear\n", ux.IconExclamation, recipeNames[guid], guid)
+			continue
+		}
+
+		icon, state := ux.IconSuccess, color.GreenString("reporting")
+		if !e.Reporting {
+			icon, state = ux.IconExclamation, color.YellowString("not reporting")
+		}
+
+		golden := "no golden metrics"
+		if len(e.GoldenMetrics.Metrics) > 0 {
+			titles := []string{}
+			for _, m := range e.GoldenMetrics.Metrics {
+				titles = append(titles, m.Title)
+			}
+			golden = "golden metrics: " + strings.Join(titles, ", ")
+		}
+
+		fmt.Fprintf(r.out, "  %s  %s (%s): %s, %s\n", icon, e.Name, recipeNames[guid], state, golden)
+		link := e.Permalink
+		if link == "" && status.PlatformLinkGenerator != nil {
+			link = status.PlatformLinkGenerator.GenerateEntityLink(guid)
+		}
+
+		if link != "" {
+			fmt.Fprintf(r.out, "     %s  %s\n", color.GreenString(ux.IconArrowRight), link)
+		}
+	}
+
+	fmt.Fprintln(r.out)
+	log.WithFields(log.Fields{
+		"entities": len(guids),
+		"found":    len(verifications),
+	}).Debug("verified installed entities")
+}
+
+func (r *EntityVerificationReporter) UpdateRequired(status *InstallStatus) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) InstallStarted(status *InstallStatus) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) InstallCanceled(status *InstallStatus) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeDetected(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeCanceled(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeAvailable(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *EntityVerificationReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package execution
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityVerificationReporter_interface(t *testing.T) {
+	var r StatusSubscriber = NewEntityVerificationReporter(NewMockEntityVerificationClient())
+	require.NotNil(t, r)
+}
+
+func TestEntityVerificationReporter_ShouldReportInstalledEntities(t *testing.T) {
+	c := NewMockEntityVerificationClient()
+	c.ResponseJSON = `{"actor": {"entities": [
+		{"guid": "abc", "name": "my-host", "reporting": true, "permalink": "https://one.newrelic.com/redirect/entity/abc",
+		 "goldenMetrics": {"metrics": [{"title": "CPU usage (%)"}, {"title": "Memory usage (%)"}]}},
+		{"guid": "def", "name": "my-logs", "reporting": false, "goldenMetrics": {"metrics": []}}
+	]}}`
+
+	out := &bytes.Buffer{}
+	r := NewEntityVerificationReporter(c)
+	r.out = out
+
+	status := &InstallStatus{
+		PlatformLinkGenerator: NewMockPlatformLinkGenerator(),
+		Statuses: []*RecipeStatus{
+			{Name: "infrastructure-agent-installer", DisplayName: "Infrastructure Agent", Status: RecipeStatusTypes.INSTALLED, EntityGUID: "abc"},
+			{Name: "logs-integration", DisplayName: "Logs integration", Status: RecipeStatusTypes.INSTALLED, EntityGUID: "def"},
+			{Name: "mysql-open-source-integration", DisplayName: "MySQL", Status: RecipeStatusTypes.INSTALLED, EntityGUID: "abc"},
+			{Name: "nginx-open-source-integration", DisplayName: "Nginx", Status: RecipeStatusTypes.FAILED, EntityGUID: "ghi"},
+		},
+	}
+
+	err := r.InstallComplete(status)
+	require.NoError(t, err)
+	require.Equal(t, 1, c.QueryCallCount)
+	require.Equal(t, [][]string{{"abc", "def"}}, c.QueriedGUIDs)
+	require.Regexp(t, `my-host \(Infrastructure Agent\): .*reporting.*, golden metrics: CPU usage \(%\), Memory usage \(%\)`, out.String())
+	require.Contains(t, out.String(), "https://one.newrelic.com/redirect/entity/abc")
+	require.Regexp(t, `my-logs \(Logs integration\): .*not reporting.*, no golden metrics`, out.String())
+}
+
+func TestEntityVerificationReporter_ShouldReportEntitiesNotFound(t *testing.T) {
+	c := NewMockEntityVerificationClient()
+	c.ResponseJSON = `{"actor": {"entities": []}}`
+
+	out := &bytes.Buffer{}
+	r := NewEntityVerificationReporter(c)
+	r.out = out
+
+	status := &InstallStatus{
+		Statuses: []*RecipeStatus{
+			{Name: "infrastructure-agent-installer", DisplayName: "Infrastructure Agent", Status: RecipeStatusTypes.INSTALLED, EntityGUID: "abc"},
+		},
+	}
+
+	err := r.InstallComplete(status)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Infrastructure Agent: entity abc not found yet")
+}
+
+func TestEntityVerificationReporter_ShouldQueryInBatches(t *testing.T) {
+	c := NewMockEntityVerificationClient()
+	r := NewEntityVerificationReporter(c)
+	r.out = &bytes.Buffer{}
+
+	status := &InstallStatus{}
+	for i := 0; i < entityVerificationBatchSize+1; i++ {
+		status.Statuses = append(status.Statuses, &RecipeStatus{
+			Name:       fmt.Sprintf("recipe-%d", i),
+			Status:     RecipeStatusTypes.INSTALLED,
+			EntityGUID: fmt.Sprintf("guid-%d", i),
+		})
+	}
+
+	err := r.InstallComplete(status)
+	require.NoError(t, err)
+	require.Equal(t, 2, c.QueryCallCount)
+	require.Len(t, c.QueriedGUIDs[0], entityVerificationBatchSize)
+	require.Len(t, c.QueriedGUIDs[1], 1)
+}
+
+func TestEntityVerificationReporter_ShouldNotQueryWithoutEntities(t *testing.T) {
+	c := NewMockEntityVerificationClient()
+	r := NewEntityVerificationReporter(c)
+	r.out = &bytes.Buffer{}
+
+	status := &InstallStatus{
+		Statuses: []*RecipeStatus{
+			{Name: "infrastructure-agent-installer", Status: RecipeStatusTypes.FAILED},
+		},
+	}
+
+	err := r.InstallComplete(status)
+	require.NoError(t, err)
+	require.Equal(t, 0, c.QueryCallCount)
+}
+
+func TestEntityVerificationReporter_ShouldWarnWhenQueryFails(t *testing.T) {
+	c := NewMockEntityVerificationClient()
+	c.QueryErr = errors.New("oops")
+
+	out := &bytes.Buffer{}
+	r := NewEntityVerificationReporter(c)
+	r.out = out
+
+	status := &InstallStatus{
+		Statuses: []*RecipeStatus{
+			{Name: "infrastructure-agent-installer", Status: RecipeStatusTypes.INSTALLED, EntityGUID: "abc"},
+		},
+	}
+
+	err := r.InstallComplete(status)
+	require.Error(t, err)
+	require.Contains(t, out.String(), "Could not verify the installed entities")
+}
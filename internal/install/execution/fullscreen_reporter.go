@@ -0,0 +1,380 @@
+package execution
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+const (
+	fullScreenRefreshInterval = 250 * time.Millisecond
+	fullScreenOutputLines     = 5
+
+	ansiEnterAltScreen = "\x1b[?1049h\x1b[?25l"
+	ansiExitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	ansiClearScreen    = "\x1b[H\x1b[2J"
+)
+
+// FullScreenStatusReporter shows the install as a live, full-screen view with a panel for each
+// recipe, and a results screen once the install completes. It's also used as the install's
+// progress indicator and receives the recipes' output, which is shown in each recipe's panel.
+type FullScreenStatusReporter struct {
+	mu         sync.Mutex
+	out        io.Writer
+	panels     []*recipePanel
+	current    *recipePanel
+	message    string
+	partial    string
+	startedAt  time.Time
+	done       chan struct{}
+	refreshing sync.WaitGroup
+	waitForKey func()
+}
+
+type recipePanel struct {
+	name               string
+	displayName        string
+	status             RecipeStatusType
+	entityGUID         string
+	startedAt          time.Time
+	duration           time.Duration
+	validationDeadline time.Time
+	output             []string
+}
+
+// NewFullScreenStatusReporter is an implementation of the ExecutionStatusReporter interface that
+// renders a full-screen view of the install to STDOUT.
+func NewFullScreenStatusReporter() *FullScreenStatusReporter {
+	r := FullScreenStatusReporter{
+		out:        os.Stdout,
+		waitForKey: waitForKeyPress,
+	}
+
+	return &r
+}
+
+func (r *FullScreenStatusReporter) InstallStarted(status *InstallStatus) error {
+	r.mu.Lock()
+	r.startedAt = time.Now()
+	r.done = make(chan struct{})
+	r.mu.Unlock()
+
+	fmt.Fprint(r.out, ansiEnterAltScreen)
+	r.render()
+
+	r.refreshing.Add(1)
+	go func(done chan struct{}) {
+		defer r.refreshing.Done()
+
+		ticker := time.NewTicker(fullScreenRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				r.render()
+			}
+		}
+	}(r.done)
+
+	return nil
+}
+
+func (r *FullScreenStatusReporter) InstallComplete(status *InstallStatus) error {
+	r.stop()
+	r.renderResults()
+	r.waitForKey()
+	fmt.Fprint(r.out, ansiExitAltScreen)
+
+	return nil
+}
+
+func (r *FullScreenStatusReporter) InstallCanceled(status *InstallStatus) error {
+	r.stop()
+	fmt.Fprint(r.out, ansiExitAltScreen)
+
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.panel(event.Recipe)
+	p.status = RecipeStatusTypes.INSTALLING
+	p.startedAt = time.Now()
+	p.validationDeadline = time.Time{}
+	p.output = []string{}
+	r.current = p
+
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	r.finishRecipe(event, RecipeStatusTypes.INSTALLED)
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	r.finishRecipe(event, RecipeStatusTypes.FAILED)
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeCanceled(status *InstallStatus, event RecipeStatusEvent) error {
+	r.finishRecipe(event, RecipeStatusTypes.CANCELED)
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	r.finishRecipe(event, RecipeStatusTypes.UNSUPPORTED)
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	r.finishRecipe(event, RecipeStatusTypes.SKIPPED)
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeDetected(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeAvailable(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *FullScreenStatusReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r *FullScreenStatusReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return nil
+}
+
+func (r *FullScreenStatusReporter) UpdateRequired(status *InstallStatus) error {
+	return nil
+}
+
+// Write adds the output of the running recipe to its panel.
+func (r *FullScreenStatusReporter) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := strings.Split(r.partial+string(p), "\n")
+	r.partial = lines[len(lines)-1]
+
+	if r.current != nil {
+		for _, line := range lines[:len(lines)-1] {
+			line = strings.TrimRight(line, "\r")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			r.current.output = append(r.current.output, line)
+			if len(r.current.output) > fullScreenOutputLines {
+				r.current.output = r.current.output[1:]
+			}
+		}
+	}
+
+	return len(p), nil
+}
+
+// StartValidation shows how long is left to validate the running recipe.
+func (r *FullScreenStatusReporter) StartValidation(displayName string, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current != nil {
+		r.current.validationDeadline = time.Now().Add(timeout)
+	}
+}
+
+func (r *FullScreenStatusReporter) Start(msg string) {
+	r.setMessage(msg)
+}
+
+func (r *FullScreenStatusReporter) Success(msg string) {
+	r.setMessage("")
+}
+
+func (r *FullScreenStatusReporter) Fail(msg string) {
+	r.setMessage("")
+}
+
+func (r *FullScreenStatusReporter) Canceled(msg string) {
+	r.setMessage("")
+}
+
+func (r *FullScreenStatusReporter) Stop() {
+	r.setMessage("")
+}
+
+func (r *FullScreenStatusReporter) ShowSpinner(bool) {
+}
+
+func (r *FullScreenStatusReporter) setMessage(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.message = msg
+}
+
+func (r *FullScreenStatusReporter) finishRecipe(event RecipeStatusEvent, statusType RecipeStatusType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.panel(event.Recipe)
+	p.status = statusType
+	p.validationDeadline = time.Time{}
+
+	if event.EntityGUID != "" {
+		p.entityGUID = event.EntityGUID
+	}
+
+	if !p.startedAt.IsZero() {
+		p.duration = time.Since(p.startedAt)
+	}
+
+	if r.current == p {
+		r.current = nil
+	}
+}
+
+// panel returns the panel of the given recipe, adding one if it's not shown yet.
+func (r *FullScreenStatusReporter) panel(recipe types.OpenInstallationRecipe) *recipePanel {
+	for _, p := range r.panels {
+		if p.name == recipe.Name {
+			return p
+		}
+	}
+
+	displayName := recipe.DisplayName
+	if displayName == "" {
+		displayName = recipe.Name
+	}
+
+	p := &recipePanel{
+		name:        recipe.Name,
+		displayName: displayName,
+	}
+	r.panels = append(r.panels, p)
+
+	return p
+}
+
+// stop stops refreshing the view, and waits for any refresh in progress to finish.
+func (r *FullScreenStatusReporter) stop() {
+	r.mu.Lock()
+	if r.done != nil {
+		close(r.done)
+		r.done = nil
+	}
+	r.mu.Unlock()
+
+	r.refreshing.Wait()
+}
+
+func (r *FullScreenStatusReporter) render() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString(ansiClearScreen)
+	fmt.Fprintf(&b, "  New Relic installation  %s\n\n", durationText(time.Since(r.startedAt)))
+
+	if r.message != "" {
+		fmt.Fprintf(&b, "  %s...\n\n", r.message)
+	}
+
+	for _, p := range r.panels {
+		fmt.Fprintf(&b, "  %s  %-40s %-12s %s\n", StatusIcon(p.status), p.displayName, strings.ToLower(string(p.status)), r.panelDuration(p))
+
+		if p.status != RecipeStatusTypes.INSTALLING {
+			continue
+		}
+
+		for _, line := range p.output {
+			fmt.Fprintf(&b, "     │ %s\n", line)
+		}
+
+		if !p.validationDeadline.IsZero() {
+			fmt.Fprintf(&b, "     validating, %s left\n", durationText(time.Until(p.validationDeadline)))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprint(r.out, b.String())
+}
+
+func (r *FullScreenStatusReporter) renderResults() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString(ansiClearScreen)
+	fmt.Fprintf(&b, "  New Relic installation complete  %s\n\n", durationText(time.Since(r.startedAt)))
+
+	totals := map[RecipeStatusType]int{}
+	for _, p := range r.panels {
+		totals[p.status]++
+		fmt.Fprintf(&b, "  %s  %-40s %-12s %-8s %s\n", StatusIcon(p.status), p.displayName, strings.ToLower(string(p.status)), r.panelDuration(p), p.entityGUID)
+	}
+
+	fmt.Fprintf(&b, "\n  %s\n\n", summaryTotalsText(totals))
+	fmt.Fprintf(&b, "  %s  Press any key to exit\n", ux.IconArrowRight)
+
+	fmt.Fprint(r.out, b.String())
+}
+
+func (r *FullScreenStatusReporter) panelDuration(p *recipePanel) string {
+	if p.status == RecipeStatusTypes.INSTALLING {
+		return durationText(time.Since(p.startedAt))
+	}
+
+	if p.duration > 0 {
+		return durationText(p.duration)
+	}
+
+	return ""
+}
+
+func durationText(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	return d.Round(time.Second).String()
+}
+
+func waitForKeyPress() {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = term.Restore(fd, state)
+	}()
+
+	key := make([]byte, 1)
+	_, _ = os.Stdin.Read(key)
+}
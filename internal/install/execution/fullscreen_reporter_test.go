@@ -0,0 +1,84 @@
+package execution
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+func TestFullScreenStatusReporter_interface(t *testing.T) {
+	var r StatusSubscriber = NewFullScreenStatusReporter()
+	require.NotNil(t, r)
+
+	var p ux.ProgressIndicator = NewFullScreenStatusReporter()
+	require.NotNil(t, p)
+
+	var v ux.ValidationProgressIndicator = NewFullScreenStatusReporter()
+	require.NotNil(t, v)
+}
+
+func TestFullScreenStatusReporterShouldShowOutputOfRunningRecipe(t *testing.T) {
+	r, out := newTestFullScreenStatusReporter()
+	recipe := types.OpenInstallationRecipe{Name: "test-recipe", DisplayName: "Test Recipe"}
+
+	require.NoError(t, r.RecipeInstalling(&InstallStatus{}, RecipeStatusEvent{Recipe: recipe}))
+	_, err := r.Write([]byte("downloading agent\ninstalling"))
+	require.NoError(t, err)
+	_, err = r.Write([]byte(" agent\n"))
+	require.NoError(t, err)
+	r.StartValidation(recipe.DisplayName, 5*time.Minute)
+	r.render()
+
+	s := out.String()
+	require.Contains(t, s, "Test Recipe")
+	require.Contains(t, s, "│ downloading agent")
+	require.Contains(t, s, "│ installing agent")
+	require.Contains(t, s, "validating, 5m0s left")
+}
+
+func TestFullScreenStatusReporterShouldKeepLastOutputLines(t *testing.T) {
+	r, _ := newTestFullScreenStatusReporter()
+	recipe := types.OpenInstallationRecipe{Name: "test-recipe"}
+
+	require.NoError(t, r.RecipeInstalling(&InstallStatus{}, RecipeStatusEvent{Recipe: recipe}))
+	for i := 0; i < fullScreenOutputLines+2; i++ {
+		_, err := r.Write([]byte("line\n"))
+		require.NoError(t, err)
+	}
+
+	require.Len(t, r.panels[0].output, fullScreenOutputLines)
+}
+
+func TestFullScreenStatusReporterShouldShowResults(t *testing.T) {
+	r, out := newTestFullScreenStatusReporter()
+	installed := types.OpenInstallationRecipe{Name: "installed-recipe", DisplayName: "Installed Recipe"}
+	failed := types.OpenInstallationRecipe{Name: "failed-recipe", DisplayName: "Failed Recipe"}
+
+	require.NoError(t, r.InstallStarted(&InstallStatus{}))
+	require.NoError(t, r.RecipeInstalling(&InstallStatus{}, RecipeStatusEvent{Recipe: installed}))
+	require.NoError(t, r.RecipeInstalled(&InstallStatus{}, RecipeStatusEvent{Recipe: installed, EntityGUID: "abcd1234"}))
+	require.NoError(t, r.RecipeInstalling(&InstallStatus{}, RecipeStatusEvent{Recipe: failed}))
+	require.NoError(t, r.RecipeFailed(&InstallStatus{}, RecipeStatusEvent{Recipe: failed}))
+	require.NoError(t, r.InstallComplete(&InstallStatus{}))
+
+	s := out.String()
+	require.Contains(t, s, ansiEnterAltScreen)
+	require.Contains(t, s, "New Relic installation complete")
+	require.Contains(t, s, "abcd1234")
+	require.Contains(t, s, "1 installed, 1 failed, 0 skipped")
+	require.Contains(t, s, ansiExitAltScreen)
+}
+
+func newTestFullScreenStatusReporter() (*FullScreenStatusReporter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	r := NewFullScreenStatusReporter()
+	r.out = out
+	r.waitForKey = func() {}
+
+	return r, out
+}
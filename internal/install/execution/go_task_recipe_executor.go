@@ -21,6 +21,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
+	"github.com/newrelic/newrelic-cli/internal/config"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
 
@@ -30,6 +31,7 @@ type GoTaskRecipeExecutor struct {
 	Stderr       io.Writer
 	Stdin        io.Reader
 	Stdout       io.Writer
+	OutputLog    io.Writer
 	Output       *OutputParser
 	RecipeOutput []string
 }
@@ -40,6 +42,7 @@ func NewGoTaskRecipeExecutor() *GoTaskRecipeExecutor {
 		Stderr:       os.Stderr,
 		Stdin:        os.Stdin,
 		Stdout:       os.Stdout,
+		OutputLog:    config.InstallLogWriter(),
 		Output:       NewOutputParser(map[string]interface{}{}),
 		RecipeOutput: []string{},
 	}
@@ -84,11 +87,17 @@ func (re *GoTaskRecipeExecutor) Execute(ctx context.Context, r types.OpenInstall
 	var stdoutCapture *LineCaptureBuffer
 	var stderrCapture *LineCaptureBuffer
 
+	// Recipe output is always captured in the install log, whether or not it's shown.
+	outputLog := re.OutputLog
+	if outputLog == nil {
+		outputLog = ioutil.Discard
+	}
+
 	if silentInstall {
-		stdoutCapture = NewLineCaptureBuffer(&bytes.Buffer{})
-		stderrCapture = NewLineCaptureBuffer(&bytes.Buffer{})
+		stdoutCapture = NewLineCaptureBuffer(io.MultiWriter(&bytes.Buffer{}, outputLog))
+		stderrCapture = NewLineCaptureBuffer(io.MultiWriter(&bytes.Buffer{}, outputLog))
 	} else {
-		buffer := NewLineCaptureBuffer(re.Stdout)
+		buffer := NewLineCaptureBuffer(io.MultiWriter(re.Stdout, outputLog))
 		stdoutCapture = buffer
 		stderrCapture = buffer
 	}
@@ -190,6 +199,11 @@ func (re *GoTaskRecipeExecutor) setOutput(outputFileName string) {
 	defer outputFile.Close()
 
 	outputBytes, _ := ioutil.ReadAll(outputFile)
+	re.Output = parseOutputLines(outputBytes)
+}
+
+// parseOutputLines merges the JSON objects recipes write, one per line, to their NR_CLI_OUTPUT file.
+func parseOutputLines(outputBytes []byte) *OutputParser {
 	if len(outputBytes) > 0 {
 		var result map[string]interface{}
 		lines := strings.Split(string(outputBytes), "\n")
@@ -207,10 +221,10 @@ func (re *GoTaskRecipeExecutor) setOutput(outputFileName string) {
 				log.Debugf(fmt.Sprintf("Invalid JSON string found in output file, skipping: %v", line))
 			}
 		}
-		re.Output = NewOutputParser(result)
-	} else {
-		re.Output = NewOutputParser(map[string]interface{}{})
+		return NewOutputParser(result)
 	}
+
+	return NewOutputParser(map[string]interface{}{})
 }
 
 func (re *GoTaskRecipeExecutor) GetOutput() *OutputParser {
@@ -0,0 +1,198 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DefaultInstallHooksFile is the hooks file used when none is given, in the configuration directory.
+const DefaultInstallHooksFile = "install-hooks.yml"
+
+// hookTimeout is how long a hook script may run before it's stopped.
+var hookTimeout = 5 * time.Minute
+
+// The names of the hooks, as set in NEW_RELIC_HOOK for the script being run.
+const (
+	HookPreInstall        = "preInstall"
+	HookPostInstall       = "postInstall"
+	HookRecipePreInstall  = "recipePreInstall"
+	HookRecipePostInstall = "recipePostInstall"
+)
+
+// HookRunner runs the install hook scripts, with the discovery manifest and the outcome of the
+// install exposed to them as NEW_RELIC_* environment variables. Hooks always run on this host,
+// including when installing onto a remote target.
+type HookRunner struct {
+	hooks   types.InstallHooks
+	runFunc func(ctx context.Context, script string, env []string) ([]byte, error)
+}
+
+func NewHookRunner(hooks types.InstallHooks) *HookRunner {
+	return &HookRunner{
+		hooks:   hooks,
+		runFunc: runHookScript,
+	}
+}
+
+// LoadInstallHooks reads the hooks of a YAML hooks file. Relative script paths are relative to the
+// directory of the file.
+func LoadInstallHooks(path string) (types.InstallHooks, error) {
+	hooks := types.InstallHooks{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return hooks, fmt.Errorf("could not read hooks file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &hooks); err != nil {
+		return hooks, fmt.Errorf("could not parse hooks file %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	hooks.PreInstall = resolveHookPath(dir, hooks.PreInstall)
+	hooks.PostInstall = resolveHookPath(dir, hooks.PostInstall)
+	for name, rh := range hooks.Recipes {
+		rh.PreInstall = resolveHookPath(dir, rh.PreInstall)
+		rh.PostInstall = resolveHookPath(dir, rh.PostInstall)
+		hooks.Recipes[name] = rh
+	}
+
+	return hooks, nil
+}
+
+func resolveHookPath(dir string, script string) string {
+	if script == "" || filepath.IsAbs(script) {
+		return script
+	}
+
+	return filepath.Join(dir, script)
+}
+
+// RunPreInstall runs the pre-install hook with the recipes that are about to be installed.
+func (h *HookRunner) RunPreInstall(ctx context.Context, m *types.DiscoveryManifest, recipeNames []string) error {
+	env := append(discoveryHookEnv(m), "NEW_RELIC_RECIPES="+strings.Join(recipeNames, ","))
+
+	return h.run(ctx, HookPreInstall, h.hooks.PreInstall, env)
+}
+
+// RunPostInstall runs the post-install hook with the outcome of the install. A failing hook is only
+// reported, since the install has already completed.
+func (h *HookRunner) RunPostInstall(ctx context.Context, status *InstallStatus, installErr error) {
+	outcome := "SUCCESS"
+	if errors.Is(installErr, types.ErrInterrupt) || errors.Is(installErr, context.Canceled) {
+		outcome = "CANCELED"
+	} else if installErr != nil || status.HasFailedRecipes {
+		outcome = "FAILED"
+	}
+
+	env := append(discoveryHookEnv(&status.DiscoveryManifest),
+		"NEW_RELIC_INSTALL_ID="+status.InstallID,
+		"NEW_RELIC_INSTALL_STATUS="+outcome,
+		"NEW_RELIC_INSTALLED_RECIPES="+strings.Join(recipeStatusNames(status.Installed), ","),
+		"NEW_RELIC_FAILED_RECIPES="+strings.Join(recipeStatusNames(status.Failed), ","),
+		"NEW_RELIC_SKIPPED_RECIPES="+strings.Join(recipeStatusNames(status.Skipped), ","),
+		"NEW_RELIC_ENTITY_GUIDS="+strings.Join(status.EntityGUIDs, ","),
+	)
+	if installErr != nil {
+		env = append(env, "NEW_RELIC_INSTALL_ERROR="+installErr.Error())
+	}
+
+	if err := h.run(ctx, HookPostInstall, h.hooks.PostInstall, env); err != nil {
+		log.Warn(err)
+	}
+}
+
+// RunRecipePreInstall runs the pre-install hook of the recipe before it's installed.
+func (h *HookRunner) RunRecipePreInstall(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe) error {
+	env := append(discoveryHookEnv(m), "NEW_RELIC_RECIPE="+r.Name)
+
+	return h.run(ctx, HookRecipePreInstall, h.hooks.Recipes[r.Name].PreInstall, env)
+}
+
+// RunRecipePostInstall runs the post-install hook of the recipe with its outcome. A failing hook is
+// only reported, since the recipe has already been installed.
+func (h *HookRunner) RunRecipePostInstall(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, status RecipeStatusType, entityGUID string, recipeErr error) {
+	env := append(discoveryHookEnv(m),
+		"NEW_RELIC_RECIPE="+r.Name,
+		"NEW_RELIC_RECIPE_STATUS="+string(status),
+		"NEW_RELIC_RECIPE_ENTITY_GUID="+entityGUID,
+	)
+	if recipeErr != nil {
+		env = append(env, "NEW_RELIC_RECIPE_ERROR="+recipeErr.Error())
+	}
+
+	if err := h.run(ctx, HookRecipePostInstall, h.hooks.Recipes[r.Name].PostInstall, env); err != nil {
+		log.Warn(err)
+	}
+}
+
+func (h *HookRunner) run(ctx context.Context, hook string, script string, env []string) error {
+	if script == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	log.Debugf("Running %s hook %s", hook, script)
+	out, err := h.runFunc(ctx, script, append(env, "NEW_RELIC_HOOK="+hook))
+	if len(out) > 0 {
+		log.Debugf("%s hook output: %s", hook, strings.TrimSpace(string(out)))
+	}
+
+	if err != nil {
+		if detail := strings.TrimSpace(string(out)); detail != "" {
+			return fmt.Errorf("%s hook %s failed: %w: %s", hook, script, err, detail)
+		}
+		return fmt.Errorf("%s hook %s failed: %w", hook, script, err)
+	}
+
+	return nil
+}
+
+func discoveryHookEnv(m *types.DiscoveryManifest) []string {
+	if m == nil {
+		return []string{}
+	}
+
+	return []string{
+		"NEW_RELIC_HOSTNAME=" + m.Hostname,
+		"NEW_RELIC_OS=" + m.OS,
+		"NEW_RELIC_PLATFORM=" + m.Platform,
+		"NEW_RELIC_PLATFORM_FAMILY=" + m.PlatformFamily,
+		"NEW_RELIC_PLATFORM_VERSION=" + m.PlatformVersion,
+		"NEW_RELIC_KERNEL_ARCH=" + m.KernelArch,
+		"NEW_RELIC_ARCH=" + m.Arch,
+		"NEW_RELIC_KERNEL_VERSION=" + m.KernelVersion,
+		"NEW_RELIC_FQDN=" + m.FQDN,
+		"NEW_RELIC_IP_ADDRESSES=" + strings.Join(m.IPAddresses, ","),
+		"NEW_RELIC_VIRTUALIZATION=" + m.VirtualizationSystem,
+	}
+}
+
+func recipeStatusNames(statuses []*RecipeStatus) []string {
+	names := []string{}
+	for _, s := range statuses {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+func runHookScript(ctx context.Context, script string, env []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Env = append(os.Environ(), env...)
+
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,166 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+type hookCall struct {
+	script string
+	env    []string
+}
+
+func newTestHookRunner(hooks types.InstallHooks, err error) (*HookRunner, *[]hookCall) {
+	calls := []hookCall{}
+	h := NewHookRunner(hooks)
+	h.runFunc = func(ctx context.Context, script string, env []string) ([]byte, error) {
+		calls = append(calls, hookCall{script: script, env: env})
+		if err != nil {
+			return []byte("ticket service unavailable"), err
+		}
+		return []byte{}, nil
+	}
+
+	return h, &calls
+}
+
+func TestHookRunnerShouldRunPreInstallHookWithDiscoveryAndRecipes(t *testing.T) {
+	h, calls := newTestHookRunner(types.InstallHooks{PreInstall: "/hooks/pre.sh"}, nil)
+	m := &types.DiscoveryManifest{Hostname: "web-1", OS: "linux", Platform: "ubuntu"}
+
+	err := h.RunPreInstall(context.Background(), m, []string{"infrastructure-agent-installer", "logs-integration"})
+
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "/hooks/pre.sh", (*calls)[0].script)
+	assert.Contains(t, (*calls)[0].env, "NEW_RELIC_HOOK=preInstall")
+	assert.Contains(t, (*calls)[0].env, "NEW_RELIC_HOSTNAME=web-1")
+	assert.Contains(t, (*calls)[0].env, "NEW_RELIC_PLATFORM=ubuntu")
+	assert.Contains(t, (*calls)[0].env, "NEW_RELIC_RECIPES=infrastructure-agent-installer,logs-integration")
+}
+
+func TestHookRunnerShouldFailWhenPreInstallHookFails(t *testing.T) {
+	h, _ := newTestHookRunner(types.InstallHooks{PreInstall: "/hooks/pre.sh"}, errors.New("exit status 1"))
+
+	err := h.RunPreInstall(context.Background(), &types.DiscoveryManifest{}, []string{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "preInstall hook /hooks/pre.sh failed")
+	assert.Contains(t, err.Error(), "ticket service unavailable")
+}
+
+func TestHookRunnerShouldNotRunUnsetHooks(t *testing.T) {
+	h, calls := newTestHookRunner(types.InstallHooks{}, nil)
+	r := &types.OpenInstallationRecipe{Name: "mysql-open-source-integration"}
+
+	require.NoError(t, h.RunPreInstall(context.Background(), &types.DiscoveryManifest{}, []string{}))
+	require.NoError(t, h.RunRecipePreInstall(context.Background(), &types.DiscoveryManifest{}, r))
+	h.RunRecipePostInstall(context.Background(), &types.DiscoveryManifest{}, r, RecipeStatusTypes.INSTALLED, "", nil)
+
+	assert.Empty(t, *calls)
+}
+
+func TestHookRunnerShouldRunPostInstallHookWithOutcome(t *testing.T) {
+	h, calls := newTestHookRunner(types.InstallHooks{PostInstall: "/hooks/post.sh"}, errors.New("exit status 1"))
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "infrastructure-agent-installer"}, EntityGUID: "MXxJTkZSQXxOQXwxMjM0"})
+	s.RecipeFailed(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "mysql-open-source-integration"}})
+	s.InstallComplete(nil)
+
+	h.RunPostInstall(context.Background(), s, nil)
+
+	require.Len(t, *calls, 1)
+	env := (*calls)[0].env
+	assert.Contains(t, env, "NEW_RELIC_HOOK=postInstall")
+	assert.Contains(t, env, "NEW_RELIC_INSTALL_STATUS=FAILED")
+	assert.Contains(t, env, "NEW_RELIC_INSTALLED_RECIPES=infrastructure-agent-installer")
+	assert.Contains(t, env, "NEW_RELIC_FAILED_RECIPES=mysql-open-source-integration")
+	assert.Contains(t, env, "NEW_RELIC_ENTITY_GUIDS=MXxJTkZSQXxOQXwxMjM0")
+}
+
+func TestHookRunnerShouldReportCanceledInstall(t *testing.T) {
+	h, calls := newTestHookRunner(types.InstallHooks{PostInstall: "/hooks/post.sh"}, nil)
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+
+	h.RunPostInstall(context.Background(), s, types.ErrInterrupt)
+
+	require.Len(t, *calls, 1)
+	assert.Contains(t, (*calls)[0].env, "NEW_RELIC_INSTALL_STATUS=CANCELED")
+}
+
+func TestHookRunnerShouldRunRecipeHooks(t *testing.T) {
+	hooks := types.InstallHooks{
+		Recipes: map[string]types.RecipeHooks{
+			"mysql-open-source-integration": {PreInstall: "/hooks/backup.sh", PostInstall: "/hooks/notify.sh"},
+		},
+	}
+	h, calls := newTestHookRunner(hooks, nil)
+	r := &types.OpenInstallationRecipe{Name: "mysql-open-source-integration"}
+	other := &types.OpenInstallationRecipe{Name: "nginx-open-source-integration"}
+
+	require.NoError(t, h.RunRecipePreInstall(context.Background(), &types.DiscoveryManifest{}, r))
+	require.NoError(t, h.RunRecipePreInstall(context.Background(), &types.DiscoveryManifest{}, other))
+	h.RunRecipePostInstall(context.Background(), &types.DiscoveryManifest{}, r, RecipeStatusTypes.FAILED, "", errors.New("execution failed"))
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "/hooks/backup.sh", (*calls)[0].script)
+	assert.Contains(t, (*calls)[0].env, "NEW_RELIC_RECIPE=mysql-open-source-integration")
+	assert.Equal(t, "/hooks/notify.sh", (*calls)[1].script)
+	assert.Contains(t, (*calls)[1].env, "NEW_RELIC_RECIPE_STATUS=FAILED")
+	assert.Contains(t, (*calls)[1].env, "NEW_RELIC_RECIPE_ERROR=execution failed")
+}
+
+func TestHookRunnerShouldStopHooksThatRunTooLong(t *testing.T) {
+	defer func(timeout time.Duration) { hookTimeout = timeout }(hookTimeout)
+	hookTimeout = 10 * time.Millisecond
+
+	h := NewHookRunner(types.InstallHooks{PreInstall: "/hooks/pre.sh"})
+	h.runFunc = func(ctx context.Context, script string, env []string) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	err := h.RunPreInstall(context.Background(), &types.DiscoveryManifest{}, []string{})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLoadInstallHooksShouldResolveScriptsRelativeToTheFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultInstallHooksFile)
+	content := `
+preInstall: ./create-ticket.sh
+postInstall: /opt/hooks/close-ticket.sh
+recipes:
+  mysql-open-source-integration:
+    preInstall: backup-mysql.sh
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	hooks, err := LoadInstallHooks(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "create-ticket.sh"), hooks.PreInstall)
+	assert.Equal(t, "/opt/hooks/close-ticket.sh", hooks.PostInstall)
+	assert.Equal(t, filepath.Join(dir, "backup-mysql.sh"), hooks.Recipes["mysql-open-source-integration"].PreInstall)
+	assert.Empty(t, hooks.Recipes["mysql-open-source-integration"].PostInstall)
+}
+
+func TestLoadInstallHooksShouldFailForInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultInstallHooksFile)
+	require.NoError(t, os.WriteFile(path, []byte("preInstall: [unclosed"), 0600))
+
+	_, err := LoadInstallHooks(path)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not parse hooks file")
+}
@@ -0,0 +1,134 @@
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+)
+
+const DefaultInstallStateFile = "install-state.json"
+
+// InstallState is the per-recipe progress of an install, persisted to disk so that
+// an interrupted install can be resumed without repeating recipes that already succeeded.
+type InstallState struct {
+	Recipes  map[string]*InstallStateRecipe `json:"recipes"`
+	filePath string
+	mu       sync.Mutex
+}
+
+type InstallStateRecipe struct {
+	Status     RecipeStatusType `json:"status"`
+	EntityGUID string           `json:"entityGuid,omitempty"`
+}
+
+// installStateFileChars are the characters of a target that are kept in the name of its install
+// state file.
+var installStateFileChars = regexp.MustCompile(`[^A-Za-z0-9@._-]`)
+
+// GetInstallStateFilePath returns the install state file of an install onto a target, or onto
+// this host when the target is empty. Each target has its own file, so the installs of a fleet
+// don't resume from each other's state.
+func GetInstallStateFilePath(target string) string {
+	if target == "" {
+		return filepath.Join(config.BasePath, DefaultInstallStateFile)
+	}
+
+	name := installStateFileChars.ReplaceAllString(target, "_")
+	return filepath.Join(config.BasePath, fmt.Sprintf("install-state-%s.json", name))
+}
+
+func NewInstallState(filePath string) *InstallState {
+	return &InstallState{
+		Recipes:  map[string]*InstallStateRecipe{},
+		filePath: filePath,
+	}
+}
+
+// LoadInstallState reads a previously persisted install state. A missing file
+// results in an empty state.
+func LoadInstallState(filePath string) (*InstallState, error) {
+	s := NewInstallState(filePath)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return s, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return NewInstallState(filePath), err
+	}
+
+	if s.Recipes == nil {
+		s.Recipes = map[string]*InstallStateRecipe{}
+	}
+
+	return s, nil
+}
+
+func (s *InstallState) IsInstalled(recipeName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.Recipes[recipeName]
+	return ok && r.Status == RecipeStatusTypes.INSTALLED
+}
+
+func (s *InstallState) EntityGUID(recipeName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.Recipes[recipeName]; ok {
+		return r.EntityGUID
+	}
+
+	return ""
+}
+
+func (s *InstallState) SetRecipeStatus(recipeName string, status RecipeStatusType, entityGUID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Recipes[recipeName] = &InstallStateRecipe{
+		Status:     status,
+		EntityGUID: entityGUID,
+	}
+}
+
+func (s *InstallState) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0750); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.filePath, data, 0600)
+}
+
+// Clear removes the persisted state, once an install has nothing left to resume.
+func (s *InstallState) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Recipes = map[string]*InstallStateRecipe{}
+
+	if err := os.Remove(s.filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
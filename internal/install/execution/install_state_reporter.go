@@ -0,0 +1,102 @@
+package execution
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// InstallStateReporter persists the outcome of each recipe to an InstallState as the install progresses.
+type InstallStateReporter struct {
+	state *InstallState
+}
+
+func NewInstallStateReporter(state *InstallState) *InstallStateReporter {
+	r := InstallStateReporter{
+		state: state,
+	}
+
+	return &r
+}
+
+func (r *InstallStateReporter) RecipeDetected(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *InstallStateReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.save(event, RecipeStatusTypes.FAILED)
+}
+
+func (r *InstallStateReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.save(event, RecipeStatusTypes.INSTALLING)
+}
+
+func (r *InstallStateReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.save(event, RecipeStatusTypes.INSTALLED)
+}
+
+func (r *InstallStateReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *InstallStateReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *InstallStateReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r *InstallStateReporter) RecipeAvailable(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *InstallStateReporter) RecipeCanceled(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.save(event, RecipeStatusTypes.CANCELED)
+}
+
+func (r *InstallStateReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *InstallStateReporter) InstallStarted(status *InstallStatus) error {
+	return nil
+}
+
+// InstallComplete clears the state of an install whose recipes didn't fail, since there's nothing
+// left for --resume to skip.
+func (r *InstallStateReporter) InstallComplete(status *InstallStatus) error {
+	if status.HasFailedRecipes || status.HasCanceledRecipes {
+		return nil
+	}
+
+	if err := r.state.Clear(); err != nil {
+		log.Debugf("could not clear install state: %s", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *InstallStateReporter) InstallCanceled(status *InstallStatus) error {
+	return nil
+}
+
+func (r *InstallStateReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return nil
+}
+
+func (r *InstallStateReporter) UpdateRequired(status *InstallStatus) error {
+	return nil
+}
+
+func (r *InstallStateReporter) save(event RecipeStatusEvent, recipeStatus RecipeStatusType) error {
+	r.state.SetRecipeStatus(event.Recipe.Name, recipeStatus, event.EntityGUID)
+
+	if err := r.state.Save(); err != nil {
+		log.Debugf("could not save install state: %s", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestLoadInstallStateShouldReturnEmptyStateWhenFileIsMissing(t *testing.T) {
+	s, err := LoadInstallState(filepath.Join(t.TempDir(), "missing.json"))
+
+	require.NoError(t, err)
+	require.Empty(t, s.Recipes)
+	require.False(t, s.IsInstalled("anything"))
+}
+
+func TestInstallStateReporterShouldPersistRecipeStatuses(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), DefaultInstallStateFile)
+	r := NewInstallStateReporter(NewInstallState(filePath))
+	status := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+
+	err := r.RecipeInstalled(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed"}, EntityGUID: "abc123"})
+	require.NoError(t, err)
+	err = r.RecipeFailed(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "failed"}})
+	require.NoError(t, err)
+
+	s, err := LoadInstallState(filePath)
+	require.NoError(t, err)
+	require.True(t, s.IsInstalled("installed"))
+	require.Equal(t, "abc123", s.EntityGUID("installed"))
+	require.False(t, s.IsInstalled("failed"))
+}
+
+func TestGetInstallStateFilePathShouldBePerTarget(t *testing.T) {
+	require.Equal(t, DefaultInstallStateFile, filepath.Base(GetInstallStateFilePath("")))
+	require.Equal(t, "install-state-ubuntu@web-1.json", filepath.Base(GetInstallStateFilePath("ubuntu@web-1")))
+	require.Equal(t, "install-state-ubuntu@web-1_2222.json", filepath.Base(GetInstallStateFilePath("ubuntu@web-1:2222")))
+	require.Equal(t, "install-state-.._.._etc.json", filepath.Base(GetInstallStateFilePath("../../etc")))
+}
+
+func TestInstallStateReporterShouldClearStateWhenInstallCompletes(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), DefaultInstallStateFile)
+	r := NewInstallStateReporter(NewInstallState(filePath))
+	status := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+
+	require.NoError(t, r.RecipeInstalled(status, RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed"}}))
+	status.HasFailedRecipes = true
+	require.NoError(t, r.InstallComplete(status))
+	require.FileExists(t, filePath)
+
+	status.HasFailedRecipes = false
+	require.NoError(t, r.InstallComplete(status))
+	require.NoFileExists(t, filePath)
+}
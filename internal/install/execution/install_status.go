@@ -2,6 +2,8 @@ package execution
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -41,12 +43,15 @@ type InstallStatus struct {
 	RedirectURL           string                  `json:"redirectUrl"`
 	HTTPSProxy            string                  `json:"httpsProxy"`
 	UpdateRequired        bool                    `json:"updateRequired"`
+	ExcludedLogFiles      []string                `json:"excludedLogFiles,omitempty"`
 	DocumentID            string
 	targetedInstall       bool
 	targetedInstallNames  []string
 	statusSubscriber      []StatusSubscriber
 	successLinkConfig     types.OpenInstallationSuccessLinkConfig
 	PlatformLinkGenerator LinkGenerator
+	// mu serializes the events of recipes, which may be reported by recipes installing concurrently.
+	mu sync.Mutex
 }
 
 type RecipeStatus struct {
@@ -57,6 +62,13 @@ type RecipeStatus struct {
 	EntityGUID  string           `json:"entityGuid,omitempty"`
 	// validationDurationMs is duration in Milliseconds that a recipe took to validate data was flowing.
 	ValidationDurationMs int64 `json:"validationDurationMs,omitempty"`
+	// DurationMs is duration in Milliseconds from the recipe starting to install to it finishing.
+	DurationMs int64 `json:"durationMs,omitempty"`
+	// AlreadyInstalled is set when the recipe was found installed on the host, and wasn't run.
+	AlreadyInstalled bool `json:"alreadyInstalled,omitempty"`
+	// Upgraded is set when the recipe was already installed, and upgraded with its upgrade steps.
+	Upgraded  bool `json:"upgraded,omitempty"`
+	startedAt time.Time
 }
 
 type RecipeStatusType string
@@ -91,12 +103,20 @@ type StatusError struct {
 	TaskPath []string `json:"taskPath"`
 }
 
-var StatusIconMap = map[RecipeStatusType]string{
-	RecipeStatusTypes.INSTALLED:   ux.IconSuccess,
-	RecipeStatusTypes.FAILED:      ux.IconError,
-	RecipeStatusTypes.UNSUPPORTED: ux.IconUnsupported,
-	RecipeStatusTypes.SKIPPED:     ux.IconMinus,
-	RecipeStatusTypes.CANCELED:    ux.IconMinus,
+// StatusIcon returns the icon shown for a recipe status, or an empty string when it has none.
+func StatusIcon(status RecipeStatusType) string {
+	switch status {
+	case RecipeStatusTypes.INSTALLED:
+		return ux.IconSuccess
+	case RecipeStatusTypes.FAILED:
+		return ux.IconError
+	case RecipeStatusTypes.UNSUPPORTED:
+		return ux.IconUnsupported
+	case RecipeStatusTypes.SKIPPED, RecipeStatusTypes.CANCELED:
+		return ux.IconMinus
+	}
+
+	return ""
 }
 
 func NewInstallStatus(context types.InstallerContext, reporters []StatusSubscriber, PlatformLinkGenerator LinkGenerator) *InstallStatus {
@@ -116,10 +136,13 @@ func NewInstallStatus(context types.InstallerContext, reporters []StatusSubscrib
 }
 
 func (s *InstallStatus) DiscoveryComplete(dm types.DiscoveryManifest) {
+	s.mu.Lock()
 	s.withDiscoveryInfo(dm)
+	snapshot := s.snapshot()
+	s.mu.Unlock()
 
-	for _, r := range s.statusSubscriber {
-		if err := r.DiscoveryComplete(s, dm); err != nil {
+	for _, r := range snapshot.statusSubscriber {
+		if err := r.DiscoveryComplete(snapshot, dm); err != nil {
 			log.Debugf("Could not report discovery info: %s", err)
 		}
 	}
@@ -130,46 +153,19 @@ func (s *InstallStatus) DiscoveryComplete(dm types.DiscoveryManifest) {
 // RecipeDetected is called when a recipe is available and passes the checks in both
 // the process match and the pre-install steps of recipe execution.
 func (s *InstallStatus) RecipeDetected(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.DETECTED)
-	s.Detected = append(s.Detected, &RecipeStatus{
-		Name:        event.Recipe.Name,
-		DisplayName: event.Recipe.DisplayName,
-		Status:      RecipeStatusTypes.DETECTED,
-	})
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeDetected(s, event); err != nil {
-			log.Debugf("Could not report recipe execution status: %s", err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.DETECTED, StatusSubscriber.RecipeDetected)
 }
 
 func (s *InstallStatus) RecipeCanceled(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.CANCELED)
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeCanceled(s, event); err != nil {
-			log.Debugf("Could not report recipe execution status: %s", err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.CANCELED, StatusSubscriber.RecipeCanceled)
 }
 
 func (s *InstallStatus) RecipeAvailable(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.AVAILABLE)
-	for _, ss := range s.statusSubscriber {
-		if err := ss.RecipeAvailable(s, event); err != nil {
-			log.Debugf("Could not report recipe execution status: %s", err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.AVAILABLE, StatusSubscriber.RecipeAvailable)
 }
 
 func (s *InstallStatus) RecipeInstalled(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.INSTALLED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeInstalled(s, event); err != nil {
-			log.Debugf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.INSTALLED, StatusSubscriber.RecipeInstalled)
 }
 
 // RecipeRecommended is responsible for setting the nerstorage scopes
@@ -178,81 +174,86 @@ func (s *InstallStatus) RecipeInstalled(event RecipeStatusEvent) {
 // should consider integrating, but not something that the recipe framework
 // will currently assist with.
 func (s *InstallStatus) RecipeRecommended(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.RECOMMENDED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeRecommended(s, event); err != nil {
-			log.Debugf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.RECOMMENDED, StatusSubscriber.RecipeRecommended)
 }
 
 func (s *InstallStatus) RecipeInstalling(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.INSTALLING)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeInstalling(s, event); err != nil {
-			log.Debugf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.INSTALLING, StatusSubscriber.RecipeInstalling)
 }
 
 func (s *InstallStatus) RecipeFailed(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.FAILED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeFailed(s, event); err != nil {
-			log.Debugf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.FAILED, StatusSubscriber.RecipeFailed)
 }
 
 func (s *InstallStatus) RecipeSkipped(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.SKIPPED)
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeSkipped(s, event); err != nil {
-			log.Debugf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
-		}
-	}
+	s.recipeEvent(event, RecipeStatusTypes.SKIPPED, StatusSubscriber.RecipeSkipped)
 }
 
 func (s *InstallStatus) RecipeUnsupported(event RecipeStatusEvent) {
-	s.withRecipeEvent(event, RecipeStatusTypes.UNSUPPORTED)
-	s.Unsupported = append(s.Unsupported, &RecipeStatus{
-		Name:        event.Recipe.Name,
-		DisplayName: event.Recipe.DisplayName,
-		Status:      RecipeStatusTypes.UNSUPPORTED,
-	})
-
-	for _, r := range s.statusSubscriber {
-		if err := r.RecipeUnsupported(s, event); err != nil {
+	s.recipeEvent(event, RecipeStatusTypes.UNSUPPORTED, StatusSubscriber.RecipeUnsupported)
+}
+
+// recipeEvent records the status of a recipe, then reports it to the subscribers with a copy of
+// the install status, so recipes installing concurrently don't wait on each other's reporting.
+func (s *InstallStatus) recipeEvent(event RecipeStatusEvent, rs RecipeStatusType, report func(StatusSubscriber, *InstallStatus, RecipeStatusEvent) error) {
+	s.mu.Lock()
+	s.withRecipeEvent(event, rs)
+
+	switch rs {
+	case RecipeStatusTypes.DETECTED:
+		s.Detected = append(s.Detected, &RecipeStatus{
+			Name:        event.Recipe.Name,
+			DisplayName: event.Recipe.DisplayName,
+			Status:      RecipeStatusTypes.DETECTED,
+		})
+	case RecipeStatusTypes.UNSUPPORTED:
+		s.Unsupported = append(s.Unsupported, &RecipeStatus{
+			Name:        event.Recipe.Name,
+			DisplayName: event.Recipe.DisplayName,
+			Status:      RecipeStatusTypes.UNSUPPORTED,
+		})
+	}
+
+	snapshot := s.snapshot()
+	s.mu.Unlock()
+
+	for _, r := range snapshot.statusSubscriber {
+		if err := report(r, snapshot, event); err != nil {
 			log.Debugf("Error writing recipe status for recipe %s: %s", event.Recipe.Name, err)
 		}
 	}
 }
 
 func (s *InstallStatus) InstallStarted() {
+	s.mu.Lock()
 	s.started()
+	snapshot := s.snapshot()
+	s.mu.Unlock()
 
-	for _, r := range s.statusSubscriber {
-		if err := r.InstallStarted(s); err != nil {
+	for _, r := range snapshot.statusSubscriber {
+		if err := r.InstallStarted(snapshot); err != nil {
 			log.Debugf("Error writing execution status: %s", err)
 		}
 	}
 }
 
 func (s *InstallStatus) InstallComplete(err error) {
+	s.mu.Lock()
 	s.completed(err)
+	snapshot := s.snapshot()
+	s.mu.Unlock()
 
-	for _, r := range s.statusSubscriber {
-		if err := r.InstallComplete(s); err != nil {
+	for _, r := range snapshot.statusSubscriber {
+		if err := r.InstallComplete(snapshot); err != nil {
 			log.Debugf("Error writing execution status: %s", err)
 		}
 	}
 }
 
 func (s *InstallStatus) RecipeHasStatus(recipeName string, status RecipeStatusType) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	for _, s := range s.Statuses {
 		if s.Name == recipeName && s.Status == status {
 			return true
@@ -289,17 +290,37 @@ func (s *InstallStatus) ReportStatus(status RecipeStatusType, event RecipeStatus
 	}
 }
 
+// FailedRecipeNames returns the names of the recipes that failed to install.
+func (s *InstallStatus) FailedRecipeNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{}
+	for _, rs := range s.Statuses {
+		if rs.Status == RecipeStatusTypes.FAILED {
+			names = append(names, rs.Name)
+		}
+	}
+	return names
+}
+
 func (s *InstallStatus) InstallCanceled() {
+	s.mu.Lock()
 	s.canceled()
+	snapshot := s.snapshot()
+	s.mu.Unlock()
 
-	for _, r := range s.statusSubscriber {
-		if err := r.InstallCanceled(s); err != nil {
+	for _, r := range snapshot.statusSubscriber {
+		if err := r.InstallCanceled(snapshot); err != nil {
 			log.Debugf("Error writing execution status: %s", err)
 		}
 	}
 }
 
 func (s *InstallStatus) WasSuccessful() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	return s.hasAnyRecipeStatus(RecipeStatusTypes.INSTALLED)
 }
 
@@ -340,7 +361,7 @@ func (s *InstallStatus) HostEntityGUID() string {
 }
 
 func (s *InstallStatus) setRedirectURL() {
-	s.RedirectURL = s.PlatformLinkGenerator.GenerateRedirectURL(*s)
+	s.RedirectURL = s.PlatformLinkGenerator.GenerateRedirectURL(s)
 }
 
 func (s *InstallStatus) withSuccessLinkConfig(l types.OpenInstallationSuccessLinkConfig) {
@@ -401,12 +422,19 @@ func (s *InstallStatus) withRecipeEvent(e RecipeStatusEvent, rs RecipeStatusType
 		if e.Msg != "" {
 			found.Error = statusError
 		}
+
+		found.AlreadyInstalled = e.AlreadyInstalled
+		found.Upgraded = e.Upgraded
+
+		found.withDuration(rs)
 	} else {
 		recipeStatus := &RecipeStatus{
-			Name:        e.Recipe.Name,
-			DisplayName: e.Recipe.DisplayName,
-			Status:      rs,
-			Error:       statusError,
+			Name:             e.Recipe.Name,
+			DisplayName:      e.Recipe.DisplayName,
+			Status:           rs,
+			Error:            statusError,
+			AlreadyInstalled: e.AlreadyInstalled,
+			Upgraded:         e.Upgraded,
 		}
 
 		if e.EntityGUID != "" {
@@ -417,6 +445,8 @@ func (s *InstallStatus) withRecipeEvent(e RecipeStatusEvent, rs RecipeStatusType
 			recipeStatus.ValidationDurationMs = e.ValidationDurationMs
 		}
 
+		recipeStatus.withDuration(rs)
+
 		s.Statuses = append(s.Statuses, recipeStatus)
 	}
 
@@ -491,6 +521,59 @@ func (s *InstallStatus) canceled() {
 	s.setRedirectURL()
 }
 
+// snapshot returns a copy of the status, with copies of the statuses of its recipes, which
+// subscribers read while other recipes keep updating the status.
+func (s *InstallStatus) snapshot() *InstallStatus {
+	return &InstallStatus{
+		InstallID:             s.InstallID,
+		Complete:              s.Complete,
+		DeployedBy:            s.DeployedBy,
+		DiscoveryManifest:     s.DiscoveryManifest,
+		EntityGUIDs:           append([]string(nil), s.EntityGUIDs...),
+		Error:                 s.Error,
+		LogFilePath:           s.LogFilePath,
+		Statuses:              copyRecipeStatuses(s.Statuses),
+		Timestamp:             s.Timestamp,
+		CLIVersion:            s.CLIVersion,
+		InstallLibraryVersion: s.InstallLibraryVersion,
+		HasInstalledRecipes:   s.HasInstalledRecipes,
+		HasCanceledRecipes:    s.HasCanceledRecipes,
+		HasSkippedRecipes:     s.HasSkippedRecipes,
+		HasFailedRecipes:      s.HasFailedRecipes,
+		HasUnsupportedRecipes: s.HasUnsupportedRecipes,
+		Detected:              copyRecipeStatuses(s.Detected),
+		Skipped:               copyRecipeStatuses(s.Skipped),
+		Canceled:              copyRecipeStatuses(s.Canceled),
+		Failed:                copyRecipeStatuses(s.Failed),
+		Installed:             copyRecipeStatuses(s.Installed),
+		Unsupported:           copyRecipeStatuses(s.Unsupported),
+		RedirectURL:           s.RedirectURL,
+		HTTPSProxy:            s.HTTPSProxy,
+		UpdateRequired:        s.UpdateRequired,
+		ExcludedLogFiles:      s.ExcludedLogFiles,
+		DocumentID:            s.DocumentID,
+		targetedInstall:       s.targetedInstall,
+		targetedInstallNames:  s.targetedInstallNames,
+		statusSubscriber:      s.statusSubscriber,
+		successLinkConfig:     s.successLinkConfig,
+		PlatformLinkGenerator: s.PlatformLinkGenerator,
+	}
+}
+
+func copyRecipeStatuses(statuses []*RecipeStatus) []*RecipeStatus {
+	if statuses == nil {
+		return nil
+	}
+
+	out := make([]*RecipeStatus, len(statuses))
+	for i, rs := range statuses {
+		c := *rs
+		out[i] = &c
+	}
+
+	return out
+}
+
 func (s *InstallStatus) getStatus(r types.OpenInstallationRecipe) *RecipeStatus {
 	for _, rs := range s.Statuses {
 		if rs.Name == r.Name {
@@ -571,3 +654,15 @@ func (s *InstallStatus) updateRecipeStatuses(installCanceled bool, isUnsupported
 		}
 	}
 }
+
+// withDuration records when the recipe starts installing, and how long it took once it finishes.
+func (rs *RecipeStatus) withDuration(statusType RecipeStatusType) {
+	switch statusType {
+	case RecipeStatusTypes.INSTALLING:
+		rs.startedAt = time.Now()
+	case RecipeStatusTypes.INSTALLED, RecipeStatusTypes.FAILED, RecipeStatusTypes.CANCELED, RecipeStatusTypes.UNSUPPORTED:
+		if !rs.startedAt.IsZero() {
+			rs.DurationMs = time.Since(rs.startedAt).Milliseconds()
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package execution
 
 import (
+	"fmt"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -239,3 +241,56 @@ func TestSetTargetInstallShouldNotSet(t *testing.T) {
 	require.False(t, status.targetedInstall)
 	require.Equal(t, len(recipeNames), len(status.targetedInstallNames))
 }
+
+func TestFailedRecipeNames(t *testing.T) {
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed"}})
+	s.RecipeFailed(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "failed"}})
+
+	require.Equal(t, []string{"failed"}, s.FailedRecipeNames())
+}
+
+func TestInstallStatusShouldRecordConcurrentRecipeEvents(t *testing.T) {
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+
+	var wg sync.WaitGroup
+	for n := 0; n < 20; n++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			s.RecipeInstalling(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: name}})
+			s.RecipeFailed(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: name}, Msg: "failed"})
+			require.True(t, s.RecipeHasStatus(name, RecipeStatusTypes.FAILED))
+		}(fmt.Sprintf("recipe-%d", n))
+	}
+	wg.Wait()
+
+	require.Len(t, s.Statuses, 20)
+	require.Len(t, s.FailedRecipeNames(), 20)
+}
+
+// statusRecordingSubscriber records the statuses its recipe events are reported with.
+type statusRecordingSubscriber struct {
+	*MockStatusSubscriber
+	statuses []*InstallStatus
+}
+
+func (r *statusRecordingSubscriber) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	r.statuses = append(r.statuses, status)
+	return nil
+}
+
+func TestInstallStatusShouldReportCopyOfStatusToSubscribers(t *testing.T) {
+	sub := &statusRecordingSubscriber{MockStatusSubscriber: NewMockStatusReporter()}
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{sub}, NewPlatformLinkGenerator())
+
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed"}, EntityGUID: "abc123"})
+	s.RecipeFailed(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "installed"}, Msg: "failed"})
+
+	// The status reported to the subscriber isn't updated by later events.
+	require.Len(t, sub.statuses, 1)
+	require.NotSame(t, s, sub.statuses[0])
+	require.True(t, sub.statuses[0].RecipeHasStatus("installed", RecipeStatusTypes.INSTALLED))
+	require.Equal(t, []string{"abc123"}, sub.statuses[0].EntityGUIDs)
+	require.True(t, s.RecipeHasStatus("installed", RecipeStatusTypes.FAILED))
+}
@@ -0,0 +1,141 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// The events written by the JSONLinesStatusReporter.
+const (
+	JSONEventDiscoveryComplete = "discoveryComplete"
+	JSONEventRecipeStarted     = "recipeStarted"
+	JSONEventRecipeCompleted   = "recipeCompleted"
+	JSONEventInstallComplete   = "installComplete"
+	JSONEventInstallCanceled   = "installCanceled"
+)
+
+// JSONLinesStatusReporter writes the events of the install as JSON lines, one object per line, for
+// programs consuming the output of the install instead of people.
+type JSONLinesStatusReporter struct {
+	*StatusFileReporter
+	out     io.Writer
+	writeMu sync.Mutex
+}
+
+// JSONLinesEvent is a line written by the JSONLinesStatusReporter.
+type JSONLinesEvent struct {
+	Event     string                   `json:"event"`
+	Timestamp int64                    `json:"timestamp"`
+	Host      *types.DiscoveryManifest `json:"host,omitempty"`
+	Recipe    *StatusFileRecipe        `json:"recipe,omitempty"`
+	Summary   *StatusFile              `json:"summary,omitempty"`
+}
+
+func NewJSONLinesStatusReporter() *JSONLinesStatusReporter {
+	return NewJSONLinesStatusReporterWithWriter(os.Stdout)
+}
+
+func NewJSONLinesStatusReporterWithWriter(out io.Writer) *JSONLinesStatusReporter {
+	r := JSONLinesStatusReporter{
+		StatusFileReporter: NewStatusFileReporter(""),
+		out:                out,
+	}
+
+	return &r
+}
+
+func (r *JSONLinesStatusReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return r.write(JSONLinesEvent{Event: JSONEventDiscoveryComplete, Host: &dm})
+}
+
+func (r *JSONLinesStatusReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	r.mu.Lock()
+	_, retried := r.startTimes[event.Recipe.Name]
+	r.mu.Unlock()
+
+	if err := r.StatusFileReporter.RecipeInstalling(status, event); err != nil {
+		return err
+	}
+
+	// Retried attempts are reported as installing again, the recipe only starts once.
+	if retried {
+		return nil
+	}
+
+	return r.write(JSONLinesEvent{
+		Event:  JSONEventRecipeStarted,
+		Recipe: &StatusFileRecipe{Name: event.Recipe.Name, DisplayName: event.Recipe.DisplayName, Status: RecipeStatusTypes.INSTALLING},
+	})
+}
+
+func (r *JSONLinesStatusReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.recipeCompleted(event, RecipeStatusTypes.INSTALLED)
+}
+
+func (r *JSONLinesStatusReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.recipeCompleted(event, RecipeStatusTypes.FAILED)
+}
+
+func (r *JSONLinesStatusReporter) RecipeCanceled(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.recipeCompleted(event, RecipeStatusTypes.CANCELED)
+}
+
+func (r *JSONLinesStatusReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.recipeCompleted(event, RecipeStatusTypes.SKIPPED)
+}
+
+func (r *JSONLinesStatusReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return r.recipeCompleted(event, RecipeStatusTypes.UNSUPPORTED)
+}
+
+func (r *JSONLinesStatusReporter) InstallComplete(status *InstallStatus) error {
+	return r.write(JSONLinesEvent{Event: JSONEventInstallComplete, Summary: r.buildStatusFile(status)})
+}
+
+func (r *JSONLinesStatusReporter) InstallCanceled(status *InstallStatus) error {
+	return r.write(JSONLinesEvent{Event: JSONEventInstallCanceled, Summary: r.buildStatusFile(status)})
+}
+
+func (r *JSONLinesStatusReporter) recipeCompleted(event RecipeStatusEvent, s RecipeStatusType) error {
+	r.recipeFinished(event)
+
+	r.mu.Lock()
+	durationMs := r.durations[event.Recipe.Name]
+	r.mu.Unlock()
+
+	recipe := &StatusFileRecipe{
+		Name:             event.Recipe.Name,
+		DisplayName:      event.Recipe.DisplayName,
+		Status:           s,
+		EntityGUID:       event.EntityGUID,
+		DurationMs:       durationMs,
+		AlreadyInstalled: event.AlreadyInstalled,
+		Upgraded:         event.Upgraded,
+	}
+	if s != RecipeStatusTypes.INSTALLED {
+		recipe.Error = event.Msg
+	}
+
+	return r.write(JSONLinesEvent{Event: JSONEventRecipeCompleted, Recipe: recipe})
+}
+
+func (r *JSONLinesStatusReporter) write(e JSONLinesEvent) error {
+	e.Timestamp = utils.GetTimestamp()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	_, err = fmt.Fprintln(r.out, string(data))
+	return err
+}
@@ -0,0 +1,48 @@
+package execution
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestJSONLinesStatusReporterShouldWriteEvents(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := NewJSONLinesStatusReporterWithWriter(out)
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{r}, NewPlatformLinkGenerator())
+	installed := types.OpenInstallationRecipe{Name: "installed", DisplayName: "Installed"}
+	failed := types.OpenInstallationRecipe{Name: "failed", DisplayName: "Failed"}
+
+	s.DiscoveryComplete(types.DiscoveryManifest{Hostname: "web-1", OS: "linux"})
+	s.RecipeInstalling(RecipeStatusEvent{Recipe: installed})
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: installed, EntityGUID: "abc123"})
+	s.RecipeInstalling(RecipeStatusEvent{Recipe: failed})
+	s.RecipeInstalling(RecipeStatusEvent{Recipe: failed})
+	s.RecipeFailed(RecipeStatusEvent{Recipe: failed, Msg: "execution failed"})
+	s.InstallComplete(nil)
+
+	events := []JSONLinesEvent{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		e := JSONLinesEvent{}
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		events = append(events, e)
+	}
+
+	require.Len(t, events, 6)
+	require.Equal(t, JSONEventDiscoveryComplete, events[0].Event)
+	require.Equal(t, "web-1", events[0].Host.Hostname)
+	require.Equal(t, JSONEventRecipeStarted, events[1].Event)
+	require.Equal(t, JSONEventRecipeCompleted, events[2].Event)
+	require.Equal(t, RecipeStatusTypes.INSTALLED, events[2].Recipe.Status)
+	require.Equal(t, "abc123", events[2].Recipe.EntityGUID)
+	require.Equal(t, JSONEventRecipeStarted, events[3].Event)
+	require.Equal(t, RecipeStatusTypes.FAILED, events[4].Recipe.Status)
+	require.Equal(t, "execution failed", events[4].Recipe.Error)
+	require.Equal(t, JSONEventInstallComplete, events[5].Event)
+	require.Len(t, events[5].Summary.Recipes, 2)
+}
@@ -0,0 +1,91 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// InfraAgentLaunchdService is the label of the launchd service of the infrastructure agent, as
+// registered by "brew services" for the newrelic-infra-agent formula.
+const InfraAgentLaunchdService = "homebrew.mxcl.newrelic-infra-agent"
+
+// LaunchdService manages a service of the launchd system domain with launchctl.
+type LaunchdService struct {
+	Label        string
+	pollInterval time.Duration
+	runFunc      func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+func NewLaunchdService(label string) *LaunchdService {
+	return &LaunchdService{
+		Label:        label,
+		pollInterval: time.Second,
+		runFunc:      runLaunchctl,
+	}
+}
+
+func (s *LaunchdService) target() string {
+	return "system/" + s.Label
+}
+
+// State returns the state of the service as reported by launchctl, such as running or not running.
+func (s *LaunchdService) State(ctx context.Context) (string, error) {
+	out, err := s.runFunc(ctx, "print", s.target())
+	if err != nil {
+		return "", fmt.Errorf("could not query service %s: %w: %s", s.Label, err, strings.TrimSpace(string(out)))
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == "state" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+
+	return "", fmt.Errorf("could not read the state of service %s", s.Label)
+}
+
+// EnsureRunning starts the service when it isn't running, and waits for it to run.
+func (s *LaunchdService) EnsureRunning(ctx context.Context, timeout time.Duration) error {
+	state, err := s.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state == "running" {
+		return nil
+	}
+
+	log.Debugf("Service %s is %s, starting it", s.Label, state)
+	if out, err := s.runFunc(ctx, "kickstart", s.target()); err != nil {
+		return fmt.Errorf("could not start service %s: %w: %s", s.Label, err, strings.TrimSpace(string(out)))
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if state, err = s.State(ctx); err != nil {
+			return err
+		}
+
+		if state == "running" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+	}
+
+	return fmt.Errorf("service %s is %s after starting it", s.Label, state)
+}
+
+func runLaunchctl(ctx context.Context, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, "launchctl", args...).CombinedOutput()
+}
@@ -0,0 +1,71 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func launchctlPrintOutput(state string) []byte {
+	return []byte(`system/homebrew.mxcl.newrelic-infra-agent = {
+	active count = 1
+	path = /Library/LaunchDaemons/homebrew.mxcl.newrelic-infra-agent.plist
+	state = ` + state + `
+	program = /opt/homebrew/opt/newrelic-infra-agent/bin/newrelic-infra-service
+}
+`)
+}
+
+func TestLaunchdServiceShouldReadState(t *testing.T) {
+	s := NewLaunchdService(InfraAgentLaunchdService)
+	s.runFunc = func(ctx context.Context, args ...string) ([]byte, error) {
+		return launchctlPrintOutput("running"), nil
+	}
+
+	state, err := s.State(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "running", state)
+}
+
+func TestLaunchdServiceShouldStartStoppedService(t *testing.T) {
+	calls := [][]string{}
+	state := "not running"
+	s := NewLaunchdService(InfraAgentLaunchdService)
+	s.pollInterval = 0
+	s.runFunc = func(ctx context.Context, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		if args[0] == "kickstart" {
+			state = "running"
+			return []byte{}, nil
+		}
+		return launchctlPrintOutput(state), nil
+	}
+
+	err := s.EnsureRunning(context.Background(), time.Second)
+
+	require.NoError(t, err)
+	target := "system/" + InfraAgentLaunchdService
+	require.Equal(t, [][]string{{"print", target}, {"kickstart", target}, {"print", target}}, calls)
+}
+
+func TestLaunchdServiceShouldFailWhenServiceIsMissing(t *testing.T) {
+	s := NewLaunchdService(InfraAgentLaunchdService)
+	s.runFunc = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("Could not find service in domain for port"), errors.New("exit status 113")
+	}
+
+	err := s.EnsureRunning(context.Background(), time.Second)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Could not find service")
+}
+
+func TestNewInfraAgentService(t *testing.T) {
+	require.IsType(t, &WindowsService{}, NewInfraAgentService("windows"))
+	require.IsType(t, &LaunchdService{}, NewInfraAgentService("darwin"))
+	require.Nil(t, NewInfraAgentService("linux"))
+}
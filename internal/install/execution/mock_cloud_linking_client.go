@@ -0,0 +1,32 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
+)
+
+type MockCloudLinkingClient struct {
+	LinkedAccounts     []cloud.CloudLinkedAccount
+	LinkedAccountsErr  error
+	LinkAccountVal     *cloud.CloudLinkAccountPayload
+	LinkAccountErr     error
+	LinkAccountCount   int
+	LinkAccountsInputs []cloud.CloudLinkCloudAccountsInput
+}
+
+func NewMockCloudLinkingClient() *MockCloudLinkingClient {
+	return &MockCloudLinkingClient{
+		LinkAccountVal: &cloud.CloudLinkAccountPayload{},
+	}
+}
+
+func (c *MockCloudLinkingClient) GetLinkedAccountsWithContext(ctx context.Context, provider string) (*[]cloud.CloudLinkedAccount, error) {
+	return &c.LinkedAccounts, c.LinkedAccountsErr
+}
+
+func (c *MockCloudLinkingClient) CloudLinkAccountWithContext(ctx context.Context, accountID int, accounts cloud.CloudLinkCloudAccountsInput) (*cloud.CloudLinkAccountPayload, error) {
+	c.LinkAccountCount++
+	c.LinkAccountsInputs = append(c.LinkAccountsInputs, accounts)
+	return c.LinkAccountVal, c.LinkAccountErr
+}
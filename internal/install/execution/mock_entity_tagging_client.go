@@ -0,0 +1,27 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/common"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/entities"
+)
+
+type MockEntityTaggingClient struct {
+	AddTagsVal        *entities.TaggingMutationResult
+	AddTagsErr        error
+	AddTagsCallCount  int
+	TaggedEntityGUIDs []string
+	Tags              []entities.TaggingTagInput
+}
+
+func NewMockEntityTaggingClient() *MockEntityTaggingClient {
+	return &MockEntityTaggingClient{}
+}
+
+func (c *MockEntityTaggingClient) TaggingAddTagsToEntityWithContext(ctx context.Context, guid common.EntityGUID, tags []entities.TaggingTagInput) (*entities.TaggingMutationResult, error) {
+	c.AddTagsCallCount++
+	c.TaggedEntityGUIDs = append(c.TaggedEntityGUIDs, string(guid))
+	c.Tags = tags
+	return c.AddTagsVal, c.AddTagsErr
+}
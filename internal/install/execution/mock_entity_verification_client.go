@@ -0,0 +1,35 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type MockEntityVerificationClient struct {
+	// ResponseJSON is unmarshaled into the response of each query.
+	ResponseJSON   string
+	QueryErr       error
+	QueryCallCount int
+	QueriedGUIDs   [][]string
+}
+
+func NewMockEntityVerificationClient() *MockEntityVerificationClient {
+	return &MockEntityVerificationClient{}
+}
+
+func (c *MockEntityVerificationClient) QueryWithResponseAndContext(ctx context.Context, query string, variables map[string]interface{}, respBody interface{}) error {
+	c.QueryCallCount++
+	if guids, ok := variables["guids"].([]string); ok {
+		c.QueriedGUIDs = append(c.QueriedGUIDs, guids)
+	}
+
+	if c.QueryErr != nil {
+		return c.QueryErr
+	}
+
+	if c.ResponseJSON == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(c.ResponseJSON), respBody)
+}
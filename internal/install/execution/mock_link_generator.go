@@ -15,7 +15,7 @@ func NewMockPlatformLinkGenerator() *MockPlatformLinkGenerator {
 	return &MockPlatformLinkGenerator{}
 }
 
-func (g *MockPlatformLinkGenerator) GenerateExplorerLink(status InstallStatus) string {
+func (g *MockPlatformLinkGenerator) GenerateExplorerLink(status *InstallStatus) string {
 	g.GenerateExplorerLinkCallCount++
 	return g.GenerateExplorerLinkVal
 }
@@ -30,7 +30,7 @@ func (g *MockPlatformLinkGenerator) GenerateLoggingLink(entityGUID string) strin
 	return g.GenerateLoggingLinkVal
 }
 
-func (g *MockPlatformLinkGenerator) GenerateRedirectURL(status InstallStatus) string {
+func (g *MockPlatformLinkGenerator) GenerateRedirectURL(status *InstallStatus) string {
 	if status.hasAnyRecipeStatus(RecipeStatusTypes.INSTALLED) {
 		switch t := status.successLinkConfig.Type; {
 		case strings.EqualFold(string(t), "explorer"):
@@ -13,6 +13,14 @@ type MockRecipeExecutor struct {
 	ExecuteErr   error
 	OutputParser *OutputParser
 	ShouldPanic  bool
+	// ExecutedRecipeNames records the recipes passed to Execute, in call order.
+	ExecutedRecipeNames []string
+	// WaitForContext makes Execute block until its context is done.
+	WaitForContext bool
+	// ExecuteErrs are returned by successive calls to Execute before falling back to ExecuteErr.
+	ExecuteErrs []error
+	// ExecutePreInstallErr is returned by ExecutePreInstall instead of ExecuteErr when set.
+	ExecutePreInstallErr error
 }
 
 func NewMockRecipeExecutor() *MockRecipeExecutor {
@@ -22,6 +30,16 @@ func NewMockRecipeExecutor() *MockRecipeExecutor {
 }
 
 func (m *MockRecipeExecutor) Execute(ctx context.Context, r types.OpenInstallationRecipe, v types.RecipeVars) error {
+	m.ExecutedRecipeNames = append(m.ExecutedRecipeNames, r.Name)
+	if m.WaitForContext {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if len(m.ExecuteErrs) > 0 {
+		err := m.ExecuteErrs[0]
+		m.ExecuteErrs = m.ExecuteErrs[1:]
+		return err
+	}
 	return m.ExecuteErr
 }
 
@@ -29,6 +47,9 @@ func (m *MockRecipeExecutor) ExecutePreInstall(ctx context.Context, r types.Open
 	if m.ShouldPanic {
 		panic(errors.New("Panicing"))
 	}
+	if m.ExecutePreInstallErr != nil {
+		return m.ExecutePreInstallErr
+	}
 	return m.ExecuteErr
 }
 
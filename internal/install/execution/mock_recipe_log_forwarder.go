@@ -4,6 +4,8 @@ import "io"
 
 type MockRecipeLogForwarder struct {
 	optIn bool
+	// SendInstallLogCallCount is how many times the install log was sent.
+	SendInstallLogCallCount int
 }
 
 func NewMockRecipeLogForwarder() *MockRecipeLogForwarder {
@@ -18,6 +20,11 @@ func (rlf *MockRecipeLogForwarder) SendLogsToNewRelic(recipeName string, recipeO
 
 }
 
+func (rlf *MockRecipeLogForwarder) SendInstallLogToNewRelic(reader io.Reader, installID string, hostname string) error {
+	rlf.SendInstallLogCallCount++
+	return nil
+}
+
 func (rlf *MockRecipeLogForwarder) HasUserOptedIn() bool {
 	return rlf.optIn
 }
@@ -42,6 +42,8 @@ type MockStatusSubscriber struct {
 	ReportRecommended map[string]int
 	ReportFailed      map[string]int
 	ReportAvailable   map[string]int
+	// InstalledOrder are the names of the installed recipes, in the order they were installed.
+	InstalledOrder []string
 
 	GUIDs      []string
 	Durations  []int64
@@ -82,6 +84,7 @@ func (r *MockStatusSubscriber) RecipeInstalled(status *InstallStatus, event Reci
 		r.ReportInstalled = make(map[string]int)
 	}
 	r.ReportInstalled[event.Recipe.Name]++
+	r.InstalledOrder = append(r.InstalledOrder, event.Recipe.Name)
 
 	r.GUIDs = status.EntityGUIDs
 
@@ -35,7 +35,7 @@ func NewPlatformLinkGenerator() *PlatformLinkGenerator {
 	}
 }
 
-func (g *PlatformLinkGenerator) GenerateExplorerLink(status InstallStatus) string {
+func (g *PlatformLinkGenerator) GenerateExplorerLink(status *InstallStatus) string {
 	return g.generateExplorerLink(status)
 }
 
@@ -51,7 +51,7 @@ func (g *PlatformLinkGenerator) GenerateLoggingLink(entityGUID string) string {
 // through an installation. The URL is displayed in the CLI out as well and is
 // also provided in the nerdstorage document. This provides the user two options
 // to see their data - click from the CLI output or from the frontend.
-func (g *PlatformLinkGenerator) GenerateRedirectURL(status InstallStatus) string {
+func (g *PlatformLinkGenerator) GenerateRedirectURL(status *InstallStatus) string {
 	installedCount := len(status.Installed)
 	haveFailure := status.HasUnsupportedRecipes || status.HasFailedRecipes
 
@@ -103,7 +103,7 @@ func (g *PlatformLinkGenerator) generateReferrerParam(entityGUID string, install
 	return string(stringifiedParam)
 }
 
-func (g *PlatformLinkGenerator) generateExplorerLink(status InstallStatus) string {
+func (g *PlatformLinkGenerator) generateExplorerLink(status *InstallStatus) string {
 	longURL := fmt.Sprintf("https://%s/launcher/nr1-core.explorer?platform[filters]=%s&platform[accountId]=%d&cards[0]=%s",
 		nrPlatformHostname(),
 		utils.Base64Encode(status.successLinkConfig.Filter),
@@ -217,5 +217,5 @@ func nrPlatformHostname() string {
 // GeneratePlanManagementURL returns the URL where a customer
 // can manage their plan and payment options.
 func GetAccountPlanManagementURL() string {
-	return fmt.Sprintf("https://%s/nr1-core/plan-management/home?account=%s", nrPlatformHostname(), configAPI.GetActiveProfileString(config.AccountID))
+	return fmt.Sprintf("https://%s/nr1-core/plan-management/home?account=%d", nrPlatformHostname(), configAPI.GetActiveProfileAccountID())
 }
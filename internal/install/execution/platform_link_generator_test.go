@@ -25,7 +25,7 @@ func TestGenerateRedirectURL_ShouldGenerateEntityLinkWhenOneInstalledWithNoFailu
 
 	expectedURL := fmt.Sprintf("https://%s/redirect/entity/%s", nrPlatformHostname(), recipeName)
 
-	result := g.GenerateRedirectURL(*s)
+	result := g.GenerateRedirectURL(s)
 	require.Equal(t, 1, len(s.EntityGUIDs))
 	require.Equal(t, 1, len(s.Installed))
 	require.Equal(t, expectedURL, result)
@@ -42,7 +42,7 @@ func TestGenerateRedirectURL_ShoudGenerateStatusLinkWhenMoreThanOneInstalled(t *
 	b.recipeStatusUpdate(loggingName, "Installed")
 	g, s := b.build()
 
-	result := g.GenerateRedirectURL(*s)
+	result := g.GenerateRedirectURL(s)
 	require.Contains(t, result, "explorer")
 }
 
@@ -61,7 +61,7 @@ func TestGenerateLoggingURL_InstallSuccess(t *testing.T) {
 	launcherEncodedParams := "eyJxdWVyeSI6IlwiZW50aXR5Lmd1aWQuSU5GUkFcIjpcIk1YeEJVRTE4UVZCUVRFbERRVlJKVDA1OE9URTJOelF4TmdcIiIsInJlZmVycmVyIjoibmV3cmVsaWMtY2xpIn0="
 	expectedLoggingLink := fmt.Sprintf("https://%s/launcher/logger.log-launcher?platform[accountId]=%d&launcher=%s", nrPlatformHostname(), accountID, launcherEncodedParams)
 
-	redirectURLResult := g.GenerateRedirectURL(*s)
+	redirectURLResult := g.GenerateRedirectURL(s)
 	loggingLinkResult := g.GenerateLoggingLink(rName)
 	require.Contains(t, redirectURLResult, "explorer")
 	require.Contains(t, loggingLinkResult, expectedLoggingLink)
@@ -78,7 +78,7 @@ func TestGenerateRedirectURL_InstallPartialSuccess(t *testing.T) {
 	b.recipeStatusUpdate(loggingName, "Failed")
 	g, s := b.build()
 
-	result := g.GenerateRedirectURL(*s)
+	result := g.GenerateRedirectURL(s)
 	expectedEncodedQueryParamSubstring := utils.Base64Encode(g.generateReferrerParam(infraName, b.installStatus.InstallID))
 
 	require.Equal(t, 1, len(s.EntityGUIDs))
@@ -96,7 +96,7 @@ func TestGenerateRedirectURL_InstallFailed(t *testing.T) {
 	b.recipeStatusUpdate(infraName, "Failed")
 	g, s := b.build()
 
-	result := g.GenerateRedirectURL(*s)
+	result := g.GenerateRedirectURL(s)
 	require.Contains(t, result, "explorer")
 }
 
@@ -109,7 +109,7 @@ func TestGenerateRedirectURL_InstallCanceled(t *testing.T) {
 	b.recipeStatusUpdate(infraName, "Canceled")
 	g, s := b.build()
 
-	result := g.GenerateRedirectURL(*s)
+	result := g.GenerateRedirectURL(s)
 	require.Contains(t, result, "explorer")
 }
 
@@ -119,7 +119,7 @@ func TestGenerateRedirectURL_NoRecipesInstalled(t *testing.T) {
 	b := newPlatformLinkGeneratorBuilder()
 	g, s := b.build()
 
-	result := g.GenerateRedirectURL(*s)
+	result := g.GenerateRedirectURL(s)
 	require.Contains(t, result, "explorer")
 }
 
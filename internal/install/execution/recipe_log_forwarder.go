@@ -21,6 +21,7 @@ import (
 type LogForwarder interface {
 	PromptUserToSendLogs(reader io.Reader) bool
 	SendLogsToNewRelic(recipeName string, data []string)
+	SendInstallLogToNewRelic(reader io.Reader, installID string, hostname string) error
 	HasUserOptedIn() bool
 	SetUserOptedIn(val bool)
 }
@@ -71,36 +72,76 @@ func (lf *RecipeLogForwarder) SetUserOptedIn(val bool) {
 func (lf *RecipeLogForwarder) SendLogsToNewRelic(recipeName string, data []string) {
 	lf.buildLogEntryBatch(recipeName, data)
 
+	if err := sendLogEntries(lf.LogEntries); err != nil {
+		log.Debug(err)
+	}
+}
+
+// SendInstallLogToNewRelic sends each line of the install log to the Logs API of the account,
+// tagged with the install ID and hostname so the logs of an install can be queried together.
+func (lf *RecipeLogForwarder) SendInstallLogToNewRelic(reader io.Reader, installID string, hostname string) error {
+	entries, err := buildInstallLogEntries(reader, installID, hostname)
+	if err != nil {
+		return fmt.Errorf("could not read the install log: %s", err)
+	}
+
+	return sendLogEntries(entries)
+}
+
+func buildInstallLogEntries(reader io.Reader, installID string, hostname string) ([]LogEntry, error) {
+	entries := []LogEntry{}
+	now := time.Now().UnixMilli()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		now++ //using timestamp to retain log sequence
+		entries = append(entries, LogEntry{
+			Attributes: map[string]interface{}{"nr-install-id": installID, "hostname": hostname, "timestamp": now},
+			LogType:    "cli-install-log",
+			Message:    line,
+		})
+	}
+
+	return entries, scanner.Err()
+}
+
+func sendLogEntries(entries []LogEntry) error {
 	// building log api client
 	config, err := createLogClientConfig()
 	if nil != err {
-		log.Debugf("Could not configure New Relic LogsApi client: %e", err)
-		return
+		return fmt.Errorf("could not configure New Relic LogsApi client: %s", err)
 	}
 	logClient := nrLogs.New(config)
 
 	// fetch accountId & configure client for batch mode
 	accountID, err := strconv.Atoi(os.Getenv("NEW_RELIC_ACCOUNT_ID"))
 	if nil != err {
-		log.Debugf("Could not determine account id for log destintation: %e", err)
-		return
+		return fmt.Errorf("could not determine account id for log destination: %s", err)
 	}
 	if err := logClient.BatchMode(context.Background(), accountID); err != nil {
-		log.Fatal("error starting batch mode: ", err)
+		return fmt.Errorf("error starting batch mode: %s", err)
 	}
 
 	// enqueue log entries.
-	for _, logEntry := range lf.LogEntries {
+	for _, logEntry := range entries {
 		if err := logClient.EnqueueLogEntry(context.Background(), logEntry); err != nil {
-			log.Fatal("error queuing log entry: ", err)
+			return fmt.Errorf("error queuing log entry: %s", err)
 		}
 	}
 
 	// Force flush/send; sleep seems necessary, otherwise logs don't appear to land in NR
 	time.Sleep(5 * time.Second)
 	if err := logClient.Flush(); err != nil {
-		log.Fatal("error flushing log queue: ", err)
+		return fmt.Errorf("error flushing log queue: %s", err)
 	}
+
+	return nil
 }
 
 func (lf *RecipeLogForwarder) buildLogEntryBatch(recipeName string, data []string) {
@@ -118,8 +159,7 @@ func createLogClientConfig() (nrConfig.Config, error) {
 		Compression: nrConfig.Compression.None,
 	}
 
-	regName, _ := region.Parse(os.Getenv("NEW_RELIC_REGION"))
-	reg, _ := region.Get(regName)
+	reg, _ := region.Get(ProfileRegion())
 	err := cfg.SetRegion(reg)
 	if nil != err {
 		log.Debugf("Could not set region on LogsApi client: %e", err)
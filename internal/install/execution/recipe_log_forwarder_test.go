@@ -3,6 +3,7 @@ package execution
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,15 @@ func TestSendLogsToNewRelicBuildsLogEntryForEachLogLine(t *testing.T) {
 
 	assert.Equal(t, 3, len(rlf.LogEntries))
 }
+
+func TestBuildInstallLogEntriesTagsEachLine(t *testing.T) {
+	entries, err := buildInstallLogEntries(strings.NewReader("line one\n\nline two\n"), "install-id", "web-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "line two", entries[1].Message)
+	assert.Equal(t, "cli-install-log", entries[1].LogType)
+	assert.Equal(t, "install-id", entries[1].Attributes["nr-install-id"])
+	assert.Equal(t, "web-1", entries[1].Attributes["hostname"])
+	assert.Greater(t, entries[1].Attributes["timestamp"], entries[0].Attributes["timestamp"])
+}
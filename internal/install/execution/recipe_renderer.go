@@ -0,0 +1,194 @@
+package execution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-task/task/v3/taskfile"
+	"gopkg.in/yaml.v3"
+
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// maxRenderedTaskCalls bounds the task calls rendered for a recipe, since tasks that call each
+// other would otherwise be rendered forever.
+const maxRenderedTaskCalls = 1000
+
+// taskRender is the state of rendering one recipe's tasks.
+type taskRender struct {
+	taskfile *taskfile.Taskfile
+	out      *strings.Builder
+	calls    int
+}
+
+// RenderRecipe returns the shell that the install tasks of the recipe run, in the order go-task
+// runs them, with their templates rendered with the recipe variables. Nothing is run, so dynamic
+// variables are rendered as the command substitution of their script, and the status and
+// precondition commands of tasks are rendered as comments.
+func RenderRecipe(r types.OpenInstallationRecipe, recipeVars types.RecipeVars) (string, error) {
+	tf := &taskfile.Taskfile{}
+	if err := yaml.Unmarshal([]byte(r.Install), tf); err != nil {
+		return "", fmt.Errorf("could not unmarshal taskfile: %s", err)
+	}
+
+	render := &taskRender{
+		taskfile: tf,
+		out:      &strings.Builder{},
+	}
+
+	globals, err := render.resolveVars(tf.Vars, map[string]string{})
+	if err != nil {
+		return "", err
+	}
+
+	// Recipe variables take precedence over the variables of the recipe's taskfile.
+	for k, v := range recipeVars {
+		globals[k] = v
+	}
+
+	if err := render.renderTask(sshDefaultTask, globals); err != nil {
+		return "", err
+	}
+
+	return render.out.String(), nil
+}
+
+func (render *taskRender) renderTask(name string, callVars map[string]string) error {
+	render.calls++
+	if render.calls > maxRenderedTaskCalls {
+		return fmt.Errorf("task: maximum task call exceeded (%d) for task %s: probably a cyclic dep or infinite loop", maxRenderedTaskCalls, name)
+	}
+
+	t, ok := render.taskfile.Tasks[name]
+	if !ok {
+		return fmt.Errorf(`task: Task "%s" not found`, name)
+	}
+
+	vars, err := render.resolveVars(t.Vars, callVars)
+	if err != nil {
+		return taskFailed(name, err)
+	}
+
+	for _, d := range t.Deps {
+		depVars, err := render.resolveVars(d.Vars, vars)
+		if err != nil {
+			return taskFailed(name, err)
+		}
+
+		if err = render.renderTask(d.Task, depVars); err != nil {
+			return taskFailed(name, err)
+		}
+	}
+
+	fmt.Fprintf(render.out, "# task: %s\n", name)
+
+	for _, p := range t.Preconditions {
+		sh, err := renderTemplate(p.Sh, vars)
+		if err != nil {
+			return taskFailed(name, err)
+		}
+		fmt.Fprintf(render.out, "# precondition: %s\n", commentLine(sh))
+	}
+
+	for _, s := range t.Status {
+		sh, err := renderTemplate(s, vars)
+		if err != nil {
+			return taskFailed(name, err)
+		}
+		fmt.Fprintf(render.out, "# up to date when: %s\n", commentLine(sh))
+	}
+
+	env := types.RecipeVars{}
+	for _, envVars := range []*taskfile.Vars{render.taskfile.Env, t.Env} {
+		err = envVars.Range(func(key string, v taskfile.Var) error {
+			env[key], err = render.resolveVar(v, vars)
+			return err
+		})
+		if err != nil {
+			return taskFailed(name, err)
+		}
+	}
+	render.out.WriteString(exportScript(env))
+
+	if t.Dir != "" {
+		dir, err := renderTemplate(t.Dir, vars)
+		if err != nil {
+			return taskFailed(name, err)
+		}
+		fmt.Fprintf(render.out, "cd %s\n", remote.ShellQuote(dir))
+	}
+
+	deferred := []*taskfile.Cmd{}
+	for _, c := range t.Cmds {
+		if c.Defer {
+			deferred = append(deferred, c)
+			continue
+		}
+
+		if err = render.renderCmd(c, vars); err != nil {
+			return taskFailed(name, err)
+		}
+	}
+
+	for i := len(deferred) - 1; i >= 0; i-- {
+		fmt.Fprintf(render.out, "# deferred by task: %s\n", name)
+		if err = render.renderCmd(deferred[i], vars); err != nil {
+			return taskFailed(name, err)
+		}
+	}
+
+	return nil
+}
+
+func (render *taskRender) renderCmd(c *taskfile.Cmd, vars map[string]string) error {
+	if c.Task != "" {
+		callVars, err := render.resolveVars(c.Vars, vars)
+		if err != nil {
+			return err
+		}
+
+		return render.renderTask(c.Task, callVars)
+	}
+
+	rendered, err := renderTemplate(c.Cmd, vars)
+	if err != nil {
+		return err
+	}
+
+	render.out.WriteString(strings.TrimRight(rendered, "\n") + "\n")
+	return nil
+}
+
+// resolveVars returns the given variables rendered against, and merged over, the variables in scope.
+func (render *taskRender) resolveVars(vs *taskfile.Vars, scope map[string]string) (map[string]string, error) {
+	resolved := map[string]string{}
+	for k, v := range scope {
+		resolved[k] = v
+	}
+
+	err := vs.Range(func(key string, v taskfile.Var) error {
+		value, err := render.resolveVar(v, resolved)
+		resolved[key] = value
+		return err
+	})
+
+	return resolved, err
+}
+
+func (render *taskRender) resolveVar(v taskfile.Var, scope map[string]string) (string, error) {
+	if v.Sh == "" {
+		return renderTemplate(v.Static, scope)
+	}
+
+	sh, err := renderTemplate(v.Sh, scope)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("$(%s)", strings.TrimSpace(sh)), nil
+}
+
+func commentLine(text string) string {
+	return strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n#   ")
+}
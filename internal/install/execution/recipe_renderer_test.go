@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestRenderRecipeShouldRenderTasksInOrder(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+vars:
+  CONFIG_DIR: /etc/newrelic-infra
+tasks:
+  default:
+    cmds:
+      - task: setup
+        vars:
+          PORT: "3306"
+      - defer: echo cleanup
+      - echo done
+  setup:
+    deps: [download]
+    dir: '{{.CONFIG_DIR}}'
+    env:
+      MYSQL_USER: '{{.NR_CLI_DB_USERNAME}}'
+    status:
+      - test -f {{.CONFIG_DIR}}/mysql.yml
+    cmds:
+      - echo {{.PORT}} > {{.CONFIG_DIR}}/mysql.yml
+  download:
+    vars:
+      VERSION:
+        sh: curl -s https://example.com/version
+    cmds:
+      - curl -o mysql.tar.gz https://example.com/{{.VERSION}}/{{OS}}.tar.gz
+`,
+	}
+
+	script, err := RenderRecipe(r, types.RecipeVars{
+		"NR_CLI_DB_USERNAME": "newrelic",
+		"OS":                 "linux",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, `# task: default
+# task: download
+curl -o mysql.tar.gz https://example.com/$(curl -s https://example.com/version)/linux.tar.gz
+# task: setup
+# up to date when: test -f /etc/newrelic-infra/mysql.yml
+export MYSQL_USER='newrelic'
+cd '/etc/newrelic-infra'
+echo 3306 > /etc/newrelic-infra/mysql.yml
+echo done
+# deferred by task: default
+echo cleanup
+`, script)
+}
+
+func TestRenderRecipeShouldFailOnMissingTask(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - task: setup
+`,
+	}
+
+	_, err := RenderRecipe(r, types.RecipeVars{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Task "setup" not found`)
+}
+
+func TestRenderRecipeShouldFailOnCyclicTasks(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - task: default
+`,
+	}
+
+	_, err := RenderRecipe(r, types.RecipeVars{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maximum task call exceeded")
+}
@@ -6,13 +6,14 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/ghodss/yaml"
 
 	survey "github.com/AlecAivazis/survey/v2"
-	"github.com/AlecAivazis/survey/v2/terminal"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/http/httpproxy"
 
 	"github.com/newrelic/newrelic-cli/internal/config"
 	configAPI "github.com/newrelic/newrelic-cli/internal/config/api"
@@ -27,15 +28,56 @@ var (
 )
 
 const (
-	EnvNriaCustomAttributes       = "NRIA_CUSTOM_ATTRIBUTES"
+	EnvNriaCustomAttributes       = types.EnvNriaCustomAttributes
 	EnvNriaPassthroughEnvironment = "NRIA_PASSTHROUGH_ENVIRONMENT"
 	EnvInstallCustomAttributes    = "INSTALL_CUSTOM_ATTRIBUTES"
+	EnvNriaProxy                  = "NRIA_PROXY"
+	EnvNriaMode                   = "NRIA_MODE"
+	EnvNriaConfigFile             = "NRIA_CONFIG_FILE"
+	EnvInfraAgentVersion          = "NEW_RELIC_INFRA_AGENT_VERSION"
 )
 
-type RecipeVarProvider struct{}
+// databaseRecipeServices are the database services of integration recipes, whose input variables
+// are pre-filled from the configuration files discovery read.
+var databaseRecipeServices = map[string]string{
+	"mysql-open-source-integration":    types.DatabaseServiceMySQL,
+	"postgres-open-source-integration": types.DatabaseServicePostgres,
+	"redis-open-source-integration":    types.DatabaseServiceRedis,
+}
+
+// webServerRecipeServices are the web servers of integration recipes, whose input variables are
+// pre-filled from the configuration files discovery read.
+var webServerRecipeServices = map[string]string{
+	"nginx-open-source-integration":   types.WebServerNginx,
+	"apache-open-source-integration":  types.WebServerApache,
+	"haproxy-open-source-integration": types.WebServerHAProxy,
+}
+
+// powerShellCommand is the POWERSHELL variable of Windows recipes, so their tasks run scripts
+// with {{.POWERSHELL}} -Command whatever the host's execution policy and profile.
+const powerShellCommand = "powershell.exe -NoProfile -NonInteractive -ExecutionPolicy Bypass"
+
+// The brew of the HOMEBREW variable of macOS recipes, where Homebrew installs it on Apple silicon
+// and Intel Macs, since recipes run with sudo may not have it in their PATH.
+const (
+	homebrewAppleSilicon = "/opt/homebrew/bin/brew"
+	homebrewIntel        = "/usr/local/bin/brew"
+)
+
+type RecipeVarProvider struct {
+	resolver *RecipeVarResolver
+}
 
 func NewRecipeVarProvider() *RecipeVarProvider {
-	return &RecipeVarProvider{}
+	return &RecipeVarProvider{
+		resolver: NewRecipeVarResolver(nil, nil),
+	}
+}
+
+// WithResolver sets the resolver used for the variables provided by the user.
+func (re *RecipeVarProvider) WithResolver(resolver *RecipeVarResolver) *RecipeVarProvider {
+	re.resolver = resolver
+	return re
 }
 
 func (re *RecipeVarProvider) Prepare(m types.DiscoveryManifest, r types.OpenInstallationRecipe, assumeYes bool) (types.RecipeVars, error) {
@@ -54,7 +96,7 @@ func (re *RecipeVarProvider) Prepare(m types.DiscoveryManifest, r types.OpenInst
 		return types.RecipeVars{}, err
 	}
 
-	inputVarsResult, err := varsFromInput(r.InputVars, assumeYes)
+	inputVarsResult, err := varsFromInput(r.InputVars, re.resolver, varsFromDiscovery(m, r), assumeYes)
 	if err != nil {
 		return types.RecipeVars{}, err
 	}
@@ -66,6 +108,7 @@ func (re *RecipeVarProvider) Prepare(m types.DiscoveryManifest, r types.OpenInst
 	results = append(results, types.RecipeVariables)
 	results = append(results, inputVarsResult)
 	results = append(results, envVarsResult)
+	results = append(results, re.resolver.Overrides())
 
 	for _, result := range results {
 		for k, v := range result {
@@ -77,20 +120,121 @@ func (re *RecipeVarProvider) Prepare(m types.DiscoveryManifest, r types.OpenInst
 }
 
 func varsFromProfile() (types.RecipeVars, error) {
-	accountID := configAPI.GetActiveProfileString(config.AccountID)
+	accountID := ""
+	if id := configAPI.GetActiveProfileAccountID(); id != 0 {
+		accountID = strconv.Itoa(id)
+	}
 	apiKey := configAPI.GetActiveProfileString(config.APIKey)
 	region := configAPI.GetActiveProfileString(config.Region)
 
-	vars := make(types.RecipeVars)
+	vars := varsFromRegion(region)
 
 	vars["NEW_RELIC_LICENSE_KEY"] = os.Getenv("NEW_RELIC_LICENSE_KEY")
 	vars["NEW_RELIC_ACCOUNT_ID"] = accountID
 	vars["NEW_RELIC_API_KEY"] = apiKey
-	vars["NEW_RELIC_REGION"] = region
 
 	return vars, nil
 }
 
+// varsFromDiscovery returns the values discovery found on the host for the input variables of a
+// recipe.
+func varsFromDiscovery(m types.DiscoveryManifest, r types.OpenInstallationRecipe) types.RecipeVars {
+	vars := varsFromDatabaseConfig(m, r)
+	for k, v := range varsFromWebServerConfig(m, r) {
+		vars[k] = v
+	}
+	for k, v := range varsFromGPU(m, r) {
+		vars[k] = v
+	}
+	for k, v := range varsFromJMX(m, r) {
+		vars[k] = v
+	}
+
+	return vars
+}
+
+// varsFromDatabaseConfig returns the values discovered in the configuration file of the database
+// service of a recipe, under the names of the input variables of integration recipes they're the
+// values of.
+func varsFromDatabaseConfig(m types.DiscoveryManifest, r types.OpenInstallationRecipe) types.RecipeVars {
+	vars := types.RecipeVars{}
+
+	c := m.DatabaseConfig(databaseRecipeServices[r.Name])
+	if c == nil {
+		return vars
+	}
+
+	for _, name := range []string{"NR_CLI_DB_PORT", "NR_CLI_PORT"} {
+		vars[name] = c.Port
+	}
+	for _, name := range []string{"NR_CLI_DB_SOCKET", "NR_CLI_SOCKET"} {
+		vars[name] = c.Socket
+	}
+	for _, name := range []string{"NR_CLI_DB_DATA_DIR", "NR_CLI_DATA_DIR"} {
+		vars[name] = c.DataDir
+	}
+
+	return vars
+}
+
+// varsFromWebServerConfig returns the configuration file and status page discovered of the web
+// server of a recipe, under the names of the input variables of integration recipes they're the
+// values of.
+func varsFromWebServerConfig(m types.DiscoveryManifest, r types.OpenInstallationRecipe) types.RecipeVars {
+	vars := types.RecipeVars{}
+
+	c := m.WebServerConfig(webServerRecipeServices[r.Name])
+	if c == nil {
+		return vars
+	}
+
+	if c.StatusURL == "" {
+		log.Warnf("The status page of %s isn't enabled in %s, which its integration reads its metrics from. To enable it, %s.", c.Service, c.ConfigPath, types.WebServerStatusHints[c.Service])
+	}
+
+	for _, name := range []string{"NR_CLI_CONFIG_PATH", "NR_CLI_CONFIG_FILE"} {
+		vars[name] = c.ConfigPath
+	}
+	for _, name := range []string{"NR_CLI_STATUS_URL", "NR_CLI_STATS_URL"} {
+		vars[name] = c.StatusURL
+	}
+
+	return vars
+}
+
+// varsFromGPU returns what discovery found of the NVIDIA GPUs of the host, under the names of the
+// input variables of the NVIDIA GPU integration recipe.
+func varsFromGPU(m types.DiscoveryManifest, r types.OpenInstallationRecipe) types.RecipeVars {
+	vars := types.RecipeVars{}
+
+	gpus := m.GPU.VendorGPUs(types.GPUVendorNVIDIA)
+	if r.Name != types.NVIDIAGPURecipeName || len(gpus) == 0 {
+		return vars
+	}
+
+	vars["NR_CLI_NVIDIA_SMI_PATH"] = m.GPU.NvidiaSMIPath
+	vars["NR_CLI_GPU_COUNT"] = strconv.Itoa(len(gpus))
+	vars["NR_CLI_GPU_DRIVER_VERSION"] = gpus[0].DriverVersion
+
+	return vars
+}
+
+// varsFromJMX returns the host and port of the first JMX endpoint discovered on the host, under the
+// names of the input variables of the JMX integration recipe.
+func varsFromJMX(m types.DiscoveryManifest, r types.OpenInstallationRecipe) types.RecipeVars {
+	vars := types.RecipeVars{}
+
+	if r.Name != types.JMXRecipeName || len(m.JMXEndpoints) == 0 {
+		return vars
+	}
+
+	e := m.JMXEndpoints[0]
+	vars["NR_CLI_JMX_HOST"] = e.Host
+	vars["NR_CLI_JMX_PORT"] = strconv.Itoa(e.Port)
+
+	return vars
+}
+
 func varsFromSystemInfo(m types.DiscoveryManifest) types.RecipeVars {
 	vars := make(types.RecipeVars)
 
@@ -100,52 +244,50 @@ func varsFromSystemInfo(m types.DiscoveryManifest) types.RecipeVars {
 	vars["PLATFORM_FAMILY"] = m.PlatformFamily
 	vars["PLATFORM_VERSION"] = m.PlatformVersion
 	vars["KERNEL_ARCH"] = m.KernelArch
+	vars["ARCH"] = types.NormalizeArch(m.KernelArch)
 	vars["KERNEL_VERSION"] = m.KernelVersion
+	vars["FQDN"] = m.FQDN
+	vars["IP_ADDRESSES"] = strings.Join(m.IPAddresses, ",")
+	vars["PRIMARY_IP"] = ""
+	if len(m.IPAddresses) > 0 {
+		vars["PRIMARY_IP"] = m.IPAddresses[0]
+	}
+	vars["VIRTUALIZATION"] = m.VirtualizationSystem
+	vars["TOTAL_MEMORY_MB"] = ""
+	if m.TotalMemoryBytes > 0 {
+		vars["TOTAL_MEMORY_MB"] = strconv.FormatUint(m.TotalMemoryBytes/(1024*1024), 10)
+	}
+	vars["CPU_COUNT"] = ""
+	if m.CPUs > 0 {
+		vars["CPU_COUNT"] = strconv.Itoa(m.CPUs)
+	}
+
+	if strings.EqualFold(m.OS, "windows") {
+		vars["POWERSHELL"] = powerShellCommand
+	}
+
+	if strings.EqualFold(m.OS, "darwin") {
+		vars["HOMEBREW"] = homebrewIntel
+		if m.KernelArch == "arm64" {
+			vars["HOMEBREW"] = homebrewAppleSilicon
+		}
+	}
 
 	return vars
 }
 
-func varsFromInput(inputVars []types.OpenInstallationRecipeInputVariable, assumeYes bool) (types.RecipeVars, error) {
+func varsFromInput(inputVars []types.OpenInstallationRecipeInputVariable, resolver *RecipeVarResolver, discovered types.RecipeVars, assumeYes bool) (types.RecipeVars, error) {
 	vars := make(types.RecipeVars)
 
 	vars["NEW_RELIC_ASSUME_YES"] = fmt.Sprintf("%t", assumeYes)
 
 	for _, envConfig := range inputVars {
-		var err error
-		envValue := os.Getenv(envConfig.Name)
-
-		if envValue != "" {
-			vars[envConfig.Name] = envValue
-			continue
-		}
-
-		if assumeYes {
-			if envConfig.Default == "" {
-				return types.RecipeVars{}, fmt.Errorf("no default value for environment variable %s and none provided", envConfig.Name)
-			}
-
-			log.WithFields(log.Fields{
-				"name":    envConfig.Name,
-				"default": envConfig.Default,
-			}).Debug("required env var not found, using default")
-
-			envValue = envConfig.Default
-		} else {
-			log.WithFields(log.Fields{
-				"name": envConfig.Name,
-			}).Debug("required environment variable not found")
-
-			envValue, err = varFromPrompt(envConfig)
-			if err != nil {
-				if err == terminal.InterruptErr {
-					return types.RecipeVars{}, types.ErrInterrupt
-				}
-
-				return types.RecipeVars{}, fmt.Errorf("prompt failed: %s", err)
-			}
+		value, err := resolver.Resolve(envConfig, discovered, assumeYes)
+		if err != nil {
+			return types.RecipeVars{}, err
 		}
 
-		vars[envConfig.Name] = envValue
+		vars[envConfig.Name] = value
 	}
 
 	return vars, nil
@@ -222,6 +364,11 @@ func varFromEnv() types.RecipeVars {
 		vars["NEW_RELIC_CLI_TAGS"] = strings.Join(strings.Split(installCustomAttributes, ","), ";")
 	}
 
+	// Make the infrastructure agent proxy-aware when the install itself goes through a proxy.
+	if proxy := httpproxy.FromEnvironment().HTTPSProxy; proxy != "" {
+		vars[EnvNriaProxy] = proxy
+	}
+
 	passthroughEnvironment := os.Getenv(EnvNriaPassthroughEnvironment)
 	if len(passthroughEnvironment) > 0 {
 		vars[EnvNriaPassthroughEnvironment] = yamlFromCommaDelimitedString(EnvNriaPassthroughEnvironment, passthroughEnvironment)
@@ -182,6 +182,119 @@ func TestRecipeVarProvider_UserInputVars(t *testing.T) {
 	require.Contains(t, "123", v["a-default"])
 }
 
+func TestRecipeVarProvider_ResolverVarsOverrideOtherSources(t *testing.T) {
+	t.Setenv("MYSQL_PORT", "3306")
+	e := NewRecipeVarProvider().WithResolver(NewRecipeVarResolver(
+		map[string]string{"MYSQL_PORT": "3307"},
+		map[string]string{"NEW_RELIC_DOWNLOAD_URL": "https://mirror.example.com/"},
+	))
+	r := types.OpenInstallationRecipe{
+		InputVars: []types.OpenInstallationRecipeInputVariable{{Name: "MYSQL_PORT", Prompt: "MySQL port?"}},
+	}
+
+	v, err := e.Prepare(types.DiscoveryManifest{}, r, false)
+
+	require.NoError(t, err)
+	require.Equal(t, "3307", v["MYSQL_PORT"])
+	require.Equal(t, "https://mirror.example.com/", v["NEW_RELIC_DOWNLOAD_URL"])
+}
+
+func TestRecipeVarProvider_DiscoveredDatabaseConfigSkipsPrompt(t *testing.T) {
+	t.Setenv("NR_CLI_DB_USERNAME", "newrelic")
+	e := NewRecipeVarProvider().WithResolver(NewRecipeVarResolver(
+		map[string]string{"NR_CLI_DB_SOCKET": "/tmp/mysql.sock"},
+		nil,
+	))
+	m := types.DiscoveryManifest{
+		Databases: []types.DatabaseConfig{
+			{Service: types.DatabaseServiceMySQL, ConfigPath: "/etc/mysql/my.cnf", Port: "3307", Socket: "/run/mysqld/mysqld.sock"},
+		},
+	}
+	r := types.OpenInstallationRecipe{
+		Name: "mysql-open-source-integration",
+		InputVars: []types.OpenInstallationRecipeInputVariable{
+			{Name: "NR_CLI_DB_USERNAME", Prompt: "MySQL user?"},
+			{Name: "NR_CLI_DB_PORT", Prompt: "MySQL port?"},
+			{Name: "NR_CLI_DB_SOCKET", Prompt: "MySQL socket?"},
+		},
+	}
+
+	v, err := e.Prepare(m, r, false)
+
+	require.NoError(t, err)
+	require.Equal(t, "newrelic", v["NR_CLI_DB_USERNAME"])
+	require.Equal(t, "3307", v["NR_CLI_DB_PORT"])
+	require.Equal(t, "/tmp/mysql.sock", v["NR_CLI_DB_SOCKET"])
+}
+
+func TestRecipeVarProvider_DatabaseConfigOfOtherServicesIsIgnored(t *testing.T) {
+	m := types.DiscoveryManifest{
+		Databases: []types.DatabaseConfig{
+			{Service: types.DatabaseServiceRedis, ConfigPath: "/etc/redis/redis.conf", Port: "6380"},
+		},
+	}
+
+	vars := varsFromDatabaseConfig(m, types.OpenInstallationRecipe{Name: "postgres-open-source-integration"})
+	require.Empty(t, vars)
+
+	vars = varsFromDatabaseConfig(m, types.OpenInstallationRecipe{Name: "redis-open-source-integration"})
+	require.Equal(t, "6380", vars["NR_CLI_PORT"])
+}
+
+func TestRecipeVarProvider_GPUsPrefillNVIDIAIntegration(t *testing.T) {
+	m := types.DiscoveryManifest{
+		GPU: &types.GPUHost{
+			NvidiaSMIPath: "/usr/bin/nvidia-smi",
+			GPUs: []types.GPU{
+				{Vendor: types.GPUVendorNVIDIA, Model: "Tesla T4", DriverVersion: "535.104.05"},
+				{Vendor: types.GPUVendorNVIDIA, Model: "Tesla T4", DriverVersion: "535.104.05"},
+			},
+		},
+	}
+
+	vars := varsFromDiscovery(m, types.OpenInstallationRecipe{Name: types.NVIDIAGPURecipeName})
+	require.Equal(t, "/usr/bin/nvidia-smi", vars["NR_CLI_NVIDIA_SMI_PATH"])
+	require.Equal(t, "2", vars["NR_CLI_GPU_COUNT"])
+	require.Equal(t, "535.104.05", vars["NR_CLI_GPU_DRIVER_VERSION"])
+
+	require.Empty(t, varsFromDiscovery(m, types.OpenInstallationRecipe{Name: "mysql-open-source-integration"}))
+	require.Empty(t, varsFromDiscovery(types.DiscoveryManifest{}, types.OpenInstallationRecipe{Name: types.NVIDIAGPURecipeName}))
+}
+
+func TestRecipeVarProvider_JMXEndpointsPrefillJMXIntegration(t *testing.T) {
+	m := types.DiscoveryManifest{
+		JMXEndpoints: []types.JMXEndpoint{
+			{PID: 1200, Name: "billing.jar", Host: "localhost", Port: 9010},
+			{PID: 1300, Name: "orders.jar", Host: "localhost", Port: 9011},
+		},
+	}
+
+	vars := varsFromDiscovery(m, types.OpenInstallationRecipe{Name: types.JMXRecipeName})
+	require.Equal(t, "localhost", vars["NR_CLI_JMX_HOST"])
+	require.Equal(t, "9010", vars["NR_CLI_JMX_PORT"])
+
+	require.Empty(t, varsFromDiscovery(m, types.OpenInstallationRecipe{Name: "mysql-open-source-integration"}))
+}
+
+func TestRecipeVarProvider_WebServerConfigPrefillsIntegration(t *testing.T) {
+	m := types.DiscoveryManifest{
+		WebServers: []types.WebServerConfig{
+			{Service: types.WebServerNginx, ConfigPath: "/etc/nginx/nginx.conf", StatusURL: "http://127.0.0.1/nginx_status"},
+			{Service: types.WebServerHAProxy, ConfigPath: "/etc/haproxy/haproxy.cfg"},
+		},
+	}
+
+	vars := varsFromDiscovery(m, types.OpenInstallationRecipe{Name: "nginx-open-source-integration"})
+	require.Equal(t, "/etc/nginx/nginx.conf", vars["NR_CLI_CONFIG_PATH"])
+	require.Equal(t, "http://127.0.0.1/nginx_status", vars["NR_CLI_STATUS_URL"])
+
+	vars = varsFromDiscovery(m, types.OpenInstallationRecipe{Name: "haproxy-open-source-integration"})
+	require.Equal(t, "/etc/haproxy/haproxy.cfg", vars["NR_CLI_CONFIG_FILE"])
+	require.Equal(t, "", vars["NR_CLI_STATS_URL"])
+
+	require.Empty(t, varsFromDiscovery(m, types.OpenInstallationRecipe{Name: "apache-open-source-integration"}))
+}
+
 func TestRecipeVarProvider_CommandLineEnvarsDirectlyPassedToRecipeContext(t *testing.T) {
 	e := NewRecipeVarProvider()
 
@@ -327,6 +440,62 @@ func TestRecipeVarProvider_CommandLineTagsPassedFormattedToRecipeContext(t *test
 	assert.Contains(t, v["NEW_RELIC_CLI_TAGS"], expectedCliTags)
 }
 
+func TestRecipeVarProvider_ProxyPassedToInfraAgent(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:8080")
+
+	vars := varFromEnv()
+
+	require.Equal(t, "http://proxy.example.com:8080", vars[EnvNriaProxy])
+}
+
+func TestRecipeVarProvider_ArchFromKernelArch(t *testing.T) {
+	vars := varsFromSystemInfo(types.DiscoveryManifest{OS: "linux", KernelArch: "aarch64"})
+
+	require.Equal(t, "aarch64", vars["KERNEL_ARCH"])
+	require.Equal(t, "arm64", vars["ARCH"])
+}
+
+func TestRecipeVarProvider_PowerShellOnWindows(t *testing.T) {
+	vars := varsFromSystemInfo(types.DiscoveryManifest{OS: "windows"})
+	require.Equal(t, powerShellCommand, vars["POWERSHELL"])
+
+	vars = varsFromSystemInfo(types.DiscoveryManifest{OS: "linux"})
+	require.NotContains(t, vars, "POWERSHELL")
+}
+
+func TestRecipeVarProvider_HomebrewOnDarwin(t *testing.T) {
+	vars := varsFromSystemInfo(types.DiscoveryManifest{OS: "darwin", KernelArch: "arm64"})
+	require.Equal(t, homebrewAppleSilicon, vars["HOMEBREW"])
+
+	vars = varsFromSystemInfo(types.DiscoveryManifest{OS: "darwin", KernelArch: "x86_64"})
+	require.Equal(t, homebrewIntel, vars["HOMEBREW"])
+
+	vars = varsFromSystemInfo(types.DiscoveryManifest{OS: "linux", KernelArch: "arm64"})
+	require.NotContains(t, vars, "HOMEBREW")
+}
+
+func TestRecipeVarProvider_HostDetails(t *testing.T) {
+	vars := varsFromSystemInfo(types.DiscoveryManifest{
+		FQDN:                 "web-1.example.com",
+		IPAddresses:          []string{"10.0.0.5", "172.17.0.1"},
+		VirtualizationSystem: "kvm",
+		TotalMemoryBytes:     8 * 1024 * 1024 * 1024,
+		CPUs:                 4,
+	})
+
+	require.Equal(t, "web-1.example.com", vars["FQDN"])
+	require.Equal(t, "10.0.0.5,172.17.0.1", vars["IP_ADDRESSES"])
+	require.Equal(t, "10.0.0.5", vars["PRIMARY_IP"])
+	require.Equal(t, "kvm", vars["VIRTUALIZATION"])
+	require.Equal(t, "8192", vars["TOTAL_MEMORY_MB"])
+	require.Equal(t, "4", vars["CPU_COUNT"])
+
+	vars = varsFromSystemInfo(types.DiscoveryManifest{})
+	require.Equal(t, "", vars["PRIMARY_IP"])
+	require.Equal(t, "", vars["TOTAL_MEMORY_MB"])
+	require.Equal(t, "", vars["CPU_COUNT"])
+}
+
 func Test_yamlFromJSON_convertsValidJsonToYaml(t *testing.T) {
 	json := "{\"customAttribute_1\":\"SOME_ATTRIBUTE\",\"customAttribute_2\": \"SOME_ATTRIBUTE_2\"}"
 
@@ -0,0 +1,188 @@
+package execution
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ParseRecipeVars parses KEY=VALUE pairs provided with --var.
+func ParseRecipeVars(pairs []string) (map[string]string, error) {
+	recipeVars := map[string]string{}
+
+	for _, p := range pairs {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid recipe variable %q, expected KEY=VALUE", p)
+		}
+
+		recipeVars[strings.TrimSpace(parts[0])] = parts[1]
+	}
+
+	return recipeVars, nil
+}
+
+// RecipeVarResolver resolves the values of recipe variables provided by the user.
+// Sources are used in order of precedence: --var flags, then the --varFile, then the
+// environment, then the values discovered on the host, then prompting, with the recipe's
+// default used last.
+type RecipeVarResolver struct {
+	flagVars map[string]string
+	fileVars map[string]string
+}
+
+func NewRecipeVarResolver(flagVars map[string]string, fileVars map[string]string) *RecipeVarResolver {
+	if flagVars == nil {
+		flagVars = map[string]string{}
+	}
+
+	if fileVars == nil {
+		fileVars = map[string]string{}
+	}
+
+	return &RecipeVarResolver{
+		flagVars: flagVars,
+		fileVars: fileVars,
+	}
+}
+
+// Overrides returns the variables provided by flags and file, which take precedence
+// over any variable the CLI sets itself.
+func (rvr *RecipeVarResolver) Overrides() types.RecipeVars {
+	vars := types.RecipeVars{}
+
+	for k, v := range rvr.fileVars {
+		vars[k] = v
+	}
+
+	for k, v := range rvr.flagVars {
+		vars[k] = v
+	}
+
+	return vars
+}
+
+// Resolve returns the value of a recipe input variable, using the value of discovered of the same
+// name instead of prompting for it.
+func (rvr *RecipeVarResolver) Resolve(inputVar types.OpenInstallationRecipeInputVariable, discovered types.RecipeVars, assumeYes bool) (string, error) {
+	if value, ok := rvr.flagVars[inputVar.Name]; ok {
+		return value, nil
+	}
+
+	if value, ok := rvr.fileVars[inputVar.Name]; ok {
+		return value, nil
+	}
+
+	if value := os.Getenv(inputVar.Name); value != "" {
+		return value, nil
+	}
+
+	if value := discovered[inputVar.Name]; value != "" {
+		log.WithFields(log.Fields{
+			"name":  inputVar.Name,
+			"value": value,
+		}).Debug("using value discovered on the host")
+
+		return value, nil
+	}
+
+	if assumeYes {
+		if inputVar.Default == "" {
+			return "", fmt.Errorf("no default value for environment variable %s and none provided", inputVar.Name)
+		}
+
+		log.WithFields(log.Fields{
+			"name":    inputVar.Name,
+			"default": inputVar.Default,
+		}).Debug("required env var not found, using default")
+
+		return inputVar.Default, nil
+	}
+
+	log.WithFields(log.Fields{
+		"name": inputVar.Name,
+	}).Debug("required environment variable not found")
+
+	value, err := varFromPrompt(inputVar)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			return "", types.ErrInterrupt
+		}
+
+		return "", fmt.Errorf("prompt failed: %s", err)
+	}
+
+	return value, nil
+}
+
+// LoadRecipeVarFile reads recipe variables from a YAML file, when it has a .yml or .yaml
+// extension, or otherwise from an env-style file of KEY=VALUE lines.
+func LoadRecipeVarFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read variable file %s: %w", path, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yml" || ext == ".yaml" {
+		vars := map[string]string{}
+		if err := yaml.Unmarshal(data, &vars); err != nil {
+			return nil, fmt.Errorf("could not parse variable file %s: %w", path, err)
+		}
+
+		return vars, nil
+	}
+
+	return parseEnvFile(path, data)
+}
+
+func parseEnvFile(path string, data []byte) (map[string]string, error) {
+	vars := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		parts := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			return nil, fmt.Errorf("could not parse variable file %s: line %d is not KEY=VALUE", path, lineNumber)
+		}
+
+		vars[key] = unquote(strings.TrimSpace(parts[1]))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read variable file %s: %w", path, err)
+	}
+
+	return vars, nil
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestRecipeVarResolverShouldPreferFlagsOverFileOverEnv(t *testing.T) {
+	t.Setenv("PORT", "1")
+	t.Setenv("USER", "env-user")
+	t.Setenv("HOST", "env-host")
+	r := NewRecipeVarResolver(
+		map[string]string{"PORT": "3"},
+		map[string]string{"PORT": "2", "USER": "file-user"},
+	)
+
+	port, err := r.Resolve(types.OpenInstallationRecipeInputVariable{Name: "PORT"}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, "3", port)
+
+	user, err := r.Resolve(types.OpenInstallationRecipeInputVariable{Name: "USER"}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, "file-user", user)
+
+	host, err := r.Resolve(types.OpenInstallationRecipeInputVariable{Name: "HOST"}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, "env-host", host)
+}
+
+func TestRecipeVarResolverShouldUseDefaultWhenAssumeYes(t *testing.T) {
+	r := NewRecipeVarResolver(nil, nil)
+
+	value, err := r.Resolve(types.OpenInstallationRecipeInputVariable{Name: "SOME_UNSET_VAR", Default: "123"}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, "123", value)
+
+	_, err = r.Resolve(types.OpenInstallationRecipeInputVariable{Name: "SOME_UNSET_VAR"}, nil, true)
+	require.Error(t, err)
+}
+
+func TestLoadRecipeVarFileShouldParseEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vars.env")
+	content := `
+# MySQL settings
+MYSQL_PORT=3306
+export MYSQL_USERNAME="newrelic"
+MYSQL_PASSWORD='p=ss'
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	vars, err := LoadRecipeVarFile(path)
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"MYSQL_PORT":     "3306",
+		"MYSQL_USERNAME": "newrelic",
+		"MYSQL_PASSWORD": "p=ss",
+	}, vars)
+}
+
+func TestLoadRecipeVarFileShouldParseYAMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vars.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("MYSQL_PORT: 3306\nMYSQL_USERNAME: newrelic\n"), 0600))
+
+	vars, err := LoadRecipeVarFile(path)
+
+	require.NoError(t, err)
+	require.Equal(t, "3306", vars["MYSQL_PORT"])
+	require.Equal(t, "newrelic", vars["MYSQL_USERNAME"])
+}
+
+func TestLoadRecipeVarFileShouldFailForInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vars.env")
+	require.NoError(t, os.WriteFile(path, []byte("MYSQL_PORT\n"), 0600))
+
+	_, err := LoadRecipeVarFile(path)
+
+	require.Error(t, err)
+}
+
+func TestParseRecipeVars(t *testing.T) {
+	recipeVars, err := ParseRecipeVars([]string{"MYSQL_PORT=3306", "MYSQL_PASSWORD=a=b"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"MYSQL_PORT": "3306", "MYSQL_PASSWORD": "a=b"}, recipeVars)
+
+	_, err = ParseRecipeVars([]string{"MYSQL_PORT"})
+	require.Error(t, err)
+
+	_, err = ParseRecipeVars([]string{"=3306"})
+	require.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package execution
+
+import (
+	"strconv"
+
+	"github.com/newrelic/newrelic-client-go/v2/pkg/region"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	configAPI "github.com/newrelic/newrelic-cli/internal/config/api"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// infraAgentEndpoints are the endpoints the infrastructure agent reports its data to.
+type infraAgentEndpoints struct {
+	Collector      string
+	Identity       string
+	CommandChannel string
+}
+
+var infraAgentRegionEndpoints = map[region.Name]infraAgentEndpoints{
+	region.US: {
+		Collector:      "https://infra-api.newrelic.com",
+		Identity:       "https://identity-api.newrelic.com",
+		CommandChannel: "https://infrastructure-command-api.newrelic.com",
+	},
+	region.EU: {
+		Collector:      "https://infra-api.eu.newrelic.com",
+		Identity:       "https://identity-api.eu.newrelic.com",
+		CommandChannel: "https://infrastructure-command-api.eu.newrelic.com",
+	},
+	region.Staging: {
+		Collector:      "https://staging-infra-api.newrelic.com",
+		Identity:       "https://staging-identity-api.newrelic.com",
+		CommandChannel: "https://staging-infrastructure-command-api.newrelic.com",
+	},
+}
+
+// ProfileRegion returns the region of the active profile, or US when it doesn't have one.
+func ProfileRegion() region.Name {
+	return parseRegion(configAPI.GetActiveProfileString(config.Region))
+}
+
+func parseRegion(r string) region.Name {
+	name, err := region.Parse(r)
+	if err != nil {
+		return region.US
+	}
+
+	return name
+}
+
+// varsFromRegion returns the region recipes install for, and the endpoints of the region the
+// infrastructure agent is configured to report to.
+func varsFromRegion(r string) types.RecipeVars {
+	name := parseRegion(r)
+	endpoints, ok := infraAgentRegionEndpoints[name]
+	if !ok {
+		endpoints = infraAgentRegionEndpoints[region.US]
+	}
+
+	return types.RecipeVars{
+		"NEW_RELIC_REGION":         name.String(),
+		"NRIA_COLLECTOR_URL":       endpoints.Collector,
+		"NRIA_IDENTITY_URL":        endpoints.Identity,
+		"NRIA_COMMAND_CHANNEL_URL": endpoints.CommandChannel,
+		"NRIA_STAGING":             strconv.FormatBool(name == region.Staging),
+	}
+}
@@ -0,0 +1,29 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarsFromRegionShouldUseRegionEndpoints(t *testing.T) {
+	vars := varsFromRegion("eu")
+
+	assert.Equal(t, "EU", vars["NEW_RELIC_REGION"])
+	assert.Equal(t, "https://infra-api.eu.newrelic.com", vars["NRIA_COLLECTOR_URL"])
+	assert.Equal(t, "https://identity-api.eu.newrelic.com", vars["NRIA_IDENTITY_URL"])
+	assert.Equal(t, "false", vars["NRIA_STAGING"])
+
+	vars = varsFromRegion("Staging")
+
+	assert.Equal(t, "Staging", vars["NEW_RELIC_REGION"])
+	assert.Equal(t, "https://staging-infra-api.newrelic.com", vars["NRIA_COLLECTOR_URL"])
+	assert.Equal(t, "true", vars["NRIA_STAGING"])
+}
+
+func TestVarsFromRegionShouldDefaultToUS(t *testing.T) {
+	vars := varsFromRegion("")
+
+	assert.Equal(t, "US", vars["NEW_RELIC_REGION"])
+	assert.Equal(t, "https://infra-api.newrelic.com", vars["NRIA_COLLECTOR_URL"])
+}
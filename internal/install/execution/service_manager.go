@@ -0,0 +1,25 @@
+package execution
+
+import (
+	"context"
+	"time"
+)
+
+// ServiceManager ensures an agent's service is running on this host, so it can be validated after
+// its recipe installs it.
+type ServiceManager interface {
+	EnsureRunning(ctx context.Context, timeout time.Duration) error
+}
+
+// NewInfraAgentService returns the manager of the infrastructure agent's service on goos, or nil
+// where the recipe leaves the agent running itself.
+func NewInfraAgentService(goos string) ServiceManager {
+	switch goos {
+	case "windows":
+		return NewWindowsService(InfraAgentWindowsService)
+	case "darwin":
+		return NewLaunchdService(InfraAgentLaunchdService)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SlackStatusReporter sends a message with the outcome of the install to a Slack incoming webhook
+// once the install completes or is canceled.
+type SlackStatusReporter struct {
+	*WebhookStatusReporter
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func NewSlackStatusReporter(url string) *SlackStatusReporter {
+	r := SlackStatusReporter{
+		WebhookStatusReporter: NewWebhookStatusReporter(url, ""),
+	}
+
+	return &r
+}
+
+func (r *SlackStatusReporter) InstallComplete(status *InstallStatus) error {
+	return r.notify(status, false)
+}
+
+func (r *SlackStatusReporter) InstallCanceled(status *InstallStatus) error {
+	return r.notify(status, true)
+}
+
+func (r *SlackStatusReporter) notify(status *InstallStatus, canceled bool) error {
+	body, err := json.Marshal(slackMessage{Text: slackMessageText(r.buildStatusFile(status), status.DiscoveryManifest.Hostname, canceled)})
+	if err != nil {
+		return err
+	}
+
+	return r.send(body)
+}
+
+func slackMessageText(sf *StatusFile, hostname string, canceled bool) string {
+	if hostname == "" {
+		hostname = "unknown host"
+	}
+
+	installed := []string{}
+	failed := []string{}
+	for _, rs := range sf.Recipes {
+		switch rs.Status {
+		case RecipeStatusTypes.INSTALLED:
+			installed = append(installed, rs.DisplayName)
+		case RecipeStatusTypes.FAILED:
+			failed = append(failed, rs.DisplayName)
+		}
+	}
+
+	lines := []string{}
+	switch {
+	case canceled:
+		lines = append(lines, fmt.Sprintf(":warning: New Relic install was canceled on *%s*", hostname))
+	case sf.Success:
+		lines = append(lines, fmt.Sprintf(":white_check_mark: New Relic install succeeded on *%s*", hostname))
+	default:
+		lines = append(lines, fmt.Sprintf(":x: New Relic install failed on *%s*", hostname))
+	}
+
+	if len(installed) > 0 {
+		lines = append(lines, "Installed: "+strings.Join(installed, ", "))
+	}
+	if len(failed) > 0 {
+		lines = append(lines, "Failed: "+strings.Join(failed, ", "))
+	}
+	if sf.Error != "" {
+		lines = append(lines, "Error: "+sf.Error)
+	}
+
+	return strings.Join(lines, "\n")
+}
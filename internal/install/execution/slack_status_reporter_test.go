@@ -0,0 +1,41 @@
+package execution
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestSlackStatusReporterShouldSendFailedRecipes(t *testing.T) {
+	message := slackMessage{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		require.NoError(t, json.Unmarshal(body, &message))
+	}))
+	defer server.Close()
+
+	r := NewSlackStatusReporter(server.URL)
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{r}, NewPlatformLinkGenerator())
+	installed := types.OpenInstallationRecipe{Name: "infra", DisplayName: "Infrastructure Agent"}
+	failed := types.OpenInstallationRecipe{Name: "mysql", DisplayName: "MySQL Integration"}
+
+	s.DiscoveryComplete(types.DiscoveryManifest{Hostname: "web-1"})
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: installed})
+	s.RecipeFailed(RecipeStatusEvent{Recipe: failed, Msg: "execution failed"})
+	s.InstallComplete(nil)
+
+	require.Equal(t, ":x: New Relic install failed on *web-1*\nInstalled: Infrastructure Agent\nFailed: MySQL Integration\nError: execution failed", message.Text)
+}
+
+func TestSlackMessageTextShouldReportSuccessAndCancellation(t *testing.T) {
+	sf := &StatusFile{Success: true, Recipes: []*StatusFileRecipe{{DisplayName: "Infrastructure Agent", Status: RecipeStatusTypes.INSTALLED}}}
+
+	require.Equal(t, ":white_check_mark: New Relic install succeeded on *web-1*\nInstalled: Infrastructure Agent", slackMessageText(sf, "web-1", false))
+	require.Equal(t, ":warning: New Relic install was canceled on *unknown host*", slackMessageText(&StatusFile{}, "", true))
+}
@@ -0,0 +1,390 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/go-task/task/v3/taskfile"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const sshDefaultTask = "default"
+
+// SSHRecipeExecutor is an implementation of the recipeExecutor interface that runs the
+// tasks of each recipe on a remote host over SSH. The tasks are run in the order go-task
+// would run them, with each command run by the remote host's shell.
+type SSHRecipeExecutor struct {
+	Stdout       io.Writer
+	OutputLog    io.Writer
+	Output       *OutputParser
+	RecipeOutput []string
+	client       remote.Client
+}
+
+// NewSSHRecipeExecutor returns a new instance of SSHRecipeExecutor.
+func NewSSHRecipeExecutor(client remote.Client) *SSHRecipeExecutor {
+	return &SSHRecipeExecutor{
+		Stdout:       os.Stdout,
+		OutputLog:    config.InstallLogWriter(),
+		Output:       NewOutputParser(map[string]interface{}{}),
+		RecipeOutput: []string{},
+		client:       client,
+	}
+}
+
+// sshTaskRun is the state of running one recipe's tasks on the remote host.
+type sshTaskRun struct {
+	ctx      context.Context
+	client   remote.Client
+	taskfile *taskfile.Taskfile
+	env      types.RecipeVars
+	stdout   *LineCaptureBuffer
+	stderr   *LineCaptureBuffer
+}
+
+func (re *SSHRecipeExecutor) ExecutePreInstall(ctx context.Context, r types.OpenInstallationRecipe, recipeVars types.RecipeVars) error {
+	if r.PreInstall.RequireAtDiscovery == "" {
+		return nil
+	}
+
+	stderr := NewLineCaptureBuffer(&bytes.Buffer{})
+	script := exportScript(recipeVars) + r.PreInstall.RequireAtDiscovery
+	if err := re.client.Run(ctx, script, ioutil.Discard, stderr); err != nil {
+		return fmt.Errorf("%w: %s", err, stderr.LastFullLine)
+	}
+
+	return nil
+}
+
+func (re *SSHRecipeExecutor) Execute(ctx context.Context, r types.OpenInstallationRecipe, recipeVars types.RecipeVars) error {
+	log.Debugf("executing recipe %s on %s", r.Name, re.client.Target())
+
+	tf := &taskfile.Taskfile{}
+	if err := yaml.Unmarshal([]byte(r.Install), tf); err != nil {
+		return fmt.Errorf("could not unmarshal taskfile: %s", err)
+	}
+
+	// The output file is created by the remote host, so it's unique there and only readable by
+	// its user.
+	out, err := remote.Output(ctx, re.client, fmt.Sprintf("mktemp %s", remote.ShellQuote(fmt.Sprintf("/tmp/newrelic-cli-%s-out.XXXXXXXX", r.Name))))
+	if err != nil {
+		return fmt.Errorf("could not prepare %s: %w", re.client.Target(), err)
+	}
+	outputFile := strings.TrimSpace(out)
+	defer re.readOutput(ctx, outputFile)
+
+	vars := types.RecipeVars{}
+	for k, v := range recipeVars {
+		vars[k] = v
+	}
+	vars["NR_CLI_OUTPUT"] = outputFile
+
+	outputLog := re.OutputLog
+	if outputLog == nil {
+		outputLog = ioutil.Discard
+	}
+
+	silentInstall := vars["assumeYes"] == "true"
+	var run *sshTaskRun
+	if silentInstall {
+		run = &sshTaskRun{
+			stdout: NewLineCaptureBuffer(io.MultiWriter(&bytes.Buffer{}, outputLog)),
+			stderr: NewLineCaptureBuffer(io.MultiWriter(&bytes.Buffer{}, outputLog)),
+		}
+	} else {
+		buffer := NewLineCaptureBuffer(io.MultiWriter(re.Stdout, outputLog))
+		run = &sshTaskRun{stdout: buffer, stderr: buffer}
+	}
+	run.ctx = ctx
+	run.client = re.client
+	run.taskfile = tf
+	run.env = vars
+
+	globals, err := run.resolveVars(tf.Vars, map[string]string{})
+	if err != nil {
+		return types.NewGoTaskGeneralError(err)
+	}
+
+	// Recipe variables take precedence over the variables of the recipe's taskfile.
+	for k, v := range vars {
+		globals[k] = v
+	}
+
+	if err = run.runTask(sshDefaultTask, globals); err != nil {
+		re.RecipeOutput = run.stdout.GetFullRecipeOutput()
+
+		if errors.Is(err, context.Canceled) {
+			return types.ErrInterrupt
+		}
+
+		if isExitStatusCode(130, err) {
+			return types.ErrInterrupt
+		}
+
+		if isExitStatusCode(131, err) {
+			return &types.UnsupportedOperatingSystemError{
+				Err: errors.New(run.stderr.LastFullLine),
+			}
+		}
+
+		goTaskError := types.NewGoTaskGeneralError(err)
+		if strings.Contains(err.Error(), "exit status") {
+			return types.NewNonZeroExitCode(goTaskError, run.stderr.LastFullLine)
+		}
+
+		return goTaskError
+	}
+
+	return nil
+}
+
+// runTask runs a task's dependencies, then its commands, unless its status commands report it's up to date.
+func (run *sshTaskRun) runTask(name string, callVars map[string]string) error {
+	t, ok := run.taskfile.Tasks[name]
+	if !ok {
+		return fmt.Errorf(`task: Task "%s" not found`, name)
+	}
+
+	vars, err := run.resolveVars(t.Vars, callVars)
+	if err != nil {
+		return taskFailed(name, err)
+	}
+
+	for _, p := range t.Preconditions {
+		if err = run.runCommand(p.Sh, t, vars, ioutil.Discard); err != nil {
+			return taskFailed(name, fmt.Errorf("task: precondition not met: %s", p.Msg))
+		}
+	}
+
+	if len(t.Status) > 0 && run.isUpToDate(t, vars) {
+		log.Debugf("task %s is up to date", name)
+		return nil
+	}
+
+	for _, d := range t.Deps {
+		depVars, err := run.resolveVars(d.Vars, vars)
+		if err != nil {
+			return taskFailed(name, err)
+		}
+
+		if err = run.runTask(d.Task, depVars); err != nil {
+			return taskFailed(name, err)
+		}
+	}
+
+	deferred := []*taskfile.Cmd{}
+	defer func() {
+		for i := len(deferred) - 1; i >= 0; i-- {
+			_ = run.runCmd(deferred[i], t, vars)
+		}
+	}()
+
+	for _, c := range t.Cmds {
+		if c.Defer {
+			deferred = append(deferred, c)
+			continue
+		}
+
+		if err = run.runCmd(c, t, vars); err != nil {
+			if c.IgnoreError || t.IgnoreError {
+				log.Debugf("ignoring error of task %s: %s", name, err)
+				continue
+			}
+
+			return taskFailed(name, err)
+		}
+	}
+
+	return nil
+}
+
+func (run *sshTaskRun) runCmd(c *taskfile.Cmd, t *taskfile.Task, vars map[string]string) error {
+	if c.Task != "" {
+		callVars, err := run.resolveVars(c.Vars, vars)
+		if err != nil {
+			return err
+		}
+
+		return run.runTask(c.Task, callVars)
+	}
+
+	return run.runCommand(c.Cmd, t, vars, nil)
+}
+
+// runCommand renders a command with the task's variables and runs it on the remote host, with the
+// task's environment and directory.
+func (run *sshTaskRun) runCommand(cmd string, t *taskfile.Task, vars map[string]string, stdout io.Writer) error {
+	rendered, err := renderTemplate(cmd, vars)
+	if err != nil {
+		return err
+	}
+
+	env := types.RecipeVars{}
+	for k, v := range run.env {
+		env[k] = v
+	}
+
+	for _, envVars := range []*taskfile.Vars{run.taskfile.Env, t.Env} {
+		err = envVars.Range(func(key string, v taskfile.Var) error {
+			env[key], err = run.resolveVar(v, vars)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	script := exportScript(env)
+	if t.Dir != "" {
+		dir, err := renderTemplate(t.Dir, vars)
+		if err != nil {
+			return err
+		}
+		script += fmt.Sprintf("cd %s || exit 1\n", remote.ShellQuote(dir))
+	}
+	script += rendered + "\n"
+
+	if stdout == nil {
+		stdout = run.stdout
+	}
+
+	return run.client.Run(run.ctx, script, stdout, run.stderr)
+}
+
+func (run *sshTaskRun) isUpToDate(t *taskfile.Task, vars map[string]string) bool {
+	for _, status := range t.Status {
+		if err := run.runCommand(status, t, vars, ioutil.Discard); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolveVars returns the given variables rendered against, and merged over, the variables in scope.
+// Dynamic variables are evaluated on the remote host.
+func (run *sshTaskRun) resolveVars(vs *taskfile.Vars, scope map[string]string) (map[string]string, error) {
+	resolved := map[string]string{}
+	for k, v := range scope {
+		resolved[k] = v
+	}
+
+	err := vs.Range(func(key string, v taskfile.Var) error {
+		value, err := run.resolveVar(v, resolved)
+		resolved[key] = value
+		return err
+	})
+
+	return resolved, err
+}
+
+func (run *sshTaskRun) resolveVar(v taskfile.Var, scope map[string]string) (string, error) {
+	if v.Sh == "" {
+		return renderTemplate(v.Static, scope)
+	}
+
+	sh, err := renderTemplate(v.Sh, scope)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := remote.Output(run.ctx, run.client, exportScript(run.env)+sh)
+	if err != nil {
+		return "", fmt.Errorf(`task: Command "%s" failed: %w`, sh, err)
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+func (re *SSHRecipeExecutor) readOutput(ctx context.Context, outputFile string) {
+	out, err := remote.Output(ctx, re.client, fmt.Sprintf("cat %[1]s; rm -f %[1]s", remote.ShellQuote(outputFile)))
+	if err != nil {
+		log.Debugf("error reading output file %s from %s: %s", outputFile, re.client.Target(), err)
+		return
+	}
+
+	re.Output = parseOutputLines([]byte(out))
+}
+
+func (re *SSHRecipeExecutor) GetOutput() *OutputParser {
+	return re.Output
+}
+
+func (re *SSHRecipeExecutor) GetRecipeOutput() []string {
+	return re.RecipeOutput
+}
+
+func renderTemplate(text string, vars map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	funcs := sprig.TxtFuncMap()
+	funcs["OS"] = func() string { return vars["OS"] }
+	funcs["ARCH"] = func() string { return types.NormalizeArch(vars["KERNEL_ARCH"]) }
+
+	tpl, err := template.New("").Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err = tpl.Execute(&b, vars); err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(b.String(), "<no value>", ""), nil
+}
+
+func taskFailed(name string, err error) error {
+	return fmt.Errorf(`task: Failed to run task "%s": %w`, name, err)
+}
+
+// exportScript exports the variables in the remote shell, since they can't be passed to it as the
+// environment of a local process.
+func exportScript(vars types.RecipeVars) string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		if isShellName(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "export %s=%s\n", k, remote.ShellQuote(vars[k]))
+	}
+
+	return b.String()
+}
+
+func isShellName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
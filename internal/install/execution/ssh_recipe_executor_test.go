@@ -0,0 +1,211 @@
+//go:build unit
+// +build unit
+
+package execution
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// localShellClient runs the "remote" scripts with the local shell.
+type localShellClient struct {
+	scripts []string
+}
+
+func (c *localShellClient) Target() remote.Target {
+	return remote.Target{User: "root", Host: "localhost", Port: 22}
+}
+
+func (c *localShellClient) Run(ctx context.Context, script string, stdout io.Writer, stderr io.Writer) error {
+	c.scripts = append(c.scripts, script)
+	cmd := exec.CommandContext(ctx, "sh", "-s")
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+func TestSSHRecipeExecutorShouldRunTasksInOrder(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+vars:
+  GREETING: hello
+tasks:
+  default:
+    cmds:
+      - task: setup
+      - task: greet
+        vars:
+          NAME: remote
+  setup:
+    deps: [check]
+    cmds:
+      - echo setup {{.TEST_VAR}}
+  check:
+    cmds:
+      - echo check $TEST_VAR
+  greet:
+    vars:
+      UPPER:
+        sh: echo {{.NAME}} | tr a-z A-Z
+    cmds:
+      - echo {{.GREETING}} {{.NAME}} {{.UPPER}}
+`,
+	}
+
+	e := NewSSHRecipeExecutor(&localShellClient{})
+	b := &bytes.Buffer{}
+	e.Stdout = b
+	err := e.Execute(context.Background(), r, types.RecipeVars{"TEST_VAR": "testValue"})
+
+	require.NoError(t, err)
+	require.Equal(t, "check testValue\nsetup testValue\nhello remote REMOTE\n", b.String())
+}
+
+func TestSSHRecipeExecutorShouldSkipTasksThatAreUpToDate(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    status:
+      - test "{{.INSTALLED}}" = "true"
+    cmds:
+      - echo installing
+`,
+	}
+
+	e := NewSSHRecipeExecutor(&localShellClient{})
+	b := &bytes.Buffer{}
+	e.Stdout = b
+	err := e.Execute(context.Background(), r, types.RecipeVars{"INSTALLED": "true"})
+
+	require.NoError(t, err)
+	require.Empty(t, b.String())
+}
+
+func TestSSHRecipeExecutorShouldReadRecipeOutput(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - echo '{"EntityGuid":"abcd"}' >> {{.NR_CLI_OUTPUT}}
+      - echo {{.NR_CLI_OUTPUT}}
+`,
+	}
+
+	e := NewSSHRecipeExecutor(&localShellClient{})
+	b := &bytes.Buffer{}
+	e.Stdout = b
+	err := e.Execute(context.Background(), r, types.RecipeVars{})
+
+	require.NoError(t, err)
+	require.Equal(t, "abcd", e.GetOutput().EntityGUID())
+
+	// The output file is a temporary file of the remote host, removed once read.
+	outputFile := strings.TrimSpace(b.String())
+	require.Regexp(t, `^/tmp/newrelic-cli-test-recipe-out\.[^/]{8}$`, outputFile)
+	require.NoFileExists(t, outputFile)
+}
+
+func TestSSHRecipeExecutorShouldRenderArchOfRemoteHost(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - echo {{OS}} {{ARCH}}
+`,
+	}
+
+	e := NewSSHRecipeExecutor(&localShellClient{})
+	b := &bytes.Buffer{}
+	e.Stdout = b
+	err := e.Execute(context.Background(), r, types.RecipeVars{"OS": "linux", "KERNEL_ARCH": "aarch64"})
+
+	require.NoError(t, err)
+	require.Equal(t, "linux arm64\n", b.String())
+}
+
+func TestSSHRecipeExecutorShouldReturnLastError(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - task: install
+  install:
+    cmds:
+      - |
+        echo something went wrong >&2
+        exit 1
+`,
+	}
+
+	e := NewSSHRecipeExecutor(&localShellClient{})
+	e.Stdout = &bytes.Buffer{}
+	err := e.Execute(context.Background(), r, types.RecipeVars{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "something went wrong")
+	goTaskErr, ok := err.(types.GoTaskError)
+	require.True(t, ok)
+	require.Equal(t, []string{"default", "install"}, goTaskErr.TaskPath())
+}
+
+func TestSSHRecipeExecutorShouldReturnUnsupportedOperatingSystem(t *testing.T) {
+	r := types.OpenInstallationRecipe{
+		Name: "test-recipe",
+		Install: `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - |
+        echo this system is not supported >&2
+        exit 131
+`,
+	}
+
+	e := NewSSHRecipeExecutor(&localShellClient{})
+	e.Stdout = &bytes.Buffer{}
+	err := e.Execute(context.Background(), r, types.RecipeVars{})
+
+	_, ok := err.(*types.UnsupportedOperatingSystemError)
+	require.True(t, ok)
+}
+
+func TestSSHRecipeExecutorShouldRunPreInstallOnRemoteHost(t *testing.T) {
+	c := remote.NewMockClient()
+	r := types.OpenInstallationRecipe{
+		PreInstall: types.OpenInstallationPreInstallConfiguration{
+			RequireAtDiscovery: "test -f /etc/mysql/my.cnf",
+		},
+	}
+
+	err := NewSSHRecipeExecutor(c).ExecutePreInstall(context.Background(), r, types.RecipeVars{})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, c.RunCallCount)
+	require.Contains(t, c.Scripts[0], "test -f /etc/mysql/my.cnf")
+}
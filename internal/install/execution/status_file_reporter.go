@@ -0,0 +1,193 @@
+package execution
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// StatusFileReporter writes a machine readable record of the install to a JSON file
+// once the install completes or is canceled.
+type StatusFileReporter struct {
+	filePath   string
+	startTimes map[string]time.Time
+	durations  map[string]int64
+	mu         sync.Mutex
+}
+
+// StatusFile is the content written by the StatusFileReporter.
+type StatusFile struct {
+	InstallID string              `json:"installId"`
+	Complete  bool                `json:"complete"`
+	Success   bool                `json:"success"`
+	Error     string              `json:"error,omitempty"`
+	Recipes   []*StatusFileRecipe `json:"recipes"`
+	// ExcludedLogFiles are the discovered log files that were excluded from forwarding.
+	ExcludedLogFiles []string `json:"excludedLogFiles,omitempty"`
+}
+
+type StatusFileRecipe struct {
+	Name        string           `json:"name"`
+	DisplayName string           `json:"displayName"`
+	Status      RecipeStatusType `json:"status"`
+	Error       string           `json:"error,omitempty"`
+	EntityGUID  string           `json:"entityGuid,omitempty"`
+	DurationMs  int64            `json:"durationMs"`
+	// AlreadyInstalled is set when the recipe was found installed on the host, and wasn't run.
+	AlreadyInstalled bool `json:"alreadyInstalled,omitempty"`
+	// Upgraded is set when the recipe was already installed, and upgraded with its upgrade steps.
+	Upgraded bool `json:"upgraded,omitempty"`
+}
+
+func NewStatusFileReporter(filePath string) *StatusFileReporter {
+	r := StatusFileReporter{
+		filePath:   filePath,
+		startTimes: map[string]time.Time{},
+		durations:  map[string]int64{},
+	}
+
+	return &r
+}
+
+func (r *StatusFileReporter) RecipeDetected(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeFailed(status *InstallStatus, event RecipeStatusEvent) error {
+	r.recipeFinished(event)
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeInstalling(status *InstallStatus, event RecipeStatusEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Retried attempts are reported as installing again, keep timing from the first one.
+	if _, ok := r.startTimes[event.Recipe.Name]; !ok {
+		r.startTimes[event.Recipe.Name] = time.Now()
+	}
+
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeInstalled(status *InstallStatus, event RecipeStatusEvent) error {
+	r.recipeFinished(event)
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeSkipped(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeRecommended(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *StatusFileReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeAvailable(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeCanceled(status *InstallStatus, event RecipeStatusEvent) error {
+	r.recipeFinished(event)
+	return nil
+}
+
+func (r *StatusFileReporter) RecipeUnsupported(status *InstallStatus, event RecipeStatusEvent) error {
+	return nil
+}
+
+func (r *StatusFileReporter) InstallStarted(status *InstallStatus) error {
+	return nil
+}
+
+func (r *StatusFileReporter) InstallComplete(status *InstallStatus) error {
+	return r.writeStatusFile(status)
+}
+
+func (r *StatusFileReporter) InstallCanceled(status *InstallStatus) error {
+	return r.writeStatusFile(status)
+}
+
+func (r *StatusFileReporter) DiscoveryComplete(status *InstallStatus, dm types.DiscoveryManifest) error {
+	return nil
+}
+
+func (r *StatusFileReporter) UpdateRequired(status *InstallStatus) error {
+	return nil
+}
+
+func (r *StatusFileReporter) recipeFinished(event RecipeStatusEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if start, ok := r.startTimes[event.Recipe.Name]; ok {
+		r.durations[event.Recipe.Name] = time.Since(start).Milliseconds()
+	}
+}
+
+// buildStatusFile includes every recipe the installer attempted, i.e. any recipe
+// that was not only detected, recommended or found unsupported.
+func (r *StatusFileReporter) buildStatusFile(status *InstallStatus) *StatusFile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sf := &StatusFile{
+		InstallID:        status.InstallID,
+		Complete:         status.Complete,
+		Error:            status.Error.Message,
+		Recipes:          []*StatusFileRecipe{},
+		ExcludedLogFiles: status.ExcludedLogFiles,
+	}
+
+	for _, rs := range status.Statuses {
+		switch rs.Status {
+		case RecipeStatusTypes.INSTALLED, RecipeStatusTypes.FAILED, RecipeStatusTypes.CANCELED, RecipeStatusTypes.SKIPPED:
+		default:
+			continue
+		}
+
+		sf.Recipes = append(sf.Recipes, &StatusFileRecipe{
+			Name:             rs.Name,
+			DisplayName:      rs.DisplayName,
+			Status:           rs.Status,
+			Error:            rs.Error.Message,
+			EntityGUID:       rs.EntityGUID,
+			DurationMs:       r.durations[rs.Name],
+			AlreadyInstalled: rs.AlreadyInstalled,
+			Upgraded:         rs.Upgraded,
+		})
+	}
+
+	sf.Success = status.Complete && sf.Error == "" && status.hasAnyRecipeStatus(RecipeStatusTypes.INSTALLED) &&
+		!status.hasAnyRecipeStatus(RecipeStatusTypes.FAILED)
+
+	return sf
+}
+
+func (r *StatusFileReporter) writeStatusFile(status *InstallStatus) error {
+	data, err := json.MarshalIndent(r.buildStatusFile(status), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0750); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(r.filePath, data, 0600); err != nil {
+		log.Debugf("could not write status file %s: %s", r.filePath, err)
+		return err
+	}
+
+	return nil
+}
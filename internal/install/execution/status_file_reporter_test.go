@@ -0,0 +1,59 @@
+package execution
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestStatusFileReporterShouldWriteAttemptedRecipes(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "status.json")
+	r := NewStatusFileReporter(filePath)
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{r}, NewPlatformLinkGenerator())
+	installed := types.OpenInstallationRecipe{Name: "installed", DisplayName: "Installed"}
+	failed := types.OpenInstallationRecipe{Name: "failed", DisplayName: "Failed"}
+
+	s.RecipeDetected(RecipeStatusEvent{Recipe: types.OpenInstallationRecipe{Name: "detected-only"}})
+	s.RecipeInstalling(RecipeStatusEvent{Recipe: installed})
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: installed, EntityGUID: "abc123"})
+	s.RecipeInstalling(RecipeStatusEvent{Recipe: failed})
+	s.RecipeFailed(RecipeStatusEvent{Recipe: failed, Msg: "execution failed"})
+	s.InstallComplete(nil)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	sf := StatusFile{}
+	require.NoError(t, json.Unmarshal(data, &sf))
+	require.Equal(t, s.InstallID, sf.InstallID)
+	require.True(t, sf.Complete)
+	require.False(t, sf.Success)
+	require.Equal(t, 2, len(sf.Recipes))
+	require.Equal(t, "installed", sf.Recipes[0].Name)
+	require.Equal(t, RecipeStatusTypes.INSTALLED, sf.Recipes[0].Status)
+	require.Equal(t, "abc123", sf.Recipes[0].EntityGUID)
+	require.Equal(t, "failed", sf.Recipes[1].Name)
+	require.Equal(t, RecipeStatusTypes.FAILED, sf.Recipes[1].Status)
+	require.Equal(t, "execution failed", sf.Recipes[1].Error)
+}
+
+func TestStatusFileReporterShouldWriteExcludedLogFiles(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "status.json")
+	r := NewStatusFileReporter(filePath)
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{r}, NewPlatformLinkGenerator())
+	s.ExcludedLogFiles = []string{"/var/log/secure"}
+
+	s.InstallComplete(nil)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	sf := StatusFile{}
+	require.NoError(t, json.Unmarshal(data, &sf))
+	require.Equal(t, []string{"/var/log/secure"}, sf.ExcludedLogFiles)
+}
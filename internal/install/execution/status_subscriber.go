@@ -29,6 +29,10 @@ type RecipeStatusEvent struct {
 	EntityGUID           string
 	ValidationDurationMs int64
 	Metadata             map[string]string
+	// AlreadyInstalled is set when an installed recipe was found on the host instead of being run.
+	AlreadyInstalled bool
+	// Upgraded is set when an installed recipe was upgraded with its upgrade steps.
+	Upgraded bool
 }
 
 func NewRecipeStatusEvent(recipe *types.OpenInstallationRecipe) RecipeStatusEvent {
@@ -5,10 +5,14 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/newrelic/newrelic-cli/internal/install/i18n"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/install/ux"
 )
@@ -47,8 +51,12 @@ func (r TerminalStatusReporter) RecipeRecommended(status *InstallStatus, event R
 }
 
 func (r TerminalStatusReporter) RecipesSelected(status *InstallStatus, recipes []types.OpenInstallationRecipe) error {
+	if ux.QuietMode {
+		return nil
+	}
+
 	if len(recipes) > 0 {
-		fmt.Println("The following will be installed:")
+		fmt.Println(i18n.T("The following will be installed:"))
 	}
 
 	for _, r := range recipes {
@@ -83,7 +91,7 @@ func (r TerminalStatusReporter) InstallStarted(status *InstallStatus) error {
 func (r TerminalStatusReporter) InstallComplete(status *InstallStatus) error {
 	linkToData := ""
 	if status.PlatformLinkGenerator != nil {
-		linkToData = status.PlatformLinkGenerator.GenerateRedirectURL(*status)
+		linkToData = status.PlatformLinkGenerator.GenerateRedirectURL(status)
 	}
 
 	hasStatuses := len(status.Statuses) > 0
@@ -91,20 +99,20 @@ func (r TerminalStatusReporter) InstallComplete(status *InstallStatus) error {
 		hasInstalledRecipes := status.hasAnyRecipeStatus(RecipeStatusTypes.INSTALLED)
 
 		if hasInstalledRecipes {
-			fmt.Print("\n  New Relic installation complete \n\n")
+			fmt.Printf("\n  %s \n\n", i18n.T("New Relic installation complete"))
 		}
 
 		fmt.Println("  --------------------")
-		fmt.Println("  Installation Summary")
+		fmt.Printf("  %s\n", i18n.T("Installation Summary"))
 		fmt.Println("")
 		r.printInstallationSummary(os.Stdout, status)
 
-		msg := "View your data at the link below:\n"
-		followInstructionsMsg := "Follow the instructions at the URL below to complete the installation process."
+		msg := i18n.T("View your data at the link below:") + "\n"
+		followInstructionsMsg := i18n.T("Follow the instructions at the URL below to complete the installation process.")
 		if hasInstalledRecipes && (status.hasAnyRecipeStatus(RecipeStatusTypes.FAILED) || status.hasAnyRecipeStatus(RecipeStatusTypes.UNSUPPORTED)) {
-			msg = fmt.Sprintf("Installation was successful overall, however, one or more installations could not be completed.\n  %s \n\n", followInstructionsMsg)
+			msg = fmt.Sprintf("%s\n  %s \n\n", i18n.T("Installation was successful overall, however, one or more installations could not be completed."), followInstructionsMsg)
 		} else if !hasInstalledRecipes {
-			msg = fmt.Sprintf("Installation incomplete. %s \n\n", followInstructionsMsg)
+			msg = fmt.Sprintf("%s %s \n\n", i18n.T("Installation incomplete."), followInstructionsMsg)
 		}
 
 		if linkToData != "" {
@@ -124,9 +132,16 @@ func (r TerminalStatusReporter) InstallComplete(status *InstallStatus) error {
 
 func (r TerminalStatusReporter) InstallCanceled(status *InstallStatus) error {
 	fmt.Print("\n\n")
-	fmt.Println("  Installation canceled.")
-	fmt.Println("  To finish your installation please use New Relic's installation wizard using the following link.")
-	fmt.Printf("  %s  %s", color.GreenString(ux.IconArrowRight), status.PlatformLinkGenerator.GenerateRedirectURL(*status))
+	fmt.Printf("  %s\n", i18n.T("Installation canceled."))
+	if len(status.Installed) > 0 {
+		fmt.Printf("  %s\n", i18n.T("Installed before the install was canceled:"))
+		for _, s := range status.Installed {
+			fmt.Printf("    %s  %s\n", StatusIcon(RecipeStatusTypes.INSTALLED), s.DisplayName)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("  %s\n", i18n.T("To finish your installation please use New Relic's installation wizard using the following link."))
+	fmt.Printf("  %s  %s", color.GreenString(ux.IconArrowRight), status.PlatformLinkGenerator.GenerateRedirectURL(status))
 	fmt.Print("\n\n")
 
 	return nil
@@ -146,7 +161,7 @@ func (r TerminalStatusReporter) UpdateRequired(status *InstallStatus) error {
 
 func (r TerminalStatusReporter) printLoggingLink(status *InstallStatus) {
 	linkToLogging := ""
-	loggingMsg := "View your logs at the link below:\n"
+	loggingMsg := i18n.T("View your logs at the link below:") + "\n"
 	statusesToDisplay := r.getRecipesStatusesForInstallationSummary(status)
 
 	for _, s := range statusesToDisplay {
@@ -165,27 +180,79 @@ func (r TerminalStatusReporter) printLoggingLink(status *InstallStatus) {
 func (r TerminalStatusReporter) printInstallationSummary(w io.Writer, status *InstallStatus) {
 	statusesToDisplay := r.getRecipesStatusesForInstallationSummary(status)
 
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	if ux.ASCIIMode {
+		t.SetStyle(table.StyleDefault)
+	}
+	t.Style().Format.Footer = text.FormatDefault
+	t.AppendHeader(table.Row{"", i18n.T("Recipe"), i18n.T("Status"), i18n.T("Duration"), "Entity GUID", i18n.T("Link")})
+
+	totals := map[RecipeStatusType]int{}
 	for _, s := range statusesToDisplay {
-		statusSuffix := strings.ToLower(string(s.Status))
+		totals[s.Status]++
 
-		if s.Status == RecipeStatusTypes.INSTALLED {
-			statusSuffix = color.GreenString(statusSuffix)
+		link := ""
+		if s.EntityGUID != "" && status.PlatformLinkGenerator != nil {
+			link = status.PlatformLinkGenerator.GenerateEntityLink(s.EntityGUID)
 		}
 
-		if s.Status == RecipeStatusTypes.FAILED {
-			statusSuffix = color.YellowString("incomplete")
+		statusText := summaryStatusText(s.Status)
+		if s.AlreadyInstalled {
+			statusText = color.GreenString(i18n.T("already installed"))
+		} else if s.Upgraded {
+			statusText = color.GreenString(i18n.T("upgraded"))
 		}
 
-		if s.Status == RecipeStatusTypes.CANCELED {
-			statusSuffix = color.YellowString(statusSuffix)
-		}
+		t.AppendRow(table.Row{StatusIcon(s.Status), s.DisplayName, statusText, summaryDuration(s.DurationMs), s.EntityGUID, link})
+	}
 
-		if s.Status == RecipeStatusTypes.UNSUPPORTED {
-			statusSuffix = color.RedString(statusSuffix)
-		}
+	t.AppendFooter(table.Row{"", i18n.T("%d recipes", len(statusesToDisplay)), summaryTotalsText(totals)})
+	t.Render()
+}
+
+func summaryStatusText(statusType RecipeStatusType) string {
+	statusText := i18n.T(strings.ToLower(string(statusType)))
 
-		fmt.Fprintf(w, "  %s  %s  (%s)  \n", StatusIconMap[s.Status], s.DisplayName, statusSuffix)
+	switch statusType {
+	case RecipeStatusTypes.INSTALLED:
+		return color.GreenString(statusText)
+	case RecipeStatusTypes.FAILED, RecipeStatusTypes.CANCELED:
+		return color.YellowString(statusText)
+	case RecipeStatusTypes.UNSUPPORTED:
+		return color.RedString(statusText)
 	}
+
+	return statusText
+}
+
+func summaryDuration(durationMs int64) string {
+	if durationMs <= 0 {
+		return "-"
+	}
+
+	return (time.Duration(durationMs) * time.Millisecond).Round(time.Second / 10).String()
+}
+
+// summaryTotalsText counts the recipes by outcome, e.g. "2 installed, 1 failed, 0 skipped".
+func summaryTotalsText(totals map[RecipeStatusType]int) string {
+	counted := []RecipeStatusType{RecipeStatusTypes.INSTALLED, RecipeStatusTypes.FAILED, RecipeStatusTypes.SKIPPED}
+	parts := []string{}
+	for _, st := range counted {
+		parts = append(parts, fmt.Sprintf("%d %s", totals[st], i18n.T(strings.ToLower(string(st)))))
+		delete(totals, st)
+	}
+
+	other := 0
+	for _, count := range totals {
+		other += count
+	}
+	if other > 0 {
+		parts = append(parts, i18n.T("%d other", other))
+	}
+
+	return strings.Join(parts, ", ")
 }
 
 // getRecipesStatusesForInstallationSummary returns the recipe installation results
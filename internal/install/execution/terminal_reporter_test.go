@@ -223,7 +223,40 @@ func TestPrintInstallationSummaryShouldPrint(t *testing.T) {
 	s := output.String()
 	fmt.Print(s)
 
-	require.Contains(t, s, "Test Recipe Installed  (installed)")
+	require.Regexp(t, `Test Recipe Installed\s+│\s+installed`, s)
 	require.NotContains(t, s, "Detected")
-	require.Contains(t, s, "Test Recipe Canceled  (canceled)")
+	require.Regexp(t, `Test Recipe Canceled\s+│\s+canceled`, s)
+	require.Contains(t, s, "1 installed, 0 failed, 0 skipped, 1 other")
+}
+
+func TestPrintInstallationSummaryShouldIncludeDurationAndEntityLink(t *testing.T) {
+	r := NewTerminalStatusReporter()
+	g := NewMockPlatformLinkGenerator()
+	var output bytes.Buffer
+
+	status := &InstallStatus{
+		PlatformLinkGenerator: g,
+	}
+	status.Statuses = []*RecipeStatus{
+		{
+			Name:        "test-recipe-installed",
+			DisplayName: "Test Recipe Installed",
+			Status:      RecipeStatusTypes.INSTALLED,
+			EntityGUID:  "abcd1234",
+			DurationMs:  12300,
+		},
+		{
+			Name:        "test-recipe-skipped",
+			DisplayName: "Test Recipe Skipped",
+			Status:      RecipeStatusTypes.SKIPPED,
+		},
+	}
+
+	r.printInstallationSummary(&output, status)
+	s := output.String()
+
+	require.Contains(t, s, "12.3s")
+	require.Contains(t, s, "abcd1234")
+	require.Equal(t, 1, g.GenerateEntityLinkCallCount)
+	require.Contains(t, s, "1 installed, 0 failed, 1 skipped")
 }
@@ -0,0 +1,140 @@
+package execution
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// WebhookSignatureHeader holds the hex encoded HMAC-SHA256 of the request body, keyed with the
+// webhook secret, as sha256=<signature>.
+const WebhookSignatureHeader = "X-NewRelic-Signature"
+
+// WebhookStatusReporter posts the summary of the install to a webhook once the install completes
+// or is canceled, retrying when the webhook can't be reached or fails.
+type WebhookStatusReporter struct {
+	*StatusFileReporter
+	url        string
+	secret     string
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+}
+
+// WebhookPayload is the summary posted by the WebhookStatusReporter.
+type WebhookPayload struct {
+	StatusFile
+	Canceled              bool                    `json:"canceled"`
+	Host                  types.DiscoveryManifest `json:"host"`
+	EntityGUIDs           []string                `json:"entityGuids"`
+	CLIVersion            string                  `json:"cliVersion"`
+	InstallLibraryVersion string                  `json:"installLibraryVersion"`
+	Timestamp             int64                   `json:"timestamp"`
+}
+
+// NewWebhookStatusReporter returns a reporter posting to url, signing each request with secret
+// when it's set.
+func NewWebhookStatusReporter(url string, secret string) *WebhookStatusReporter {
+	r := WebhookStatusReporter{
+		StatusFileReporter: NewStatusFileReporter(""),
+		url:                url,
+		secret:             secret,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		maxRetries:         3,
+		retryDelay:         time.Second,
+	}
+
+	return &r
+}
+
+func (r *WebhookStatusReporter) InstallComplete(status *InstallStatus) error {
+	return r.notify(status, false)
+}
+
+func (r *WebhookStatusReporter) InstallCanceled(status *InstallStatus) error {
+	return r.notify(status, true)
+}
+
+func (r *WebhookStatusReporter) notify(status *InstallStatus, canceled bool) error {
+	payload := WebhookPayload{
+		StatusFile:            *r.buildStatusFile(status),
+		Canceled:              canceled,
+		Host:                  status.DiscoveryManifest,
+		EntityGUIDs:           status.EntityGUIDs,
+		CLIVersion:            status.CLIVersion,
+		InstallLibraryVersion: status.InstallLibraryVersion,
+		Timestamp:             status.Timestamp,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return r.send(body)
+}
+
+// send posts the body to the webhook, retrying with an increasing delay when it fails.
+func (r *WebhookStatusReporter) send(body []byte) error {
+	var err error
+	delay := r.retryDelay
+	for attempt := 0; ; attempt++ {
+		err = r.post(body)
+		if err == nil {
+			log.Debugf("Notified webhook %s of the install", r.url)
+			return nil
+		}
+
+		if attempt >= r.maxRetries {
+			break
+		}
+
+		log.Debugf("could not notify webhook %s, retrying in %s: %s", r.url, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	log.Warnf("Could not notify webhook %s of the install: %s", r.url, err)
+	return err
+}
+
+func (r *WebhookStatusReporter) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if r.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(r.secret, body))
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("received non-2xx Status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of body, keyed with secret.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package execution
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestWebhookStatusReporterShouldPostSignedSummary(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ = io.ReadAll(req.Body)
+		signature = req.Header.Get(WebhookSignatureHeader)
+	}))
+	defer server.Close()
+
+	r := NewWebhookStatusReporter(server.URL, "s3cret")
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{r}, NewPlatformLinkGenerator())
+	installed := types.OpenInstallationRecipe{Name: "installed", DisplayName: "Installed"}
+
+	s.DiscoveryComplete(types.DiscoveryManifest{Hostname: "web-1", OS: "linux"})
+	s.RecipeInstalling(RecipeStatusEvent{Recipe: installed})
+	s.RecipeInstalled(RecipeStatusEvent{Recipe: installed, EntityGUID: "abc123"})
+	s.InstallComplete(nil)
+
+	require.NotEmpty(t, body)
+	require.Equal(t, "sha256="+SignWebhookPayload("s3cret", body), signature)
+
+	payload := WebhookPayload{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, s.InstallID, payload.InstallID)
+	require.True(t, payload.Success)
+	require.False(t, payload.Canceled)
+	require.Equal(t, "web-1", payload.Host.Hostname)
+	require.Equal(t, []string{"abc123"}, payload.EntityGUIDs)
+	require.Equal(t, 1, len(payload.Recipes))
+	require.Equal(t, RecipeStatusTypes.INSTALLED, payload.Recipes[0].Status)
+}
+
+func TestWebhookStatusReporterShouldRetryFailedRequests(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	r := NewWebhookStatusReporter(server.URL, "")
+	r.retryDelay = 0
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+
+	err := r.InstallCanceled(s)
+
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+}
+
+func TestWebhookStatusReporterShouldFailAfterRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := NewWebhookStatusReporter(server.URL, "")
+	r.retryDelay = 0
+	s := NewInstallStatus(types.InstallerContext{}, []StatusSubscriber{}, NewPlatformLinkGenerator())
+
+	err := r.InstallComplete(s)
+
+	require.Error(t, err)
+	require.Equal(t, 4, attempts)
+}
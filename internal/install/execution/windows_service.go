@@ -0,0 +1,94 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// InfraAgentWindowsService is the name of the infrastructure agent's Windows service.
+const InfraAgentWindowsService = "newrelic-infra"
+
+// WindowsService manages a Windows service with sc.exe.
+type WindowsService struct {
+	Name         string
+	pollInterval time.Duration
+	runFunc      func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+func NewWindowsService(name string) *WindowsService {
+	return &WindowsService{
+		Name:         name,
+		pollInterval: time.Second,
+		runFunc:      runSC,
+	}
+}
+
+// State returns the state of the service as reported by sc.exe, such as RUNNING or STOPPED.
+func (s *WindowsService) State(ctx context.Context) (string, error) {
+	out, err := s.runFunc(ctx, "query", s.Name)
+	if err != nil {
+		return "", fmt.Errorf("could not query service %s: %w: %s", s.Name, err, strings.TrimSpace(string(out)))
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(key) != "STATE" {
+			continue
+		}
+
+		// The state is reported as its code followed by its name, e.g. "4  RUNNING".
+		fields := strings.Fields(value)
+		if len(fields) >= 2 {
+			return fields[1], nil
+		}
+	}
+
+	return "", fmt.Errorf("could not read the state of service %s", s.Name)
+}
+
+// EnsureRunning starts the service when it isn't running, and waits for it to run.
+func (s *WindowsService) EnsureRunning(ctx context.Context, timeout time.Duration) error {
+	state, err := s.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state == "RUNNING" {
+		return nil
+	}
+
+	log.Debugf("Service %s is %s, starting it", s.Name, state)
+	if state != "START_PENDING" {
+		if out, err := s.runFunc(ctx, "start", s.Name); err != nil {
+			return fmt.Errorf("could not start service %s: %w: %s", s.Name, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if state, err = s.State(ctx); err != nil {
+			return err
+		}
+
+		if state == "RUNNING" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+	}
+
+	return fmt.Errorf("service %s is %s after starting it", s.Name, state)
+}
+
+func runSC(ctx context.Context, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, "sc.exe", args...).CombinedOutput()
+}
@@ -0,0 +1,63 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scQueryOutput(state string) []byte {
+	return []byte(`
+SERVICE_NAME: newrelic-infra
+        TYPE               : 10  WIN32_OWN_PROCESS
+        STATE              : ` + state + `
+        WIN32_EXIT_CODE    : 0  (0x0)
+`)
+}
+
+func TestWindowsServiceShouldReadState(t *testing.T) {
+	s := NewWindowsService(InfraAgentWindowsService)
+	s.runFunc = func(ctx context.Context, args ...string) ([]byte, error) {
+		return scQueryOutput("4  RUNNING"), nil
+	}
+
+	state, err := s.State(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "RUNNING", state)
+}
+
+func TestWindowsServiceShouldStartStoppedService(t *testing.T) {
+	calls := [][]string{}
+	state := "1  STOPPED"
+	s := NewWindowsService(InfraAgentWindowsService)
+	s.pollInterval = 0
+	s.runFunc = func(ctx context.Context, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		if args[0] == "start" {
+			state = "4  RUNNING"
+			return []byte{}, nil
+		}
+		return scQueryOutput(state), nil
+	}
+
+	err := s.EnsureRunning(context.Background(), time.Second)
+
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"query", "newrelic-infra"}, {"start", "newrelic-infra"}, {"query", "newrelic-infra"}}, calls)
+}
+
+func TestWindowsServiceShouldFailWhenServiceIsMissing(t *testing.T) {
+	s := NewWindowsService(InfraAgentWindowsService)
+	s.runFunc = func(ctx context.Context, args ...string) ([]byte, error) {
+		return []byte("[SC] EnumQueryServicesStatus:OpenService FAILED 1060"), errors.New("exit status 1060")
+	}
+
+	err := s.EnsureRunning(context.Background(), time.Second)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "FAILED 1060")
+}
@@ -0,0 +1,177 @@
+package install
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// Exit codes returned by the install command, so automation can branch on the cause of a failure.
+const (
+	ExitCodeSuccess            = 0
+	ExitCodeGeneralFailure     = 1
+	ExitCodeDiscoveryFailure   = 2
+	ExitCodeRecipeFetchFailure = 3
+	ExitCodeExecutionFailure   = 4
+	ExitCodeValidationFailure  = 5
+	ExitCodeCredentialFailure  = 6
+	ExitCodePreflightFailure   = 7
+	// ExitCodeCanceled is the exit code shells give a process ended by Ctrl-C.
+	ExitCodeCanceled = 130
+)
+
+var credentialEventTypes = map[types.EventType]bool{
+	types.EventTypes.AccountIDMissing:        true,
+	types.EventTypes.APIKeyMissing:           true,
+	types.EventTypes.InvalidUserAPIKeyFormat: true,
+	types.EventTypes.RegionMissing:           true,
+	types.EventTypes.InvalidRegion:           true,
+	types.EventTypes.UnableToFetchLicenseKey: true,
+	types.EventTypes.InvalidIngestKey:        true,
+	types.EventTypes.InaccessibleAccount:     true,
+}
+
+// ExitError carries the exit code the process should end with for an install error.
+type ExitError struct {
+	Err  error
+	Code int
+}
+
+func NewExitError(err error) *ExitError {
+	return &ExitError{
+		Err:  err,
+		Code: ExitCode(err),
+	}
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// ExitCode classifies an install error into one of the install exit codes.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+
+	if errors.Is(err, types.ErrInterrupt) {
+		return ExitCodeCanceled
+	}
+
+	var detailErr *types.DetailError
+	if errors.As(err, &detailErr) {
+		switch {
+		case credentialEventTypes[detailErr.EventName]:
+			return ExitCodeCredentialFailure
+		case detailErr.EventName == types.EventTypes.UnableToDiscover:
+			return ExitCodeDiscoveryFailure
+		case detailErr.EventName == types.EventTypes.UnableToLocatePostedData:
+			return ExitCodeValidationFailure
+		}
+
+		return ExitCodeGeneralFailure
+	}
+
+	var licenseKeyErr *types.ErrUnalbeToFetchLicenseKey
+	if errors.As(err, &licenseKeyErr) {
+		return ExitCodeCredentialFailure
+	}
+
+	var preflightErr *types.PreflightError
+	if errors.As(err, &preflightErr) {
+		return ExitCodePreflightFailure
+	}
+
+	var discoveryErr *types.DiscoveryError
+	if errors.As(err, &discoveryErr) {
+		return ExitCodeDiscoveryFailure
+	}
+
+	var fetchErr *types.RecipeFetchError
+	if errors.As(err, &fetchErr) {
+		return ExitCodeRecipeFetchFailure
+	}
+
+	var validationErr *types.ValidationError
+	if errors.As(err, &validationErr) {
+		return ExitCodeValidationFailure
+	}
+
+	var failuresErr *types.RecipeFailuresError
+	if errors.As(err, &failuresErr) {
+		return recipeFailuresExitCode(failuresErr)
+	}
+
+	if isExecutionError(err) {
+		return ExitCodeExecutionFailure
+	}
+
+	return ExitCodeGeneralFailure
+}
+
+// recipeFailuresExitCode is a validation failure only when every failed recipe failed validation.
+func recipeFailuresExitCode(e *types.RecipeFailuresError) int {
+	if len(e.Errors) == 0 {
+		return ExitCodeExecutionFailure
+	}
+
+	for _, err := range e.Errors {
+		var validationErr *types.ValidationError
+		if !errors.As(err, &validationErr) {
+			return ExitCodeExecutionFailure
+		}
+	}
+
+	return ExitCodeValidationFailure
+}
+
+func isExecutionError(err error) bool {
+	if _, ok := err.(types.GoTaskError); ok {
+		return true
+	}
+
+	var executionErr *types.ExecutionError
+	if errors.As(err, &executionErr) {
+		return true
+	}
+
+	var unsupportedErr *types.UnsupportedOperatingSystemError
+	return errors.As(err, &unsupportedErr)
+}
+
+// recipeErrors collects the errors of failed recipes, which may be installing concurrently.
+type recipeErrors struct {
+	errs []error
+	mu   sync.Mutex
+}
+
+func (r *recipeErrors) add(err error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errs = append(r.errs, err)
+}
+
+func (r *recipeErrors) all() []error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]error{}, r.errs...)
+}
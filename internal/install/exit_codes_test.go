@@ -0,0 +1,54 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestExitCode(t *testing.T) {
+	validationErr := &types.ValidationError{Err: errors.New("no data")}
+	executionErr := &types.ExecutionError{Err: errors.New("exit status 1")}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"no error", nil, ExitCodeSuccess},
+		{"unclassified", errors.New("something else"), ExitCodeGeneralFailure},
+		{"canceled", types.ErrInterrupt, ExitCodeCanceled},
+		{"pre-flight", &types.PreflightError{FailedChecks: []string{"Network"}}, ExitCodePreflightFailure},
+		{"discovery", &types.DiscoveryError{Err: errors.New("no host")}, ExitCodeDiscoveryFailure},
+		{"recipe fetch", &types.RecipeFetchError{Err: errors.New("not found")}, ExitCodeRecipeFetchFailure},
+		{"execution", executionErr, ExitCodeExecutionFailure},
+		{"go-task", types.NewGoTaskGeneralError(errors.New("task failed")), ExitCodeExecutionFailure},
+		{"validation", validationErr, ExitCodeValidationFailure},
+		{"wrapped validation", fmt.Errorf("install: %w", validationErr), ExitCodeValidationFailure},
+		{"missing api key", types.NewDetailError(types.EventTypes.APIKeyMissing, "User API key is required."), ExitCodeCredentialFailure},
+		{"invalid license key", types.NewDetailError(types.EventTypes.InvalidIngestKey, "invalid"), ExitCodeCredentialFailure},
+		{"unable to post data", types.NewDetailError(types.EventTypes.UnableToPostData, "failed"), ExitCodeGeneralFailure},
+		{"only validation failures", &types.RecipeFailuresError{Errors: []error{validationErr}}, ExitCodeValidationFailure},
+		{"mixed failures", &types.RecipeFailuresError{Errors: []error{validationErr, executionErr}}, ExitCodeExecutionFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestNewExitErrorShouldKeepOriginalError(t *testing.T) {
+	err := &types.DiscoveryError{Err: errors.New("there was an error discovering system info")}
+
+	exitErr := NewExitError(err)
+
+	assert.Equal(t, ExitCodeDiscoveryFailure, exitErr.ExitCode())
+	assert.Equal(t, err.Error(), exitErr.Error())
+	assert.True(t, errors.Is(exitErr, err))
+}
@@ -0,0 +1,352 @@
+package install
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+)
+
+// fleetOnlyFlags are the flags of the fleet install itself, which aren't passed on to the install of each host.
+var fleetOnlyFlags = map[string]bool{
+	"targetsFile":      true,
+	"ansibleInventory": true,
+	"limit":            true,
+	"hostConcurrency":  true,
+	"target":           true,
+	"statusFile":       true,
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FleetInstall installs onto many remote hosts at the same time. Each host is installed by its own
+// `newrelic install --target` process, so their output, logs and status files are kept apart.
+type FleetInstall struct {
+	Targets     []FleetTarget
+	Args        []string
+	Concurrency int
+	OutputDir   string
+	executable  string
+	out         io.Writer
+}
+
+// FleetTarget is a host to install onto, with the flags that only apply to its install.
+type FleetTarget struct {
+	Target string
+	Args   []string
+}
+
+// FleetHostResult is the outcome of the install of one host.
+type FleetHostResult struct {
+	Target     string                `json:"target"`
+	ExitCode   int                   `json:"exitCode"`
+	DurationMs int64                 `json:"durationMs"`
+	StatusFile string                `json:"statusFile"`
+	LogFile    string                `json:"logFile"`
+	Status     *execution.StatusFile `json:"status,omitempty"`
+}
+
+func NewFleetInstall(targets []FleetTarget, args []string, concurrency int) (*FleetInstall, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("could not find the newrelic executable: %w", err)
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &FleetInstall{
+		Targets:     targets,
+		Args:        args,
+		Concurrency: concurrency,
+		OutputDir:   filepath.Join(config.BasePath, "fleet", time.Now().Format("20060102-150405")),
+		executable:  executable,
+		out:         os.Stdout,
+	}, nil
+}
+
+// LoadTargetsFile reads the targets to install onto, one user@host[:port] per line. Empty lines
+// and lines starting with # are ignored.
+func LoadTargetsFile(path string) ([]FleetTarget, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read targets file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	targets := []FleetTarget{}
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if _, err := remote.ParseTarget(line); err != nil {
+			return nil, fmt.Errorf("invalid target on line %d of %s: %w", lineNumber, path, err)
+		}
+
+		if !seen[line] {
+			seen[line] = true
+			targets = append(targets, FleetTarget{Target: line})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read targets file %s: %w", path, err)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets found in %s", path)
+	}
+
+	return targets, nil
+}
+
+// FleetArgs returns the flags set for the fleet install that also apply to the install of each host.
+func FleetArgs(flags *pflag.FlagSet) []string {
+	args := []string{}
+
+	flags.Visit(func(f *pflag.Flag) {
+		if fleetOnlyFlags[f.Name] {
+			return
+		}
+
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range sv.GetSlice() {
+				args = append(args, fmt.Sprintf("--%s=%s", f.Name, v))
+			}
+			return
+		}
+
+		args = append(args, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+
+	return args
+}
+
+// Install installs onto every target, up to Concurrency at a time, and prints a summary of each host.
+func (fi *FleetInstall) Install(ctx context.Context) ([]*FleetHostResult, error) {
+	if err := os.MkdirAll(fi.OutputDir, 0750); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(fi.out, "\nInstalling New Relic on %d hosts, %d at a time. Logs and status files are written to %s\n\n", len(fi.Targets), fi.Concurrency, fi.OutputDir)
+
+	results := make([]*FleetHostResult, len(fi.Targets))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for w := 0; w < fi.Concurrency && w < len(fi.Targets); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range queue {
+				result := fi.installHost(ctx, fi.Targets[idx])
+
+				mu.Lock()
+				results[idx] = result
+				fi.printHostResult(result)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for idx := range fi.Targets {
+		queue <- idx
+	}
+	close(queue)
+	wg.Wait()
+
+	fi.printSummary(results)
+
+	return results, ctx.Err()
+}
+
+func (fi *FleetInstall) installHost(ctx context.Context, target FleetTarget) *FleetHostResult {
+	name := unsafeFileNameChars.ReplaceAllString(target.Target, "_")
+	result := &FleetHostResult{
+		Target:     target.Target,
+		StatusFile: filepath.Join(fi.OutputDir, name+".json"),
+		LogFile:    filepath.Join(fi.OutputDir, name+".log"),
+	}
+
+	logFile, err := os.Create(result.LogFile)
+	if err != nil {
+		log.Debugf("could not create log file %s: %s", result.LogFile, err)
+		result.ExitCode = ExitCodeGeneralFailure
+		return result
+	}
+	defer logFile.Close()
+
+	// The flags of the fleet install come after the host's own, so they take precedence.
+	args := append([]string{"install"}, target.Args...)
+	args = append(args, fi.Args...)
+	args = append(args, "--target="+target.Target, "--statusFile="+result.StatusFile)
+
+	cmd := exec.CommandContext(ctx, fi.executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+
+	start := time.Now()
+	err = cmd.Run()
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+	} else if err != nil {
+		fmt.Fprintf(logFile, "could not run the install: %s\n", err)
+		result.ExitCode = ExitCodeGeneralFailure
+	}
+
+	if data, err := os.ReadFile(result.StatusFile); err == nil {
+		status := &execution.StatusFile{}
+		if err := json.Unmarshal(data, status); err == nil {
+			result.Status = status
+		}
+	}
+
+	return result
+}
+
+func (fi *FleetInstall) printHostResult(r *FleetHostResult) {
+	if r.ExitCode == ExitCodeSuccess {
+		fmt.Fprintf(fi.out, "  %s  %s installed\n", execution.StatusIcon(execution.RecipeStatusTypes.INSTALLED), r.Target)
+		return
+	}
+
+	fmt.Fprintf(fi.out, "  %s  %s failed with exit code %d, see %s\n", execution.StatusIcon(execution.RecipeStatusTypes.FAILED), r.Target, r.ExitCode, r.LogFile)
+}
+
+func (fi *FleetInstall) printSummary(results []*FleetHostResult) {
+	t := table.NewWriter()
+	t.SetOutputMirror(fi.out)
+	t.SetStyle(table.StyleLight)
+	t.Style().Format.Header = text.FormatDefault
+	t.Style().Format.Footer = text.FormatDefault
+	t.AppendHeader(table.Row{"Host", "Result", "Installed", "Failed", "Duration", "Status file"})
+
+	failedHosts := 0
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+
+		result := "installed"
+		if r.ExitCode != ExitCodeSuccess {
+			result = fmt.Sprintf("failed (%d)", r.ExitCode)
+			failedHosts++
+		}
+
+		installed, failed := 0, 0
+		if r.Status != nil {
+			for _, recipe := range r.Status.Recipes {
+				switch recipe.Status {
+				case execution.RecipeStatusTypes.INSTALLED:
+					installed++
+				case execution.RecipeStatusTypes.FAILED:
+					failed++
+				}
+			}
+		}
+
+		duration := (time.Duration(r.DurationMs) * time.Millisecond).Round(time.Second)
+		t.AppendRow(table.Row{r.Target, result, installed, failed, duration, r.StatusFile})
+	}
+
+	t.AppendFooter(table.Row{fmt.Sprintf("%d hosts", len(results)), fmt.Sprintf("%d failed", failedHosts)})
+
+	fmt.Fprintln(fi.out)
+	fmt.Fprintln(fi.out, "  Fleet installation summary")
+	t.Render()
+}
+
+// FleetExitCode is the exit code shared by every failed host, or a general failure when hosts failed
+// for different reasons.
+func FleetExitCode(results []*FleetHostResult) int {
+	code := ExitCodeSuccess
+	for _, r := range results {
+		if r == nil || r.ExitCode == ExitCodeSuccess {
+			continue
+		}
+
+		if code != ExitCodeSuccess && code != r.ExitCode {
+			return ExitCodeGeneralFailure
+		}
+		code = r.ExitCode
+	}
+
+	return code
+}
+
+// fleetInstallError writes the results of the hosts installed onto to the status file, and returns
+// the exit error of the fleet install. An interrupted install exits with the canceled code, once
+// the results of the hosts it got to are written.
+func fleetInstallError(statusFile string, results []*FleetHostResult, installErr error) error {
+	if results == nil && installErr != nil {
+		return NewExitError(installErr)
+	}
+
+	if statusFile != "" {
+		if err := WriteFleetStatusFile(statusFile, results); err != nil {
+			log.Warnf("Could not write the status file %s: %s", statusFile, err)
+		}
+	}
+
+	if installErr != nil {
+		return &ExitError{
+			Err:  fmt.Errorf("the install was canceled: %w", installErr),
+			Code: ExitCodeCanceled,
+		}
+	}
+
+	if code := FleetExitCode(results); code != ExitCodeSuccess {
+		failed := 0
+		for _, r := range results {
+			if r != nil && r.ExitCode != ExitCodeSuccess {
+				failed++
+			}
+		}
+
+		return &ExitError{
+			Err:  fmt.Errorf("the install failed on %d of %d hosts", failed, len(results)),
+			Code: code,
+		}
+	}
+
+	return nil
+}
+
+// WriteFleetStatusFile writes the results of every host to a JSON file.
+func WriteFleetStatusFile(path string, results []*FleetHostResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,143 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+)
+
+func TestLoadTargetsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.txt")
+	content := "# web servers\nubuntu@web-1\n\nubuntu@web-2:2222\nubuntu@web-1\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	targets, err := LoadTargetsFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []FleetTarget{{Target: "ubuntu@web-1"}, {Target: "ubuntu@web-2:2222"}}, targets)
+}
+
+func TestLoadTargetsFileShouldFailForInvalidTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.txt")
+	require.NoError(t, os.WriteFile(path, []byte("ubuntu@web-1\nweb-2\n"), 0600))
+
+	_, err := LoadTargetsFile(path)
+
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestFleetArgsShouldPassOnHostFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("install", pflag.ContinueOnError)
+	flags.Bool("assumeYes", false, "")
+	flags.StringSlice("recipe", []string{}, "")
+	flags.String("targetsFile", "", "")
+	flags.String("statusFile", "", "")
+	flags.String("sshKey", "", "")
+	require.NoError(t, flags.Parse([]string{"--assumeYes", "--recipe=mysql,nginx", "--targetsFile=hosts.txt", "--statusFile=fleet.json", "--sshKey=id_rsa"}))
+
+	args := FleetArgs(flags)
+
+	assert.Equal(t, []string{"--assumeYes=true", "--recipe=mysql", "--recipe=nginx", "--sshKey=id_rsa"}, args)
+}
+
+func TestFleetExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeSuccess, FleetExitCode([]*FleetHostResult{{ExitCode: 0}, {ExitCode: 0}}))
+	assert.Equal(t, ExitCodeValidationFailure, FleetExitCode([]*FleetHostResult{{ExitCode: 0}, {ExitCode: 5}, {ExitCode: 5}}))
+	assert.Equal(t, ExitCodeGeneralFailure, FleetExitCode([]*FleetHostResult{{ExitCode: 4}, {ExitCode: 5}}))
+}
+
+func TestFleetInstallShouldInstallEachHost(t *testing.T) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "newrelic")
+	script := `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --target=*) target="${arg#--target=}" ;;
+    --statusFile=*) statusFile="${arg#--statusFile=}" ;;
+  esac
+done
+echo "installing on $target"
+case "$target" in
+  *bad*) echo '{"complete":true,"success":false,"recipes":[{"name":"infrastructure-agent-installer","status":"FAILED"}]}' > "$statusFile"; exit 4 ;;
+  *) echo '{"complete":true,"success":true,"recipes":[{"name":"infrastructure-agent-installer","status":"INSTALLED"}]}' > "$statusFile" ;;
+esac
+`
+	require.NoError(t, os.WriteFile(executable, []byte(script), 0700))
+
+	out := &bytes.Buffer{}
+	fi := &FleetInstall{
+		Targets:     []FleetTarget{{Target: "ubuntu@good-1"}, {Target: "ubuntu@bad-1"}, {Target: "ubuntu@good-2"}},
+		Args:        []string{"--assumeYes=true"},
+		Concurrency: 2,
+		OutputDir:   filepath.Join(dir, "fleet"),
+		executable:  executable,
+		out:         out,
+	}
+
+	results, err := fi.Install(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, ExitCodeSuccess, results[0].ExitCode)
+	assert.Equal(t, ExitCodeExecutionFailure, results[1].ExitCode)
+	assert.Equal(t, execution.RecipeStatusTypes.FAILED, results[1].Status.Recipes[0].Status)
+	assert.Equal(t, execution.RecipeStatusTypes.INSTALLED, results[2].Status.Recipes[0].Status)
+
+	hostLog, err := os.ReadFile(results[2].LogFile)
+	require.NoError(t, err)
+	assert.Equal(t, "installing on ubuntu@good-2\n", string(hostLog))
+
+	assert.Contains(t, out.String(), "Fleet installation summary")
+	assert.Contains(t, out.String(), "3 hosts")
+	assert.Contains(t, out.String(), "1 failed")
+	assert.Equal(t, ExitCodeExecutionFailure, FleetExitCode(results))
+}
+
+func TestFleetInstallErrorShouldWriteStatusFileWhenCanceled(t *testing.T) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "newrelic")
+	require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\nsleep 5\n"), 0700))
+
+	fi := &FleetInstall{
+		Targets:     []FleetTarget{{Target: "ubuntu@web-1"}, {Target: "ubuntu@web-2"}},
+		Concurrency: 1,
+		OutputDir:   filepath.Join(dir, "fleet"),
+		executable:  executable,
+		out:         &bytes.Buffer{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := fi.Install(ctx)
+	require.Error(t, err)
+
+	statusFile := filepath.Join(dir, "status.json")
+	err = fleetInstallError(statusFile, results, err)
+
+	exitErr, ok := err.(*ExitError)
+	require.True(t, ok)
+	assert.Equal(t, ExitCodeCanceled, exitErr.Code)
+
+	data, err := os.ReadFile(statusFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "ubuntu@web-2")
+}
+
+func TestFleetInstallErrorShouldExitWithHostsExitCode(t *testing.T) {
+	err := fleetInstallError("", []*FleetHostResult{{ExitCode: 0}, {ExitCode: 5}}, nil)
+
+	exitErr, ok := err.(*ExitError)
+	require.True(t, ok)
+	assert.Equal(t, ExitCodeValidationFailure, exitErr.Code)
+	assert.Equal(t, "the install failed on 1 of 2 hosts", exitErr.Err.Error())
+
+	assert.NoError(t, fleetInstallError("", []*FleetHostResult{{ExitCode: 0}}, nil))
+}
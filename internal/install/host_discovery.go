@@ -0,0 +1,402 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/discovery"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// The formats the discovery report is printed in.
+const (
+	discoveryOutputTable = "table"
+	discoveryOutputJSON  = "json"
+)
+
+// DiscoveryReport is what discovery found on the host, and whether each recipe would be recommended
+// for it.
+type DiscoveryReport struct {
+	Host      *types.DiscoveryManifest `json:"host"`
+	Processes []string                 `json:"processes"`
+	// Runtimes are the applications running on the host that APM agents could instrument.
+	Runtimes []types.AppRuntime  `json:"runtimes"`
+	Recipes  []*DiscoveredRecipe `json:"recipes"`
+}
+
+// DiscoveredRecipe is whether a recipe would be recommended for the host, and why.
+type DiscoveredRecipe struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Recommended bool   `json:"recommended"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+	// MatchedProcesses are the running processes that match the recipe's process patterns.
+	MatchedProcesses []string `json:"matchedProcesses,omitempty"`
+}
+
+// PrintDiscovery discovers the host and detects the recipes the install would recommend for it,
+// printing the report in the given format without installing anything.
+func (i *RecipeInstall) PrintDiscovery(w io.Writer, format string) error {
+	if format != discoveryOutputTable && format != discoveryOutputJSON {
+		return fmt.Errorf("invalid --output %q, expected %s or %s", format, discoveryOutputTable, discoveryOutputJSON)
+	}
+
+	ctx, cancel := context.WithCancel(utils.SignalCtx)
+	defer cancel()
+
+	report, err := i.discoverHost(ctx)
+	if err != nil {
+		return err
+	}
+
+	if format == discoveryOutputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	printDiscoveryReport(w, report)
+	return nil
+}
+
+func (i *RecipeInstall) discoverHost(ctx context.Context) (*DiscoveryReport, error) {
+	m, err := i.discoverer.Discover(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("there was an error discovering system info: %s", err)
+	}
+
+	if err := i.exportManifest(ctx, m); err != nil {
+		return nil, err
+	}
+
+	fetched, err := i.recipeFetcher.FetchRecipes(ctx)
+	if err != nil {
+		return nil, &types.RecipeFetchError{Err: err}
+	}
+
+	repo := recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
+		return fetched, nil
+	}, m).WithLogExclusions(i.ExcludeLogs)
+
+	available, unavailable, err := i.recipeDetectorFactory(ctx, repo, &i.InstallerContext).GetDetectedRecipes()
+	if err != nil {
+		return nil, &types.RecipeFetchError{Err: err}
+	}
+
+	processes := i.processEvaluator.GetOrLoadProcesses(ctx)
+	report := &DiscoveryReport{
+		Host:      m,
+		Processes: processNames(processes),
+		Runtimes:  i.detectRuntimes(ctx),
+		Recipes:   []*DiscoveredRecipe{},
+	}
+
+	detected := map[string]bool{}
+	for _, d := range append(available, unavailable...) {
+		detected[d.Recipe.Name] = true
+		report.Recipes = append(report.Recipes, &DiscoveredRecipe{
+			Name:             d.Recipe.Name,
+			DisplayName:      recipeDisplayName(d.Recipe),
+			Recommended:      d.Status == execution.RecipeStatusTypes.AVAILABLE,
+			Status:           string(d.Status),
+			Reason:           i.detectionReason(ctx, m, d),
+			MatchedProcesses: matchedProcesses(ctx, processes, d.Recipe),
+		})
+	}
+
+	// The recipes without an install target that matches the host aren't detected at all.
+	for _, r := range fetched {
+		if detected[r.Name] {
+			continue
+		}
+		detected[r.Name] = true
+
+		report.Recipes = append(report.Recipes, &DiscoveredRecipe{
+			Name:        r.Name,
+			DisplayName: recipeDisplayName(r),
+			Status:      string(execution.RecipeStatusTypes.UNSUPPORTED),
+			Reason:      "none of its install targets match the OS, platform, version or architecture of this host",
+		})
+	}
+
+	sort.SliceStable(report.Recipes, func(a, b int) bool {
+		if report.Recipes[a].Recommended != report.Recipes[b].Recommended {
+			return report.Recipes[a].Recommended
+		}
+		return report.Recipes[a].Name < report.Recipes[b].Name
+	})
+
+	return report, nil
+}
+
+// exportManifest writes the discovery manifest, with the processes running on the host, to the
+// path of --manifestOut so the recipes of the host can be resolved elsewhere with --manifestIn.
+func (i *RecipeInstall) exportManifest(ctx context.Context, m *types.DiscoveryManifest) error {
+	if i.ManifestOut == "" {
+		return nil
+	}
+
+	f := discovery.NewManifestFile(m, i.processEvaluator.GetOrLoadProcesses(ctx))
+	if err := f.Write(i.ManifestOut); err != nil {
+		return fmt.Errorf("could not export the discovery manifest: %w", err)
+	}
+
+	log.Debugf("exported the discovery manifest to %s", i.ManifestOut)
+	return nil
+}
+
+// detectionReason returns why a recipe would, or wouldn't, be recommended for the host.
+func (i *RecipeInstall) detectionReason(ctx context.Context, m *types.DiscoveryManifest, d *recipes.RecipeDetectionResult) string {
+	r := d.Recipe
+
+	switch d.Status {
+	case execution.RecipeStatusTypes.AVAILABLE:
+		if len(r.ProcessMatch) > 0 && len(matchedProcesses(ctx, i.processEvaluator.GetOrLoadProcesses(ctx), r)) > 0 {
+			return "a running process matches it"
+		}
+		if running := recipes.ServiceContainers(r.Name, m.Containers()); len(running) > 0 && r.Name != types.DockerRecipeName {
+			return fmt.Sprintf("its service runs in container %s (%s)", running[0].Name, running[0].Image)
+		}
+		if units := recipes.ServiceUnits(ctx, r, m.SystemdUnits()); len(units) > 0 {
+			return fmt.Sprintf("its service is installed as the %s systemd unit", units[0].Name)
+		}
+		if reason := recipes.WindowsServiceMatch(ctx, r, m.Windows); reason != "" {
+			return reason
+		}
+		if reason := recipes.JMXMatch(r, m.JMXEndpoints); reason != "" {
+			return reason
+		}
+		if reason := recipes.GPUMatch(r, m.GPU); reason != "" {
+			return reason
+		}
+		if rt := i.runtimeOf(ctx, recipes.RuntimeLanguage(r.Name)); rt != nil {
+			return fmt.Sprintf("its application %s runs on the host", runtimeDescription(*rt))
+		}
+		if i.hasDiscoveryMatcher(r.Name) {
+			return "a discovery matcher of --discoveryMatchers matches it"
+		}
+		if ports := recipes.ServicePorts(r.Name); len(ports) > 0 && i.Target == "" && i.ManifestIn == "" {
+			return fmt.Sprintf("a service listens on its default port %d", ports[0])
+		}
+		if len(r.ProcessMatch) > 0 {
+			return "a running process matches it"
+		}
+		return "it applies to every host it supports"
+	case execution.RecipeStatusTypes.SKIPPED:
+		return "excluded with --skipRecipes"
+	case execution.RecipeStatusTypes.DETECTED:
+		return "its discovery check found it, but reported it can't be installed automatically"
+	case execution.RecipeStatusTypes.UNSUPPORTED:
+		return "its discovery check reported this host isn't supported"
+	}
+
+	if len(r.PreInstall.DiscoveryMode) == 1 && r.PreInstall.DiscoveryMode[0] == types.OpenInstallationDiscoveryModeTypes.TARGETED {
+		return "it's only installed when requested with --recipeName"
+	}
+
+	if i.processEvaluator.DetectionStatus(ctx, r) != execution.RecipeStatusTypes.AVAILABLE {
+		return "no running process matches it"
+	}
+
+	return "its discovery check failed"
+}
+
+// detectRuntimes returns the applications running on the host. The executables of Go applications
+// are only read on the host the CLI runs on.
+func (i *RecipeInstall) detectRuntimes(ctx context.Context) []types.AppRuntime {
+	local := i.Target == "" && i.ManifestIn == ""
+	return recipes.NewRuntimeDetector(local).Detect(i.processEvaluator.GetOrLoadProcesses(ctx))
+}
+
+// runtimeOf returns the first application of a language running on the host, or nil when there's none.
+func (i *RecipeInstall) runtimeOf(ctx context.Context, language string) *types.AppRuntime {
+	if language == "" {
+		return nil
+	}
+
+	// Go applications have no agent recipe, so their executables aren't read.
+	for _, rt := range recipes.NewRuntimeDetector(false).Detect(i.processEvaluator.GetOrLoadProcesses(ctx)) {
+		if rt.Language == language {
+			return &rt
+		}
+	}
+
+	return nil
+}
+
+// runtimeDescription describes an application by its name, version and app server, such as
+// orders (java 17, tomcat, pid 42).
+func runtimeDescription(rt types.AppRuntime) string {
+	details := []string{strings.TrimSpace(rt.Language + " " + rt.Version)}
+	if rt.AppServer != "" {
+		details = append(details, rt.AppServer)
+	}
+	details = append(details, fmt.Sprintf("pid %d", rt.PID))
+
+	name := rt.ServiceName
+	if name == "" {
+		name = "unnamed"
+	}
+
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+}
+
+// webServerDescription describes a web server as "service (config path, status URL)", with how to
+// enable its status page when it isn't.
+func webServerDescription(c types.WebServerConfig) string {
+	if c.StatusURL == "" {
+		return fmt.Sprintf("%s (%s, no status page: %s)", c.Service, c.ConfigPath, types.WebServerStatusHints[c.Service])
+	}
+
+	return fmt.Sprintf("%s (%s, status %s)", c.Service, c.ConfigPath, c.StatusURL)
+}
+
+// jmxDescription describes a JMX endpoint as "name (host:port, pid N)".
+func jmxDescription(e types.JMXEndpoint) string {
+	name := e.Name
+	if name == "" {
+		name = "java"
+	}
+
+	return fmt.Sprintf("%s (%s:%d, pid %d)", name, e.Host, e.Port, e.PID)
+}
+
+// gpuDescription describes a GPU as "model (memory, driver version, PCI address)", with what is
+// known of it.
+func gpuDescription(g types.GPU) string {
+	name := g.Model
+	if name == "" {
+		name = strings.TrimSpace(g.Vendor + " " + g.DeviceID)
+	}
+
+	details := []string{}
+	if g.MemoryMB > 0 {
+		details = append(details, fmt.Sprintf("%d MB", g.MemoryMB))
+	}
+	if g.DriverVersion != "" {
+		details = append(details, "driver "+g.DriverVersion)
+	}
+	if g.BusID != "" {
+		details = append(details, g.BusID)
+	}
+
+	if len(details) == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+}
+
+func (i *RecipeInstall) hasDiscoveryMatcher(recipeName string) bool {
+	for _, m := range i.DiscoveryMatchers {
+		if m.Recipe == recipeName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func processNames(processes []types.GenericProcess) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, p := range processes {
+		name, err := p.Name()
+		if err != nil || name == "" || seen[filepath.Base(name)] {
+			continue
+		}
+		seen[filepath.Base(name)] = true
+		names = append(names, filepath.Base(name))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func matchedProcesses(ctx context.Context, processes []types.GenericProcess, r *types.OpenInstallationRecipe) []string {
+	if len(r.ProcessMatch) == 0 {
+		return nil
+	}
+
+	matched := []string{}
+	for _, m := range recipes.NewRegexProcessMatchFinder().FindMatches(ctx, processes, *r) {
+		name, _ := m.Name()
+		matched = append(matched, fmt.Sprintf("%s (pid %d)", filepath.Base(name), m.PID()))
+	}
+
+	return matched
+}
+
+func printDiscoveryReport(w io.Writer, report *DiscoveryReport) {
+	m := report.Host
+	fmt.Fprintf(w, "Host:      %s\n", m.Hostname)
+	fmt.Fprintf(w, "OS:        %s %s %s (%s)\n", m.OS, m.Platform, m.PlatformVersion, m.KernelArch)
+	if m.CPUs > 0 {
+		fmt.Fprintf(w, "Hardware:  %d CPUs, %d MB of memory\n", m.CPUs, m.TotalMemoryBytes/(1024*1024))
+	}
+	if m.VirtualizationSystem != "" {
+		fmt.Fprintf(w, "Virtual:   %s %s\n", m.VirtualizationSystem, m.VirtualizationRole)
+	}
+	fmt.Fprintf(w, "Processes: %d running\n", len(report.Processes))
+	for _, rt := range report.Runtimes {
+		fmt.Fprintf(w, "Runtime:   %s\n", runtimeDescription(rt))
+	}
+	if m.Cloud != nil {
+		fmt.Fprintf(w, "Cloud:     %s\n", m.Cloud.Provider)
+	}
+	if m.Docker != nil {
+		fmt.Fprintf(w, "Docker:    %d containers running\n", len(m.Docker.Containers))
+	}
+	for _, c := range m.WebServers {
+		fmt.Fprintf(w, "Web:       %s\n", webServerDescription(c))
+	}
+	for _, e := range m.JMXEndpoints {
+		fmt.Fprintf(w, "JMX:       %s\n", jmxDescription(e))
+	}
+	if m.GPU != nil {
+		for _, g := range m.GPU.GPUs {
+			fmt.Fprintf(w, "GPU:       %s\n", gpuDescription(g))
+		}
+	}
+	if m.Windows != nil {
+		fmt.Fprintf(w, "Services:  %d installed, %d IIS sites, %d SQL Server instances\n", len(m.Windows.Services), len(m.Windows.IISSites), len(m.Windows.SQLServerInstances))
+	}
+	fmt.Fprintln(w)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	if ux.ASCIIMode {
+		t.SetStyle(table.StyleDefault)
+	}
+	t.AppendHeader(table.Row{"", "Recipe", "Reason"})
+
+	for _, r := range report.Recipes {
+		icon := ux.IconMinus
+		if r.Recommended {
+			icon = ux.IconSuccess
+		}
+
+		reason := r.Reason
+		if len(r.MatchedProcesses) > 0 {
+			reason = fmt.Sprintf("%s: %s", reason, r.MatchedProcesses[0])
+		}
+
+		t.AppendRow(table.Row{icon, r.Name, reason})
+	}
+
+	t.Render()
+}
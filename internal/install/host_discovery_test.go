@@ -0,0 +1,111 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/discovery"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestDiscoverHostShouldExplainEachRecipe(t *testing.T) {
+	mysql := &types.OpenInstallationRecipe{Name: "mysql-open-source-integration", ProcessMatch: []string{"mysqld"}}
+	redis := &types.OpenInstallationRecipe{Name: "redis-open-source-integration"}
+	iis := &types.OpenInstallationRecipe{Name: "iis-open-source-integration"}
+	recipeInstall := NewRecipeInstallBuilder().
+		WithFetchRecipesVal([]*types.OpenInstallationRecipe{mysql, redis, iis}).
+		WithRecipeDetectionResult(
+			&recipes.RecipeDetectionResult{Recipe: mysql, Status: execution.RecipeStatusTypes.AVAILABLE},
+			&recipes.RecipeDetectionResult{Recipe: redis, Status: execution.RecipeStatusTypes.NULL},
+		).
+		WithRunningProcess("/usr/sbin/mysqld --port 3306", "/usr/sbin/mysqld").
+		Build()
+
+	report, err := recipeInstall.discoverHost(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, report.Recipes, 3)
+	assert.Equal(t, []string{"mysqld"}, report.Processes)
+
+	assert.Equal(t, "mysql-open-source-integration", report.Recipes[0].Name)
+	assert.True(t, report.Recipes[0].Recommended)
+	assert.Equal(t, "a running process matches it", report.Recipes[0].Reason)
+	assert.Equal(t, []string{"mysqld (pid 0)"}, report.Recipes[0].MatchedProcesses)
+
+	assert.Equal(t, "iis-open-source-integration", report.Recipes[1].Name)
+	assert.False(t, report.Recipes[1].Recommended)
+	assert.Contains(t, report.Recipes[1].Reason, "none of its install targets match")
+
+	assert.Equal(t, "redis-open-source-integration", report.Recipes[2].Name)
+	assert.Equal(t, "its discovery check failed", report.Recipes[2].Reason)
+}
+
+func TestPrintDiscoveryShouldPrintJSON(t *testing.T) {
+	var out bytes.Buffer
+	recipeInstall := NewRecipeInstallBuilder().Build()
+
+	err := recipeInstall.PrintDiscovery(&out, "json")
+	require.NoError(t, err)
+
+	report := &DiscoveryReport{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), report))
+	assert.NotNil(t, report.Host)
+}
+
+func TestPrintDiscoveryShouldRejectUnknownFormats(t *testing.T) {
+	err := NewRecipeInstallBuilder().Build().PrintDiscovery(&bytes.Buffer{}, "yaml")
+
+	assert.Error(t, err)
+}
+
+func TestDiscoverHostShouldReportApplicationRuntimes(t *testing.T) {
+	node := &types.OpenInstallationRecipe{Name: "node-agent-installer"}
+	recipeInstall := NewRecipeInstallBuilder().
+		WithFetchRecipesVal([]*types.OpenInstallationRecipe{node}).
+		WithRecipeDetectionResult(&recipes.RecipeDetectionResult{Recipe: node, Status: execution.RecipeStatusTypes.AVAILABLE}).
+		WithRunningProcess("/home/app/.nvm/versions/node/v18.12.0/bin/node /srv/checkout/server.js", "node").
+		Build()
+
+	report, err := recipeInstall.discoverHost(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, report.Runtimes, 1)
+	assert.Equal(t, "checkout", report.Runtimes[0].ServiceName)
+	assert.Equal(t, "its application checkout (node 18.12.0, pid 0) runs on the host", report.Recipes[0].Reason)
+
+	var out bytes.Buffer
+	printDiscoveryReport(&out, report)
+	assert.Contains(t, out.String(), "Runtime:   checkout (node 18.12.0, pid 0)")
+}
+
+func TestDetectionReasonShouldExplainTargetedRecipes(t *testing.T) {
+	r := &types.OpenInstallationRecipe{Name: "custom"}
+	r.PreInstall.DiscoveryMode = []types.OpenInstallationDiscoveryMode{types.OpenInstallationDiscoveryModeTypes.TARGETED}
+
+	reason := NewRecipeInstallBuilder().Build().detectionReason(context.Background(), &types.DiscoveryManifest{}, &recipes.RecipeDetectionResult{Recipe: r, Status: execution.RecipeStatusTypes.NULL})
+
+	assert.Equal(t, "it's only installed when requested with --recipeName", reason)
+}
+
+func TestDiscoverHostShouldExportManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	recipeInstall := NewRecipeInstallBuilder().WithRunningProcess("/usr/sbin/mysqld --port 3306", "mysqld").Build()
+	recipeInstall.ManifestOut = path
+
+	_, err := recipeInstall.discoverHost(context.Background())
+	require.NoError(t, err)
+
+	f, err := discovery.LoadManifestFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "linux", f.Manifest.OS)
+	require.Len(t, f.Processes, 1)
+	assert.Equal(t, "/usr/sbin/mysqld --port 3306", f.Processes[0].Command)
+}
@@ -0,0 +1,234 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var versionRegex = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// defaultInstalledChecks are how the status of the core recipes is inspected when the recipe
+// doesn't define an installed check of its own, by OS.
+var defaultInstalledChecks = map[string]map[string]types.OpenInstallationInstalledCheck{
+	"linux": {
+		types.InfraAgentRecipeName: {
+			Files:    []string{"/etc/newrelic-infra.yml"},
+			Services: []string{"newrelic-infra"},
+			Packages: []string{"newrelic-infra"},
+			Version:  "newrelic-infra --version",
+		},
+		types.LoggingRecipeName: {
+			Files: []string{"/etc/newrelic-infra/logging.d/logging.yml"},
+		},
+	},
+	"windows": {
+		types.InfraAgentRecipeName: {
+			Files:    []string{`C:\Program Files\New Relic\newrelic-infra\newrelic-infra.yml`},
+			Services: []string{execution.InfraAgentWindowsService},
+			Version:  `'C:\Program Files\New Relic\newrelic-infra\newrelic-infra.exe' --version`,
+		},
+		types.LoggingRecipeName: {
+			Files: []string{`C:\Program Files\New Relic\newrelic-infra\logging.d\logging.yml`},
+		},
+	},
+}
+
+// ComponentStatus is what a recipe installed on the host.
+type ComponentStatus struct {
+	Name        string
+	DisplayName string
+	// Version is the installed version, when the recipe can tell.
+	Version  string
+	Services []ServiceStatus
+}
+
+// ServiceStatus is whether a service of an installed component is running.
+type ServiceStatus struct {
+	Name    string
+	Running bool
+}
+
+// HostInspector finds what the recipes installed on this host, using the installed check of each
+// recipe.
+type HostInspector struct {
+	goos    string
+	runFunc func(ctx context.Context, script string) (string, error)
+}
+
+func NewHostInspector() *HostInspector {
+	return &HostInspector{
+		goos:    runtime.GOOS,
+		runFunc: runStatusScript,
+	}
+}
+
+// Inspect returns the status of each of the recipes installed on the host, in install order.
+func (h *HostInspector) Inspect(ctx context.Context, recipesIn []*types.OpenInstallationRecipe) []*ComponentStatus {
+	statuses := []*ComponentStatus{}
+
+	// The install order is the reverse of the uninstall order.
+	ordered := sortRecipesForUninstall(recipesIn)
+	for idx := len(ordered) - 1; idx >= 0; idx-- {
+		r := ordered[idx]
+		check := h.installedCheck(r)
+
+		installed := filesAndPackages(check)
+		if len(installed) == 0 {
+			for _, s := range check.Services {
+				installed = append(installed, serviceCondition(s))
+			}
+		}
+		if len(installed) == 0 {
+			continue
+		}
+
+		if _, err := h.runFunc(ctx, strings.Join(installed, " && ")); err != nil {
+			log.Debugf("recipe %s is not installed: %s", r.Name, err)
+			continue
+		}
+
+		cs := &ComponentStatus{
+			Name:        r.Name,
+			DisplayName: recipeDisplayName(r),
+			Services:    []ServiceStatus{},
+		}
+
+		if check.Version != "" {
+			out, err := h.runFunc(ctx, check.Version)
+			if err != nil {
+				log.Debugf("could not get the version of recipe %s: %s", r.Name, err)
+			}
+			cs.Version = parseVersion(out)
+		}
+
+		for _, s := range check.Services {
+			_, err := h.runFunc(ctx, serviceCondition(s))
+			cs.Services = append(cs.Services, ServiceStatus{Name: s, Running: err == nil})
+		}
+
+		statuses = append(statuses, cs)
+	}
+
+	return statuses
+}
+
+func (h *HostInspector) installedCheck(r *types.OpenInstallationRecipe) types.OpenInstallationInstalledCheck {
+	if r.HasInstalledCheck() {
+		return r.PreInstall.InstalledCheck
+	}
+
+	return defaultInstalledChecks[h.goos][r.Name]
+}
+
+func filesAndPackages(c types.OpenInstallationInstalledCheck) []string {
+	conditions := []string{}
+	for _, f := range c.Files {
+		conditions = append(conditions, fileCondition(f))
+	}
+
+	for _, p := range c.Packages {
+		conditions = append(conditions, packageCondition(p))
+	}
+
+	return conditions
+}
+
+// parseVersion returns the version number printed by a version script, or its first line when
+// it doesn't print one.
+func parseVersion(out string) string {
+	if v := versionRegex.FindString(out); v != "" {
+		return v
+	}
+
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(out), "\n", 2)[0])
+}
+
+func runStatusScript(ctx context.Context, script string) (string, error) {
+	var out bytes.Buffer
+
+	e := execution.NewShRecipeExecutor()
+	e.Stdin = nil
+	e.Stdout = &out
+
+	r := types.OpenInstallationRecipe{Name: "status"}
+	r.PreInstall.RequireAtDiscovery = script
+
+	err := e.ExecutePreInstall(ctx, r, types.RecipeVars{})
+	return out.String(), err
+}
+
+// PrintHostStatus prints the instrumentation the recipes installed on this host, with the
+// installed versions and whether their services are running.
+func (i *RecipeInstall) PrintHostStatus(w io.Writer) error {
+	ctx, cancel := context.WithCancel(utils.SignalCtx)
+	defer cancel()
+
+	m, err := i.discoverer.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("there was an error discovering system info: %s", err)
+	}
+
+	repo := recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
+		return i.recipeFetcher.FetchRecipes(ctx)
+	}, m)
+
+	all, err := repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	statuses := i.hostInspector.Inspect(ctx, all)
+	if len(statuses) == 0 {
+		fmt.Fprintln(w, "No New Relic instrumentation was found on this host.")
+		return nil
+	}
+
+	printHostStatus(w, statuses)
+	return nil
+}
+
+func printHostStatus(w io.Writer, statuses []*ComponentStatus) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	if ux.ASCIIMode {
+		t.SetStyle(table.StyleDefault)
+	}
+	t.AppendHeader(table.Row{"", "Component", "Version", "Services"})
+
+	for _, s := range statuses {
+		icon := ux.IconSuccess
+		services := []string{}
+		for _, svc := range s.Services {
+			state := "running"
+			if !svc.Running {
+				state = "not running"
+				icon = ux.IconExclamation
+			}
+			services = append(services, fmt.Sprintf("%s (%s)", svc.Name, state))
+		}
+
+		version := s.Version
+		if version == "" {
+			version = "-"
+		}
+
+		t.AppendRow(table.Row{icon, s.DisplayName, version, strings.Join(services, ", ")})
+	}
+
+	t.Render()
+}
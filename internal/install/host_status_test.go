@@ -0,0 +1,93 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestHostInspectorShouldReportInstalledRecipes(t *testing.T) {
+	infra := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	nginx := recipes.NewRecipeBuilder().Name("nginx-open-source-integration").Build()
+	nginx.DisplayName = "NGINX Integration"
+	nginx.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{
+		Files:   []string{"/etc/newrelic-infra/integrations.d/nginx-config.yml"},
+		Version: "nri-nginx --version",
+	}
+	mysql := recipes.NewRecipeBuilder().Name("mysql-open-source-integration").Build()
+	mysql.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Files: []string{"/etc/newrelic-infra/integrations.d/mysql-config.yml"}}
+
+	h := &HostInspector{goos: "linux", runFunc: func(ctx context.Context, script string) (string, error) {
+		switch {
+		case strings.Contains(script, "mysql-config.yml"):
+			return "", errors.New("exit status 1")
+		case strings.Contains(script, "is-active"):
+			return "", errors.New("exit status 3")
+		case script == "newrelic-infra --version":
+			return "New Relic Infrastructure Agent version: 1.48.0, GoVersion: go1.20.7", nil
+		case script == "nri-nginx --version":
+			return "New Relic NGINX integration Version: 3.4.1\n", nil
+		}
+		return "", nil
+	}}
+
+	statuses := h.Inspect(context.Background(), []*types.OpenInstallationRecipe{nginx, mysql, infra})
+
+	require.Equal(t, 2, len(statuses))
+	assert.Equal(t, types.InfraAgentRecipeName, statuses[0].Name)
+	assert.Equal(t, "1.48.0", statuses[0].Version)
+	assert.Equal(t, []ServiceStatus{{Name: "newrelic-infra", Running: false}}, statuses[0].Services)
+	assert.Equal(t, "NGINX Integration", statuses[1].DisplayName)
+	assert.Equal(t, "3.4.1", statuses[1].Version)
+}
+
+func TestHostInspectorShouldSkipRecipesWithoutInstalledCheck(t *testing.T) {
+	r := recipes.NewRecipeBuilder().Name("apache-open-source-integration").Build()
+	calls := 0
+	h := &HostInspector{goos: "linux", runFunc: func(ctx context.Context, script string) (string, error) {
+		calls++
+		return "", nil
+	}}
+
+	statuses := h.Inspect(context.Background(), []*types.OpenInstallationRecipe{r})
+
+	assert.Empty(t, statuses)
+	assert.Equal(t, 0, calls)
+}
+
+func TestHostInspectorShouldRunChecksOnThisHost(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "nginx-config.yml")
+	require.NoError(t, os.WriteFile(config, []byte{}, 0600))
+	r := recipes.NewRecipeBuilder().Name("nginx-open-source-integration").Build()
+	r.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Files: []string{config}, Version: "echo 3.4.1"}
+
+	statuses := NewHostInspector().Inspect(context.Background(), []*types.OpenInstallationRecipe{r})
+
+	require.Equal(t, 1, len(statuses))
+	assert.Equal(t, "3.4.1", statuses[0].Version)
+}
+
+func TestPrintHostStatusShouldShowServiceState(t *testing.T) {
+	var out bytes.Buffer
+
+	printHostStatus(&out, []*ComponentStatus{{
+		DisplayName: "Infrastructure Agent",
+		Version:     "1.48.0",
+		Services:    []ServiceStatus{{Name: "newrelic-infra", Running: true}},
+	}})
+
+	assert.Contains(t, out.String(), "Infrastructure Agent")
+	assert.Contains(t, out.String(), "1.48.0")
+	assert.Contains(t, out.String(), "newrelic-infra (running)")
+}
@@ -0,0 +1,117 @@
+package i18n
+
+// catalogs are the translations of the installer's prompts and messages, by locale and then by
+// their English text. Translations keep the formatting verbs of the English text, in order.
+var catalogs = map[string]map[string]string{
+	"de": {
+		"Welcome to New Relic. Let's set up full stack observability for your environment.": "Willkommen bei New Relic. Richten wir Full-Stack-Observability für Ihre Umgebung ein.",
+		"Our Data Privacy Notice: %s":      "Unser Datenschutzhinweis: %s",
+		"Installing New Relic":             "New Relic wird installiert",
+		"The following will be installed:": "Folgendes wird installiert:",
+		"We've detected additional monitoring that can be configured by installing the following:": "Wir haben zusätzliches Monitoring erkannt, das durch die Installation der folgenden Komponenten eingerichtet werden kann:",
+		"Select the integrations to install:":                                                      "Wählen Sie die zu installierenden Integrationen aus:",
+		"Installing %s":                                                                            "%s wird installiert",
+		"Complete!":                                                                                "Abgeschlossen!",
+		"Upgrading %s":                                                                             "%s wird aktualisiert",
+		"%s is already installed, skipping":                                                        "%s ist bereits installiert und wird übersprungen",
+		"%s was installed previously, skipping":                                                    "%s wurde bereits zuvor installiert und wird übersprungen",
+		"Run the install with --upgrade to upgrade it.":                                            "Führen Sie die Installation mit --upgrade aus, um es zu aktualisieren.",
+		"%s failed to install, continuing with the remaining integrations":                         "%s konnte nicht installiert werden, die übrigen Integrationen werden weiter installiert",
+		"New Relic installation complete":                                                          "New Relic-Installation abgeschlossen",
+		"Installation Summary":                                                                     "Installationsübersicht",
+		"View your data at the link below:":                                                        "Ihre Daten finden Sie unter folgendem Link:",
+		"View your logs at the link below:":                                                        "Ihre Logs finden Sie unter folgendem Link:",
+		"Follow the instructions at the URL below to complete the installation process.":                  "Folgen Sie den Anweisungen unter der folgenden URL, um die Installation abzuschließen.",
+		"Installation was successful overall, however, one or more installations could not be completed.": "Die Installation war insgesamt erfolgreich, jedoch konnten eine oder mehrere Installationen nicht abgeschlossen werden.",
+		"Installation incomplete.": "Installation unvollständig.",
+		"Installation canceled.":   "Installation abgebrochen.",
+		"To finish your installation please use New Relic's installation wizard using the following link.": "Um Ihre Installation abzuschließen, verwenden Sie bitte den Installationsassistenten von New Relic unter folgendem Link.",
+		"Recipe":            "Rezept",
+		"Status":            "Status",
+		"Duration":          "Dauer",
+		"Link":              "Link",
+		"installed":         "installiert",
+		"failed":            "fehlgeschlagen",
+		"skipped":           "übersprungen",
+		"canceled":          "abgebrochen",
+		"unsupported":       "nicht unterstützt",
+		"already installed": "bereits installiert",
+		"upgraded":          "aktualisiert",
+		"%d recipes":        "%d Rezepte",
+		"%d other":          "%d sonstige",
+	},
+	"es": {
+		"Welcome to New Relic. Let's set up full stack observability for your environment.": "Bienvenido a New Relic. Configuremos la observabilidad full stack de su entorno.",
+		"Our Data Privacy Notice: %s":      "Nuestro aviso de privacidad de datos: %s",
+		"Installing New Relic":             "Instalando New Relic",
+		"The following will be installed:": "Se instalará lo siguiente:",
+		"We've detected additional monitoring that can be configured by installing the following:": "Detectamos monitorización adicional que se puede configurar instalando lo siguiente:",
+		"Select the integrations to install:":                                                      "Seleccione las integraciones que desea instalar:",
+		"Installing %s":                                                                            "Instalando %s",
+		"Complete!":                                                                                "¡Completado!",
+		"Upgrading %s":                                                                             "Actualizando %s",
+		"%s is already installed, skipping":                                                        "%s ya está instalado, se omite",
+		"%s was installed previously, skipping":                                                    "%s se instaló anteriormente, se omite",
+		"Run the install with --upgrade to upgrade it.":                                            "Ejecute la instalación con --upgrade para actualizarlo.",
+		"%s failed to install, continuing with the remaining integrations":                         "No se pudo instalar %s, se continúa con el resto de las integraciones",
+		"New Relic installation complete":                                                          "Instalación de New Relic completada",
+		"Installation Summary":                                                                     "Resumen de la instalación",
+		"View your data at the link below:":                                                        "Vea sus datos en el siguiente enlace:",
+		"View your logs at the link below:":                                                        "Vea sus logs en el siguiente enlace:",
+		"Follow the instructions at the URL below to complete the installation process.":                  "Siga las instrucciones de la siguiente URL para completar el proceso de instalación.",
+		"Installation was successful overall, however, one or more installations could not be completed.": "La instalación fue correcta en general, pero una o más instalaciones no se pudieron completar.",
+		"Installation incomplete.": "Instalación incompleta.",
+		"Installation canceled.":   "Instalación cancelada.",
+		"To finish your installation please use New Relic's installation wizard using the following link.": "Para terminar la instalación, use el asistente de instalación de New Relic en el siguiente enlace.",
+		"Recipe":            "Receta",
+		"Status":            "Estado",
+		"Duration":          "Duración",
+		"Link":              "Enlace",
+		"installed":         "instalado",
+		"failed":            "fallido",
+		"skipped":           "omitido",
+		"canceled":          "cancelado",
+		"unsupported":       "no compatible",
+		"already installed": "ya instalado",
+		"upgraded":          "actualizado",
+		"%d recipes":        "%d recetas",
+		"%d other":          "%d otros",
+	},
+	"ja": {
+		"Welcome to New Relic. Let's set up full stack observability for your environment.": "New Relic へようこそ。お使いの環境のフルスタックオブザーバビリティを設定しましょう。",
+		"Our Data Privacy Notice: %s":      "データプライバシーに関する通知: %s",
+		"Installing New Relic":             "New Relic をインストールしています",
+		"The following will be installed:": "以下をインストールします:",
+		"We've detected additional monitoring that can be configured by installing the following:": "以下をインストールすると、検出された追加のモニタリングを設定できます:",
+		"Select the integrations to install:":                                                      "インストールするインテグレーションを選択してください:",
+		"Installing %s":                                                                            "%s をインストールしています",
+		"Complete!":                                                                                "完了しました!",
+		"Upgrading %s":                                                                             "%s をアップグレードしています",
+		"%s is already installed, skipping":                                                        "%s はすでにインストールされているため、スキップします",
+		"%s was installed previously, skipping":                                                    "%s は以前にインストールされているため、スキップします",
+		"Run the install with --upgrade to upgrade it.":                                            "アップグレードするには --upgrade を指定してインストールを実行してください。",
+		"%s failed to install, continuing with the remaining integrations":                         "%s のインストールに失敗しました。残りのインテグレーションのインストールを続行します",
+		"New Relic installation complete":                                                          "New Relic のインストールが完了しました",
+		"Installation Summary":                                                                     "インストールの概要",
+		"View your data at the link below:":                                                        "以下のリンクからデータを確認できます:",
+		"View your logs at the link below:":                                                        "以下のリンクからログを確認できます:",
+		"Follow the instructions at the URL below to complete the installation process.":                  "インストールを完了するには、以下の URL の手順に従ってください。",
+		"Installation was successful overall, however, one or more installations could not be completed.": "インストールは概ね成功しましたが、一部のインストールを完了できませんでした。",
+		"Installation incomplete.": "インストールは完了していません。",
+		"Installation canceled.":   "インストールはキャンセルされました。",
+		"To finish your installation please use New Relic's installation wizard using the following link.": "インストールを完了するには、以下のリンクから New Relic のインストールウィザードを使用してください。",
+		"Recipe":            "レシピ",
+		"Status":            "ステータス",
+		"Duration":          "所要時間",
+		"Link":              "リンク",
+		"installed":         "インストール済み",
+		"failed":            "失敗",
+		"skipped":           "スキップ",
+		"canceled":          "キャンセル",
+		"unsupported":       "未サポート",
+		"already installed": "既存",
+		"upgraded":          "アップグレード済み",
+		"%d recipes":        "%d 件のレシピ",
+		"%d other":          "その他 %d 件",
+	},
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the locale messages are written in, and the one used when the locale of the
+// user has no catalog.
+const DefaultLocale = "en"
+
+// localeEnvVars are the environment variables the locale is detected from, in order of precedence.
+var localeEnvVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+var locale = DefaultLocale
+
+// Locales returns the locales messages can be shown in.
+func Locales() []string {
+	locales := []string{DefaultLocale}
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales[1:])
+
+	return locales
+}
+
+// SetLocale sets the locale messages are shown in, given as a language such as es, or a POSIX
+// locale such as es_ES.UTF-8.
+func SetLocale(l string) error {
+	normalized := normalizeLocale(l)
+	if normalized != DefaultLocale {
+		if _, ok := catalogs[normalized]; !ok {
+			return fmt.Errorf("unsupported locale %q, expected one of %s", l, strings.Join(Locales(), ", "))
+		}
+	}
+
+	locale = normalized
+	return nil
+}
+
+// Locale returns the locale messages are shown in.
+func Locale() string {
+	return locale
+}
+
+// DetectLocale returns the locale of the user from the LC_ALL, LC_MESSAGES and LANG environment
+// variables, or the default locale when it has no catalog.
+func DetectLocale() string {
+	for _, k := range localeEnvVars {
+		v := os.Getenv(k)
+		if v == "" {
+			continue
+		}
+
+		if l := normalizeLocale(v); l == DefaultLocale || catalogs[l] != nil {
+			return l
+		}
+
+		return DefaultLocale
+	}
+
+	return DefaultLocale
+}
+
+// normalizeLocale returns the language of a locale, such as ja for ja_JP.UTF-8.
+func normalizeLocale(l string) string {
+	l = strings.ToLower(strings.TrimSpace(l))
+	if i := strings.IndexAny(l, "_-.@"); i >= 0 {
+		l = l[:i]
+	}
+
+	if l == "" || l == "c" || l == "posix" {
+		return DefaultLocale
+	}
+
+	return l
+}
+
+// T returns the message translated into the locale, formatted with the args when given. Messages
+// are looked up by their English text, which is returned when the locale has no translation.
+func T(message string, args ...interface{}) string {
+	if translated, ok := catalogs[locale][message]; ok {
+		message = translated
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
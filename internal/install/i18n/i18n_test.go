@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var verbRegex = regexp.MustCompile(`%[a-z]`)
+
+func TestSetLocaleShouldNormalizePosixLocales(t *testing.T) {
+	defer func() { locale = DefaultLocale }()
+
+	require.NoError(t, SetLocale("ja_JP.UTF-8"))
+	require.Equal(t, "ja", Locale())
+
+	require.NoError(t, SetLocale("C"))
+	require.Equal(t, DefaultLocale, Locale())
+}
+
+func TestSetLocaleShouldRejectLocalesWithoutCatalog(t *testing.T) {
+	err := SetLocale("fr")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "en, de, es, ja")
+	require.Equal(t, DefaultLocale, Locale())
+}
+
+func TestDetectLocaleShouldPreferLCAll(t *testing.T) {
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+
+	require.Equal(t, "de", DetectLocale())
+}
+
+func TestDetectLocaleShouldFallBackToDefault(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	require.Equal(t, DefaultLocale, DetectLocale())
+}
+
+func TestTShouldTranslateAndFormat(t *testing.T) {
+	defer func() { locale = DefaultLocale }()
+
+	require.Equal(t, "Installing MySQL", T("Installing %s", "MySQL"))
+
+	require.NoError(t, SetLocale("es"))
+	require.Equal(t, "Instalando MySQL", T("Installing %s", "MySQL"))
+	require.Equal(t, "not translated", T("not translated"))
+}
+
+func TestCatalogsShouldKeepFormattingVerbs(t *testing.T) {
+	for l, catalog := range catalogs {
+		for message, translated := range catalog {
+			require.Equal(t, verbRegex.FindAllString(message, -1), verbRegex.FindAllString(translated, -1), "%s: %s", l, message)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// DefaultInstallConfigFile is the conventional name of an unattended install configuration.
+const DefaultInstallConfigFile = "newrelic-install.yaml"
+
+// discoveredLogFilesVar is the variable the logs recipes read the log files to forward from.
+const discoveredLogFilesVar = "NR_DISCOVERED_LOG_FILES"
+
+// InstallConfig fully describes an unattended install, such as one run by configuration
+// management, so that it never prompts. Flags given alongside it take precedence.
+type InstallConfig struct {
+	LicenseKey  InstallConfigLicenseKey `yaml:"licenseKey"`
+	Recipes     []string                `yaml:"recipes"`
+	SkipRecipes []string                `yaml:"skipRecipes"`
+	Vars        map[string]string       `yaml:"vars"`
+	Tags        []string                `yaml:"tags"`
+	LogPaths    []string                `yaml:"logPaths"`
+}
+
+// InstallConfigLicenseKey is where the license key of an unattended install is read from, instead
+// of fetching it with the profile's User API key.
+type InstallConfigLicenseKey struct {
+	Env  string `yaml:"env"`
+	File string `yaml:"file"`
+}
+
+func LoadInstallConfig(path string) (*InstallConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read install configuration %s: %w", path, err)
+	}
+
+	cfg := &InstallConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("could not parse install configuration %s: %w", path, err)
+	}
+
+	if cfg.LicenseKey.Env != "" && cfg.LicenseKey.File != "" {
+		return nil, fmt.Errorf("invalid install configuration %s: set the license key env or file, not both", path)
+	}
+
+	return cfg, nil
+}
+
+// readLicenseKey returns the license key of the configuration, or an empty string when the license
+// key is fetched with the profile.
+func (c *InstallConfig) readLicenseKey() (string, error) {
+	if c.LicenseKey.Env != "" {
+		key := strings.TrimSpace(os.Getenv(c.LicenseKey.Env))
+		if key == "" {
+			return "", fmt.Errorf("the license key environment variable %s is not set", c.LicenseKey.Env)
+		}
+		return key, nil
+	}
+
+	if c.LicenseKey.File != "" {
+		data, err := os.ReadFile(c.LicenseKey.File)
+		if err != nil {
+			return "", fmt.Errorf("could not read license key file: %w", err)
+		}
+
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return "", errors.New("the license key file is empty")
+		}
+		return key, nil
+	}
+
+	return "", nil
+}
+
+// applyInstallConfig sets the variables, log paths, skipped recipes, tags and license key of the
+// configuration on the installer context, where the flags haven't set them already.
+func applyInstallConfig(ic *types.InstallerContext, cfg *InstallConfig) error {
+	for k, v := range cfg.Vars {
+		if _, ok := ic.RecipeVars[k]; !ok {
+			ic.RecipeVars[k] = v
+		}
+	}
+
+	if _, ok := ic.RecipeVars[discoveredLogFilesVar]; !ok && len(cfg.LogPaths) > 0 {
+		ic.RecipeVars[discoveredLogFilesVar] = strings.Join(cfg.LogPaths, ",")
+	}
+
+	ic.SkipRecipes = append([]string{}, ic.SkipRecipes...)
+	for _, name := range cfg.SkipRecipes {
+		if !ic.IsRecipeSkipped(name) {
+			ic.SkipRecipes = append(ic.SkipRecipes, name)
+		}
+	}
+
+	ic.SetTags(append(ic.GetTags(), cfg.Tags...))
+
+	key, err := cfg.readLicenseKey()
+	if err != nil {
+		return err
+	}
+
+	// A license key given with --licenseKey takes precedence.
+	if key != "" && licenseKey == "" {
+		licenseKey = key
+		os.Setenv("NEW_RELIC_LICENSE_KEY", key)
+		log.Debugf("using license key %s from the install configuration", utils.Obfuscate(key))
+	}
+
+	return nil
+}
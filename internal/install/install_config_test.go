@@ -0,0 +1,118 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func writeInstallConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), DefaultInstallConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadInstallConfig(t *testing.T) {
+	path := writeInstallConfig(t, `
+licenseKey:
+  env: MY_LICENSE_KEY
+recipes:
+  - infrastructure-agent-installer
+  - logs-integration@0.0.125
+skipRecipes:
+  - nginx-open-source-integration
+vars:
+  MYSQL_PORT: "3306"
+tags:
+  - env:production
+logPaths:
+  - /var/log/app/*.log
+`)
+
+	cfg, err := LoadInstallConfig(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "MY_LICENSE_KEY", cfg.LicenseKey.Env)
+	assert.Equal(t, []string{"infrastructure-agent-installer", "logs-integration@0.0.125"}, cfg.Recipes)
+	assert.Equal(t, []string{"nginx-open-source-integration"}, cfg.SkipRecipes)
+	assert.Equal(t, map[string]string{"MYSQL_PORT": "3306"}, cfg.Vars)
+	assert.Equal(t, []string{"env:production"}, cfg.Tags)
+	assert.Equal(t, []string{"/var/log/app/*.log"}, cfg.LogPaths)
+}
+
+func TestLoadInstallConfigShouldRejectUnknownFields(t *testing.T) {
+	path := writeInstallConfig(t, "recipe: [infrastructure-agent-installer]\n")
+
+	_, err := LoadInstallConfig(path)
+
+	require.Error(t, err)
+}
+
+func TestLoadInstallConfigShouldRejectTwoLicenseKeySources(t *testing.T) {
+	path := writeInstallConfig(t, "licenseKey:\n  env: MY_LICENSE_KEY\n  file: /etc/newrelic/license\n")
+
+	_, err := LoadInstallConfig(path)
+
+	require.Error(t, err)
+}
+
+func TestApplyInstallConfigShouldNotOverrideFlags(t *testing.T) {
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "")
+	defer func() {
+		licenseKey = ""
+	}()
+	keyFile := filepath.Join(t.TempDir(), "license")
+	require.NoError(t, os.WriteFile(keyFile, []byte("abc123NRAL\n"), 0600))
+	cfg := &InstallConfig{
+		LicenseKey:  InstallConfigLicenseKey{File: keyFile},
+		SkipRecipes: []string{"nginx-open-source-integration"},
+		Vars:        map[string]string{"MYSQL_PORT": "3306", "MYSQL_USERNAME": "newrelic"},
+		Tags:        []string{"env:production"},
+		LogPaths:    []string{"/var/log/app.log", "/var/log/worker.log"},
+	}
+	ic := types.InstallerContext{RecipeVars: map[string]string{"MYSQL_PORT": "3307"}}
+	ic.SetTags([]string{"team:platform"})
+
+	err := applyInstallConfig(&ic, cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "3307", ic.RecipeVars["MYSQL_PORT"])
+	assert.Equal(t, "newrelic", ic.RecipeVars["MYSQL_USERNAME"])
+	assert.Equal(t, "/var/log/app.log,/var/log/worker.log", ic.RecipeVars[discoveredLogFilesVar])
+	assert.Equal(t, []string{"nginx-open-source-integration"}, ic.SkipRecipes)
+	assert.Equal(t, []string{"team:platform", "env:production"}, ic.GetTags())
+	assert.Equal(t, "abc123NRAL", licenseKey)
+	assert.Equal(t, "abc123NRAL", os.Getenv("NEW_RELIC_LICENSE_KEY"))
+}
+
+func TestApplyInstallConfigShouldNotOverrideLicenseKeyFlag(t *testing.T) {
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "flag456NRAL")
+	t.Setenv("MY_LICENSE_KEY", "abc123NRAL")
+	licenseKey = "flag456NRAL"
+	defer func() {
+		licenseKey = ""
+	}()
+	cfg := &InstallConfig{LicenseKey: InstallConfigLicenseKey{Env: "MY_LICENSE_KEY"}}
+	ic := types.InstallerContext{RecipeVars: map[string]string{}}
+
+	err := applyInstallConfig(&ic, cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "flag456NRAL", os.Getenv("NEW_RELIC_LICENSE_KEY"))
+}
+
+func TestApplyInstallConfigShouldFailWhenLicenseKeyIsMissing(t *testing.T) {
+	t.Setenv("MY_LICENSE_KEY", "")
+	cfg := &InstallConfig{LicenseKey: InstallConfigLicenseKey{Env: "MY_LICENSE_KEY"}}
+	ic := types.InstallerContext{RecipeVars: map[string]string{}}
+
+	err := applyInstallConfig(&ic, cfg)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MY_LICENSE_KEY")
+}
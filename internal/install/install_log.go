@@ -0,0 +1,94 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+)
+
+// installLogTransport logs the metadata of each HTTP request made during an install,
+// so it's captured in the install log. Query strings are left out as they can hold keys.
+type installLogTransport struct {
+	next http.RoundTripper
+}
+
+func (t *installLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	fields := log.Fields{
+		"method":     req.Method,
+		"host":       req.URL.Host,
+		"path":       req.URL.Path,
+		"durationMs": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		log.WithFields(fields).Debugf("http request failed: %s", err)
+		return resp, err
+	}
+
+	fields["statusCode"] = resp.StatusCode
+	log.WithFields(fields).Debug("http request")
+
+	return resp, nil
+}
+
+// initInstallLog creates the log file for this install and starts logging HTTP requests to it.
+// It returns the path of the log file, or an empty string when it couldn't be created.
+func initInstallLog() string {
+	path, err := config.InitInstallLogFile()
+	if err != nil {
+		log.Debugf("could not create install log: %s", err)
+		return ""
+	}
+
+	if _, ok := http.DefaultTransport.(*installLogTransport); !ok {
+		http.DefaultTransport = &installLogTransport{next: http.DefaultTransport}
+	}
+
+	return path
+}
+
+func printInstallLogPath(w io.Writer, path string) {
+	if path == "" {
+		return
+	}
+
+	fmt.Fprintf(w, "  A detailed log of this install was written to %s\n", path)
+	fmt.Fprint(w, "  Please include it when contacting New Relic support.\n\n")
+}
+
+// sendInstallLog forwards the install log at path to the Logs API of the account, tagged with the
+// host installed onto and the install's ID.
+func (i *RecipeInstall) sendInstallLog(path string) {
+	if path == "" {
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Debugf("could not open install log: %s", err)
+		return
+	}
+	defer f.Close()
+
+	hostname, _ := os.Hostname()
+	if target, err := remote.ParseTarget(i.Target); err == nil {
+		hostname = target.Host
+	}
+
+	if err := i.recipeLogForwarder.SendInstallLogToNewRelic(f, i.status.InstallID, hostname); err != nil {
+		log.Warnf("could not send the install log to New Relic: %s", err)
+		return
+	}
+
+	fmt.Fprintf(i.out, "  The install log was sent to New Relic, query it with: SELECT * FROM Log WHERE `nr-install-id` = '%s'\n\n", i.status.InstallID)
+}
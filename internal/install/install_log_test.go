@@ -0,0 +1,54 @@
+package install
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+)
+
+func TestInstallLogTransportShouldLogRequestMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	level := log.GetLevel()
+	log.SetLevel(log.DebugLevel)
+	defer log.SetLevel(level)
+
+	hook := test.NewLocal(log.StandardLogger())
+	defer hook.Reset()
+
+	client := &http.Client{Transport: &installLogTransport{next: http.DefaultTransport}}
+	resp, err := client.Get(server.URL + "/recipes?apiKey=secret")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, "http request", entry.Message)
+	require.Equal(t, http.MethodGet, entry.Data["method"])
+	require.Equal(t, "/recipes", entry.Data["path"])
+	require.Equal(t, http.StatusAccepted, entry.Data["statusCode"])
+	require.NotContains(t, entry.Data, "secret")
+}
+
+func TestSendInstallLogShouldForwardTheInstallLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "install.log")
+	require.NoError(t, os.WriteFile(path, []byte("{\"msg\":\"installing\"}\n"), 0600))
+
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.sendInstallLog(path)
+	recipeInstall.sendInstallLog("")
+
+	forwarder := recipeInstall.recipeLogForwarder.(*execution.MockRecipeLogForwarder)
+	require.Equal(t, 1, forwarder.SendInstallLogCallCount)
+}
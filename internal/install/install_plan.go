@@ -0,0 +1,422 @@
+package install
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var sensitiveRecipeVars = map[string]bool{
+	"NEW_RELIC_LICENSE_KEY": true,
+	"NEW_RELIC_API_KEY":     true,
+}
+
+// InstallPlan is the ordered set of recipes that an install would execute,
+// built without running any of the recipe tasks.
+type InstallPlan struct {
+	CLIVersion            string           `json:"cliVersion,omitempty" yaml:"cliVersion,omitempty"`
+	InstallLibraryVersion string           `json:"installLibraryVersion,omitempty" yaml:"installLibraryVersion,omitempty"`
+	Recipes               []*PlannedRecipe `json:"recipes" yaml:"recipes"`
+}
+
+// PlannedRecipe describes a single recipe within an InstallPlan.
+type PlannedRecipe struct {
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"displayName" yaml:"displayName"`
+	// Action is upgrade or skip for recipes already installed on the host, and empty for recipes
+	// that would be installed.
+	Action string           `json:"action,omitempty" yaml:"action,omitempty"`
+	Tasks  []string         `json:"tasks" yaml:"tasks"`
+	Vars   types.RecipeVars `json:"vars" yaml:"vars"`
+	// VarsError holds the reason the recipe variables could not be fully resolved, if any.
+	VarsError string `json:"varsError,omitempty" yaml:"varsError,omitempty"`
+	// Version is the version of the recipe library the recipe was released in, if known.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Checksum identifies the exact recipe definition the plan was built from.
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	// Inputs are the resolved input and user provided variables that applying the plan sets,
+	// without any secrets.
+	Inputs types.RecipeVars `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	// Script is the shell the recipe's tasks run, rendered with its variables, when rendering.
+	Script string `json:"script,omitempty" yaml:"script,omitempty"`
+}
+
+// The actions of an install plan for the recipes already installed on the host.
+const (
+	PlannedActionUpgrade = "upgrade"
+	PlannedActionSkip    = "skip"
+)
+
+// LoadInstallPlan reads an install plan written by "newrelic install plan".
+func LoadInstallPlan(path string) (*InstallPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read install plan %s: %w", path, err)
+	}
+
+	plan := &InstallPlan{}
+	if err := yaml.Unmarshal(data, plan); err != nil {
+		return nil, fmt.Errorf("could not parse install plan %s: %w", path, err)
+	}
+
+	if len(plan.Recipes) == 0 {
+		return nil, fmt.Errorf("install plan %s has no recipes to install", path)
+	}
+
+	for _, r := range plan.Recipes {
+		if r.Name == "" {
+			return nil, fmt.Errorf("install plan %s has a recipe without a name", path)
+		}
+	}
+
+	return plan, nil
+}
+
+func (p *InstallPlan) ContainsRecipe(name string) bool {
+	for _, r := range p.Recipes {
+		if r.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Write writes the plan as YAML, as read by LoadInstallPlan.
+func (p *InstallPlan) Write(w io.Writer) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// RecipeNames returns the names of the planned recipes, in install order.
+func (p *InstallPlan) RecipeNames() []string {
+	names := make([]string, 0, len(p.Recipes))
+	for _, r := range p.Recipes {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+// Versions returns the recipe library version of each planned recipe, by recipe name.
+func (p *InstallPlan) Versions() map[string]string {
+	versions := map[string]string{}
+	for _, r := range p.Recipes {
+		if r.Version != "" {
+			versions[r.Name] = r.Version
+		}
+	}
+
+	return versions
+}
+
+// Checksums returns the checksum of each planned recipe, by recipe name.
+func (p *InstallPlan) Checksums() map[string]string {
+	checksums := map[string]string{}
+	for _, r := range p.Recipes {
+		if r.Checksum != "" {
+			checksums[r.Name] = r.Checksum
+		}
+	}
+
+	return checksums
+}
+
+// Inputs returns the variables of all the planned recipes, since recipe variables are
+// set for the whole install.
+func (p *InstallPlan) Inputs() (map[string]string, error) {
+	inputs := map[string]string{}
+	for _, r := range p.Recipes {
+		for k, v := range r.Inputs {
+			if existing, ok := inputs[k]; ok && existing != v {
+				return nil, fmt.Errorf("the planned recipes set different values for variable %s", k)
+			}
+			inputs[k] = v
+		}
+	}
+
+	return inputs, nil
+}
+
+// Print writes a human readable representation of the plan.
+func (p *InstallPlan) Print(w io.Writer) {
+	if len(p.Recipes) == 0 {
+		fmt.Fprintln(w, "\nNo recipes would be installed on this system.")
+		return
+	}
+
+	fmt.Fprintln(w, "\nThe following recipes would be installed, in order:")
+
+	for idx, r := range p.Recipes {
+		fmt.Fprintf(w, "\n  %d. %s (%s)\n", idx+1, r.DisplayName, r.Name)
+
+		switch r.Action {
+		case PlannedActionUpgrade:
+			fmt.Fprintln(w, "     already installed, would be upgraded")
+		case PlannedActionSkip:
+			fmt.Fprintln(w, "     already installed, would be skipped")
+		}
+
+		if len(r.Tasks) > 0 {
+			fmt.Fprintf(w, "     tasks: %s\n", strings.Join(r.Tasks, ", "))
+		}
+
+		if r.VarsError != "" {
+			fmt.Fprintf(w, "     vars: could not be fully resolved: %s\n", r.VarsError)
+		}
+
+		if len(r.Vars) > 0 {
+			fmt.Fprintln(w, "     vars:")
+			keys := make([]string, 0, len(r.Vars))
+			for k := range r.Vars {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				fmt.Fprintf(w, "       %s=%s\n", k, r.Vars[k])
+			}
+		}
+	}
+
+	fmt.Fprintln(w)
+}
+
+// PrintScripts writes the rendered shell of each recipe of the plan, in order.
+func (p *InstallPlan) PrintScripts(w io.Writer) {
+	if len(p.Recipes) == 0 {
+		fmt.Fprintln(w, "# No recipes would be installed on this system.")
+		return
+	}
+
+	for idx, r := range p.Recipes {
+		fmt.Fprintf(w, "# %d. %s (%s)\n", idx+1, r.DisplayName, r.Name)
+
+		switch r.Action {
+		case PlannedActionUpgrade:
+			fmt.Fprintln(w, "# already installed, would be upgraded")
+		case PlannedActionSkip:
+			fmt.Fprintln(w, "# already installed, would be skipped")
+		}
+
+		if r.VarsError != "" {
+			fmt.Fprintf(w, "# vars could not be fully resolved: %s\n", r.VarsError)
+		}
+
+		fmt.Fprintln(w, r.Script)
+	}
+}
+
+// buildInstallPlan resolves the recipes that would be installed from the given bundles,
+// in the same order the bundle installer would execute them.
+func (i *RecipeInstall) buildInstallPlan(ctx context.Context, m *types.DiscoveryManifest, bundles ...*recipes.Bundle) *InstallPlan {
+	plan := &InstallPlan{
+		CLIVersion:            i.status.CLIVersion,
+		InstallLibraryVersion: i.status.InstallLibraryVersion,
+		Recipes:               []*PlannedRecipe{},
+	}
+
+	for _, b := range bundles {
+		if b == nil {
+			continue
+		}
+
+		for _, br := range b.BundleRecipes {
+			i.addBundleRecipeToPlan(ctx, plan, m, br)
+		}
+	}
+
+	return plan
+}
+
+func (i *RecipeInstall) addBundleRecipeToPlan(ctx context.Context, plan *InstallPlan, m *types.DiscoveryManifest, br *recipes.BundleRecipe) {
+	if !br.HasStatus(execution.RecipeStatusTypes.AVAILABLE) {
+		return
+	}
+
+	for _, d := range br.Dependencies {
+		i.addBundleRecipeToPlan(ctx, plan, m, d)
+	}
+
+	if plan.ContainsRecipe(br.Recipe.Name) {
+		return
+	}
+
+	plan.Recipes = append(plan.Recipes, i.planRecipe(ctx, m, br.Recipe))
+}
+
+func (i *RecipeInstall) planRecipe(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe) *PlannedRecipe {
+	pr := &PlannedRecipe{
+		Name:        r.Name,
+		DisplayName: r.DisplayName,
+		Action:      i.plannedAction(ctx, r),
+		Tasks:       getRecipeTaskNames(r),
+		Vars:        types.RecipeVars{},
+		Version:     i.recipeVersion(r.Name),
+		Checksum:    recipeChecksum(r),
+		Inputs:      types.RecipeVars{},
+	}
+
+	if pr.DisplayName == "" {
+		pr.DisplayName = r.Name
+	}
+
+	if pr.Action == PlannedActionUpgrade {
+		u := r.ToUpgradeRecipe()
+		pr.Tasks = getRecipeTaskNames(&u)
+	}
+
+	// Variables are always resolved non-interactively so a plan never prompts.
+	vars, err := i.recipeVarPreparer.Prepare(*m, *r, true)
+	if err != nil {
+		log.Debugf("could not resolve vars for recipe %s: %s", r.Name, err)
+		pr.VarsError = err.Error()
+	}
+
+	secrets := map[string]bool{}
+	inputs := map[string]bool{}
+	for _, iv := range r.InputVars {
+		if iv.Secret {
+			secrets[iv.Name] = true
+		}
+		inputs[iv.Name] = true
+	}
+
+	for k := range i.RecipeFileVars {
+		inputs[k] = true
+	}
+
+	for k := range i.RecipeVars {
+		inputs[k] = true
+	}
+
+	for k, v := range vars {
+		if sensitiveRecipeVars[k] || secrets[k] {
+			pr.Vars[k] = utils.Obfuscate(v)
+			continue
+		}
+		pr.Vars[k] = v
+
+		if inputs[k] {
+			pr.Inputs[k] = v
+		}
+	}
+
+	if i.RenderOnly && pr.Action != PlannedActionSkip {
+		rendered := *r
+		if pr.Action == PlannedActionUpgrade {
+			rendered = r.ToUpgradeRecipe()
+		}
+
+		// The script is rendered with the obfuscated secrets, so they aren't printed.
+		script, err := execution.RenderRecipe(rendered, pr.Vars)
+		if err != nil {
+			log.Debugf("could not render tasks for recipe %s: %s", r.Name, err)
+			script = fmt.Sprintf("# could not render the tasks: %s\n", err)
+		}
+		pr.Script = script
+	}
+
+	return pr
+}
+
+// plannedAction returns what installing the plan would do with a recipe already installed on the
+// host, without prompting, or an empty string when the recipe isn't installed.
+func (i *RecipeInstall) plannedAction(ctx context.Context, r *types.OpenInstallationRecipe) string {
+	if !i.isAlreadyInstalled(ctx, r) {
+		return ""
+	}
+
+	if i.Upgrade && i.canUpgrade(ctx, r) {
+		return PlannedActionUpgrade
+	}
+
+	return PlannedActionSkip
+}
+
+// verifyRecipeChecksums checks that the recipes fetched for the install are the ones an applied
+// install plan or lockfile was created from, so they install exactly as reviewed. Recipes that
+// don't support this host are left to recipe detection to report.
+func (i *RecipeInstall) verifyRecipeChecksums(repo *recipes.RecipeRepository) error {
+	names := make([]string, 0, len(i.RecipeChecksums))
+	for name := range i.RecipeChecksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r := repo.FindRecipeByName(name)
+		if r == nil {
+			log.Debugf("recipe %s of the install plan or lockfile is not available for this host", name)
+			continue
+		}
+
+		if recipeChecksum(r) != i.RecipeChecksums[name] {
+			return fmt.Errorf("recipe %s has changed since the install plan or lockfile was created", name)
+		}
+	}
+
+	return nil
+}
+
+// recipeVersion returns the version of the recipe library a recipe is installed from.
+func (i *RecipeInstall) recipeVersion(name string) string {
+	if v, ok := i.RecipeVersions[name]; ok {
+		return v
+	}
+
+	return i.status.InstallLibraryVersion
+}
+
+// recipeChecksum returns a SHA-256 checksum of the recipe definition.
+func recipeChecksum(r *types.OpenInstallationRecipe) string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		log.Debugf("could not compute checksum for recipe %s: %s", r.Name, err)
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// getRecipeTaskNames returns the go-task task names defined by a recipe, in file order.
+func getRecipeTaskNames(r *types.OpenInstallationRecipe) []string {
+	names := []string{}
+
+	var taskFile struct {
+		Tasks yaml.MapSlice `yaml:"tasks"`
+	}
+
+	if err := yaml.Unmarshal([]byte(r.Install), &taskFile); err != nil {
+		log.Debugf("could not parse tasks for recipe %s: %s", r.Name, err)
+		return names
+	}
+
+	for _, t := range taskFile.Tasks {
+		if name, ok := t.Key.(string); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,251 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestDryRunShouldNotExecuteRecipes(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).WithStatusReporter(statusReporter).Build()
+	recipeInstall.DryRun = true
+
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, statusReporter.RecipeInstallingCallCount, "Installing Count")
+	assert.Equal(t, 0, statusReporter.RecipeInstalledCallCount, "Installed Count")
+}
+
+func TestBuildInstallPlanShouldOrderDependenciesFirst(t *testing.T) {
+	dependency := recipes.NewRecipeBuilder().Name("dependency").BuildBundleRecipe()
+	dependency.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	parent := recipes.NewRecipeBuilder().Name("parent").Dependency(dependency).BuildBundleRecipe()
+	parent.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	unavailable := recipes.NewRecipeBuilder().Name("unavailable").BuildBundleRecipe()
+	bundle := &recipes.Bundle{BundleRecipes: []*recipes.BundleRecipe{parent, dependency, unavailable}}
+
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	plan := recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+
+	assert.Equal(t, 2, len(plan.Recipes))
+	assert.Equal(t, "dependency", plan.Recipes[0].Name)
+	assert.Equal(t, "parent", plan.Recipes[1].Name)
+}
+
+func TestBuildInstallPlanShouldIncludeTasksAndObfuscatedVars(t *testing.T) {
+	br := recipes.NewRecipeBuilder().Name("recipe1").InstallGoTaskScript(`
+version: '3'
+tasks:
+  default:
+    cmds:
+      - task: setup
+      - task: restart
+  setup:
+    cmds:
+      - echo setup
+  restart:
+    cmds:
+      - echo restart
+`).BuildBundleRecipe()
+	br.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	bundle := &recipes.Bundle{BundleRecipes: []*recipes.BundleRecipe{br}}
+
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeVarValues(map[string]string{
+		"NEW_RELIC_LICENSE_KEY": "0123456789abcdef",
+		"HOSTNAME":              "myhost",
+	}, nil).Build()
+	plan := recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+
+	assert.Equal(t, []string{"default", "setup", "restart"}, plan.Recipes[0].Tasks)
+	assert.Equal(t, "myhost", plan.Recipes[0].Vars["HOSTNAME"])
+	assert.Equal(t, "01234567********", plan.Recipes[0].Vars["NEW_RELIC_LICENSE_KEY"])
+
+	var out bytes.Buffer
+	plan.Print(&out)
+	assert.Contains(t, out.String(), "1. recipe1 (recipe1)")
+	assert.Contains(t, out.String(), "tasks: default, setup, restart")
+	assert.NotContains(t, out.String(), "0123456789abcdef")
+}
+
+func TestBuildInstallPlanShouldRenderScripts(t *testing.T) {
+	br := recipes.NewRecipeBuilder().Name("recipe1").InstallGoTaskScript(`
+version: '3'
+tasks:
+  default:
+    cmds:
+      - echo {{.HOSTNAME}} {{.NEW_RELIC_LICENSE_KEY}}
+`).BuildBundleRecipe()
+	br.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	bundle := &recipes.Bundle{BundleRecipes: []*recipes.BundleRecipe{br}}
+
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeVarValues(map[string]string{
+		"NEW_RELIC_LICENSE_KEY": "0123456789abcdef",
+		"HOSTNAME":              "myhost",
+	}, nil).Build()
+	recipeInstall.RenderOnly = true
+	plan := recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+
+	var out bytes.Buffer
+	plan.PrintScripts(&out)
+	assert.Contains(t, out.String(), "# 1. recipe1 (recipe1)\n# task: default\necho myhost 01234567********\n")
+}
+
+func TestBuildInstallPlanShouldRecordVarErrors(t *testing.T) {
+	br := recipes.NewRecipeBuilder().Name("recipe1").BuildBundleRecipe()
+	br.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	bundle := &recipes.Bundle{BundleRecipes: []*recipes.BundleRecipe{br}}
+
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeVarValues(nil, errors.New("no default value")).Build()
+	plan := recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+
+	assert.Equal(t, "no default value", plan.Recipes[0].VarsError)
+}
+
+func TestDryRunShouldWritePlanOutput(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).Build()
+	recipeInstall.DryRun = true
+
+	var out bytes.Buffer
+	err := recipeInstall.WithPlanOutput(&out).Install()
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "name: "+types.InfraAgentRecipeName)
+	assert.Contains(t, out.String(), "checksum: ")
+}
+
+func TestDryRunShouldPrintEverythingElseToItsOutput(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).Build()
+	recipeInstall.DryRun = true
+
+	var plan, out bytes.Buffer
+	err := recipeInstall.WithPlanOutput(&plan).WithOutput(&out).Install()
+
+	assert.NoError(t, err)
+	assert.Contains(t, plan.String(), "name: "+types.InfraAgentRecipeName)
+	assert.NotContains(t, plan.String(), "Welcome to New Relic")
+	assert.Contains(t, out.String(), "Welcome to New Relic")
+	assert.Contains(t, out.String(), "Connecting to New Relic Platform")
+}
+
+func TestInstallPlanShouldRoundTripWithoutSecrets(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name("recipe1").Build()
+	recipe.InputVars = []types.OpenInstallationRecipeInputVariable{
+		{Name: "MYSQL_PORT"},
+		{Name: "MYSQL_PASSWORD", Secret: true},
+	}
+	br := &recipes.BundleRecipe{Recipe: recipe}
+	br.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	bundle := &recipes.Bundle{BundleRecipes: []*recipes.BundleRecipe{br}}
+
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeVarValues(map[string]string{
+		"NEW_RELIC_LICENSE_KEY": "0123456789abcdef",
+		"HOSTNAME":              "myhost",
+		"MYSQL_PORT":            "3306",
+		"MYSQL_PASSWORD":        "supersecret",
+	}, nil).Build()
+	plan := recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	assert.NoError(t, plan.Write(f))
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "0123456789abcdef")
+	assert.NotContains(t, string(data), "supersecret")
+
+	loaded, err := LoadInstallPlan(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"recipe1"}, loaded.RecipeNames())
+	assert.Equal(t, map[string]string{"recipe1": recipeChecksum(recipe)}, loaded.Checksums())
+
+	inputs, err := loaded.Inputs()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"MYSQL_PORT": "3306"}, inputs)
+}
+
+func TestLoadInstallPlanShouldRejectEmptyPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("recipes: []\n"), 0600))
+
+	_, err := LoadInstallPlan(path)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has no recipes to install")
+}
+
+func TestInstallPlanInputsShouldRejectConflictingValues(t *testing.T) {
+	plan := &InstallPlan{Recipes: []*PlannedRecipe{
+		{Name: "recipe1", Inputs: types.RecipeVars{"PORT": "3306"}},
+		{Name: "recipe2", Inputs: types.RecipeVars{"PORT": "5432"}},
+	}}
+
+	_, err := plan.Inputs()
+
+	assert.Error(t, err)
+}
+
+func TestInstallShouldRejectRecipesChangedSinceThePlan(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name("recipe1").Build()
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal([]*types.OpenInstallationRecipe{recipe}).WithStatusReporter(statusReporter).Build()
+	recipeInstall.RecipeChecksums = map[string]string{"recipe1": "stale"}
+
+	err := recipeInstall.Install()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "recipe recipe1 has changed since the install plan or lockfile was created")
+	assert.Equal(t, 0, statusReporter.RecipeInstallingCallCount)
+}
+
+func TestBuildInstallPlanShouldPlanUpgradesOfInstalledRecipes(t *testing.T) {
+	br := recipes.NewRecipeBuilder().Name("recipe1").BuildBundleRecipe()
+	br.Recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Packages: []string{"newrelic-infra"}}
+	br.Recipe.Upgrade = `
+version: '3'
+tasks:
+  upgrade_infra:
+    cmds:
+      - apt-get install --only-upgrade newrelic-infra
+`
+	br.AddDetectionStatus(execution.RecipeStatusTypes.AVAILABLE, 0)
+	bundle := &recipes.Bundle{BundleRecipes: []*recipes.BundleRecipe{br}}
+
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	plan := recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+	assert.Equal(t, PlannedActionSkip, plan.Recipes[0].Action)
+
+	recipeInstall.Upgrade = true
+	plan = recipeInstall.buildInstallPlan(context.Background(), &types.DiscoveryManifest{}, bundle)
+	assert.Equal(t, PlannedActionUpgrade, plan.Recipes[0].Action)
+	assert.Equal(t, []string{"upgrade_infra"}, plan.Recipes[0].Tasks)
+
+	var out bytes.Buffer
+	plan.Print(&out)
+	assert.Contains(t, out.String(), "already installed, would be upgraded")
+}
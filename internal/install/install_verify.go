@@ -0,0 +1,157 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// verifyTimeout is how long the validation query of an installed recipe is polled for before its
+// data is reported missing.
+var verifyTimeout = 30 * time.Second
+
+// VerificationResult is whether a recipe installed on the host is still reporting data.
+type VerificationResult struct {
+	Component *ComponentStatus
+	// Checked is whether the recipe has a validation query to check its data with.
+	Checked   bool
+	Reporting bool
+	Error     string
+}
+
+// VerifyHostStatus prints whether each of the recipes installed on this host is still reporting data,
+// by running its validation query, and returns an error when any of them isn't.
+func (i *RecipeInstall) VerifyHostStatus(w io.Writer) error {
+	ctx, cancel := context.WithCancel(utils.SignalCtx)
+	defer cancel()
+
+	m, err := i.discoverer.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("there was an error discovering system info: %s", err)
+	}
+
+	repo := recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
+		return i.recipeFetcher.FetchRecipes(ctx)
+	}, m)
+
+	all, err := repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	statuses := i.hostInspector.Inspect(ctx, all)
+	if len(statuses) == 0 {
+		fmt.Fprintln(w, "No New Relic instrumentation was found on this host.")
+		return nil
+	}
+
+	results := i.verifyComponents(ctx, m, all, statuses)
+	printVerificationResults(w, results)
+
+	missing := 0
+	for _, r := range results {
+		if r.Checked && !r.Reporting {
+			missing++
+		}
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("%d of the %d components installed on this host aren't reporting data", missing, len(results))
+	}
+
+	return nil
+}
+
+// verifyComponents runs the validation query of each installed component's recipe at the same time,
+// returning the results in the order of the components.
+func (i *RecipeInstall) verifyComponents(ctx context.Context, m *types.DiscoveryManifest, all []*types.OpenInstallationRecipe, statuses []*ComponentStatus) []*VerificationResult {
+	byName := map[string]*types.OpenInstallationRecipe{}
+	for _, r := range all {
+		byName[r.Name] = r
+	}
+
+	results := make([]*VerificationResult, len(statuses))
+	var wg sync.WaitGroup
+	for idx, s := range statuses {
+		results[idx] = &VerificationResult{Component: s}
+
+		r, ok := byName[s.Name]
+		if !ok || r.ValidationNRQL == "" || i.Offline {
+			continue
+		}
+
+		results[idx].Checked = true
+		wg.Add(1)
+		go func(result *VerificationResult, r *types.OpenInstallationRecipe) {
+			defer wg.Done()
+
+			vars, err := i.recipeVarPreparer.Prepare(*m, *r, true)
+			if err == nil {
+				timeoutCtx, cancel := context.WithTimeout(ctx, verifyTimeout)
+				defer cancel()
+
+				_, err = i.recipeValidator.ValidateRecipe(timeoutCtx, *m, *r, vars)
+			}
+
+			if err != nil {
+				log.Debugf("no data was found for recipe %s: %s", r.Name, err)
+				result.Error = err.Error()
+				return
+			}
+			result.Reporting = true
+		}(results[idx], r)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func printVerificationResults(w io.Writer, results []*VerificationResult) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	if ux.ASCIIMode {
+		t.SetStyle(table.StyleDefault)
+	}
+	t.AppendHeader(table.Row{"", "Component", "Version", "Data"})
+
+	for _, r := range results {
+		icon := ux.IconSuccess
+		data := "reporting"
+		switch {
+		case !r.Checked:
+			data = "not checked, the recipe has no validation query"
+		case !r.Reporting:
+			icon = ux.IconError
+			data = "not reporting"
+		}
+
+		for _, svc := range r.Component.Services {
+			if !svc.Running {
+				data += fmt.Sprintf(", %s is not running", svc.Name)
+				if icon == ux.IconSuccess {
+					icon = ux.IconExclamation
+				}
+			}
+		}
+
+		version := r.Component.Version
+		if version == "" {
+			version = "-"
+		}
+
+		t.AppendRow(table.Row{icon, r.Component.DisplayName, version, data})
+	}
+
+	t.Render()
+}
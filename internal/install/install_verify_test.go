@@ -0,0 +1,43 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestVerifyComponentsShouldRunValidationQueries(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeValidationError(errors.New("reached max validation attempts")).Build()
+	statuses := []*ComponentStatus{
+		{Name: "mysql-open-source-integration", DisplayName: "MySQL Integration"},
+		{Name: "custom", DisplayName: "Custom"},
+	}
+	all := []*types.OpenInstallationRecipe{
+		{Name: "mysql-open-source-integration", ValidationNRQL: "SELECT count(*) FROM MysqlSample SINCE 10 minutes ago"},
+		{Name: "custom"},
+	}
+
+	results := recipeInstall.verifyComponents(context.Background(), &types.DiscoveryManifest{}, all, statuses)
+
+	assert.True(t, results[0].Checked)
+	assert.False(t, results[0].Reporting)
+	assert.Contains(t, results[0].Error, "reached max validation attempts")
+	assert.False(t, results[1].Checked)
+}
+
+func TestPrintVerificationResultsShouldShowMissingData(t *testing.T) {
+	var out bytes.Buffer
+
+	printVerificationResults(&out, []*VerificationResult{
+		{Component: &ComponentStatus{DisplayName: "Infrastructure Agent", Version: "1.48.0"}, Checked: true, Reporting: true},
+		{Component: &ComponentStatus{DisplayName: "MySQL Integration", Services: []ServiceStatus{{Name: "mysql", Running: false}}}, Checked: true},
+	})
+
+	assert.Contains(t, out.String(), "reporting")
+	assert.Contains(t, out.String(), "not reporting, mysql is not running")
+}
@@ -0,0 +1,176 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// isAlreadyInstalled returns whether all of the recipe's installed check is met on the host the
+// recipe would be installed onto, in which case it isn't run again so that hand-tuned
+// configuration isn't overwritten.
+func (i *RecipeInstall) isAlreadyInstalled(ctx context.Context, r *types.OpenInstallationRecipe) bool {
+	if !r.HasInstalledCheck() {
+		return false
+	}
+
+	// The check is run as a shell script, on the remote target too, since the go-task executor of
+	// local installs doesn't run requireAtDiscovery scripts.
+	check := *r
+	check.PreInstall.RequireAtDiscovery = installedCheckScript(r.PreInstall.InstalledCheck)
+
+	if err := i.checkExecutorFactory().ExecutePreInstall(ctx, check, types.RecipeVars{}); err != nil {
+		log.Debugf("recipe %s is not installed: %s", r.Name, err)
+		i.absentRecipes.add(r.Name)
+		return false
+	}
+
+	return true
+}
+
+// absentRecipes are the recipes whose installed check wasn't met, shared by the installers of
+// recipes installed at the same time.
+type absentRecipes struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+func (a *absentRecipes) add(name string) {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.names[name] = true
+}
+
+func (a *absentRecipes) has(name string) bool {
+	if a == nil {
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.names[name]
+}
+
+// canUpgrade returns whether an installed recipe defines upgrade steps and, when the recipe can
+// tell, whether a newer version than the installed one is available.
+func (i *RecipeInstall) canUpgrade(ctx context.Context, r *types.OpenInstallationRecipe) bool {
+	if !r.HasUpgrade() {
+		return false
+	}
+
+	if r.PreInstall.InstalledCheck.UpgradeAvailable == "" {
+		return true
+	}
+
+	check := *r
+	check.PreInstall.RequireAtDiscovery = r.PreInstall.InstalledCheck.UpgradeAvailable
+
+	if err := i.checkExecutorFactory().ExecutePreInstall(ctx, check, types.RecipeVars{}); err != nil {
+		log.Debugf("recipe %s is up to date: %s", r.Name, err)
+		return false
+	}
+
+	return true
+}
+
+// shouldUpgrade returns whether to upgrade an installed recipe, asking when --upgrade isn't given
+// and prompting is possible.
+func (i *RecipeInstall) shouldUpgrade(r *types.OpenInstallationRecipe, assumeYes bool) bool {
+	if i.Upgrade {
+		return true
+	}
+
+	if assumeYes {
+		return false
+	}
+
+	upgrade, err := i.prompter.PromptYesNo(fmt.Sprintf("%s is already installed and can be upgraded. Upgrade it?", r.DisplayName))
+	if err != nil {
+		log.Debugf("could not prompt to upgrade recipe %s: %s", r.Name, err)
+		return false
+	}
+
+	return upgrade
+}
+
+// upgradeRecipe runs the upgrade steps of an installed recipe. The recipe isn't validated again,
+// since it was already reporting before the upgrade.
+func (i *RecipeInstall) upgradeRecipe(ctx context.Context, r *types.OpenInstallationRecipe, vars types.RecipeVars) (string, error) {
+	i.status.RecipeInstalling(execution.RecipeStatusEvent{Recipe: *r})
+
+	u := r.ToUpgradeRecipe()
+	if err := i.executeRecipeWithRetries(ctx, &u, vars); err != nil {
+		if err == types.ErrInterrupt {
+			return "", err
+		}
+
+		err = &types.ExecutionError{Err: fmt.Errorf("upgrade failed for %s: %w", r.Name, err)}
+		i.status.RecipeFailed(execution.RecipeStatusEvent{
+			Recipe:   *r,
+			Msg:      err.Error(),
+			Metadata: i.recipeExecutor.GetOutput().Metadata(),
+		})
+
+		return "", err
+	}
+
+	entityGUID := i.recipeExecutor.GetOutput().EntityGUID()
+	i.status.RecipeInstalled(execution.RecipeStatusEvent{
+		Recipe:     *r,
+		EntityGUID: entityGUID,
+		Metadata:   i.recipeExecutor.GetOutput().Metadata(),
+		Upgraded:   true,
+	})
+
+	return entityGUID, nil
+}
+
+// installedCheckScript returns a script that exits successfully when every file exists, every
+// service is running and every package is installed, whichever service and package manager the
+// host uses.
+func installedCheckScript(c types.OpenInstallationInstalledCheck) string {
+	conditions := []string{}
+
+	for _, f := range c.Files {
+		conditions = append(conditions, fileCondition(f))
+	}
+
+	for _, s := range c.Services {
+		conditions = append(conditions, serviceCondition(s))
+	}
+
+	for _, p := range c.Packages {
+		conditions = append(conditions, packageCondition(p))
+	}
+
+	return strings.Join(conditions, " && \\\n")
+}
+
+func fileCondition(path string) string {
+	return fmt.Sprintf("test -e %s", remote.ShellQuote(path))
+}
+
+func serviceCondition(service string) string {
+	return fmt.Sprintf(
+		"{ systemctl is-active --quiet %[1]s || service %[1]s status || launchctl print system/%[1]s || sc.exe query %[1]s | findstr RUNNING; } >/dev/null 2>&1",
+		remote.ShellQuote(service))
+}
+
+func packageCondition(pkg string) string {
+	return fmt.Sprintf(
+		"{ dpkg-query -W -f='${Status}' %[1]s | grep -q 'ok installed' || rpm -q %[1]s || brew list %[1]s; } >/dev/null 2>&1",
+		remote.ShellQuote(pkg))
+}
@@ -0,0 +1,147 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/segment"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestInstalledCheckScriptShouldRequireEveryFile(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "newrelic-infra.yml")
+	require.NoError(t, os.WriteFile(config, []byte("license_key: abc"), 0600))
+
+	r := recipes.NewRecipeBuilder().Name("test-recipe").Build()
+	executor := execution.NewShRecipeExecutor()
+
+	r.PreInstall.RequireAtDiscovery = installedCheckScript(types.OpenInstallationInstalledCheck{Files: []string{config}})
+	assert.NoError(t, executor.ExecutePreInstall(context.Background(), *r, types.RecipeVars{}))
+
+	r.PreInstall.RequireAtDiscovery = installedCheckScript(types.OpenInstallationInstalledCheck{Files: []string{config, filepath.Join(dir, "missing.yml")}})
+	assert.Error(t, executor.ExecutePreInstall(context.Background(), *r, types.RecipeVars{}))
+}
+
+func TestInstalledCheckScriptShouldQuoteNames(t *testing.T) {
+	script := installedCheckScript(types.OpenInstallationInstalledCheck{
+		Files:    []string{"/etc/it's.yml"},
+		Services: []string{"newrelic-infra"},
+		Packages: []string{"newrelic-infra"},
+	})
+
+	assert.Contains(t, script, `test -e '/etc/it'\''s.yml'`)
+	assert.Contains(t, script, "systemctl is-active --quiet 'newrelic-infra'")
+	assert.Contains(t, script, "rpm -q 'newrelic-infra'")
+}
+
+func TestInstallShouldNotRunAlreadyInstalledRecipes(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Files: []string{"/etc/newrelic-infra.yml"}}
+	r := &recipes.RecipeDetectionResult{Recipe: recipe, Status: execution.RecipeStatusTypes.AVAILABLE}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).WithStatusReporter(statusReporter).Build()
+
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Empty(t, recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecutedRecipeNames)
+	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount, "Installed Count")
+	assert.True(t, recipeInstall.status.Statuses[0].AlreadyInstalled)
+}
+
+func TestRecipeInstallerShouldCheckInstalledRecipesOnLocalHost(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "newrelic-infra.yml")
+	require.NoError(t, os.WriteFile(config, []byte("license_key: abc"), 0600))
+
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Files: []string{config}, UpgradeAvailable: "exit 0"}
+	recipe.Upgrade = "version: '3'"
+	recipeInstall := NewRecipeInstaller(types.InstallerContext{}, &newrelic.NewRelic{}, segment.NewNoOp())
+
+	assert.True(t, recipeInstall.isAlreadyInstalled(context.Background(), recipe))
+	assert.True(t, recipeInstall.canUpgrade(context.Background(), recipe))
+	assert.Equal(t, PlannedActionSkip, recipeInstall.plannedAction(context.Background(), recipe))
+
+	recipeInstall.Upgrade = true
+	assert.Equal(t, PlannedActionUpgrade, recipeInstall.plannedAction(context.Background(), recipe))
+
+	recipe.PreInstall.InstalledCheck.Files = []string{filepath.Join(dir, "missing.yml")}
+	assert.False(t, recipeInstall.isAlreadyInstalled(context.Background(), recipe))
+	assert.Equal(t, "", recipeInstall.plannedAction(context.Background(), recipe))
+}
+
+func TestInstallShouldRunRecipesNotInstalled(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Services: []string{"newrelic-infra"}}
+	r := &recipes.RecipeDetectionResult{Recipe: recipe, Status: execution.RecipeStatusTypes.AVAILABLE}
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).Build()
+	executor := recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor)
+	executor.ExecutePreInstallErr = errors.New("exit status 1")
+
+	alreadyInstalled := recipeInstall.isAlreadyInstalled(context.Background(), recipe)
+
+	assert.False(t, alreadyInstalled)
+}
+
+func TestInstallShouldRunRecipesWithoutInstalledCheck(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	recipeInstall := NewRecipeInstallBuilder().Build()
+
+	assert.False(t, recipeInstall.isAlreadyInstalled(context.Background(), recipe))
+}
+
+func TestInstallShouldUpgradeInstalledRecipesWithUpgradeFlag(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Packages: []string{"newrelic-infra"}}
+	recipe.Upgrade = "version: '3'"
+	r := &recipes.RecipeDetectionResult{Recipe: recipe, Status: execution.RecipeStatusTypes.AVAILABLE}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).WithStatusReporter(statusReporter).Build()
+	recipeInstall.Upgrade = true
+
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{types.InfraAgentRecipeName}, recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecutedRecipeNames)
+	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount, "Installed Count")
+	assert.True(t, recipeInstall.status.Statuses[0].Upgraded)
+}
+
+func TestInstallShouldNotUpgradeUpToDateRecipes(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Packages: []string{"newrelic-infra"}, UpgradeAvailable: "exit 1"}
+	recipe.Upgrade = "version: '3'"
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.checkExecutorFactory = func() execution.RecipeExecutor {
+		return execution.NewShRecipeExecutor()
+	}
+
+	assert.False(t, recipeInstall.canUpgrade(context.Background(), recipe))
+
+	recipe.PreInstall.InstalledCheck.UpgradeAvailable = "exit 0"
+	assert.True(t, recipeInstall.canUpgrade(context.Background(), recipe))
+}
+
+func TestInstallShouldAskToUpgradeInstalledRecipes(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	rib := NewRecipeInstallBuilder()
+	recipeInstall := rib.Build()
+
+	assert.False(t, recipeInstall.shouldUpgrade(recipe, true))
+	assert.Equal(t, 0, rib.prompter.PromptYesNoCallCount)
+
+	recipeInstall.shouldUpgrade(recipe, false)
+	assert.Equal(t, 1, rib.prompter.PromptYesNoCallCount)
+}
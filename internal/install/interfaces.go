@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/preflight"
 	"github.com/newrelic/newrelic-cli/internal/install/recipes"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
@@ -21,6 +22,8 @@ type Discoverer interface {
 type Prompter interface {
 	PromptYesNo(msg string) (bool, error)
 	MultiSelect(msg string, options []string) ([]string, error)
+	Select(msg string, options []string, defaultOption string) (string, error)
+	Input(msg string, defaultValue string) (string, error)
 }
 
 type ProcessEvaluator interface {
@@ -47,6 +50,12 @@ type AgentValidator interface {
 	Validate(ctx context.Context, url string) (string, error)
 }
 
+// EntityTagger adds the install's tags to the entities created by recipes.
+type EntityTagger interface {
+	AddTags(tags []string)
+	TagEntity(ctx context.Context, entityGUID string) error
+}
+
 type RecipeVarPreparer interface {
 	Prepare(m types.DiscoveryManifest, r types.OpenInstallationRecipe, assumeYes bool) (types.RecipeVars, error)
 }
@@ -61,12 +70,18 @@ type RecipeInstaller interface {
 	executeAndValidate(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, vars types.RecipeVars, assumeYes bool) (string, error)
 	validateRecipeViaAllMethods(ctx context.Context, r *types.OpenInstallationRecipe, m *types.DiscoveryManifest, vars types.RecipeVars, assumeYes bool) (string, error)
 	executeAndValidateWithProgress(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, assumeYes bool) (string, error)
+	cloneForConcurrentInstall() RecipeInstaller
+}
+
+type PreflightChecker interface {
+	Check(ctx context.Context, recipes []*types.OpenInstallationRecipe) *preflight.Report
 }
 
 type RecipeBundler interface {
 	CreateCoreBundle() *recipes.Bundle
 	CreateAdditionalTargetedBundle(names []string) *recipes.Bundle
 	CreateAdditionalGuidedBundle() *recipes.Bundle
+	SplitEarlyBundle(bundle *recipes.Bundle) (*recipes.Bundle, *recipes.Bundle)
 }
 type RecipeBundleInstaller interface {
 	InstallStopOnError(bundle *recipes.Bundle, assumeYes bool) error
@@ -0,0 +1,199 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+const (
+	// KubernetesRecipeName is the recipe the Kubernetes install is reported as, as it is in the
+	// open install library.
+	KubernetesRecipeName = "kubernetes-open-source-integration"
+
+	helmRepoName    = "newrelic"
+	helmRepoURL     = "https://helm-charts.newrelic.com"
+	helmChart       = helmRepoName + "/nri-bundle"
+	helmRelease     = "newrelic-bundle"
+	helmNamespace   = "newrelic"
+	clusterNameHint = "Kubernetes cluster name"
+)
+
+// nriBundleValues are the values of the nri-bundle Helm chart installing the Kubernetes
+// integration, kube-state-metrics, Kubernetes events and logs.
+type nriBundleValues struct {
+	Global struct {
+		LicenseKey string `yaml:"licenseKey"`
+		Cluster    string `yaml:"cluster"`
+	} `yaml:"global"`
+	Infrastructure    chartToggle `yaml:"newrelic-infrastructure"`
+	MetadataInjection chartToggle `yaml:"nri-metadata-injection"`
+	KubeStateMetrics  chartToggle `yaml:"kube-state-metrics"`
+	KubeEvents        chartToggle `yaml:"nri-kube-events"`
+	Logging           chartToggle `yaml:"newrelic-logging"`
+}
+
+type chartToggle struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// KubernetesInstaller installs the Kubernetes integration onto a cluster with its Helm chart,
+// instead of the host agents.
+type KubernetesInstaller struct {
+	ic       *types.InstallerContext
+	prompter Prompter
+	status   *execution.InstallStatus
+	progress ux.ProgressIndicator
+	runFunc  func(ctx context.Context, name string, args ...string) ([]byte, error)
+	lookPath func(string) (string, error)
+}
+
+func NewKubernetesInstaller(ic *types.InstallerContext, prompter Prompter, status *execution.InstallStatus, progress ux.ProgressIndicator) *KubernetesInstaller {
+	return &KubernetesInstaller{
+		ic:       ic,
+		prompter: prompter,
+		status:   status,
+		progress: progress,
+		runFunc:  runHelm,
+		lookPath: exec.LookPath,
+	}
+}
+
+// Install renders the chart's values for the cluster, and applies them with helm, or writes them to
+// the HelmValuesFile of the install to apply later.
+func (k *KubernetesInstaller) Install(ctx context.Context, host *types.KubernetesHost) error {
+	recipe := types.OpenInstallationRecipe{Name: KubernetesRecipeName, DisplayName: "Kubernetes Integration"}
+
+	clusterName, err := k.clusterName(host)
+	if err != nil {
+		return err
+	}
+
+	values, err := renderNRIBundleValues(clusterName, os.Getenv("NEW_RELIC_LICENSE_KEY"))
+	if err != nil {
+		return err
+	}
+
+	if k.ic.HelmValuesFile != "" {
+		if err := os.WriteFile(k.ic.HelmValuesFile, values, 0600); err != nil {
+			return fmt.Errorf("could not write Helm values to %s: %w", k.ic.HelmValuesFile, err)
+		}
+
+		fmt.Printf("Wrote the Helm values of cluster %s to %s. Install them with:\n\n", clusterName, k.ic.HelmValuesFile)
+		fmt.Printf("  helm repo add %s %s\n", helmRepoName, helmRepoURL)
+		fmt.Printf("  %s\n\n", strings.Join(append([]string{"helm"}, helmInstallArgs(host, k.ic.HelmValuesFile)...), " "))
+		return nil
+	}
+
+	k.status.RecipeInstalling(execution.RecipeStatusEvent{Recipe: recipe})
+	msg := fmt.Sprintf("Installing the Kubernetes integration onto cluster %s", clusterName)
+	k.progress.Start(msg)
+
+	err = k.apply(ctx, host, values)
+	event := execution.NewRecipeStatusEvent(&recipe)
+	if err != nil {
+		k.progress.Fail(msg)
+		event.Msg = err.Error()
+		k.status.RecipeFailed(event)
+		return err
+	}
+
+	k.progress.Success(msg)
+	k.status.RecipeInstalled(event)
+
+	return nil
+}
+
+// clusterName returns the cluster's name, asking for it, defaulting to the kubeconfig context, unless
+// the install is unattended.
+func (k *KubernetesInstaller) clusterName(host *types.KubernetesHost) (string, error) {
+	name := k.ic.ClusterName
+	if name == "" && host != nil {
+		name = host.Context
+	}
+
+	if k.ic.ClusterName == "" && !k.ic.AssumeYes {
+		answer, err := k.prompter.Input(clusterNameHint, name)
+		if err != nil {
+			return "", err
+		}
+		name = answer
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("a Kubernetes cluster name is required, set it with --clusterName")
+	}
+
+	return name, nil
+}
+
+func (k *KubernetesInstaller) apply(ctx context.Context, host *types.KubernetesHost, values []byte) error {
+	if _, err := k.lookPath("helm"); err != nil {
+		return errors.New("helm is required to install the Kubernetes integration, see https://helm.sh/docs/intro/install/")
+	}
+
+	f, err := os.CreateTemp("", "nri-bundle-values-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(values)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	commands := [][]string{
+		{"repo", "add", helmRepoName, helmRepoURL, "--force-update"},
+		helmInstallArgs(host, f.Name()),
+	}
+	for _, args := range commands {
+		log.Debugf("Running helm %s", strings.Join(args, " "))
+		if out, err := k.runFunc(ctx, "helm", args...); err != nil {
+			return fmt.Errorf("helm %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	return nil
+}
+
+func helmInstallArgs(host *types.KubernetesHost, valuesFile string) []string {
+	args := []string{"upgrade", "--install", helmRelease, helmChart,
+		"--namespace", helmNamespace, "--create-namespace", "--values", valuesFile}
+
+	if host != nil && host.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", host.Kubeconfig)
+	}
+
+	return args
+}
+
+func renderNRIBundleValues(clusterName string, licenseKey string) ([]byte, error) {
+	v := nriBundleValues{
+		Infrastructure:    chartToggle{Enabled: true},
+		MetadataInjection: chartToggle{Enabled: true},
+		KubeStateMetrics:  chartToggle{Enabled: true},
+		KubeEvents:        chartToggle{Enabled: true},
+		Logging:           chartToggle{Enabled: true},
+	}
+	v.Global.LicenseKey = licenseKey
+	v.Global.Cluster = clusterName
+
+	return yaml.Marshal(v)
+}
+
+func runHelm(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
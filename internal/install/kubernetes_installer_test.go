@@ -0,0 +1,126 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+func newTestKubernetesInstaller(ic *types.InstallerContext, statusReporter *execution.MockStatusSubscriber) *KubernetesInstaller {
+	status := execution.NewInstallStatus(*ic, []execution.StatusSubscriber{statusReporter}, execution.NewPlatformLinkGenerator())
+	k := NewKubernetesInstaller(ic, ux.NewMockPrompter(), status, ux.NewMockProgressIndicator())
+	k.lookPath = func(string) (string, error) { return "/usr/local/bin/helm", nil }
+
+	return k
+}
+
+func TestKubernetesInstallerShouldApplyChartWithHelm(t *testing.T) {
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "0123456789abcdefNRAL")
+	statusReporter := execution.NewMockStatusReporter()
+	k := newTestKubernetesInstaller(&types.InstallerContext{AssumeYes: true}, statusReporter)
+	commands := []string{}
+	values := ""
+	k.runFunc = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		commands = append(commands, name+" "+strings.Join(args[:2], " "))
+		for i, arg := range args {
+			if arg == "--values" {
+				data, err := os.ReadFile(args[i+1])
+				require.NoError(t, err)
+				values = string(data)
+			}
+		}
+		return []byte{}, nil
+	}
+
+	err := k.Install(context.Background(), &types.KubernetesHost{Kubelet: true, Context: "prod-eu"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"helm repo add", "helm upgrade --install"}, commands)
+	require.Contains(t, values, "licenseKey: 0123456789abcdefNRAL")
+	require.Contains(t, values, "cluster: prod-eu")
+	require.Equal(t, 1, statusReporter.RecipeInstalledCallCount)
+}
+
+func TestKubernetesInstallerShouldWriteValuesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	ic := &types.InstallerContext{AssumeYes: true, ClusterName: "staging", HelmValuesFile: path}
+	statusReporter := execution.NewMockStatusReporter()
+	k := newTestKubernetesInstaller(ic, statusReporter)
+	k.runFunc = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		t.Fatal("helm should not run")
+		return nil, nil
+	}
+
+	err := k.Install(context.Background(), nil)
+
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "cluster: staging")
+	require.Contains(t, string(data), "nri-kube-events:\n  enabled: true")
+	require.Equal(t, 0, statusReporter.RecipeInstalledCallCount)
+}
+
+func TestKubernetesInstallerShouldPromptForClusterName(t *testing.T) {
+	k := newTestKubernetesInstaller(&types.InstallerContext{}, execution.NewMockStatusReporter())
+	prompter := ux.NewMockPrompter()
+	prompter.PromptInputVals[clusterNameHint] = "my-cluster"
+	k.prompter = prompter
+
+	name, err := k.clusterName(&types.KubernetesHost{Context: "kind-kind"})
+
+	require.NoError(t, err)
+	require.Equal(t, "my-cluster", name)
+	require.Equal(t, 1, prompter.PromptInputCallCount)
+}
+
+func TestKubernetesInstallerShouldRequireClusterName(t *testing.T) {
+	k := newTestKubernetesInstaller(&types.InstallerContext{AssumeYes: true}, execution.NewMockStatusReporter())
+
+	err := k.Install(context.Background(), &types.KubernetesHost{Kubelet: true})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--clusterName")
+}
+
+func TestKubernetesInstallerShouldFailWhenHelmFails(t *testing.T) {
+	statusReporter := execution.NewMockStatusReporter()
+	k := newTestKubernetesInstaller(&types.InstallerContext{AssumeYes: true, ClusterName: "prod"}, statusReporter)
+	k.runFunc = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		return []byte("Error: Kubernetes cluster unreachable"), errors.New("exit status 1")
+	}
+
+	err := k.Install(context.Background(), nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cluster unreachable")
+	require.Equal(t, 1, statusReporter.RecipeFailedCallCount)
+}
+
+func TestShouldInstallKubernetesWhenDetected(t *testing.T) {
+	m := &types.DiscoveryManifest{Kubernetes: &types.KubernetesHost{Kubelet: true}}
+	recipeInstall := NewRecipeInstallBuilder().Build()
+
+	ok, err := recipeInstall.shouldInstallKubernetes(m)
+	require.NoError(t, err)
+	require.True(t, ok, "interactive installs are offered the Kubernetes install")
+
+	recipeInstall.AssumeYes = true
+	ok, err = recipeInstall.shouldInstallKubernetes(m)
+	require.NoError(t, err)
+	require.False(t, ok, "unattended installs keep installing onto the host")
+
+	recipeInstall.Kubernetes = true
+	ok, err = recipeInstall.shouldInstallKubernetes(&types.DiscoveryManifest{})
+	require.NoError(t, err)
+	require.True(t, ok)
+}
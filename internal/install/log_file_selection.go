@@ -0,0 +1,222 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// discoveredLogConfigVar is the variable the logs recipes read the logging configuration of the
+// selected log files from, with their attributes, as the logs section of a logging.d file.
+const discoveredLogConfigVar = "NR_DISCOVERED_LOG_CONFIG"
+
+const noLogParsingRuleset = "none"
+
+// logParsingRulesets are the built-in parsing rules of New Relic Logs a log file can be parsed with.
+var logParsingRulesets = []string{
+	noLogParsingRuleset, "apache", "apache_error", "cassandra", "haproxy_http", "mongodb", "monit",
+	"mysql-error", "nginx", "nginx-error", "postgresql", "rabbitmq", "redis", "syslog-rfc5424",
+}
+
+// logFileConfig is a log file, or glob of log files, for the logs recipe to forward.
+type logFileConfig struct {
+	Name       string            `yaml:"name"`
+	File       string            `yaml:"file,omitempty"`
+	Pattern    string            `yaml:"pattern,omitempty"`
+	Systemd    string            `yaml:"systemd,omitempty"`
+	Winlog     *logWinlogConfig  `yaml:"winlog,omitempty"`
+	Syslog     *logSyslogConfig  `yaml:"syslog,omitempty"`
+	Attributes map[string]string `yaml:"attributes,omitempty"`
+}
+
+// promptLogFiles lets the log files the logs recipe forwards be reviewed before it writes the
+// logging configuration: the discovered paths can be edited or skipped, custom paths, log daemons
+// and Event Log channels added, and a service name and parsing ruleset set for each. Log files
+// provided with a variable or an install configuration aren't asked for. With AcceptAllLogs,
+// every discovered log file is forwarded without asking, even when the install doesn't prompt.
+func (i *RecipeInstall) promptLogFiles(ctx context.Context, m *types.DiscoveryManifest, repo *recipes.RecipeRepository, availableRecipes recipes.RecipeDetectionResults) error {
+	if (i.AssumeYes && !i.AcceptAllLogs) || i.isRecipeVarProvided(discoveredLogFilesVar) {
+		return nil
+	}
+
+	if _, ok := availableRecipes.GetRecipeDetection(types.LoggingRecipeName); !ok {
+		return nil
+	}
+
+	all, err := repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	matches, _ := recipes.ExcludeLogMatches(i.logMatchFinder.GetPaths(ctx, all), i.ExcludeLogs)
+	if i.AcceptAllLogs {
+		files := []logFileConfig{}
+		for _, lm := range matches {
+			files = append(files, newLogFileConfig(lm))
+		}
+
+		return setLogFileVars(files)
+	}
+
+	fmt.Println("\n  Log files to forward")
+
+	files := []logFileConfig{}
+	for _, lm := range matches {
+		path, err := i.prompter.Input(fmt.Sprintf("Log files of %s (edit the path or glob, leave empty to skip)", lm.Name), lm.File)
+		if err != nil {
+			return err
+		}
+
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+
+		f := newLogFileConfig(lm)
+		f.File = path
+		files = append(files, f)
+	}
+
+	for {
+		path, err := i.prompter.Input("Another log file path or glob to forward (leave empty to continue)", "")
+		if err != nil {
+			return err
+		}
+
+		if path = strings.TrimSpace(path); path == "" {
+			break
+		}
+
+		if recipes.IsLogFileExcluded(path, i.ExcludeLogs) {
+			fmt.Printf("  %s is excluded with --excludeLogs, and won't be forwarded.\n", path)
+			continue
+		}
+
+		files = append(files, logFileConfig{
+			Name:       logFileName(path),
+			File:       path,
+			Attributes: map[string]string{},
+		})
+	}
+
+	sources, err := i.promptLogSources(ctx, m, files)
+	if err != nil {
+		return err
+	}
+	files = append(files, sources...)
+
+	if len(files) > 0 {
+		setAttributes, err := i.prompter.PromptYesNo("Set the service name or parsing ruleset of the log files?")
+		if err != nil {
+			return err
+		}
+
+		if setAttributes {
+			if err := i.promptLogFileAttributes(files); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Println()
+
+	return setLogFileVars(files)
+}
+
+func newLogFileConfig(m types.OpenInstallationLogMatch) logFileConfig {
+	f := logFileConfig{
+		Name:       m.Name,
+		File:       m.File,
+		Pattern:    m.Pattern,
+		Systemd:    m.Systemd,
+		Attributes: map[string]string{},
+	}
+	if m.Attributes.Logtype != "" {
+		f.Attributes["logtype"] = m.Attributes.Logtype
+	}
+
+	return f
+}
+
+func (i *RecipeInstall) promptLogFileAttributes(files []logFileConfig) error {
+	for _, f := range files {
+		serviceName, err := i.prompter.Input(fmt.Sprintf("Service name of %s (leave empty for none)", f.source()), f.Attributes["service.name"])
+		if err != nil {
+			return err
+		}
+
+		if serviceName = strings.TrimSpace(serviceName); serviceName != "" {
+			f.Attributes["service.name"] = serviceName
+		}
+
+		ruleset := noLogParsingRuleset
+		if logtype, ok := f.Attributes["logtype"]; ok {
+			ruleset = logtype
+		}
+
+		ruleset, err = i.prompter.Select(fmt.Sprintf("Parsing ruleset of %s", f.source()), logParsingRulesets, ruleset)
+		if err != nil {
+			return err
+		}
+
+		delete(f.Attributes, "logtype")
+		if ruleset != noLogParsingRuleset {
+			f.Attributes["logtype"] = ruleset
+		}
+	}
+
+	return nil
+}
+
+// setLogFileVars sets the variables the logs recipe configures the log files it forwards with.
+func setLogFileVars(files []logFileConfig) error {
+	paths := []string{}
+	for _, f := range files {
+		if f.File != "" {
+			paths = append(paths, f.File)
+		}
+
+		if f.Syslog != nil {
+			types.RecipeVariables[discoveredSyslogURIVar] = f.Syslog.URI
+		}
+	}
+
+	config, err := yaml.Marshal(struct {
+		Logs []logFileConfig `yaml:"logs"`
+	}{files})
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"logFiles": len(files),
+	}).Debug("selected log files")
+
+	types.RecipeVariables[discoveredLogFilesVar] = strings.Join(paths, ",")
+	types.RecipeVariables[discoveredLogConfigVar] = string(config)
+
+	return nil
+}
+
+// logFileName returns the name to forward a custom log file as: the name of the file, or of its
+// directory when the file name is a glob.
+func logFileName(path string) string {
+	for p := path; p != "." && p != "/" && p != ""; p = filepath.Dir(p) {
+		name := strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))
+		if name = strings.Trim(strings.NewReplacer("*", "", "?", "").Replace(name), ".-_"); name != "" {
+			return name
+		}
+
+		if filepath.Dir(p) == p {
+			break
+		}
+	}
+
+	return "custom"
+}
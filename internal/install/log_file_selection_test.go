@@ -0,0 +1,138 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+func TestPromptLogFilesShouldEditAddAndSetAttributes(t *testing.T) {
+	defer resetRecipeVariables()
+	recipeInstall := NewRecipeInstallBuilder().WithLogMatches(
+		types.OpenInstallationLogMatch{Name: "nginx", File: "/var/log/nginx/*.log", Attributes: types.OpenInstallationAttributes{Logtype: "nginx"}},
+		types.OpenInstallationLogMatch{Name: "syslog", File: "/var/log/syslog"},
+	).Build()
+	prompter := ux.NewMockPrompter()
+	prompter.PromptInputVals["Log files of nginx (edit the path or glob, leave empty to skip)"] = "/var/log/nginx/access.log"
+	prompter.PromptInputVals["Log files of syslog (edit the path or glob, leave empty to skip)"] = ""
+	prompter.PromptInputQueues["Another log file path or glob to forward (leave empty to continue)"] = []string{"/opt/app/logs/*.log", ""}
+	prompter.PromptInputVals["Service name of /opt/app/logs/*.log (leave empty for none)"] = "checkout"
+	prompter.PromptSelectVals["Parsing ruleset of /var/log/nginx/access.log"] = "nginx-error"
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), loggingDetection())
+
+	require.NoError(t, err)
+	assert.Equal(t, "/var/log/nginx/access.log,/opt/app/logs/*.log", types.RecipeVariables[discoveredLogFilesVar])
+	assert.Equal(t, `logs:
+- name: nginx
+  file: /var/log/nginx/access.log
+  attributes:
+    logtype: nginx-error
+- name: logs
+  file: /opt/app/logs/*.log
+  attributes:
+    service.name: checkout
+`, types.RecipeVariables[discoveredLogConfigVar])
+}
+
+func TestPromptLogFilesShouldNotPromptWhenLogFilesAreProvided(t *testing.T) {
+	defer resetRecipeVariables()
+	recipeInstall := NewRecipeInstallBuilder().WithLogMatches(types.OpenInstallationLogMatch{Name: "syslog", File: "/var/log/syslog"}).Build()
+	recipeInstall.RecipeVars = map[string]string{discoveredLogFilesVar: "/var/log/app.log"}
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), loggingDetection())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptInputCallCount)
+
+	recipeInstall.RecipeVars = nil
+	recipeInstall.AssumeYes = true
+	err = recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), loggingDetection())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptInputCallCount)
+}
+
+func TestPromptLogFilesShouldAcceptAllLogsWithoutPrompting(t *testing.T) {
+	defer resetRecipeVariables()
+	recipeInstall := NewRecipeInstallBuilder().WithLogMatches(
+		types.OpenInstallationLogMatch{Name: "nginx", File: "/var/log/nginx/*.log", Attributes: types.OpenInstallationAttributes{Logtype: "nginx"}},
+		types.OpenInstallationLogMatch{Name: "syslog", File: "/var/log/syslog"},
+	).Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.AcceptAllLogs = true
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), loggingDetection())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptInputCallCount)
+	assert.Equal(t, 0, prompter.PromptYesNoCallCount)
+	assert.Equal(t, "/var/log/nginx/*.log,/var/log/syslog", types.RecipeVariables[discoveredLogFilesVar])
+	assert.Contains(t, types.RecipeVariables[discoveredLogConfigVar], "logtype: nginx")
+}
+
+func TestPromptLogFilesShouldNotPromptWithoutLoggingRecipe(t *testing.T) {
+	defer resetRecipeVariables()
+	recipeInstall := NewRecipeInstallBuilder().WithLogMatches(types.OpenInstallationLogMatch{Name: "syslog", File: "/var/log/syslog"}).Build()
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), recipes.RecipeDetectionResults{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, prompter.PromptInputCallCount)
+	assert.NotContains(t, types.RecipeVariables, discoveredLogConfigVar)
+}
+
+func TestPromptLogFilesShouldNotOfferExcludedLogFiles(t *testing.T) {
+	defer resetRecipeVariables()
+	recipeInstall := NewRecipeInstallBuilder().WithLogMatches(
+		types.OpenInstallationLogMatch{Name: "secure", File: "/var/log/secure"},
+		types.OpenInstallationLogMatch{Name: "syslog", File: "/var/log/syslog"},
+	).Build()
+	recipeInstall.ExcludeLogs = []string{"/var/log/secure*"}
+	prompter := ux.NewMockPrompter()
+	prompter.PromptInputQueues["Another log file path or glob to forward (leave empty to continue)"] = []string{"/var/log/secure-20230101", ""}
+	prompter.PromptYesNoVal = false
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), loggingDetection())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, prompter.PromptInputCallCount)
+	assert.Equal(t, "/var/log/syslog", types.RecipeVariables[discoveredLogFilesVar])
+}
+
+func TestLogFileName(t *testing.T) {
+	assert.Equal(t, "access", logFileName("/var/log/nginx/access.log"))
+	assert.Equal(t, "logs", logFileName("/opt/app/logs/*.log"))
+	assert.Equal(t, "log", logFileName("/var/log/*"))
+	assert.Equal(t, "custom", logFileName("*.log"))
+}
+
+func newLogFileSelectionRepo() *recipes.RecipeRepository {
+	return recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
+		return []*types.OpenInstallationRecipe{}, nil
+	}, &types.DiscoveryManifest{})
+}
+
+func loggingDetection() recipes.RecipeDetectionResults {
+	return recipes.RecipeDetectionResults{
+		{
+			Recipe: recipes.NewRecipeBuilder().Name(types.LoggingRecipeName).Build(),
+			Status: execution.RecipeStatusTypes.AVAILABLE,
+		},
+	}
+}
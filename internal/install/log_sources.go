@@ -0,0 +1,196 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+const (
+	journaldProcessName = "systemd-journald"
+	rsyslogProcessName  = "rsyslogd"
+
+	// discoveredSyslogURIVar is the variable the logs recipes read the URI to configure rsyslog to
+	// forward its messages to from.
+	discoveredSyslogURIVar = "NR_DISCOVERED_SYSLOG_URI"
+	defaultSyslogURI       = "tcp://127.0.0.1:5140"
+	defaultSyslogParser    = "rfc3164"
+
+	systemdUnitsScript = "systemctl list-units --type=service --state=running --no-legend --plain"
+
+	// eventLogChannelsScript lists the Windows Event Log channels that have events.
+	eventLogChannelsScript = `powershell.exe -NoProfile -Command 'Get-WinEvent -ListLog * -ErrorAction SilentlyContinue | Where-Object { $_.RecordCount -gt 0 } | ForEach-Object { $_.LogName }'`
+)
+
+// defaultEventLogChannels are the Windows Event Log channels offered first, before the custom
+// channels of applications.
+var defaultEventLogChannels = []string{"Application", "System", "Security"}
+
+// logWinlogConfig is a Windows Event Log channel to forward the events of.
+type logWinlogConfig struct {
+	Channel string `yaml:"channel"`
+}
+
+// logSyslogConfig is a syslog server the infrastructure agent listens on for the messages to forward.
+type logSyslogConfig struct {
+	URI    string `yaml:"uri"`
+	Parser string `yaml:"parser"`
+}
+
+// promptLogSources offers to forward the logs of the system's log daemons that are running, rather
+// than the files they write: the journal of the selected systemd units, and the messages rsyslog
+// forwards to the infrastructure agent. On Windows, the selected Event Log channels are offered.
+func (i *RecipeInstall) promptLogSources(ctx context.Context, m *types.DiscoveryManifest, files []logFileConfig) ([]logFileConfig, error) {
+	if m.OS == "windows" {
+		return i.promptEventLogChannels(ctx)
+	}
+
+	running := map[string]bool{}
+	for _, p := range i.processEvaluator.GetOrLoadProcesses(ctx) {
+		if name, err := p.Name(); err == nil {
+			running[filepath.Base(name)] = true
+		}
+	}
+
+	sources := []logFileConfig{}
+	if running[journaldProcessName] {
+		units, err := i.promptSystemdUnits(ctx, files)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range units {
+			sources = append(sources, logFileConfig{
+				Name:       strings.TrimSuffix(u, ".service"),
+				Systemd:    strings.TrimSuffix(u, ".service"),
+				Attributes: map[string]string{},
+			})
+		}
+	}
+
+	if running[rsyslogProcessName] {
+		forward, err := i.prompter.PromptYesNo("rsyslog is running. Forward the messages it receives?")
+		if err != nil {
+			return nil, err
+		}
+
+		if forward {
+			sources = append(sources, logFileConfig{
+				Name:       "syslog",
+				Syslog:     &logSyslogConfig{URI: defaultSyslogURI, Parser: defaultSyslogParser},
+				Attributes: map[string]string{},
+			})
+		}
+	}
+
+	return sources, nil
+}
+
+// promptSystemdUnits asks which of the running systemd units to forward the journal of, leaving out
+// the units already forwarded.
+func (i *RecipeInstall) promptSystemdUnits(ctx context.Context, files []logFileConfig) ([]string, error) {
+	out, err := i.scriptRunner(ctx, systemdUnitsScript)
+	if err != nil {
+		log.Debugf("could not list the running systemd units: %s", err)
+		return []string{}, nil
+	}
+
+	forwarded := []string{}
+	for _, f := range files {
+		forwarded = append(forwarded, f.Systemd)
+	}
+
+	units := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || utils.StringInSlice(strings.TrimSuffix(fields[0], ".service"), forwarded) {
+			continue
+		}
+		units = append(units, fields[0])
+	}
+
+	if len(units) == 0 {
+		return units, nil
+	}
+
+	forward, err := i.prompter.PromptYesNo("systemd-journald is running. Forward the journal of systemd units?")
+	if err != nil || !forward {
+		return []string{}, err
+	}
+
+	return i.prompter.MultiSelect("Select the systemd units to forward the journal of:", units)
+}
+
+// promptEventLogChannels asks which of the Windows Event Log channels with events to forward: the
+// Application, System and Security logs, and the custom channels of applications.
+func (i *RecipeInstall) promptEventLogChannels(ctx context.Context) ([]logFileConfig, error) {
+	out, err := i.scriptRunner(ctx, eventLogChannelsScript)
+	if err != nil {
+		log.Debugf("could not list the Event Log channels: %s", err)
+		return []logFileConfig{}, nil
+	}
+
+	channels := []string{}
+	custom := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		channel := strings.TrimSpace(line)
+		switch {
+		case channel == "":
+		case utils.StringInSlice(channel, defaultEventLogChannels):
+			channels = append(channels, channel)
+		// Channels with a / are the operational and analytic logs of Windows components.
+		case !strings.Contains(channel, "/"):
+			custom = append(custom, channel)
+		}
+	}
+
+	ordered := []string{}
+	for _, c := range defaultEventLogChannels {
+		if utils.StringInSlice(c, channels) {
+			ordered = append(ordered, c)
+		}
+	}
+	sort.Strings(custom)
+	ordered = append(ordered, custom...)
+
+	sources := []logFileConfig{}
+	if len(ordered) == 0 {
+		return sources, nil
+	}
+
+	selected, err := i.prompter.MultiSelect("Select the Event Log channels to forward:", ordered)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range selected {
+		sources = append(sources, logFileConfig{
+			Name:       "windows-" + strings.ToLower(strings.ReplaceAll(c, " ", "-")),
+			Winlog:     &logWinlogConfig{Channel: c},
+			Attributes: map[string]string{},
+		})
+	}
+
+	return sources, nil
+}
+
+// source returns what the logs of the log file configuration are read from.
+func (f logFileConfig) source() string {
+	switch {
+	case f.Winlog != nil:
+		return fmt.Sprintf("the %s Event Log", f.Winlog.Channel)
+	case f.Syslog != nil:
+		return fmt.Sprintf("syslog messages on %s", f.Syslog.URI)
+	case f.File == "" && f.Systemd != "":
+		return fmt.Sprintf("the journal of %s", f.Systemd)
+	}
+
+	return f.File
+}
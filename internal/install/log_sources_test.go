@@ -0,0 +1,84 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+func TestPromptLogFilesShouldForwardJournaldAndSyslog(t *testing.T) {
+	defer resetRecipeVariables()
+	recipeInstall := NewRecipeInstallBuilder().
+		WithLogMatches(types.OpenInstallationLogMatch{Name: "nginx", File: "/var/log/nginx/*.log", Systemd: "nginx"}).
+		WithRunningProcess("/usr/lib/systemd/systemd-journald", "systemd-journald").
+		WithRunningProcess("/usr/sbin/rsyslogd -n", "rsyslogd").
+		WithScriptOutput(systemdUnitsScript, "nginx.service loaded active running nginx\nsshd.service loaded active running OpenSSH server daemon\n").
+		Build()
+	prompter := ux.NewMockPrompter()
+	prompter.PromptMultiSelectAll = false
+	prompter.PromptMultiSelectVal = []string{"sshd.service"}
+	prompter.PromptYesNoVal = true
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.promptLogFiles(context.Background(), &types.DiscoveryManifest{OS: "linux"}, newLogFileSelectionRepo(), loggingDetection())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, prompter.PromptMultiSelectCallCount)
+	assert.Equal(t, "/var/log/nginx/*.log", types.RecipeVariables[discoveredLogFilesVar])
+	assert.Equal(t, defaultSyslogURI, types.RecipeVariables[discoveredSyslogURIVar])
+	assert.Equal(t, `logs:
+- name: nginx
+  file: /var/log/nginx/*.log
+  systemd: nginx
+- name: sshd
+  systemd: sshd
+- name: syslog
+  syslog:
+    uri: tcp://127.0.0.1:5140
+    parser: rfc3164
+`, types.RecipeVariables[discoveredLogConfigVar])
+}
+
+func TestPromptLogSourcesShouldNotOfferDaemonsThatAreNotRunning(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().
+		WithScriptOutput(systemdUnitsScript, "sshd.service loaded active running OpenSSH server daemon\n").
+		Build()
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	sources, err := recipeInstall.promptLogSources(context.Background(), &types.DiscoveryManifest{OS: "linux"}, []logFileConfig{})
+
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+	assert.Equal(t, 0, prompter.PromptYesNoCallCount)
+}
+
+func TestLogFileConfigSource(t *testing.T) {
+	assert.Equal(t, "/var/log/syslog", logFileConfig{File: "/var/log/syslog"}.source())
+	assert.Equal(t, "the journal of sshd", logFileConfig{Systemd: "sshd"}.source())
+	assert.Equal(t, "syslog messages on tcp://127.0.0.1:5140", logFileConfig{Syslog: &logSyslogConfig{URI: defaultSyslogURI}}.source())
+}
+
+func TestPromptLogSourcesShouldForwardEventLogChannelsOnWindows(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().
+		WithScriptOutput(eventLogChannelsScript, "Security\r\nMicrosoft-Windows-PowerShell/Operational\r\nApplication\r\nMy App\r\nSystem\r\n").
+		Build()
+	prompter := ux.NewMockPrompter()
+	recipeInstall.prompter = prompter
+
+	sources, err := recipeInstall.promptLogSources(context.Background(), &types.DiscoveryManifest{OS: "windows"}, []logFileConfig{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []logFileConfig{
+		{Name: "windows-application", Winlog: &logWinlogConfig{Channel: "Application"}, Attributes: map[string]string{}},
+		{Name: "windows-system", Winlog: &logWinlogConfig{Channel: "System"}, Attributes: map[string]string{}},
+		{Name: "windows-security", Winlog: &logWinlogConfig{Channel: "Security"}, Attributes: map[string]string{}},
+		{Name: "windows-my-app", Winlog: &logWinlogConfig{Channel: "My App"}, Attributes: map[string]string{}},
+	}, sources)
+	assert.Equal(t, "the System Event Log", sources[1].source())
+}
@@ -0,0 +1,24 @@
+package install
+
+import (
+	"context"
+
+	"github.com/newrelic/newrelic-cli/internal/install/preflight"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+type MockPreflightChecker struct {
+	Report         *preflight.Report
+	CheckCallCount int
+}
+
+func NewMockPreflightChecker() *MockPreflightChecker {
+	return &MockPreflightChecker{
+		Report: &preflight.Report{},
+	}
+}
+
+func (m *MockPreflightChecker) Check(ctx context.Context, recipes []*types.OpenInstallationRecipe) *preflight.Report {
+	m.CheckCallCount++
+	return m.Report
+}
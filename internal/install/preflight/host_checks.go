@@ -0,0 +1,349 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	// MinFreeDiskSpaceBytes is the free disk space needed to download and install agents.
+	MinFreeDiskSpaceBytes = 500 * 1024 * 1024
+
+	// MinPowerShellVersion is the oldest Windows PowerShell major version that recipes support.
+	MinPowerShellVersion = 5
+
+	networkCheckTimeout = 10 * time.Second
+)
+
+// DefaultEndpoints are the New Relic endpoints recipes download agents and packages from.
+var DefaultEndpoints = []string{
+	"https://download.newrelic.com",
+}
+
+// conflictingAgents are the processes of other monitoring agents that are known to conflict
+// with New Relic's, keyed by process name.
+var conflictingAgents = map[string]string{
+	"datadog-agent":  "Datadog Agent",
+	"dd-agent":       "Datadog Agent",
+	"oneagent":       "Dynatrace OneAgent",
+	"oneagentos":     "Dynatrace OneAgent",
+	"oneagentos.exe": "Dynatrace OneAgent",
+	"splunkd":        "Splunk Universal Forwarder",
+	"splunkd.exe":    "Splunk Universal Forwarder",
+}
+
+// HostChecks returns the checks run on every install. Network checks are left out for
+// offline installs.
+func HostChecks(offline bool) []Check {
+	checks := []Check{}
+	if !offline {
+		checks = append(checks, NewNetworkCheck(DefaultEndpoints))
+	}
+
+	return append(checks,
+		NewPrivilegeCheck(),
+		NewDiskSpaceCheck(os.TempDir(), MinFreeDiskSpaceBytes),
+		NewSystemdCheck(),
+		NewPowerShellCheck(),
+		NewHomebrewCheck(),
+		NewConflictingAgentsCheck(),
+	)
+}
+
+type NetworkCheck struct {
+	endpoints []string
+	client    *http.Client
+}
+
+func NewNetworkCheck(endpoints []string) *NetworkCheck {
+	return &NetworkCheck{
+		endpoints: endpoints,
+		client:    &http.Client{Timeout: networkCheckTimeout},
+	}
+}
+
+func (c *NetworkCheck) Name() string {
+	return "Network"
+}
+
+func (c *NetworkCheck) Run(ctx context.Context) CheckResult {
+	for _, endpoint := range c.endpoints {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+		if err != nil {
+			return failed(c.Name(), fmt.Sprintf("invalid endpoint %s: %s", endpoint, err), "")
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return failed(c.Name(), fmt.Sprintf("could not reach %s", endpoint),
+				"Allow outbound HTTPS traffic to New Relic, or install through a proxy with --proxy")
+		}
+		resp.Body.Close()
+	}
+
+	return passed(c.Name(), fmt.Sprintf("reached %s", strings.Join(c.endpoints, ", ")))
+}
+
+type PrivilegeCheck struct {
+	goos       string
+	euid       func() int
+	lookPath   func(string) (string, error)
+	isElevated func(ctx context.Context) bool
+}
+
+func NewPrivilegeCheck() *PrivilegeCheck {
+	return &PrivilegeCheck{
+		goos:       runtime.GOOS,
+		euid:       os.Geteuid,
+		lookPath:   exec.LookPath,
+		isElevated: isWindowsElevated,
+	}
+}
+
+func (c *PrivilegeCheck) Name() string {
+	return "Privileges"
+}
+
+func (c *PrivilegeCheck) Run(ctx context.Context) CheckResult {
+	// Recipes ask for elevation themselves on Windows, which an unattended install can't answer.
+	if c.goos == "windows" {
+		if c.isElevated(ctx) {
+			return passed(c.Name(), "running as Administrator")
+		}
+		return warning(c.Name(), "not running as Administrator",
+			"Run the install from an elevated PowerShell, otherwise each recipe asks for elevation")
+	}
+
+	if c.euid() == 0 {
+		return passed(c.Name(), "running as root")
+	}
+
+	if _, err := c.lookPath("sudo"); err == nil {
+		return passed(c.Name(), "sudo is available")
+	}
+
+	return failed(c.Name(), "not running as root and sudo is not available", "Run the install as root, or install sudo")
+}
+
+type DiskSpaceCheck struct {
+	path     string
+	minBytes uint64
+	usage    func(string) (*disk.UsageStat, error)
+}
+
+func NewDiskSpaceCheck(path string, minBytes uint64) *DiskSpaceCheck {
+	return &DiskSpaceCheck{
+		path:     path,
+		minBytes: minBytes,
+		usage:    disk.Usage,
+	}
+}
+
+func (c *DiskSpaceCheck) Name() string {
+	return "Disk space"
+}
+
+func (c *DiskSpaceCheck) Run(ctx context.Context) CheckResult {
+	usage, err := c.usage(c.path)
+	if err != nil {
+		return warning(c.Name(), fmt.Sprintf("could not read the free disk space of %s", c.path), "")
+	}
+
+	free := fmt.Sprintf("%d MB free in %s", usage.Free/1024/1024, c.path)
+	if usage.Free < c.minBytes {
+		return failed(c.Name(), free, fmt.Sprintf("Free up at least %d MB of disk space", c.minBytes/1024/1024))
+	}
+
+	return passed(c.Name(), free)
+}
+
+type SystemdCheck struct {
+	goos string
+	stat func(string) (os.FileInfo, error)
+}
+
+func NewSystemdCheck() *SystemdCheck {
+	return &SystemdCheck{
+		goos: runtime.GOOS,
+		stat: os.Stat,
+	}
+}
+
+func (c *SystemdCheck) Name() string {
+	return "Service manager"
+}
+
+func (c *SystemdCheck) Run(ctx context.Context) CheckResult {
+	if c.goos == "darwin" {
+		return passed(c.Name(), "launchd is running")
+	}
+
+	if c.goos != "linux" {
+		return passed(c.Name(), "not required on "+c.goos)
+	}
+
+	// This is how sd_booted(3) detects that systemd is the init system.
+	if _, err := c.stat("/run/systemd/system"); err == nil {
+		return passed(c.Name(), "systemd is running")
+	}
+
+	return warning(c.Name(), "systemd is not running",
+		"Agents will be set up for SysV init or upstart if available, otherwise they must be started manually")
+}
+
+// PowerShellCheck checks that Windows PowerShell, which Windows recipes run their steps with, is
+// available and recent enough.
+type PowerShellCheck struct {
+	goos    string
+	version func(ctx context.Context) (int, error)
+}
+
+func NewPowerShellCheck() *PowerShellCheck {
+	return &PowerShellCheck{
+		goos:    runtime.GOOS,
+		version: powerShellVersion,
+	}
+}
+
+func (c *PowerShellCheck) Name() string {
+	return "PowerShell"
+}
+
+func (c *PowerShellCheck) Run(ctx context.Context) CheckResult {
+	if c.goos != "windows" {
+		return passed(c.Name(), "not required on "+c.goos)
+	}
+
+	version, err := c.version(ctx)
+	if err != nil {
+		return failed(c.Name(), "Windows PowerShell is not available", "Install Windows PowerShell 5.1 and add powershell.exe to the PATH")
+	}
+
+	if version < MinPowerShellVersion {
+		return failed(c.Name(), fmt.Sprintf("Windows PowerShell %d is installed", version),
+			fmt.Sprintf("Upgrade to Windows PowerShell %d.1 or later", MinPowerShellVersion))
+	}
+
+	return passed(c.Name(), fmt.Sprintf("Windows PowerShell %d is installed", version))
+}
+
+func powerShellVersion(ctx context.Context) (int, error) {
+	out, err := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-NonInteractive", "-Command", "$PSVersionTable.PSVersion.Major").Output()
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// homebrewPaths are where Homebrew installs brew on Apple silicon and Intel Macs, which may not be
+// in the PATH of an install run with sudo.
+var homebrewPaths = []string{"/opt/homebrew/bin/brew", "/usr/local/bin/brew"}
+
+// HomebrewCheck checks that Homebrew, which the macOS recipes install agents with, is installed.
+type HomebrewCheck struct {
+	goos     string
+	lookPath func(string) (string, error)
+	stat     func(string) (os.FileInfo, error)
+}
+
+func NewHomebrewCheck() *HomebrewCheck {
+	return &HomebrewCheck{
+		goos:     runtime.GOOS,
+		lookPath: exec.LookPath,
+		stat:     os.Stat,
+	}
+}
+
+func (c *HomebrewCheck) Name() string {
+	return "Homebrew"
+}
+
+func (c *HomebrewCheck) Run(ctx context.Context) CheckResult {
+	if c.goos != "darwin" {
+		return passed(c.Name(), "not required on "+c.goos)
+	}
+
+	if path, err := c.lookPath("brew"); err == nil {
+		return passed(c.Name(), "brew is installed at "+path)
+	}
+
+	for _, path := range homebrewPaths {
+		if _, err := c.stat(path); err == nil {
+			return passed(c.Name(), "brew is installed at "+path)
+		}
+	}
+
+	return failed(c.Name(), "Homebrew is not installed", "Install Homebrew from https://brew.sh")
+}
+
+// isWindowsElevated reports whether the install runs as Administrator, as "net session" only
+// succeeds in an elevated process.
+func isWindowsElevated(ctx context.Context) bool {
+	return exec.CommandContext(ctx, "net", "session").Run() == nil
+}
+
+type ConflictingAgentsCheck struct {
+	processNames func(ctx context.Context) ([]string, error)
+}
+
+func NewConflictingAgentsCheck() *ConflictingAgentsCheck {
+	return &ConflictingAgentsCheck{
+		processNames: runningProcessNames,
+	}
+}
+
+func (c *ConflictingAgentsCheck) Name() string {
+	return "Conflicting agents"
+}
+
+func (c *ConflictingAgentsCheck) Run(ctx context.Context) CheckResult {
+	names, err := c.processNames(ctx)
+	if err != nil {
+		return warning(c.Name(), "could not list running processes", "")
+	}
+
+	found := map[string]bool{}
+	agents := []string{}
+	for _, name := range names {
+		agent, ok := conflictingAgents[strings.ToLower(name)]
+		if ok && !found[agent] {
+			found[agent] = true
+			agents = append(agents, agent)
+		}
+	}
+
+	if len(agents) > 0 {
+		return warning(c.Name(), fmt.Sprintf("found %s", strings.Join(agents, ", ")),
+			"Running several monitoring agents may report duplicate data, consider removing the other agents")
+	}
+
+	return passed(c.Name(), "none found")
+}
+
+func runningProcessNames(ctx context.Context) ([]string, error) {
+	processes, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, p := range processes {
+		name, err := p.NameWithContext(ctx)
+		if err == nil {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,134 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkCheckShouldPassWhenEndpointsAreReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	result := NewNetworkCheck([]string{server.URL}).Run(context.Background())
+
+	require.Equal(t, CheckStatuses.PASSED, result.Status)
+}
+
+func TestNetworkCheckShouldFailWhenEndpointIsUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	result := NewNetworkCheck([]string{server.URL}).Run(context.Background())
+
+	require.Equal(t, CheckStatuses.FAILED, result.Status)
+	require.NotEmpty(t, result.Remediation)
+}
+
+func TestPrivilegeCheck(t *testing.T) {
+	noSudo := func(string) (string, error) { return "", errors.New("not found") }
+	withSudo := func(string) (string, error) { return "/usr/bin/sudo", nil }
+	elevated := func(context.Context) bool { return true }
+	notElevated := func(context.Context) bool { return false }
+
+	tests := []struct {
+		name     string
+		check    *PrivilegeCheck
+		expected CheckStatus
+	}{
+		{"root", &PrivilegeCheck{goos: "linux", euid: func() int { return 0 }, lookPath: noSudo}, CheckStatuses.PASSED},
+		{"sudo", &PrivilegeCheck{goos: "linux", euid: func() int { return 1000 }, lookPath: withSudo}, CheckStatuses.PASSED},
+		{"neither", &PrivilegeCheck{goos: "linux", euid: func() int { return 1000 }, lookPath: noSudo}, CheckStatuses.FAILED},
+		{"windows elevated", &PrivilegeCheck{goos: "windows", euid: func() int { return -1 }, lookPath: noSudo, isElevated: elevated}, CheckStatuses.PASSED},
+		{"windows not elevated", &PrivilegeCheck{goos: "windows", euid: func() int { return -1 }, lookPath: noSudo, isElevated: notElevated}, CheckStatuses.WARNING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.check.Run(context.Background()).Status)
+		})
+	}
+}
+
+func TestDiskSpaceCheckShouldFailBelowMinimum(t *testing.T) {
+	c := NewDiskSpaceCheck("/", 100)
+
+	c.usage = func(string) (*disk.UsageStat, error) { return &disk.UsageStat{Free: 99}, nil }
+	require.Equal(t, CheckStatuses.FAILED, c.Run(context.Background()).Status)
+
+	c.usage = func(string) (*disk.UsageStat, error) { return &disk.UsageStat{Free: 100}, nil }
+	require.Equal(t, CheckStatuses.PASSED, c.Run(context.Background()).Status)
+}
+
+func TestSystemdCheckShouldWarnWhenSystemdIsNotRunning(t *testing.T) {
+	c := &SystemdCheck{goos: "linux", stat: func(string) (os.FileInfo, error) { return nil, os.ErrNotExist }}
+
+	require.Equal(t, CheckStatuses.WARNING, c.Run(context.Background()).Status)
+}
+
+func TestHomebrewCheck(t *testing.T) {
+	notFound := func(string) (string, error) { return "", errors.New("not found") }
+	missing := func(string) (os.FileInfo, error) { return nil, os.ErrNotExist }
+
+	tests := []struct {
+		name     string
+		check    *HomebrewCheck
+		expected CheckStatus
+	}{
+		{"not darwin", &HomebrewCheck{goos: "linux", lookPath: notFound, stat: missing}, CheckStatuses.PASSED},
+		{"brew in path", &HomebrewCheck{goos: "darwin", lookPath: func(string) (string, error) { return "/usr/local/bin/brew", nil }, stat: missing}, CheckStatuses.PASSED},
+		{"brew in prefix", &HomebrewCheck{goos: "darwin", lookPath: notFound, stat: func(path string) (os.FileInfo, error) {
+			if path == "/opt/homebrew/bin/brew" {
+				return nil, nil
+			}
+			return nil, os.ErrNotExist
+		}}, CheckStatuses.PASSED},
+		{"no brew", &HomebrewCheck{goos: "darwin", lookPath: notFound, stat: missing}, CheckStatuses.FAILED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.check.Run(context.Background()).Status)
+		})
+	}
+}
+
+func TestConflictingAgentsCheckShouldWarnWhenOtherAgentsAreRunning(t *testing.T) {
+	c := &ConflictingAgentsCheck{processNames: func(context.Context) ([]string, error) {
+		return []string{"bash", "datadog-agent", "dd-agent"}, nil
+	}}
+
+	result := c.Run(context.Background())
+
+	require.Equal(t, CheckStatuses.WARNING, result.Status)
+	require.Equal(t, "found Datadog Agent", result.Message)
+}
+
+func TestPowerShellCheck(t *testing.T) {
+	version := func(v int, err error) func(context.Context) (int, error) {
+		return func(context.Context) (int, error) { return v, err }
+	}
+
+	tests := []struct {
+		name     string
+		check    *PowerShellCheck
+		expected CheckStatus
+	}{
+		{"not windows", &PowerShellCheck{goos: "linux", version: version(0, errors.New("not found"))}, CheckStatuses.PASSED},
+		{"missing", &PowerShellCheck{goos: "windows", version: version(0, errors.New("not found"))}, CheckStatuses.FAILED},
+		{"too old", &PowerShellCheck{goos: "windows", version: version(4, nil)}, CheckStatuses.FAILED},
+		{"supported", &PowerShellCheck{goos: "windows", version: version(5, nil)}, CheckStatuses.PASSED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.check.Run(context.Background()).Status)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+type CheckStatus string
+
+var CheckStatuses = struct {
+	PASSED  CheckStatus
+	WARNING CheckStatus
+	FAILED  CheckStatus
+}{
+	PASSED:  "PASSED",
+	WARNING: "WARNING",
+	FAILED:  "FAILED",
+}
+
+func checkStatusIcon(status CheckStatus) string {
+	switch status {
+	case CheckStatuses.PASSED:
+		return ux.IconSuccess
+	case CheckStatuses.WARNING:
+		return ux.IconExclamation
+	case CheckStatuses.FAILED:
+		return ux.IconError
+	}
+
+	return ""
+}
+
+// Check is a condition of the host that must hold for an install to succeed.
+type Check interface {
+	Name() string
+	Run(ctx context.Context) CheckResult
+}
+
+// CheckResult is the outcome of a check, with a hint on how to fix it when it didn't pass.
+type CheckResult struct {
+	Name        string
+	Status      CheckStatus
+	Message     string
+	Remediation string
+}
+
+func passed(name string, message string) CheckResult {
+	return CheckResult{Name: name, Status: CheckStatuses.PASSED, Message: message}
+}
+
+func warning(name string, message string, remediation string) CheckResult {
+	return CheckResult{Name: name, Status: CheckStatuses.WARNING, Message: message, Remediation: remediation}
+}
+
+func failed(name string, message string, remediation string) CheckResult {
+	return CheckResult{Name: name, Status: CheckStatuses.FAILED, Message: message, Remediation: remediation}
+}
+
+// Report holds the results of the checks run before an install.
+type Report struct {
+	Results []CheckResult
+}
+
+func (r *Report) HasFailures() bool {
+	for _, result := range r.Results {
+		if result.Status == CheckStatuses.FAILED {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FailedChecks returns the names of the checks that failed.
+func (r *Report) FailedChecks() []string {
+	names := []string{}
+	for _, result := range r.Results {
+		if result.Status == CheckStatuses.FAILED {
+			names = append(names, result.Name)
+		}
+	}
+
+	return names
+}
+
+// Print prints the result of each check, or only the checks that didn't pass in quiet mode.
+func (r *Report) Print(w io.Writer) {
+	results := r.Results
+	if ux.QuietMode {
+		results = []CheckResult{}
+		for _, result := range r.Results {
+			if result.Status != CheckStatuses.PASSED {
+				results = append(results, result)
+			}
+		}
+
+		if len(results) == 0 {
+			return
+		}
+	}
+
+	fmt.Fprintln(w, "  Pre-flight checks")
+	fmt.Fprintln(w)
+
+	for _, result := range results {
+		fmt.Fprintf(w, "  %s  %s: %s\n", checkStatusIcon(result.Status), result.Name, result.Message)
+		if result.Status != CheckStatuses.PASSED && result.Remediation != "" {
+			fmt.Fprintf(w, "     %s %s\n", color.CyanString(ux.IconArrowRight), result.Remediation)
+		}
+	}
+
+	fmt.Fprintln(w)
+}
+
+// Checker runs the host checks, and the prerequisites declared by the recipes to install,
+// before anything is changed on the host.
+type Checker struct {
+	hostChecks []Check
+}
+
+func NewChecker(hostChecks ...Check) *Checker {
+	return &Checker{
+		hostChecks: hostChecks,
+	}
+}
+
+func (c *Checker) Check(ctx context.Context, recipes []*types.OpenInstallationRecipe) *Report {
+	checks := append([]Check{}, c.hostChecks...)
+	checks = append(checks, RecipeChecks(recipes)...)
+
+	report := &Report{}
+	for _, check := range checks {
+		result := check.Run(ctx)
+		result.Name = check.Name()
+
+		log.WithFields(log.Fields{
+			"check":   result.Name,
+			"status":  result.Status,
+			"message": result.Message,
+		}).Debug("pre-flight check")
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
@@ -0,0 +1,97 @@
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+type testCheck struct {
+	name   string
+	result CheckResult
+}
+
+func (c *testCheck) Name() string {
+	return c.name
+}
+
+func (c *testCheck) Run(ctx context.Context) CheckResult {
+	return c.result
+}
+
+func TestCheckerShouldReportEachCheck(t *testing.T) {
+	c := NewChecker(
+		&testCheck{name: "first", result: passed("", "ok")},
+		&testCheck{name: "second", result: failed("", "not ok", "fix it")},
+	)
+
+	report := c.Check(context.Background(), []*types.OpenInstallationRecipe{})
+
+	require.Len(t, report.Results, 2)
+	require.Equal(t, "first", report.Results[0].Name)
+	require.True(t, report.HasFailures())
+	require.Equal(t, []string{"second"}, report.FailedChecks())
+}
+
+func TestReportShouldPrintRemediationOfChecksThatDidNotPass(t *testing.T) {
+	report := &Report{
+		Results: []CheckResult{
+			passed("Network", "reached"),
+			warning("Service manager", "systemd is not running", "start agents manually"),
+			failed("Disk space", "10 MB free", "free up space"),
+		},
+	}
+	var out bytes.Buffer
+
+	report.Print(&out)
+
+	s := out.String()
+	require.Contains(t, s, "Network: reached")
+	require.Contains(t, s, "start agents manually")
+	require.Contains(t, s, "free up space")
+	require.False(t, (&Report{Results: report.Results[:2]}).HasFailures())
+}
+
+func TestReportShouldOnlyPrintChecksThatDidNotPassInQuietMode(t *testing.T) {
+	ux.QuietMode = true
+	defer func() { ux.QuietMode = false }()
+
+	var out bytes.Buffer
+	(&Report{Results: []CheckResult{passed("Network", "reached")}}).Print(&out)
+	require.Empty(t, out.String())
+
+	(&Report{Results: []CheckResult{
+		passed("Network", "reached"),
+		warning("Service manager", "systemd is not running", "start agents manually"),
+	}}).Print(&out)
+
+	s := out.String()
+	require.NotContains(t, s, "Network: reached")
+	require.Contains(t, s, "Service manager: systemd is not running")
+}
+
+func TestRecipeChecksShouldRunDeclaredPrerequisites(t *testing.T) {
+	recipe := &types.OpenInstallationRecipe{
+		Name:        "test-recipe",
+		DisplayName: "Test Recipe",
+		PreInstall: types.OpenInstallationPreInstallConfiguration{
+			Prerequisites: []types.OpenInstallationPrerequisite{
+				{Name: "met", Check: "exit 0"},
+				{Name: "not met", Check: "exit 1", Remediation: "install it"},
+			},
+		},
+	}
+
+	report := NewChecker().Check(context.Background(), []*types.OpenInstallationRecipe{recipe})
+
+	require.Len(t, report.Results, 2)
+	require.Equal(t, "Test Recipe: met", report.Results[0].Name)
+	require.Equal(t, CheckStatuses.PASSED, report.Results[0].Status)
+	require.Equal(t, CheckStatuses.FAILED, report.Results[1].Status)
+	require.Equal(t, "install it", report.Results[1].Remediation)
+}
@@ -0,0 +1,57 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// RecipeCheck runs a prerequisite declared by a recipe in its preInstall block.
+type RecipeCheck struct {
+	recipe       types.OpenInstallationRecipe
+	prerequisite types.OpenInstallationPrerequisite
+	executor     execution.RecipeExecutor
+}
+
+// RecipeChecks returns a check for each prerequisite declared by the given recipes.
+func RecipeChecks(recipes []*types.OpenInstallationRecipe) []Check {
+	checks := []Check{}
+	for _, r := range recipes {
+		for _, p := range r.PreInstall.Prerequisites {
+			checks = append(checks, NewRecipeCheck(*r, p))
+		}
+	}
+
+	return checks
+}
+
+func NewRecipeCheck(r types.OpenInstallationRecipe, p types.OpenInstallationPrerequisite) *RecipeCheck {
+	return &RecipeCheck{
+		recipe:       r,
+		prerequisite: p,
+		executor:     execution.NewShRecipeExecutor(),
+	}
+}
+
+func (c *RecipeCheck) Name() string {
+	displayName := c.recipe.DisplayName
+	if displayName == "" {
+		displayName = c.recipe.Name
+	}
+
+	return fmt.Sprintf("%s: %s", displayName, c.prerequisite.Name)
+}
+
+func (c *RecipeCheck) Run(ctx context.Context) CheckResult {
+	// The prerequisite's check is run the same way as the recipe's requireAtDiscovery script.
+	r := c.recipe
+	r.PreInstall.RequireAtDiscovery = c.prerequisite.Check
+
+	if err := c.executor.ExecutePreInstall(ctx, r, types.RecipeVars{}); err != nil {
+		return failed(c.Name(), "not met", c.prerequisite.Remediation)
+	}
+
+	return passed(c.Name(), "met")
+}
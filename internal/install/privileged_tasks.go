@@ -0,0 +1,136 @@
+package install
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+var sudoCommandRegex = regexp.MustCompile(`(^|[\s;&|(])sudo\s`)
+
+// privilegedTask is a task of a recipe's install with the commands it runs with root privileges.
+type privilegedTask struct {
+	Recipe   string
+	Task     string
+	Commands []string
+}
+
+// privilegedTasks returns the tasks of the recipe's install that run commands with sudo, in file
+// order, or every task when the recipe runs as root.
+func privilegedTasks(r *types.OpenInstallationRecipe, asRoot bool) []privilegedTask {
+	var taskFile struct {
+		Tasks yaml.MapSlice `yaml:"tasks"`
+	}
+
+	if err := yaml.Unmarshal([]byte(r.Install), &taskFile); err != nil {
+		log.Debugf("could not parse tasks for recipe %s: %s", r.Name, err)
+		return []privilegedTask{}
+	}
+
+	tasks := []privilegedTask{}
+	for _, t := range taskFile.Tasks {
+		name, _ := t.Key.(string)
+		var task struct {
+			Cmds []interface{} `yaml:"cmds"`
+		}
+
+		data, err := yaml.Marshal(t.Value)
+		if err != nil || yaml.Unmarshal(data, &task) != nil {
+			continue
+		}
+
+		commands := []string{}
+		for _, c := range task.Cmds {
+			cmd := taskCommand(c)
+			if cmd != "" && (asRoot || sudoCommandRegex.MatchString(cmd)) {
+				commands = append(commands, cmd)
+			}
+		}
+
+		if len(commands) > 0 {
+			tasks = append(tasks, privilegedTask{Recipe: r.DisplayName, Task: name, Commands: commands})
+		}
+	}
+
+	return tasks
+}
+
+// taskCommand returns the shell of a go-task command, given as a string or with a cmd key.
+func taskCommand(c interface{}) string {
+	switch cmd := c.(type) {
+	case string:
+		return strings.TrimSpace(cmd)
+	case map[interface{}]interface{}:
+		if s, ok := cmd["cmd"].(string); ok {
+			return strings.TrimSpace(s)
+		}
+	}
+
+	return ""
+}
+
+// confirmPrivilegedTasks shows the commands of the recipes to install that run with root
+// privileges, and asks to confirm them before any recipe runs. An unattended install runs them
+// only with AllowSudo.
+func (i *RecipeInstall) confirmPrivilegedTasks(availableRecipes recipes.RecipeDetectionResults) error {
+	asRoot := i.runsAsRoot()
+	names := i.recipeNamesToInstall(availableRecipes)
+
+	tasks := []privilegedTask{}
+	for _, d := range availableRecipes {
+		for _, n := range names {
+			if d.Recipe.Name == n {
+				tasks = append(tasks, privilegedTasks(d.Recipe, asRoot)...)
+			}
+		}
+	}
+
+	if len(tasks) == 0 || (i.AssumeYes && i.AllowSudo) {
+		return nil
+	}
+
+	if !ux.JSONMode {
+		printPrivilegedTasks(tasks, asRoot)
+	}
+
+	if i.AssumeYes {
+		return fmt.Errorf("the recipes to install run commands with root privileges, run the install with --allowSudo to allow them")
+	}
+
+	confirmed, err := i.prompter.PromptYesNo("Run these commands with root privileges?")
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return types.ErrInterrupt
+	}
+
+	return nil
+}
+
+func printPrivilegedTasks(tasks []privilegedTask, asRoot bool) {
+	if asRoot {
+		fmt.Println("\n  The install runs as root, so these commands of the recipes run as root:")
+	} else {
+		fmt.Println("\n  These commands of the recipes run with sudo:")
+	}
+
+	for _, t := range tasks {
+		fmt.Printf("\n  %s, task %s\n", t.Recipe, t.Task)
+		for _, c := range t.Commands {
+			for _, line := range strings.Split(c, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,81 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+const privilegedRecipeInstall = `
+version: '3'
+tasks:
+  default:
+    cmds:
+      - task: download
+      - task: setup
+  download:
+    cmds:
+      - curl -o /tmp/agent.tar.gz https://download.newrelic.com/agent.tar.gz
+  setup:
+    cmds:
+      - cmd: sudo tar -xzf /tmp/agent.tar.gz -C /opt
+      - |
+        echo "license_key: {{.NEW_RELIC_LICENSE_KEY}}" | sudo tee /etc/agent.yml
+`
+
+func TestPrivilegedTasksShouldFindSudoCommands(t *testing.T) {
+	r := &types.OpenInstallationRecipe{Name: "agent", DisplayName: "Agent", Install: privilegedRecipeInstall}
+
+	tasks := privilegedTasks(r, false)
+
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "setup", tasks[0].Task)
+	assert.Equal(t, []string{
+		"sudo tar -xzf /tmp/agent.tar.gz -C /opt",
+		`echo "license_key: {{.NEW_RELIC_LICENSE_KEY}}" | sudo tee /etc/agent.yml`,
+	}, tasks[0].Commands)
+
+	assert.Len(t, privilegedTasks(r, true), 2)
+}
+
+func TestConfirmPrivilegedTasksShouldRequireAllowSudoWhenUnattended(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	recipeInstall.AssumeYes = true
+	detections := privilegedDetections()
+
+	err := recipeInstall.confirmPrivilegedTasks(detections)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--allowSudo")
+
+	recipeInstall.AllowSudo = true
+
+	assert.NoError(t, recipeInstall.confirmPrivilegedTasks(detections))
+}
+
+func TestConfirmPrivilegedTasksShouldCancelWhenDeclined(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().Build()
+	prompter := ux.NewMockPrompter()
+	prompter.PromptYesNoVal = false
+	recipeInstall.prompter = prompter
+
+	err := recipeInstall.confirmPrivilegedTasks(privilegedDetections())
+
+	assert.ErrorIs(t, err, types.ErrInterrupt)
+	assert.Equal(t, 1, prompter.PromptYesNoCallCount)
+}
+
+func privilegedDetections() recipes.RecipeDetectionResults {
+	r := recipes.NewRecipeBuilder().Name("agent").Build()
+	r.Install = privilegedRecipeInstall
+
+	return recipes.RecipeDetectionResults{
+		{Recipe: r, Status: execution.RecipeStatusTypes.AVAILABLE},
+	}
+}
@@ -0,0 +1,38 @@
+package install
+
+import (
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// niceIncrement is how much less favorably the recipe tasks of an install with --nice are scheduled.
+const niceIncrement = 10
+
+// packageManagerRegex matches the package managers recipes install packages with, which hold a lock
+// for the duration of their operations.
+var packageManagerRegex = regexp.MustCompile(`\b(apt-get|apt|dpkg|yum|dnf|rpm|zypper|brew|choco|msiexec)\s`)
+
+// usesPackageManager returns whether the install tasks of the recipe run a package manager.
+func usesPackageManager(r *types.OpenInstallationRecipe) bool {
+	return packageManagerRegex.MatchString(r.Install)
+}
+
+// lowerPriority lowers the priority of the CLI, so the recipe tasks it runs don't compete with the
+// workloads of the host for CPU and disk. A nice install still completes when the priority can't
+// be lowered.
+func (i *RecipeInstall) lowerPriority() {
+	if i.Target != "" {
+		log.Warnf("--nice only lowers the priority of recipes installed onto the local host, not onto %s", i.Target)
+		return
+	}
+
+	if err := lowerProcessPriority(); err != nil {
+		log.Warnf("Could not lower the priority of the install: %s", err)
+		return
+	}
+
+	log.Debugf("Lowered the priority of the install by %d", niceIncrement)
+}
@@ -0,0 +1,15 @@
+package install
+
+import (
+	"syscall"
+)
+
+// lowerProcessPriority lowers the CPU priority of the CLI, which the recipe tasks it runs inherit.
+func lowerProcessPriority() error {
+	prio, err := syscall.Getpriority(syscall.PRIO_PROCESS, 0)
+	if err != nil {
+		return err
+	}
+
+	return syscall.Setpriority(syscall.PRIO_PROCESS, 0, prio+niceIncrement)
+}
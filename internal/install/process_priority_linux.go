@@ -0,0 +1,48 @@
+package install
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+const (
+	ioprioWhoProcess  = 1
+	ioprioClassBE     = 2
+	ioprioClassShift  = 13
+	ioprioLowestLevel = 7
+)
+
+// lowerProcessPriority lowers the CPU and I/O priority of the CLI, which the recipe tasks it runs
+// inherit. Linux schedules each thread on its own, so the priority of every thread is lowered, and
+// the threads started after it inherit theirs from the thread that started them.
+func lowerProcessPriority() error {
+	entries, err := os.ReadDir("/proc/self/task")
+	if err != nil {
+		return err
+	}
+
+	ioprio := ioprioClassBE<<ioprioClassShift | ioprioLowestLevel
+	for _, e := range entries {
+		tid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+
+		prio, err := syscall.Getpriority(syscall.PRIO_PROCESS, tid)
+		if err != nil {
+			return err
+		}
+
+		// The kernel returns the nice value as 20 - nice, so it's never negative.
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, 20-prio+niceIncrement); err != nil {
+			return err
+		}
+
+		if _, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(tid), uintptr(ioprio)); errno != 0 {
+			return errno
+		}
+	}
+
+	return nil
+}
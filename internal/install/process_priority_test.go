@@ -0,0 +1,15 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+func TestUsesPackageManager(t *testing.T) {
+	assert.True(t, usesPackageManager(recipes.NewRecipeBuilder().InstallShell("sudo apt-get install -y newrelic-infra").Build()))
+	assert.True(t, usesPackageManager(recipes.NewRecipeBuilder().InstallShell("yum -y install nri-mysql").Build()))
+	assert.False(t, usesPackageManager(recipes.NewRecipeBuilder().InstallShell("curl -o /tmp/rapt https://example.com").Build()))
+}
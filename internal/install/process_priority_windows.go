@@ -0,0 +1,23 @@
+package install
+
+import (
+	"syscall"
+)
+
+const belowNormalPriorityClass = 0x00004000
+
+// lowerProcessPriority runs the CLI in the below normal priority class, which the processes of the
+// recipe tasks it runs inherit.
+func lowerProcessPriority() error {
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return err
+	}
+
+	setPriorityClass := syscall.NewLazyDLL("kernel32.dll").NewProc("SetPriorityClass")
+	if ok, _, err := setPriorityClass.Call(uintptr(process), belowNormalPriorityClass); ok == 0 {
+		return err
+	}
+
+	return nil
+}
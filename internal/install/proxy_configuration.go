@@ -1,8 +1,38 @@
 package install
 
-import "golang.org/x/net/http/httpproxy"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
 
 func IsProxyConfigured() bool {
 	proxyConfig := httpproxy.FromEnvironment()
 	return proxyConfig.HTTPProxy != "" || proxyConfig.HTTPSProxy != "" || proxyConfig.NoProxy != ""
 }
+
+// ConfigureProxy routes the install's HTTP requests, and those made by recipes, through
+// the given proxy. Hosts listed in NO_PROXY are still reached directly.
+func ConfigureProxy(proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %s, expected a URL such as http://proxy.example.com:8080", proxyURL)
+	}
+
+	os.Setenv("HTTPS_PROXY", proxyURL)
+	os.Setenv("HTTP_PROXY", proxyURL)
+
+	// The default transport caches the proxy environment on first use, so it's
+	// pointed at the proxy directly in case a request has already been made.
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+		t.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	return nil
+}
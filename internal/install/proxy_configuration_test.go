@@ -0,0 +1,39 @@
+package install
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureProxyShouldRouteRequestsThroughProxy(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	transport := http.DefaultTransport.(*http.Transport)
+	defaultProxy := transport.Proxy
+	defer func() { transport.Proxy = defaultProxy }()
+
+	err := ConfigureProxy("http://proxy.example.com:8080")
+	require.NoError(t, err)
+	assert.True(t, IsProxyConfigured())
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.newrelic.com", nil)
+	u, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.example.com:8080", u.Host)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://internal.example.com", nil)
+	u, err = transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Nil(t, u)
+}
+
+func TestConfigureProxyShouldFailForInvalidURL(t *testing.T) {
+	err := ConfigureProxy("not a proxy")
+
+	assert.Error(t, err)
+}
@@ -2,10 +2,13 @@ package install
 
 import (
 	"context"
+	"os"
+	"sync"
 
 	"github.com/newrelic/newrelic-cli/internal/diagnose"
 	"github.com/newrelic/newrelic-cli/internal/install/discovery"
 	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/preflight"
 	"github.com/newrelic/newrelic-cli/internal/install/recipes"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/install/ux"
@@ -13,22 +16,29 @@ import (
 )
 
 type RecipeInstallBuilder struct {
-	configValidator    *diagnose.MockConfigValidator
-	recipeFetcher      *recipes.MockRecipeFetcher
-	discoverer         *discovery.MockDiscoverer
-	status             *execution.InstallStatus
-	mockOsValidator    *discovery.MockOsValidator
-	manifestValidator  *discovery.ManifestValidator
-	shouldInstallCore  func() bool
-	installerContext   types.InstallerContext
-	recipeLogForwarder *execution.MockRecipeLogForwarder
-	recipeVarProvider  *execution.MockRecipeVarProvider
-	recipeExecutor     *execution.MockRecipeExecutor
-	progressIndicator  *ux.SpinnerProgressIndicator
-	agentValidator     *validation.MockAgentValidator
-	recipeValidator    *validation.MockRecipeValidator
-	recipeDetector     *MockRecipeDetector
-	processes          []types.GenericProcess
+	configValidator     *diagnose.MockConfigValidator
+	recipeFetcher       *recipes.MockRecipeFetcher
+	discoverer          *discovery.MockDiscoverer
+	status              *execution.InstallStatus
+	mockOsValidator     *discovery.MockOsValidator
+	manifestValidator   *discovery.ManifestValidator
+	shouldInstallCore   func() bool
+	installerContext    types.InstallerContext
+	recipeLogForwarder  *execution.MockRecipeLogForwarder
+	recipeVarProvider   *execution.MockRecipeVarProvider
+	recipeExecutor      *execution.MockRecipeExecutor
+	progressIndicator   *ux.SpinnerProgressIndicator
+	prompter            *ux.MockPrompter
+	agentValidator      *validation.MockAgentValidator
+	recipeValidator     *validation.MockRecipeValidator
+	recipeDetector      *MockRecipeDetector
+	processes           []types.GenericProcess
+	installState        *execution.InstallState
+	entityTaggingClient *execution.MockEntityTaggingClient
+	cloudLinkingClient  *execution.MockCloudLinkingClient
+	preflightChecker    *MockPreflightChecker
+	logMatchFinder      *recipes.MockLogMatchFinder
+	scriptOutput        map[string]string
 }
 
 func NewRecipeInstallBuilder() *RecipeInstallBuilder {
@@ -54,9 +64,16 @@ func NewRecipeInstallBuilder() *RecipeInstallBuilder {
 	rib.recipeVarProvider.Vars = map[string]string{}
 	rib.recipeExecutor = execution.NewMockRecipeExecutor()
 	rib.progressIndicator = ux.NewSpinnerProgressIndicator()
+	rib.prompter = ux.NewMockPrompter()
 	rib.agentValidator = &validation.MockAgentValidator{}
 	rib.recipeValidator = &validation.MockRecipeValidator{}
 	rib.recipeDetector = &MockRecipeDetector{}
+	rib.installState = execution.NewInstallState("")
+	rib.entityTaggingClient = execution.NewMockEntityTaggingClient()
+	rib.cloudLinkingClient = execution.NewMockCloudLinkingClient()
+	rib.preflightChecker = NewMockPreflightChecker()
+	rib.logMatchFinder = &recipes.MockLogMatchFinder{}
+	rib.scriptOutput = map[string]string{}
 
 	return rib
 }
@@ -161,6 +178,36 @@ func (rib *RecipeInstallBuilder) WithRunningProcess(cmd string, name string) *Re
 	return rib
 }
 
+func (rib *RecipeInstallBuilder) WithInstallState(s *execution.InstallState) *RecipeInstallBuilder {
+	rib.installState = s
+	return rib
+}
+
+func (rib *RecipeInstallBuilder) WithTags(tags []string) *RecipeInstallBuilder {
+	rib.installerContext.SetTags(tags)
+	return rib
+}
+
+func (rib *RecipeInstallBuilder) WithHooks(hooks types.InstallHooks) *RecipeInstallBuilder {
+	rib.installerContext.Hooks = hooks
+	return rib
+}
+
+func (rib *RecipeInstallBuilder) WithPreflightReport(report *preflight.Report) *RecipeInstallBuilder {
+	rib.preflightChecker.Report = report
+	return rib
+}
+
+func (rib *RecipeInstallBuilder) WithLogMatches(matches ...types.OpenInstallationLogMatch) *RecipeInstallBuilder {
+	rib.logMatchFinder.Matches = matches
+	return rib
+}
+
+func (rib *RecipeInstallBuilder) WithScriptOutput(script string, output string) *RecipeInstallBuilder {
+	rib.scriptOutput[script] = output
+	return rib
+}
+
 func (rib *RecipeInstallBuilder) Build() *RecipeInstall {
 	recipeInstall := &RecipeInstall{}
 	recipeInstall.discoverer = rib.discoverer
@@ -169,7 +216,7 @@ func (rib *RecipeInstallBuilder) Build() *RecipeInstall {
 	recipeInstall.status = rib.status
 	recipeInstall.manifestValidator = rib.manifestValidator
 	recipeInstall.bundlerFactory = func(ctx context.Context, detections recipes.RecipeDetectionResults) RecipeBundler {
-		return recipes.NewBundler(ctx, detections)
+		return recipes.NewBundler(ctx, detections).WithRecipeOrder(recipeInstall.RecipeOrder)
 	}
 	recipeInstall.bundleInstallerFactory = func(ctx context.Context, manifest *types.DiscoveryManifest, recipeInstallerInterface RecipeInstaller, statusReporter StatusReporter) RecipeBundleInstaller {
 		return NewBundleInstaller(context.Background(), &types.DiscoveryManifest{}, recipeInstall, rib.status)
@@ -180,14 +227,42 @@ func (rib *RecipeInstallBuilder) Build() *RecipeInstall {
 	recipeInstall.recipeVarPreparer = rib.recipeVarProvider
 	recipeInstall.recipeExecutor = rib.recipeExecutor
 	recipeInstall.progressIndicator = rib.progressIndicator
+	recipeInstall.out = os.Stdout
+	recipeInstall.prompter = rib.prompter
 	recipeInstall.agentValidator = rib.agentValidator
 	recipeInstall.recipeValidator = rib.recipeValidator
+	recipeInstall.entityTagger = execution.NewEntityTagger(rib.entityTaggingClient, rib.installerContext.GetTags())
+	recipeInstall.preflightChecker = rib.preflightChecker
 	recipeInstall.recipeDetectorFactory = func(ctx context.Context, repo *recipes.RecipeRepository, ic *types.InstallerContext) RecipeStatusDetector {
 		return rib.recipeDetector
 	}
 	mockProcessEvaluator := recipes.NewMockProcessEvaluator()
 	mockProcessEvaluator.WithProcesses(rib.processes)
 	recipeInstall.processEvaluator = mockProcessEvaluator
+	recipeInstall.installState = rib.installState
+	recipeInstall.recipeErrors = &recipeErrors{}
+	recipeInstall.packageManagerLock = &sync.Mutex{}
+	recipeInstall.configChanges = &configChanges{backups: map[string]*ConfigBackup{}}
+	recipeInstall.absentRecipes = &absentRecipes{names: map[string]bool{}}
+	recipeInstall.hookRunner = execution.NewHookRunner(rib.installerContext.Hooks)
+	recipeInstall.awsLinker = execution.NewAWSIntegrationLinker(rib.cloudLinkingClient, 12345)
+	recipeInstall.hostInspector = NewHostInspector()
+	recipeInstall.logMatchFinder = rib.logMatchFinder
+	recipeInstall.scriptRunner = func(ctx context.Context, script string) (string, error) {
+		return rib.scriptOutput[script], nil
+	}
+	recipeInstall.recipeExecutorFactory = func() execution.RecipeExecutor {
+		return rib.recipeExecutor
+	}
+	recipeInstall.checkExecutorFactory = func() execution.RecipeExecutor {
+		return rib.recipeExecutor
+	}
+	recipeInstall.recipeLogForwarderFactory = func() execution.LogForwarder {
+		return rib.recipeLogForwarder
+	}
+	recipeInstall.runsAsRoot = func() bool {
+		return false
+	}
 
 	return recipeInstall
 }
@@ -4,18 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/newrelic/newrelic-cli/internal/cli"
+	configAPI "github.com/newrelic/newrelic-cli/internal/config/api"
 	"github.com/newrelic/newrelic-cli/internal/diagnose"
 	"github.com/newrelic/newrelic-cli/internal/install/discovery"
 	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/i18n"
+	"github.com/newrelic/newrelic-cli/internal/install/preflight"
 	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
 	"github.com/newrelic/newrelic-cli/internal/install/segment"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/install/ux"
@@ -26,8 +34,13 @@ import (
 
 const (
 	validationTimeout = 5 * time.Minute
+	// interruptGracePeriod is how long a canceled install waits for the recipe being installed to stop.
+	interruptGracePeriod = 10 * time.Second
 )
 
+// recipeRetryDelayMs is the delay before the first retry of a failed recipe, doubling with each further retry.
+var recipeRetryDelayMs = 5000
+
 var infraAgentEntityKey string
 
 type RecipeInstall struct {
@@ -44,49 +57,192 @@ type RecipeInstall struct {
 	configValidator        ConfigValidator
 	recipeVarPreparer      RecipeVarPreparer
 	agentValidator         AgentValidator
+	entityTagger           EntityTagger
 	shouldInstallCore      func() bool
 	bundlerFactory         func(ctx context.Context, availableRecipes recipes.RecipeDetectionResults) RecipeBundler
 	bundleInstallerFactory func(ctx context.Context, manifest *types.DiscoveryManifest, recipeInstallerInterface RecipeInstaller, statusReporter StatusReporter) RecipeBundleInstaller
 	progressIndicator      ux.ProgressIndicator
+	stepCounter            *ux.StepCounter
+	preflightChecker       PreflightChecker
 	recipeDetectorFactory  func(ctx context.Context, repo *recipes.RecipeRepository, ic *types.InstallerContext) RecipeStatusDetector
 	processEvaluator       recipes.ProcessEvaluatorInterface
+	installState           *execution.InstallState
+	recipeExecutorFactory  func() execution.RecipeExecutor
+	recipeErrors           *recipeErrors
+	planOutput             io.Writer
+	// out is where the install prints its messages and progress.
+	out               io.Writer
+	hookRunner        *execution.HookRunner
+	infraAgentService execution.ServiceManager
+	awsLinker         *execution.AWSIntegrationLinker
+	hostInspector     *HostInspector
+	logMatchFinder    recipes.LogMatchFinderDefinition
+	scriptRunner      func(ctx context.Context, script string) (string, error)
+	// checkExecutorFactory returns the executor of the installed checks of recipes, which run on
+	// the host installed onto when the recipe executor can't run scripts.
+	checkExecutorFactory func() execution.RecipeExecutor
+	// recipeLogForwarderFactory returns the log forwarder of an installer cloned to install recipes
+	// concurrently, since a forwarder collects the output of the recipes it sends.
+	recipeLogForwarderFactory func() execution.LogForwarder
+	// runsAsRoot returns whether recipes run as root, so every one of their commands is privileged.
+	runsAsRoot func() bool
+	// packageManagerLock is held by the recipes of a nice install that run a package manager, so
+	// recipes installed at the same time run one package manager at a time.
+	packageManagerLock *sync.Mutex
+	// configFilePatterns are the configuration files backed up, with a diff printed, when recipes
+	// change them.
+	configFilePatterns []string
+	configBackupPath   string
+	configChanges      *configChanges
+	// absentRecipes are the recipes whose installed check showed they weren't installed, the only
+	// ones uninstalled when their install fails.
+	absentRecipes *absentRecipes
 }
 
 type RecipeInstallFunc func(ctx context.Context, i *RecipeInstall, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, recipes []types.OpenInstallationRecipe) error
 
-func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic, sg *segment.Segment) *RecipeInstall {
+// newRecipeFetcher returns the fetcher of the recipes for the installer context, with any
+// recipes pinned to a version fetched from that version of the recipe library, from the recipe
+// source when one is set. The signatures of recipes that are downloaded are verified with the
+// trust store.
+func newRecipeFetcher(ic types.InstallerContext) recipes.RecipeFetcher {
 	var recipeFetcher recipes.RecipeFetcher
+	verifier := recipes.NewSignatureVerifier(recipes.NewTrustStore(recipes.GetDefaultTrustStorePath()), ic.RequireSigned)
+
+	newLibraryRecipeFetcher := func(version string) recipes.RecipeFetcher {
+		return recipes.NewLibraryRecipeFetcher(version).WithVerifier(verifier)
+	}
+	if len(ic.RecipeSources) > 0 {
+		newLibraryRecipeFetcher = func(version string) recipes.RecipeFetcher {
+			return newSourcesRecipeFetcher(ic, version, verifier)
+		}
+	}
 
-	if ic.LocalRecipes != "" {
+	if ic.RecipeBundle != "" {
+		recipeFetcher = recipes.NewRecipeArchiveFetcher(ic.RecipeBundle)
+	} else if ic.LocalRecipes != "" {
 		recipeFetcher = &recipes.LocalRecipeFetcher{
 			Path: ic.LocalRecipes,
 		}
 	} else if len(ic.RecipePaths) > 0 {
-		recipeFetcher = recipes.NewRecipeFileFetcher(ic.RecipePaths)
+		recipeFetcher = recipes.NewRecipeFileFetcher(ic.RecipePaths).
+			WithCache(recipes.NewRecipeCache(recipes.GetDefaultRecipeCachePath())).
+			WithVerifier(verifier).
+			WithChecksums(ic.RecipeSHA256)
+	} else if len(ic.RecipeSources) > 0 {
+		recipeFetcher = newSourcesRecipeFetcher(ic, "", verifier,
+			recipes.RecipeSource{Name: "the recipe cache", Fetcher: recipes.NewCachedLibraryRecipeFetcher().WithVerifier(verifier)},
+			recipes.RecipeSource{Name: "the recipes included with the CLI", Fetcher: recipes.NewEmbeddedRecipeFetcher()},
+		)
 	} else {
 		recipeFetcher = recipes.NewEmbeddedRecipeFetcher()
 	}
 
+	if len(ic.RecipeVersions) > 0 {
+		recipeFetcher = recipes.NewPinnedRecipeFetcher(recipeFetcher, ic.RecipeVersions).
+			WithLibraryFetcher(newLibraryRecipeFetcher)
+	}
+
+	return recipeFetcher
+}
+
+// newSourcesRecipeFetcher returns a fetcher of the given version of the recipe library, or its current
+// version, that fails over between the recipe sources in order, and then the fallbacks.
+func newSourcesRecipeFetcher(ic types.InstallerContext, version string, verifier *recipes.SignatureVerifier, fallbacks ...recipes.RecipeSource) recipes.RecipeFetcher {
+	sources := []recipes.RecipeSource{}
+	for _, s := range ic.RecipeSources {
+		sourceVerifier := *verifier
+		sourceVerifier.HTTPGetFunc = recipes.NewRetryingHTTPGetFunc(recipes.NewSourceHTTPGetFunc(s, ic.RecipeSourceHeaders))
+
+		sources = append(sources, recipes.RecipeSource{
+			Name:    s,
+			Fetcher: recipes.NewLibraryRecipeFetcher(version).WithSource(s, sourceVerifier.HTTPGetFunc).WithVerifier(&sourceVerifier),
+		})
+	}
+
+	return recipes.NewFailoverRecipeFetcher(append(sources, fallbacks...)...)
+}
+
+func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic, sg *segment.Segment) *RecipeInstall {
+	recipeFetcher := newRecipeFetcher(ic)
 	mv := discovery.NewManifestValidator()
 	ff := recipes.NewRecipeFileFetcher([]string{})
 	lf := execution.NewRecipeLogForwarder()
+	is := newInstallState(ic)
 	ers := []execution.StatusSubscriber{
 		execution.NewNerdStorageStatusReporter(&nrClient.NerdStorage),
 		execution.NewTerminalStatusReporter(),
+		execution.NewEntityVerificationReporter(&nrClient.NerdGraph),
 		execution.NewInstallEventsReporter(&nrClient.InstallEvents),
 		execution.NewSegmentReporter(sg),
+		execution.NewInstallStateReporter(is),
+	}
+	if ic.Offline {
+		// Only report locally, as New Relic can't be reached during an offline install.
+		ers = []execution.StatusSubscriber{
+			execution.NewTerminalStatusReporter(),
+			execution.NewInstallStateReporter(is),
+		}
+	} else if ic.NoTelemetry {
+		// Don't send the status of the install to New Relic, only verify the entities it created.
+		ers = []execution.StatusSubscriber{
+			execution.NewTerminalStatusReporter(),
+			execution.NewEntityVerificationReporter(&nrClient.NerdGraph),
+			execution.NewInstallStateReporter(is),
+		}
+	}
+	if ic.JSONOutput {
+		// The events written as JSON lines replace the summary printed to the terminal.
+		for n, r := range ers {
+			if _, ok := r.(*execution.TerminalStatusReporter); ok {
+				ers[n] = execution.NewJSONLinesStatusReporter()
+			}
+		}
+	}
+	if ic.StatusFile != "" {
+		ers = append(ers, execution.NewStatusFileReporter(ic.StatusFile))
+	}
+	if ic.NotifyWebhook != "" {
+		ers = append(ers, execution.NewWebhookStatusReporter(ic.NotifyWebhook, os.Getenv(types.EnvNotifyWebhookSecret)))
+	}
+	if ic.NotifySlack != "" {
+		ers = append(ers, execution.NewSlackStatusReporter(ic.NotifySlack))
+	}
+	var fullScreen *execution.FullScreenStatusReporter
+	if ic.UI && !ic.DryRun {
+		// The full-screen view is reported to first, so it's closed before the summary is printed.
+		fullScreen = execution.NewFullScreenStatusReporter()
+		ers = append([]execution.StatusSubscriber{fullScreen}, ers...)
+	}
+	if ic.DryRun {
+		// A dry run doesn't install anything, so there is no status to report.
+		ers = []execution.StatusSubscriber{}
 	}
 	slg := execution.NewPlatformLinkGenerator()
 	statusRollup := execution.NewInstallStatus(ic, ers, slg)
 	sg.SetInstallID(statusRollup.InstallID)
 
-	d := discovery.NewPSUtilDiscoverer()
+	d := discovery.NewCachingDiscoverer(
+		discovery.NewPSUtilDiscoverer().WithTimeout(ic.DiscoveryTimeout),
+		discovery.GetDefaultManifestCachePath(),
+		discovery.DefaultManifestCacheTTL,
+	).WithRefresh(ic.RefreshDiscovery).WithDiscoveryTimeout(ic.DiscoveryTimeout)
 	re := execution.NewGoTaskRecipeExecutor()
 	v := validation.NewPollingRecipeValidator(&nrClient.Nrdb)
 	cv := diagnose.NewConfigValidator(nrClient)
 	p := ux.NewPromptUIPrompter()
-	rvp := execution.NewRecipeVarProvider()
+	rvp := execution.NewRecipeVarProvider().WithResolver(execution.NewRecipeVarResolver(ic.RecipeVars, ic.RecipeFileVars))
 	av := validation.NewAgentValidator()
+	et := execution.NewEntityTagger(&nrClient.Entities, ic.GetTags())
+	if ic.Offline {
+		et = execution.NewEntityTagger(&nrClient.Entities, []string{})
+	}
+	// The patterns were checked when the installer context was created.
+	processFilter, err := recipes.NewProcessFilter(ic.DiscoveryInclude, ic.DiscoveryExclude)
+	if err != nil {
+		log.Warnf("Ignoring the discovery process filters: %s", err)
+		processFilter = &recipes.ProcessFilter{}
+	}
 
 	i := RecipeInstall{
 		discoverer:         d,
@@ -101,8 +257,65 @@ func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic,
 		configValidator:    cv,
 		recipeVarPreparer:  rvp,
 		agentValidator:     av,
-		progressIndicator:  ux.NewSpinnerProgressIndicator(),
-		processEvaluator:   recipes.NewProcessEvaluator(),
+		entityTagger:       et,
+		progressIndicator:  ux.NewProgressIndicator(),
+		stepCounter:        ux.NewStepCounter(),
+		preflightChecker:   preflight.NewChecker(preflight.HostChecks(ic.Offline)...),
+		processEvaluator:   recipes.NewProcessEvaluator().WithProcessFilter(processFilter),
+		installState:       is,
+		recipeErrors:       &recipeErrors{},
+		hookRunner:         execution.NewHookRunner(ic.Hooks),
+		infraAgentService:  execution.NewInfraAgentService(runtime.GOOS),
+		awsLinker:          execution.NewAWSIntegrationLinker(&nrClient.Cloud, configAPI.GetActiveProfileAccountID()),
+		hostInspector:      NewHostInspector(),
+		logMatchFinder:     recipes.NewLogMatchFinder(),
+		scriptRunner:       runStatusScript,
+		runsAsRoot: func() bool {
+			return runtime.GOOS != "windows" && os.Geteuid() == 0
+		},
+		packageManagerLock: &sync.Mutex{},
+		configFilePatterns: agentConfigFilePatterns[runtime.GOOS],
+		configBackupPath:   GetDefaultConfigBackupPath(),
+		configChanges:      &configChanges{backups: map[string]*ConfigBackup{}},
+		absentRecipes:      &absentRecipes{names: map[string]bool{}},
+		out:                os.Stdout,
+	}
+
+	if ic.MaxConcurrency > 1 && ic.AssumeYes && ic.Target == "" {
+		log.Warn("The configuration files changed by recipes installed at the same time with --maxConcurrency aren't backed up.")
+	}
+
+	if fullScreen != nil {
+		re.OutputLog = io.MultiWriter(re.OutputLog, fullScreen)
+		i.progressIndicator = fullScreen
+	}
+
+	var sshClient *remote.SSHClient
+	if target, err := remote.ParseTarget(ic.Target); err == nil {
+		// Discovery, detection and recipes run on the target, and the local host is left untouched.
+		sshClient = remote.NewSSHClient(*target, ic.SSHKey)
+		i.discoverer = discovery.NewSSHDiscoverer(sshClient)
+		i.recipeExecutor = execution.NewSSHRecipeExecutor(sshClient)
+		i.processEvaluator = recipes.NewProcessEvaluatorWithFetcher(func(ctx context.Context) []types.GenericProcess {
+			return remote.Processes(ctx, sshClient)
+		}).WithProcessFilter(processFilter)
+		i.scriptRunner = func(ctx context.Context, script string) (string, error) {
+			return remote.Output(ctx, sshClient, script)
+		}
+		i.runsAsRoot = func() bool {
+			return target.User == "root"
+		}
+
+		// The pre-flight checks inspect the local host, which isn't the one being installed onto.
+		ic.SkipPreflightChecks = true
+	}
+
+	if ic.ManifestIn != "" {
+		// Recipes are resolved for the host and processes of the manifest, which was discovered elsewhere.
+		fd := discovery.NewFileDiscoverer(ic.ManifestIn)
+		i.discoverer = fd
+		i.processEvaluator = recipes.NewProcessEvaluatorWithFetcher(fd.Processes).WithProcessFilter(processFilter)
+		ic.SkipPreflightChecks = true
 	}
 
 	i.InstallerContext = ic
@@ -112,18 +325,69 @@ func NewRecipeInstaller(ic types.InstallerContext, nrClient *newrelic.NewRelic,
 	}
 
 	i.bundlerFactory = func(ctx context.Context, availableRecipes recipes.RecipeDetectionResults) RecipeBundler {
-		return recipes.NewBundler(ctx, availableRecipes)
+		return recipes.NewBundler(ctx, availableRecipes).WithRecipeOrder(i.RecipeOrder)
 	}
 
 	i.bundleInstallerFactory = func(ctx context.Context, manifest *types.DiscoveryManifest, recipeInstallerInterface RecipeInstaller, statusReporter StatusReporter) RecipeBundleInstaller {
-		return NewBundleInstaller(ctx, manifest, recipeInstallerInterface, statusReporter)
+		return NewBundleInstaller(ctx, manifest, recipeInstallerInterface, statusReporter).
+			WithMaxConcurrency(i.MaxConcurrency).
+			WithStepCounter(i.stepCounter)
+	}
+	i.recipeExecutorFactory = func() execution.RecipeExecutor {
+		if sshClient != nil {
+			return execution.NewSSHRecipeExecutor(sshClient)
+		}
+		return execution.NewGoTaskRecipeExecutor()
+	}
+	i.checkExecutorFactory = func() execution.RecipeExecutor {
+		if sshClient != nil {
+			return execution.NewSSHRecipeExecutor(sshClient)
+		}
+		return execution.NewShRecipeExecutor()
+	}
+	i.recipeLogForwarderFactory = func() execution.LogForwarder {
+		return execution.NewRecipeLogForwarder()
+	}
+	// The matchers were checked when they were loaded, and the services listening on their default
+	// ports and the agents of the applications running on the host are recommended too.
+	builtIn := append(recipes.ServicePortMatchers(), recipes.RuntimeMatchers()...)
+	matchers, err := recipes.NewMatcherRegistry().Build(append(builtIn, ic.DiscoveryMatchers...))
+	if err != nil {
+		log.Warnf("Ignoring the discovery matchers: %s", err)
 	}
 	i.recipeDetectorFactory = func(ctx context.Context, repo *recipes.RecipeRepository, ic *types.InstallerContext) RecipeStatusDetector {
-		return recipes.NewRecipeDetector(ctx, repo, i.processEvaluator, ic)
+		// Only the processes of a host installed onto or discovered elsewhere are known.
+		if sshClient != nil {
+			return recipes.NewRecipeDetector(ctx, repo, i.processEvaluator, ic).WithScriptExecutor(execution.NewSSHRecipeExecutor(sshClient)).WithMatchers(matchers, recipes.NewProcessMatchHost(i.processEvaluator.GetOrLoadProcesses))
+		}
+		if ic.ManifestIn != "" {
+			return recipes.NewRecipeDetector(ctx, repo, i.processEvaluator, ic).WithoutDiscoveryScripts().WithMatchers(matchers, recipes.NewProcessMatchHost(i.processEvaluator.GetOrLoadProcesses))
+		}
+		return recipes.NewRecipeDetector(ctx, repo, i.processEvaluator, ic).WithMatchers(matchers, recipes.NewLocalMatchHost(i.processEvaluator.GetOrLoadProcesses))
 	}
 	return &i
 }
 
+func newInstallState(ic types.InstallerContext) *execution.InstallState {
+	target := ic.Target
+	if t, err := remote.ParseTarget(ic.Target); err == nil {
+		target = t.String()
+	}
+	filePath := execution.GetInstallStateFilePath(target)
+
+	// An uninstall reads the recipes installed before, as a resumed install does.
+	if !ic.Resume && !ic.Uninstall {
+		return execution.NewInstallState(filePath)
+	}
+
+	s, err := execution.LoadInstallState(filePath)
+	if err != nil {
+		log.Debugf("could not load install state from %s, starting a new install: %s", filePath, err)
+	}
+
+	return s
+}
+
 var getLatestCliVersionReleased = func(ctx context.Context) (string, error) {
 	return cli.GetLatestReleaseVersion(ctx)
 }
@@ -148,7 +412,7 @@ func (i *RecipeInstall) promptIfNotLatestCLIVersion(ctx context.Context) error {
 	if !isMostRecentCliVersion {
 		i.status.UpdateRequired = true
 
-		cli.PrintUpdateCLIMessage(latestCliVersion)
+		fmt.Fprintln(i.out, cli.FormatUpdateVersionMessage(latestCliVersion))
 
 		err := &types.UpdateRequiredError{
 			Err:     fmt.Errorf(`%s`, cli.FormatUpdateVersionMessage(latestCliVersion)),
@@ -182,18 +446,30 @@ func (i *RecipeInstall) ensureSingleConcurrentInstall(ctx context.Context) error
 	return nil
 }
 
-func (i *RecipeInstall) Install() error {
-	fmt.Printf(`
+const dataPrivacyNoticeURL = "https://newrelic.com/termsandconditions/services-notices"
+
+func (i *RecipeInstall) printWelcome() {
+	fmt.Fprintf(i.out, `
  _   _                 ____      _ _
 | \ | | _____      __ |  _ \ ___| (_) ___
 |  \| |/ _ \ \ /\ / / | |_) / _ | | |/ __|
 | |\  |  __/\ V  V /  |  _ |  __| | | (__
 |_| \_|\___| \_/\_/   |_| \_\___|_|_|\___|
 
-Welcome to New Relic. Let's set up full stack observability for your environment.
-Our Data Privacy Notice: https://newrelic.com/termsandconditions/services-notices
-	`)
-	fmt.Println()
+%s
+%s
+	`, i18n.T("Welcome to New Relic. Let's set up full stack observability for your environment."), i18n.T("Our Data Privacy Notice: %s", dataPrivacyNoticeURL))
+	fmt.Fprintln(i.out)
+}
+
+func (i *RecipeInstall) Install() error {
+	if !ux.QuietMode {
+		if i.Advanced {
+			fmt.Fprintln(i.out, i18n.T("Our Data Privacy Notice: %s", dataPrivacyNoticeURL))
+		} else {
+			i.printWelcome()
+		}
+	}
 
 	log.Tracef("InstallerContext: %+v", i.InstallerContext)
 	log.WithFields(log.Fields{
@@ -221,22 +497,30 @@ Our Data Privacy Notice: https://newrelic.com/termsandconditions/services-notice
 	ctx, cancel := context.WithCancel(utils.SignalCtx)
 	defer cancel()
 
-	errChan := make(chan error)
+	// The install can always send its result, once the grace period of a canceled install is over too.
+	errChan := make(chan error, 1)
 	var err error
 
-	err = i.connectToPlatform()
-	if err != nil {
-		i.status.InstallComplete(err)
-		return err
-	}
-
-	// If not in a dev environemt, check to see if
-	// the installed CLI is up to date.
-	if !cli.IsDevEnvironment() {
-		if err = i.promptIfNotLatestCLIVersion(ctx); err != nil {
+	if i.Offline {
+		if err = i.prepareOfflinePackages(); err != nil {
 			i.status.InstallComplete(err)
 			return err
 		}
+	} else {
+		err = i.connectToPlatform()
+		if err != nil {
+			i.status.InstallComplete(err)
+			return err
+		}
+
+		// If not in a dev environemt, check to see if
+		// the installed CLI is up to date.
+		if !cli.IsDevEnvironment() {
+			if err = i.promptIfNotLatestCLIVersion(ctx); err != nil {
+				i.status.InstallComplete(err)
+				return err
+			}
+		}
 	}
 
 	go func(ctx context.Context) {
@@ -245,21 +529,53 @@ Our Data Privacy Notice: https://newrelic.com/termsandconditions/services-notice
 
 	select {
 	case <-ctx.Done():
-		err = ctx.Err()
-		i.status.InstallComplete(err)
-		return err
+		// Let the recipe being installed report that it was canceled before the install does.
+		select {
+		case <-errChan:
+		case <-time.After(interruptGracePeriod):
+			log.Debug("the install didn't stop within the grace period after being canceled")
+		}
+
+		i.status.InstallCanceled()
+		i.runPostInstallHook(ctx, types.ErrInterrupt)
+		return types.ErrInterrupt
 	case err = <-errChan:
 		if errors.Is(err, types.ErrInterrupt) {
 			i.status.InstallCanceled()
+			i.runPostInstallHook(ctx, err)
 			return err
 		}
 
 		i.status.InstallComplete(err)
+		i.runPostInstallHook(ctx, err)
+
+		// The summary has already reported them, but any failed recipe still fails the install.
+		if failed := i.status.FailedRecipeNames(); err == nil && len(failed) > 0 {
+			return &types.RecipeFailuresError{RecipeNames: failed, Errors: i.recipeErrors.all()}
+		}
 
 		return err
 	}
 }
 
+// prepareOfflinePackages extracts the agent packages from the recipe archive, and makes
+// their location available to recipes through the EnvOfflinePackagesPath variable.
+func (i *RecipeInstall) prepareOfflinePackages() error {
+	dir, err := os.MkdirTemp("", "newrelic-offline-packages")
+	if err != nil {
+		return err
+	}
+
+	if err := recipes.NewRecipeArchiveFetcher(i.RecipeBundle).ExtractPackages(dir); err != nil {
+		return fmt.Errorf("could not extract agent packages: %w", err)
+	}
+
+	log.Debugf("Extracted offline agent packages to %s", dir)
+	os.Setenv(types.EnvOfflinePackagesPath, dir)
+
+	return nil
+}
+
 func (i *RecipeInstall) connectToPlatform() error {
 	loaderChan := make(chan error)
 
@@ -295,56 +611,285 @@ func (i *RecipeInstall) install(ctx context.Context) error {
 		return err
 	}
 
+	if m.Cloud != nil {
+		i.addCloudTags(m.Cloud)
+	}
+
+	useKubernetes, err := i.shouldInstallKubernetes(m)
+	if err != nil {
+		return err
+	}
+	if useKubernetes {
+		return NewKubernetesInstaller(&i.InstallerContext, i.prompter, i.status, i.progressIndicator).Install(ctx, m.Kubernetes)
+	}
+
 	repo := recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
 		recipes, err2 := i.recipeFetcher.FetchRecipes(ctx)
 		return recipes, err2
-	}, m)
+	}, m).WithLogExclusions(i.ExcludeLogs)
 
 	i.printStartInstallingMessage(repo)
 
+	if len(i.RecipeChecksums) > 0 {
+		if err := i.verifyRecipeChecksums(repo); err != nil {
+			return err
+		}
+	}
+
 	recipeDetector := i.recipeDetectorFactory(ctx, repo, &i.InstallerContext)
 	availableRecipes, unavailableRecipes, err := recipeDetector.GetDetectedRecipes()
 	if err != nil {
-		return err
+		return &types.RecipeFetchError{Err: err}
 	}
 
 	i.reportRecipeStatuses(availableRecipes, unavailableRecipes)
+	i.status.ExcludedLogFiles = repo.ExcludedLogFiles()
 
 	if len(availableRecipes) == 0 && !i.RecipeNamesProvided() {
-		fmt.Println("This system is not supported by any available recipes for automatic installation. Please see our documentation for requirements.")
+		fmt.Fprintln(i.out, "This system is not supported by any available recipes for automatic installation. Please see our documentation for requirements.")
 		return &types.UncaughtError{
 			Err: fmt.Errorf("no recipes found supporting this system"),
 		}
 	}
 
 	bundler := i.bundlerFactory(ctx, availableRecipes)
+
+	if i.DryRun {
+		return i.printInstallPlan(ctx, bundler, m)
+	}
+
+	if err := i.runPreflightChecks(ctx, availableRecipes); err != nil {
+		return err
+	}
+
+	if err := i.promptAdvancedOptions(m, availableRecipes); err != nil {
+		return err
+	}
+
+	if err := i.promptLogFiles(ctx, m, repo, availableRecipes); err != nil {
+		return err
+	}
+
+	if err := i.confirmPrivilegedTasks(availableRecipes); err != nil {
+		return err
+	}
+
+	if i.Nice {
+		i.lowerPriority()
+	}
+
+	if err := i.hookRunner.RunPreInstall(ctx, m, i.recipeNamesToInstall(availableRecipes)); err != nil {
+		return err
+	}
+
 	bundleInstaller := i.bundleInstallerFactory(ctx, m, i, i.status)
 
+	additionalBundle := i.createAdditionalBundle(bundler)
+	earlyBundle, laterBundle := bundler.SplitEarlyBundle(additionalBundle)
+	if len(earlyBundle.BundleRecipes) > 0 {
+		log.Debugf("Early bundle recipes:%s", earlyBundle)
+		bundleInstaller.InstallContinueOnError(earlyBundle, i.AssumeYes)
+	}
+
 	cbErr := i.installCoreBundle(bundler, bundleInstaller)
 	if cbErr != nil {
 		return cbErr
 	}
 
-	abErr := i.installAdditionalBundle(bundler, bundleInstaller, repo)
+	abErr := i.installAdditionalBundle(additionalBundle, laterBundle, bundleInstaller, repo)
 	if abErr != nil {
 		return abErr
 	}
 
+	i.offerAWSIntegration(ctx, m)
+
 	i.reportRecipeRecommendations(availableRecipes)
 
 	log.Debugf("Done installing.")
 	return nil
 }
 
+// addCloudTags tags the install and its entities with the cloud instance the host runs on, so
+// they can be filtered by provider, region and account. Tags given with --tag take precedence.
+func (i *RecipeInstall) addCloudTags(c *types.CloudHost) {
+	given := map[string]bool{}
+	for _, t := range i.GetTags() {
+		given[strings.SplitN(t, types.TagSeparator, 2)[0]] = true
+	}
+
+	tags := []string{}
+	for _, t := range c.Tags() {
+		if !given[strings.SplitN(t, types.TagSeparator, 2)[0]] {
+			tags = append(tags, t)
+		}
+	}
+
+	i.SetTags(append(i.GetTags(), tags...))
+	if !i.Offline {
+		i.entityTagger.AddTags(tags)
+	}
+}
+
+// The choices of linking the AWS account of an EC2 host to New Relic.
+const (
+	awsLinkWithRole           = "Link with an existing IAM role"
+	awsLinkWithCloudFormation = "Create the IAM role with CloudFormation"
+	awsLinkSkip               = "Skip"
+)
+
+// offerAWSIntegration links the AWS account of an EC2 host to New Relic once its agents are
+// installed, so the account's cloud telemetry lands next to the host's. The IAM role is the one of
+// --awsRoleArn, or one the user enters or creates with CloudFormation. It's an optional step, so
+// failures are only reported.
+func (i *RecipeInstall) offerAWSIntegration(ctx context.Context, m *types.DiscoveryManifest) {
+	if m.Cloud == nil || m.Cloud.Provider != "aws" || i.Offline || i.awsLinker == nil {
+		return
+	}
+
+	if i.AWSRoleARN == "" && i.AssumeYes {
+		log.Debugf("not linking AWS account %s, run the install with --awsRoleArn to link it", m.Cloud.AccountID)
+		return
+	}
+
+	linked, err := i.awsLinker.IsLinked(ctx, m.Cloud.AccountID)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+
+	if linked {
+		log.Debugf("AWS account %s is already linked", m.Cloud.AccountID)
+		return
+	}
+
+	roleARN := i.AWSRoleARN
+	if roleARN == "" {
+		msg := fmt.Sprintf("Link AWS account %s to New Relic to monitor its cloud services too?", m.Cloud.AccountID)
+		choice, err := i.prompter.Select(msg, []string{awsLinkWithRole, awsLinkWithCloudFormation, awsLinkSkip}, awsLinkSkip)
+		if err != nil || choice == awsLinkSkip {
+			return
+		}
+
+		if choice == awsLinkWithCloudFormation {
+			fmt.Fprintf(i.out, "\n  Create the IAM role at %s\n  and enter the RoleArn output of the stack once it's created.\n\n", i.awsLinker.CloudFormationURL(m.Cloud.Region))
+		}
+
+		if roleARN, err = i.prompter.Input("IAM role ARN", ""); err != nil || roleARN == "" {
+			return
+		}
+	}
+
+	msg := fmt.Sprintf("Linking AWS account %s", m.Cloud.AccountID)
+	i.progressIndicator.Start(msg)
+	if err := i.awsLinker.Link(ctx, strings.TrimSpace(roleARN), "AWS "+m.Cloud.AccountID); err != nil {
+		i.progressIndicator.Fail(msg)
+		log.Warn(err)
+		return
+	}
+	i.progressIndicator.Success(msg)
+}
+
+// shouldInstallKubernetes returns whether to install the Kubernetes integration onto a cluster
+// instead of recipes onto the host, which is offered when discovery found Kubernetes. Unattended
+// installs only do so with --kubernetes.
+func (i *RecipeInstall) shouldInstallKubernetes(m *types.DiscoveryManifest) (bool, error) {
+	if i.Kubernetes || i.HelmValuesFile != "" {
+		return true, nil
+	}
+
+	if m.Kubernetes == nil || i.RecipeNamesProvided() || i.Target != "" || i.DryRun {
+		return false, nil
+	}
+
+	if i.AssumeYes {
+		log.Infof("Kubernetes was detected, run the install with --kubernetes to install the Kubernetes integration instead of the host agents")
+		return false, nil
+	}
+
+	return i.prompter.PromptYesNo("Kubernetes was detected. Install the Kubernetes integration with Helm instead of the host agents?")
+}
+
+// runPreflightChecks checks the host, and the prerequisites of the recipes available to install,
+// before anything is installed.
+func (i *RecipeInstall) runPreflightChecks(ctx context.Context, availableRecipes recipes.RecipeDetectionResults) error {
+	if i.SkipPreflightChecks {
+		log.Debug("skipping pre-flight checks")
+		return nil
+	}
+
+	recipesToCheck := []*types.OpenInstallationRecipe{}
+	for _, d := range availableRecipes {
+		recipesToCheck = append(recipesToCheck, d.Recipe)
+	}
+
+	if !ux.QuietMode {
+		fmt.Fprintln(i.out)
+	}
+	report := i.preflightChecker.Check(ctx, recipesToCheck)
+	report.Print(i.out)
+
+	if report.HasFailures() {
+		return &types.PreflightError{FailedChecks: report.FailedChecks()}
+	}
+
+	return nil
+}
+
+func (i *RecipeInstall) printInstallPlan(ctx context.Context, bundler RecipeBundler, m *types.DiscoveryManifest) error {
+	var coreBundle *recipes.Bundle
+	if i.shouldInstallCore() {
+		coreBundle = bundler.CreateCoreBundle()
+	}
+
+	earlyBundle, laterBundle := bundler.SplitEarlyBundle(i.createAdditionalBundle(bundler))
+
+	plan := i.buildInstallPlan(ctx, m, earlyBundle, coreBundle, laterBundle)
+	if i.planOutput != nil {
+		return plan.Write(i.planOutput)
+	}
+
+	if i.RenderOnly {
+		plan.PrintScripts(i.out)
+		return nil
+	}
+
+	plan.Print(i.out)
+	return nil
+}
+
+// WithPlanOutput writes the install plan of a dry run as YAML to w, instead of printing it.
+func (i *RecipeInstall) WithPlanOutput(w io.Writer) *RecipeInstall {
+	i.planOutput = w
+	return i
+}
+
+// WithOutput prints the messages and progress of the install to w instead of STDOUT, with a line
+// for each step.
+func (i *RecipeInstall) WithOutput(w io.Writer) *RecipeInstall {
+	i.out = w
+	i.progressIndicator = ux.NewPlainProgressTo(w)
+	if ux.QuietMode {
+		i.progressIndicator = ux.NewQuietProgressTo(w)
+	}
+	return i
+}
+
 func (i *RecipeInstall) printStartInstallingMessage(repo *recipes.RecipeRepository) {
-	message := "\n\nInstalling New Relic"
+	if ux.QuietMode {
+		return
+	}
+
+	message := "\n\n" + i18n.T("Installing New Relic")
 	if i.RecipeNamesProvided() && len(i.RecipeNames) > 0 {
 		r := repo.FindRecipeByName(i.RecipeNames[0])
 		if r != nil {
 			message = fmt.Sprintf("%s %s", message, r.DisplayName)
 		}
 	}
-	fmt.Println(message)
+	if i.Target != "" {
+		message = fmt.Sprintf("%s on %s", message, i.Target)
+	}
+	fmt.Fprintln(i.out, message)
 }
 
 func (i *RecipeInstall) reportRecipeStatuses(availableRecipes recipes.RecipeDetectionResults,
@@ -390,6 +935,20 @@ func (i *RecipeInstall) getRecipeRecommendations(availableRecipes recipes.Recipe
 	return recommendations
 }
 
+// recipeNamesToInstall returns the names of the available recipes the install may install, which
+// are only the targeted ones when recipes are named.
+func (i *RecipeInstall) recipeNamesToInstall(availableRecipes recipes.RecipeDetectionResults) []string {
+	names := []string{}
+	for _, d := range availableRecipes {
+		if i.RecipeNamesProvided() && !i.isTargetInstallRecipe(d.Recipe.Name) {
+			continue
+		}
+		names = append(names, d.Recipe.Name)
+	}
+
+	return names
+}
+
 func (i *RecipeInstall) isTargetInstallRecipe(recipeName string) bool {
 	for _, r := range i.RecipeNames {
 		if r == recipeName {
@@ -399,18 +958,26 @@ func (i *RecipeInstall) isTargetInstallRecipe(recipeName string) bool {
 	return false
 }
 
-func (i *RecipeInstall) installAdditionalBundle(bundler RecipeBundler, bundleInstaller RecipeBundleInstaller, repo *recipes.RecipeRepository) error {
-	var additionalBundle *recipes.Bundle
+func (i *RecipeInstall) createAdditionalBundle(bundler RecipeBundler) *recipes.Bundle {
 	if i.RecipeNamesProvided() {
-		additionalBundle = bundler.CreateAdditionalTargetedBundle(i.RecipeNames)
-		i.reportUnsupportedTargetedRecipes(additionalBundle, repo)
+		additionalBundle := bundler.CreateAdditionalTargetedBundle(i.RecipeNames)
 		log.Debugf("Additional Targeted bundle recipes:%s", additionalBundle)
-	} else {
-		additionalBundle = bundler.CreateAdditionalGuidedBundle()
-		log.Debugf("Additional Guided bundle recipes:%s", additionalBundle)
+		return additionalBundle
+	}
+
+	additionalBundle := bundler.CreateAdditionalGuidedBundle()
+	log.Debugf("Additional Guided bundle recipes:%s", additionalBundle)
+	return additionalBundle
+}
+
+// installAdditionalBundle installs the recipes of the additional bundle that remain once the
+// early recipes and the core bundle are installed.
+func (i *RecipeInstall) installAdditionalBundle(additionalBundle *recipes.Bundle, remaining *recipes.Bundle, bundleInstaller RecipeBundleInstaller, repo *recipes.RecipeRepository) error {
+	if additionalBundle.IsAdditionalTargeted() {
+		i.reportUnsupportedTargetedRecipes(additionalBundle, repo)
 	}
 
-	bundleInstaller.InstallContinueOnError(additionalBundle, i.AssumeYes)
+	bundleInstaller.InstallContinueOnError(remaining, i.AssumeYes)
 
 	if bundleInstaller.InstalledRecipesCount() == 0 {
 		return &types.UncaughtError{
@@ -429,6 +996,13 @@ func (i *RecipeInstall) installCoreBundle(bundler RecipeBundler, bundleInstaller
 	if i.shouldInstallCore() {
 		coreBundle := bundler.CreateCoreBundle()
 		log.Debugf("Core bundle recipes:%s", coreBundle)
+
+		if i.ContinueOnError {
+			bundleInstaller.InstallContinueOnError(coreBundle, i.AssumeYes)
+			i.warnCoreBundleFailures(coreBundle)
+			return nil
+		}
+
 		err := bundleInstaller.InstallStopOnError(coreBundle, i.AssumeYes)
 		if err != nil {
 			log.Debugf("error installing core bundle:%s", err)
@@ -441,6 +1015,15 @@ func (i *RecipeInstall) installCoreBundle(bundler RecipeBundler, bundleInstaller
 	return nil
 }
 
+// warnCoreBundleFailures reports the core recipes that failed, since the rest of the install continues without them.
+func (i *RecipeInstall) warnCoreBundleFailures(coreBundle *recipes.Bundle) {
+	for _, br := range coreBundle.BundleRecipes {
+		if i.status.RecipeHasStatus(br.Recipe.Name, execution.RecipeStatusTypes.FAILED) {
+			fmt.Fprintf(i.out, "  %s  %s\n", ux.IconExclamation, i18n.T("%s failed to install, continuing with the remaining integrations", br.Recipe.DisplayName))
+		}
+	}
+}
+
 func (i *RecipeInstall) assertDiscoveryValid(ctx context.Context, m *types.DiscoveryManifest) error {
 	err := i.manifestValidator.Validate(m)
 	if err != nil {
@@ -455,14 +1038,18 @@ func (i *RecipeInstall) discover(ctx context.Context) (*types.DiscoveryManifest,
 
 	m, err := i.discoverer.Discover(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("there was an error discovering system info: %s", err)
+		return nil, &types.DiscoveryError{Err: fmt.Errorf("there was an error discovering system info: %s", err)}
+	}
+
+	if err := i.exportManifest(ctx, m); err != nil {
+		return nil, &types.DiscoveryError{Err: err}
 	}
 
 	err = i.assertDiscoveryValid(ctx, m)
 	i.status.DiscoveryComplete(*m)
 
 	if err != nil {
-		return nil, fmt.Errorf("there was an error discovering system info: %s", err)
+		return nil, &types.DiscoveryError{Err: fmt.Errorf("there was an error discovering system info: %s", err)}
 	}
 
 	return m, nil
@@ -494,10 +1081,12 @@ func (i *RecipeInstall) reportUnsupportedTargetedRecipes(bundle *recipes.Bundle,
 func (i *RecipeInstall) executeAndValidate(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, vars types.RecipeVars, assumeYes bool) (string, error) {
 	i.status.RecipeInstalling(execution.RecipeStatusEvent{Recipe: *r})
 
-	// Execute the recipe steps.
-	if err := i.recipeExecutor.Execute(ctx, *r, vars); err != nil {
-		if err == types.ErrInterrupt {
-			return "", err
+	// Execute the recipe steps, backing up the configuration files they change.
+	if err := i.executeRecipeWithConfigBackup(ctx, r, vars); err != nil {
+		// The executors fail however the tasks they were running were stopped when the install is
+		// canceled.
+		if err == types.ErrInterrupt || ctx.Err() != nil {
+			return "", types.ErrInterrupt
 		}
 
 		if e, ok := err.(*types.UnsupportedOperatingSystemError); ok {
@@ -524,16 +1113,18 @@ func (i *RecipeInstall) executeAndValidate(ctx context.Context, m *types.Discove
 			e.SetError(msg)
 			se.TaskPath = e.TaskPath()
 		} else {
-			err = errors.New(msg)
+			err = &types.ExecutionError{Err: fmt.Errorf("execution failed for %s: %w", r.Name, err)}
 		}
 
 		i.status.RecipeFailed(se)
+		i.rollbackRecipe(ctx, r, vars)
 		return "", err
 	}
 
 	entityGUID := i.recipeExecutor.GetOutput().EntityGUID()
 	if entityGUID != "" {
 		log.Debugf("Found entityGuid from recipe execution:%s", entityGUID)
+		i.tagEntity(ctx, r, entityGUID)
 
 		i.status.RecipeInstalled(execution.RecipeStatusEvent{
 			Recipe:     *r,
@@ -552,6 +1143,8 @@ func (i *RecipeInstall) executeAndValidate(ctx context.Context, m *types.Discove
 		if infraAgentEntityKey == "" {
 			log.Debug("empty infrastructure agent entity key")
 		}
+
+		i.ensureInfraAgentServiceRunning(ctx)
 	}
 
 	// show validation spinner if we need to validate and has no other spinner (Spinner is show when assume yes)
@@ -561,11 +1154,17 @@ func (i *RecipeInstall) executeAndValidate(ctx context.Context, m *types.Discove
 		i.progressIndicator.Start(msg)
 	}
 
+	if vp, ok := i.progressIndicator.(ux.ValidationProgressIndicator); ok {
+		vp.StartValidation(r.DisplayName, validationTimeout)
+	}
+
 	validationStart := time.Now()
 	entityGUID, err := i.validateRecipeViaAllMethods(ctx, r, m, vars, assumeYes)
 	validationDurationMs := time.Since(validationStart).Milliseconds()
 	if err != nil {
-		validationErr := fmt.Errorf("encountered an error while validating receipt of data for %s: %w", r.Name, err)
+		validationErr := &types.ValidationError{
+			Err: fmt.Errorf("encountered an error while validating receipt of data for %s: %w", r.Name, err),
+		}
 		i.status.RecipeFailed(execution.RecipeStatusEvent{
 			Recipe:               *r,
 			Msg:                  validationErr.Error(),
@@ -576,6 +1175,8 @@ func (i *RecipeInstall) executeAndValidate(ctx context.Context, m *types.Discove
 		return "", validationErr
 	}
 
+	i.tagEntity(ctx, r, entityGUID)
+
 	i.status.RecipeInstalled(execution.RecipeStatusEvent{
 		Recipe:               *r,
 		EntityGUID:           entityGUID,
@@ -586,6 +1187,142 @@ func (i *RecipeInstall) executeAndValidate(ctx context.Context, m *types.Discove
 	return entityGUID, nil
 }
 
+// ensureInfraAgentServiceRunning starts the infrastructure agent's Windows or launchd service when
+// the recipe left it stopped, such as when the host restarted during the install, so the agent can
+// be validated.
+func (i *RecipeInstall) ensureInfraAgentServiceRunning(ctx context.Context) {
+	if i.Target != "" || i.infraAgentService == nil {
+		return
+	}
+
+	if err := i.infraAgentService.EnsureRunning(ctx, time.Minute); err != nil {
+		log.Warnf("The infrastructure agent service is not running: %s", err)
+	}
+}
+
+// tagEntity adds the install's tags to the entity created by a recipe. A failure to tag
+// doesn't fail the recipe, as the entity is already reporting.
+func (i *RecipeInstall) tagEntity(ctx context.Context, r *types.OpenInstallationRecipe, entityGUID string) {
+	if err := i.entityTagger.TagEntity(ctx, entityGUID); err != nil {
+		log.Warnf("Could not add tags to the entity created by %s: %s", r.DisplayName, err)
+	}
+}
+
+// executeRecipeWithConfigBackup executes the recipe steps, and backs up the configuration files they
+// changed, whether or not they succeed.
+func (i *RecipeInstall) executeRecipeWithConfigBackup(ctx context.Context, r *types.OpenInstallationRecipe, vars types.RecipeVars) error {
+	snapshot := i.snapshotConfigFiles()
+	defer i.backupChangedConfigFiles(r.Name, snapshot)
+
+	return i.executeRecipeWithRetries(ctx, r, vars)
+}
+
+// executeRecipeWithRetries executes the recipe steps, retrying failed attempts with an exponential backoff
+// up to the configured number of retries. Each attempt is bound by the recipe's install timeout.
+func (i *RecipeInstall) executeRecipeWithRetries(ctx context.Context, r *types.OpenInstallationRecipe, vars types.RecipeVars) error {
+	timeout := r.GetInstallTimeout(i.RecipeTimeout)
+	maxAttempts := i.MaxRetries + 1
+	attempt := 0
+
+	retry := utils.NewRetry(maxAttempts, recipeRetryDelayMs, func() error {
+		attempt++
+		if attempt > 1 {
+			log.Debugf("retrying recipe %s, attempt %d of %d", r.Name, attempt, maxAttempts)
+			i.status.RecipeInstalling(execution.RecipeStatusEvent{
+				Recipe:   *r,
+				Msg:      fmt.Sprintf("retrying, attempt %d of %d", attempt, maxAttempts),
+				Metadata: map[string]string{"attempt": strconv.Itoa(attempt)},
+			})
+		}
+
+		return i.executeRecipeWithTimeout(ctx, r, vars, timeout)
+	}).WithBackoff(2)
+	retry.IsRetryable = isRetryableRecipeError
+
+	retryCtx := retry.ExecWithRetries(ctx)
+	if retryCtx.Success {
+		return nil
+	}
+
+	if retryCtx.Canceled {
+		return types.ErrInterrupt
+	}
+
+	return retryCtx.MostRecentError()
+}
+
+func (i *RecipeInstall) executeRecipeWithTimeout(ctx context.Context, r *types.OpenInstallationRecipe, vars types.RecipeVars, timeout time.Duration) error {
+	if i.Nice && i.packageManagerLock != nil && usesPackageManager(r) {
+		i.packageManagerLock.Lock()
+		defer i.packageManagerLock.Unlock()
+	}
+
+	if timeout <= 0 {
+		return i.recipeExecutor.Execute(ctx, *r, vars)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := i.recipeExecutor.Execute(timeoutCtx, *r, vars)
+	if err != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		return &types.RecipeTimeoutError{RecipeName: r.Name, Timeout: timeout}
+	}
+
+	return err
+}
+
+func isRetryableRecipeError(err error) bool {
+	if err == types.ErrInterrupt {
+		return false
+	}
+
+	if _, ok := err.(*types.UnsupportedOperatingSystemError); ok {
+		return false
+	}
+
+	return true
+}
+
+// rollbackRecipe reverses the changes of a recipe whose install failed partway through, so the
+// host isn't left partly instrumented. Recipes whose installed check showed they weren't installed
+// before they ran are uninstalled with their uninstall tasks. Any other recipe may have been
+// installed already, so only the configuration files it changed are restored.
+func (i *RecipeInstall) rollbackRecipe(ctx context.Context, r *types.OpenInstallationRecipe, vars types.RecipeVars) {
+	if i.NoRollback {
+		return
+	}
+
+	if r.HasUninstall() && i.absentRecipes.has(r.Name) {
+		log.Debugf("rolling back recipe %s", r.Name)
+		if err := i.recipeExecutor.Execute(ctx, r.ToUninstallRecipe(), vars); err != nil {
+			log.Debugf("error rolling back recipe %s: %s", r.Name, err)
+			if !ux.QuietMode {
+				fmt.Fprintf(i.out, "  %s\n", i18n.T("The changes of %s could not be rolled back: %s", r.DisplayName, err))
+			}
+			return
+		}
+
+		if !ux.QuietMode {
+			fmt.Fprintf(i.out, "  %s\n", i18n.T("The changes of %s were rolled back.", r.DisplayName))
+		}
+		return
+	}
+
+	restored, err := i.restoreChangedConfigFiles(r.Name)
+	if err != nil {
+		log.Debugf("error restoring the configuration files changed by recipe %s: %s", r.Name, err)
+		if !ux.QuietMode {
+			fmt.Fprintf(i.out, "  %s\n", i18n.T("The configuration files changed by %s could not be restored: %s", r.DisplayName, err))
+		}
+		return
+	}
+
+	if restored && !ux.QuietMode {
+		fmt.Fprintf(i.out, "  %s\n", i18n.T("The configuration files changed by %s were restored.", r.DisplayName))
+	}
+}
+
 func (i *RecipeInstall) optInToSendLogsAndUpdateRecipeMetadata(recipeName string) {
 	i.progressIndicator.Fail("Installing " + recipeName)
 	recipeOutput := i.recipeExecutor.GetRecipeOutput()
@@ -613,7 +1350,11 @@ func (i *RecipeInstall) validateRecipeViaAllMethods(ctx context.Context, r *type
 	// Add agent validation if configured
 	hasValidationURL := r.ValidationURL != ""
 	isAbsoluteURL := utils.IsAbsoluteURL(r.ValidationURL)
-	if hasValidationURL && isAbsoluteURL {
+	// The agent and integration validations check the local host, so only NRQL validates remote installs.
+	isRemote := i.Target != ""
+	if isRemote {
+		log.Debugf("remote install, skipping validationUrl and validationIntegration")
+	} else if hasValidationURL && isAbsoluteURL {
 		validationFuncs = append(validationFuncs, func() (string, error) {
 			return i.agentValidator.Validate(timeoutCtx, r.ValidationURL)
 		})
@@ -625,7 +1366,7 @@ func (i *RecipeInstall) validateRecipeViaAllMethods(ctx context.Context, r *type
 	hasValidationNRQL := r.ValidationNRQL != ""
 
 	// Add either Integration Validation or NRQL Validation if configured
-	if hasValidationIntegration {
+	if hasValidationIntegration && !isRemote {
 		integrationName := r.ValidationIntegration
 
 		validationFuncs = append(validationFuncs, func() (string, error) {
@@ -635,7 +1376,9 @@ func (i *RecipeInstall) validateRecipeViaAllMethods(ctx context.Context, r *type
 		log.Debugf("no validationIntegration defined, skipping")
 	}
 
-	if hasValidationNRQL {
+	if hasValidationNRQL && i.Offline {
+		log.Debugf("offline install, skipping validationNRQL")
+	} else if hasValidationNRQL {
 		validationFuncs = append(validationFuncs, func() (string, error) {
 			return i.recipeValidator.ValidateRecipe(timeoutCtx, *m, *r, vars)
 		})
@@ -678,17 +1421,94 @@ func (i *RecipeInstall) validateRecipeViaAllMethods(ctx context.Context, r *type
 
 // Installing recipe
 func (i *RecipeInstall) executeAndValidateWithProgress(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, assumeYes bool) (string, error) {
-	fmt.Println()
-	msg := fmt.Sprintf("Installing %s", r.DisplayName)
+	if !ux.QuietMode {
+		fmt.Fprintln(i.out)
+	}
+	step := i.stepCounter.Next()
+
+	if i.Resume && i.installState.IsInstalled(r.Name) {
+		entityGUID := i.installState.EntityGUID(r.Name)
+		if !ux.QuietMode {
+			fmt.Fprintf(i.out, "  %s%s\n", step, i18n.T("%s was installed previously, skipping", r.DisplayName))
+		}
+
+		i.status.RecipeInstalled(execution.RecipeStatusEvent{
+			Recipe:     *r,
+			EntityGUID: entityGUID,
+		})
+
+		return entityGUID, nil
+	}
+
+	action := "Installing %s"
+	upgrading := false
+	if i.isAlreadyInstalled(ctx, r) {
+		canUpgrade := i.canUpgrade(ctx, r)
+		if !canUpgrade || !i.shouldUpgrade(r, assumeYes) {
+			if !ux.QuietMode {
+				fmt.Fprintf(i.out, "  %s%s\n", step, i18n.T("%s is already installed, skipping", r.DisplayName))
+				if canUpgrade {
+					fmt.Fprintf(i.out, "  %s\n", i18n.T("Run the install with --upgrade to upgrade it."))
+				}
+			}
+
+			i.status.RecipeInstalled(execution.RecipeStatusEvent{
+				Recipe:           *r,
+				AlreadyInstalled: true,
+			})
+
+			return "", nil
+		}
+
+		action = "Upgrading %s"
+		upgrading = true
+	}
+
+	actionMsg := i18n.T(action, r.DisplayName)
+	msg := step + actionMsg
+
+	var reviewedVars types.RecipeVars
+	if i.Review && !assumeYes {
+		vars, approved, err := i.reviewRecipe(m, r, upgrading)
+		if err != nil {
+			i.status.RecipeFailed(execution.RecipeStatusEvent{Recipe: *r, Msg: err.Error()})
+			i.recipeErrors.add(err)
+			return "", err
+		}
+
+		if !approved {
+			if !ux.QuietMode {
+				fmt.Fprintf(i.out, "  %s%s\n", step, i18n.T("%s skipped", r.DisplayName))
+			}
+			i.status.RecipeSkipped(execution.RecipeStatusEvent{Recipe: *r})
+			return "", nil
+		}
+		reviewedVars = vars
+	}
+
+	if err := i.hookRunner.RunRecipePreInstall(ctx, m, r); err != nil {
+		fmt.Fprintf(i.out, "  %s%s\n", step, err)
+		i.status.RecipeFailed(execution.RecipeStatusEvent{Recipe: *r, Msg: err.Error()})
+		i.recipeErrors.add(err)
+		return "", err
+	}
+
+	// The spinner is started before the worker goroutine so its own progress
+	// updates (validation, failures) always come after it.
+	i.progressIndicator.ShowSpinner(assumeYes)
+	i.progressIndicator.Start(msg)
 
 	errorChan := make(chan error)
 	successChan := make(chan string)
 
 	go func() {
-		vars, err := i.recipeVarPreparer.Prepare(*m, *r, assumeYes)
-		if err != nil {
-			errorChan <- err
-			return
+		var err error
+		vars := reviewedVars
+		if vars == nil {
+			if vars, err = i.recipeVarPreparer.Prepare(*m, *r, assumeYes); err != nil {
+				errorChan <- err
+				return
+			}
 		}
 
 		vars["assumeYes"] = fmt.Sprintf("%v", assumeYes)
@@ -696,7 +1516,12 @@ func (i *RecipeInstall) executeAndValidateWithProgress(ctx context.Context, m *t
 			vars["INFRA_KEY"] = infraAgentEntityKey
 		}
 
-		entityGUID, err := i.executeAndValidate(ctx, m, r, vars, assumeYes)
+		var entityGUID string
+		if upgrading {
+			entityGUID, err = i.upgradeRecipe(ctx, r, vars)
+		} else {
+			entityGUID, err = i.executeAndValidate(ctx, m, r, vars, assumeYes)
+		}
 		if err != nil {
 			errorChan <- err
 			return
@@ -704,23 +1529,32 @@ func (i *RecipeInstall) executeAndValidateWithProgress(ctx context.Context, m *t
 		successChan <- entityGUID
 	}()
 
-	i.progressIndicator.ShowSpinner(assumeYes)
-	i.progressIndicator.Start(msg)
-
 	for {
 		select {
 		case entityGUID := <-successChan:
-			i.progressIndicator.Success("Installing " + r.DisplayName)
+			i.progressIndicator.Success(actionMsg)
+			if !ux.QuietMode {
+				i.printConfigChanges(i.out, r.Name)
+			}
+			i.hookRunner.RunRecipePostInstall(ctx, m, r, execution.RecipeStatusTypes.INSTALLED, entityGUID, nil)
 
 			return entityGUID, nil
 		case err := <-errorChan:
 			if errors.Is(err, types.ErrInterrupt) {
-				i.progressIndicator.Canceled("Installing " + r.DisplayName)
+				i.progressIndicator.Canceled(actionMsg)
 				i.status.RecipeCanceled(execution.RecipeStatusEvent{Recipe: *r})
+				hookCtx, cancel := postInstallHookContext(ctx, interruptGracePeriod)
+				i.hookRunner.RunRecipePostInstall(hookCtx, m, r, execution.RecipeStatusTypes.CANCELED, "", err)
+				cancel()
 			} else {
 				// progressIndicator has already been called; we need to finish i.e. message about logs being sent
 				// and actually post logs to NR if the user has opted-in
 				i.finishHandlingFailure(r.DisplayName)
+				if !ux.QuietMode {
+					i.printConfigChanges(i.out, r.Name)
+				}
+				i.recipeErrors.add(err)
+				i.hookRunner.RunRecipePostInstall(ctx, m, r, execution.RecipeStatusTypes.FAILED, "", err)
 			}
 			log.Debugf("install error encountered: %s", err)
 			return "", err
@@ -728,10 +1562,64 @@ func (i *RecipeInstall) executeAndValidateWithProgress(ctx context.Context, m *t
 	}
 }
 
+func (i *RecipeInstall) runPostInstallHook(ctx context.Context, installErr error) {
+	hookCtx, cancel := postInstallHookContext(ctx, interruptGracePeriod)
+	defer cancel()
+
+	i.hookRunner.RunPostInstall(hookCtx, i.status, installErr)
+}
+
+// postInstallHookContext returns the context of a post-install hook, which outlives the install so
+// the hook can report that it was canceled, but ends the grace period after the install's does.
+func postInstallHookContext(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	hookCtx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-hookCtx.Done():
+			return
+		}
+
+		select {
+		case <-time.After(grace):
+			cancel()
+		case <-hookCtx.Done():
+		}
+	}()
+
+	return hookCtx, cancel
+}
+
+// cloneForConcurrentInstall returns a copy of the installer with its own recipe executor and log
+// forwarder, since they hold the output of the recipes they ran. The install status is shared, and
+// locks the events of the recipes. Progress is written line by line so the output of recipes
+// running side by side stays readable. The configuration files the recipes change aren't backed up.
+func (i *RecipeInstall) cloneForConcurrentInstall() RecipeInstaller {
+	c := *i
+	c.recipeExecutor = i.recipeExecutorFactory()
+	// Recipes running side by side would back up each other's changes to the configuration files.
+	c.configFilePatterns = nil
+	c.recipeLogForwarder = i.recipeLogForwarderFactory()
+	c.recipeLogForwarder.SetUserOptedIn(i.recipeLogForwarder.HasUserOptedIn())
+	c.progressIndicator = ux.NewPlainProgress()
+	if ux.CIMode {
+		c.progressIndicator = ux.NewCIProgress()
+	}
+	if ux.QuietMode {
+		c.progressIndicator = ux.NewQuietProgress()
+	}
+	if ux.JSONMode {
+		c.progressIndicator = ux.NewJSONProgress()
+	}
+
+	return &c
+}
+
 func (i *RecipeInstall) finishHandlingFailure(recipeName string) {
 	if i.recipeLogForwarder.HasUserOptedIn() {
 		i.progressIndicator.Start("Sending logs to New Relic")
 		i.recipeLogForwarder.SendLogsToNewRelic(recipeName, i.recipeExecutor.GetRecipeOutput())
-		i.progressIndicator.Success("Complete!")
+		i.progressIndicator.Success(i18n.T("Complete!"))
 	}
 }
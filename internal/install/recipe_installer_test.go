@@ -5,16 +5,23 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/newrelic/newrelic-cli/internal/config"
 
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/cloud"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/preflight"
 	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/segment"
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 	"github.com/newrelic/newrelic-cli/internal/install/ux"
 	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
@@ -169,6 +176,55 @@ func TestInstallGuidedShouldNotSkipCoreInstall(t *testing.T) {
 	assert.Equal(t, 1, statusReporter.ReportInstalled[r2.Recipe.Name], "Recipe2 Installed")
 }
 
+func TestInstallShouldInstallOrderedRecipesBeforeCore(t *testing.T) {
+	infra := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	mysql := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("mysql").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	firewall := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("firewall-open").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(infra).WithRecipeDetectionResult(mysql).
+		WithRecipeDetectionResult(firewall).WithStatusReporter(statusReporter).Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.RecipeOrder = []string{"firewall-open"}
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"firewall-open", types.InfraAgentRecipeName, "mysql"}, statusReporter.InstalledOrder)
+}
+
+func TestInstallShouldInstallPriorityRecipesBeforeCore(t *testing.T) {
+	infra := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	mysql := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("mysql").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	firewall := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("firewall-open").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	firewall.Recipe.Priority = 10
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(infra).WithRecipeDetectionResult(mysql).
+		WithRecipeDetectionResult(firewall).WithStatusReporter(statusReporter).Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.RecipeNames = []string{"mysql", "firewall-open"}
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"firewall-open", types.InfraAgentRecipeName, "mysql"}, statusReporter.InstalledOrder)
+}
+
 func TestInstallGuidedShouldSkipOTEL(t *testing.T) {
 	r := &recipes.RecipeDetectionResult{
 		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
@@ -229,6 +285,35 @@ func TestInstallGuidedCoreShouldStopOnError(t *testing.T) {
 	assert.Equal(t, 0, statusReporter.ReportRecommended[r2.Recipe.Name], "Recipe2 Recommended")
 }
 
+func TestInstallGuidedCoreShouldContinueOnErrorWhenRequested(t *testing.T) {
+	installErr := errors.New("Install Error")
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	r2 := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.LoggingRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	r3 := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("Other").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).WithRecipeDetectionResult(r2).WithRecipeDetectionResult(r3).
+		WithStatusReporter(statusReporter).Build()
+	recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecuteErrs = []error{installErr, installErr}
+	recipeInstall.AssumeYes = true
+	recipeInstall.ContinueOnError = true
+	err := recipeInstall.Install()
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, statusReporter.RecipeInstallingCallCount, "Installing Count")
+	assert.Equal(t, 2, statusReporter.RecipeFailedCallCount, "Failed Count")
+	assert.Equal(t, 1, statusReporter.ReportInstalled[r3.Recipe.Name], "Recipe3 Installed")
+	assert.Equal(t, ExitCodeExecutionFailure, ExitCode(err))
+}
+
 func TestInstallTargetedInstallShouldInstallWithRecomendataion(t *testing.T) {
 	r := &recipes.RecipeDetectionResult{
 		Recipe: recipes.NewRecipeBuilder().Name("Other").Build(),
@@ -614,6 +699,17 @@ func TestExecuteAndValidateRecipeWithAllMethodWithRecipeValidationError(t *testi
 	assert.True(t, strings.Contains(err.Error(), "no validation was successful.  most recent validation error"))
 }
 
+func TestValidateRecipeViaAllMethodsShouldSkipNRQLValidationWhenOffline(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeValidationError(errors.New("Some error")).Build()
+	recipeInstall.Offline = true
+	recipe := recipes.NewRecipeBuilder().Name("").Build()
+	recipe.ValidationNRQL = "FROM SOMETHING"
+
+	_, err := recipeInstall.validateRecipeViaAllMethods(context.TODO(), recipe, &types.DiscoveryManifest{}, nil, false)
+
+	assert.NoError(t, err)
+}
+
 func TestExecuteAndValidateWithProgressWhenPostValidationFailed(t *testing.T) {
 	expected := errors.New("Some error")
 	statusReporter := execution.NewMockStatusReporter()
@@ -642,6 +738,32 @@ func TestExecuteAndValidateWithProgressWhenSucceed(t *testing.T) {
 	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount)
 }
 
+func TestExecuteAndValidateWithProgressShouldTagCreatedEntity(t *testing.T) {
+	statusReporter := execution.NewMockStatusReporter()
+	builder := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).WithTags([]string{"team:payments"}).WithOutput("{\"EntityGuid\":\"abcd\"}")
+	recipeInstall := builder.Build()
+	recipe := recipes.NewRecipeBuilder().Name("").Build()
+
+	entityGUID, err := recipeInstall.executeAndValidateWithProgress(context.TODO(), &types.DiscoveryManifest{}, recipe, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", entityGUID)
+	assert.Equal(t, []string{"abcd"}, builder.entityTaggingClient.TaggedEntityGUIDs)
+}
+
+func TestExecuteAndValidateWithProgressShouldSucceedWhenTaggingFails(t *testing.T) {
+	statusReporter := execution.NewMockStatusReporter()
+	builder := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).WithTags([]string{"team:payments"}).WithOutput("{\"EntityGuid\":\"abcd\"}")
+	builder.entityTaggingClient.AddTagsErr = errors.New("unauthorized")
+	recipeInstall := builder.Build()
+	recipe := recipes.NewRecipeBuilder().Name("").Build()
+
+	_, err := recipeInstall.executeAndValidateWithProgress(context.TODO(), &types.DiscoveryManifest{}, recipe, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount)
+}
+
 func TestReportUnSupportTargetRecipeWithBadRecipeName(t *testing.T) {
 	targetRecipe := "target"
 	statusReporter := execution.NewMockStatusReporter()
@@ -825,3 +947,317 @@ func captureLoggingOutput(f func()) string {
 	existingLogger.SetOutput(os.Stderr)
 	return buf.String()
 }
+
+func TestInstallResumeShouldSkipPreviouslyInstalledRecipes(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	state := execution.NewInstallState("")
+	state.SetRecipeStatus(types.InfraAgentRecipeName, execution.RecipeStatusTypes.INSTALLED, "guid")
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).WithStatusReporter(statusReporter).WithInstallState(state).Build()
+	recipeInstall.Resume = true
+
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, statusReporter.RecipeInstallingCallCount, "Installing Count")
+	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount, "Installed Count")
+}
+
+func TestExecuteAndValidateShouldFailWhenRecipeTimesOut(t *testing.T) {
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).Build()
+	recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).WaitForContext = true
+	recipeInstall.RecipeTimeout = 10 * time.Millisecond
+	recipe := recipes.NewRecipeBuilder().Name("hanging-recipe").Build()
+
+	_, err := recipeInstall.executeAndValidate(context.TODO(), &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "did not complete within 10ms")
+	assert.Equal(t, 1, statusReporter.RecipeFailedCallCount, "Failed Count")
+}
+
+func TestExecuteAndValidateShouldRetryFailedExecution(t *testing.T) {
+	recipeRetryDelayMs = 0
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).Build()
+	executor := recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor)
+	executor.ExecuteErrs = []error{errors.New("apt lock held"), errors.New("network unreachable")}
+	recipeInstall.MaxRetries = 2
+	recipe := recipes.NewRecipeBuilder().Name("flaky-recipe").Build()
+
+	_, err := recipeInstall.executeAndValidate(context.TODO(), &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(executor.ExecutedRecipeNames))
+	assert.Equal(t, 3, statusReporter.RecipeInstallingCallCount, "Installing Count")
+	assert.Equal(t, 0, statusReporter.RecipeFailedCallCount, "Failed Count")
+}
+
+func TestExecuteAndValidateShouldFailWhenRetriesAreExhausted(t *testing.T) {
+	recipeRetryDelayMs = 0
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).WithRecipeExecutionError(errors.New("apt lock held")).Build()
+	recipeInstall.MaxRetries = 1
+	recipe := recipes.NewRecipeBuilder().Name("failing-recipe").Build()
+
+	_, err := recipeInstall.executeAndValidate(context.TODO(), &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecutedRecipeNames))
+	assert.Equal(t, 1, statusReporter.RecipeFailedCallCount, "Failed Count")
+}
+
+func TestInstallShouldReturnRecipeFailuresWhenAdditionalRecipeFails(t *testing.T) {
+	infra := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	integration := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("mysql-open-source-integration").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).WithRecipeDetectionResult(infra, integration).Build()
+	recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecuteErrs = []error{nil, errors.New("Some error")}
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Install()
+
+	assert.Error(t, err)
+	failuresErr, ok := err.(*types.RecipeFailuresError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"mysql-open-source-integration"}, failuresErr.RecipeNames)
+	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount, "Installed Count")
+	assert.Equal(t, 1, statusReporter.RecipeFailedCallCount, "Failed Count")
+}
+
+func TestInstallShouldNotInstallWhenPreflightChecksFail(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("Other").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	report := &preflight.Report{
+		Results: []preflight.CheckResult{
+			{Name: "Network", Status: preflight.CheckStatuses.FAILED, Message: "could not reach https://download.newrelic.com"},
+		},
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).withShouldInstallCore(func() bool { return false }).
+		WithStatusReporter(statusReporter).WithPreflightReport(report).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Install()
+
+	var preflightErr *types.PreflightError
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Equal(t, []string{"Network"}, preflightErr.FailedChecks)
+	assert.Equal(t, 0, statusReporter.RecipeInstallingCallCount, "Installing Count")
+}
+
+func TestInstallShouldSkipPreflightChecksWhenRequested(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("Other").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	report := &preflight.Report{
+		Results: []preflight.CheckResult{
+			{Name: "Network", Status: preflight.CheckStatuses.FAILED},
+		},
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).withShouldInstallCore(func() bool { return false }).
+		WithStatusReporter(statusReporter).WithPreflightReport(report).Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.SkipPreflightChecks = true
+
+	err := recipeInstall.Install()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, statusReporter.RecipeInstalledCallCount, "InstalledCount")
+}
+
+func TestInstallShouldNotInstallWhenPreInstallHookFails(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("Other").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	hooks := types.InstallHooks{PreInstall: filepath.Join(t.TempDir(), "missing-hook.sh")}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).withShouldInstallCore(func() bool { return false }).
+		WithStatusReporter(statusReporter).WithHooks(hooks).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Install()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "preInstall hook")
+	assert.Equal(t, 0, statusReporter.RecipeInstallingCallCount, "Installing Count")
+}
+
+func TestInstallShouldFailRecipeWhenItsPreInstallHookFails(t *testing.T) {
+	r := &recipes.RecipeDetectionResult{
+		Recipe: recipes.NewRecipeBuilder().Name("Other").Build(),
+		Status: execution.RecipeStatusTypes.AVAILABLE,
+	}
+	hooks := types.InstallHooks{
+		Recipes: map[string]types.RecipeHooks{"Other": {PreInstall: filepath.Join(t.TempDir(), "missing-hook.sh")}},
+	}
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeDetectionResult(r).withShouldInstallCore(func() bool { return false }).
+		WithStatusReporter(statusReporter).WithHooks(hooks).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Install()
+
+	require.Error(t, err)
+	assert.Equal(t, 0, statusReporter.RecipeInstallingCallCount, "Installing Count")
+	assert.Equal(t, 1, statusReporter.RecipeFailedCallCount, "Failed Count")
+}
+
+func TestInstallShouldTagWithCloudInstanceUnlessTagged(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().WithTags([]string{"cloud.region:custom"}).Build()
+
+	recipeInstall.addCloudTags(&types.CloudHost{Provider: "aws", Region: "eu-west-1", AccountID: "123456789012", InstanceID: "i-0abc"})
+
+	assert.Equal(t, []string{"cloud.region:custom", "cloud.provider:aws", "cloud.account.id:123456789012", "cloud.instance.id:i-0abc"}, recipeInstall.GetTags())
+}
+
+func TestInstallShouldLinkAWSAccountWithRoleARN(t *testing.T) {
+	rib := NewRecipeInstallBuilder()
+	recipeInstall := rib.Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.AWSRoleARN = "arn:aws:iam::123456789012:role/NewRelic"
+
+	recipeInstall.offerAWSIntegration(context.Background(), &types.DiscoveryManifest{Cloud: &types.CloudHost{Provider: "aws", AccountID: "123456789012"}})
+
+	assert.Equal(t, 1, rib.cloudLinkingClient.LinkAccountCount)
+	assert.Equal(t, 0, rib.prompter.PromptSelectCallCount)
+}
+
+func TestInstallShouldNotOfferAWSLinkingWhenUnattended(t *testing.T) {
+	rib := NewRecipeInstallBuilder()
+	recipeInstall := rib.Build()
+	recipeInstall.AssumeYes = true
+
+	recipeInstall.offerAWSIntegration(context.Background(), &types.DiscoveryManifest{Cloud: &types.CloudHost{Provider: "aws", AccountID: "123456789012"}})
+
+	assert.Equal(t, 0, rib.cloudLinkingClient.LinkAccountCount)
+}
+
+func TestInstallShouldNotOfferAWSLinkingWhenLinked(t *testing.T) {
+	rib := NewRecipeInstallBuilder()
+	rib.cloudLinkingClient.LinkedAccounts = []cloud.CloudLinkedAccount{{ExternalId: "123456789012"}}
+	recipeInstall := rib.Build()
+
+	recipeInstall.offerAWSIntegration(context.Background(), &types.DiscoveryManifest{Cloud: &types.CloudHost{Provider: "aws", AccountID: "123456789012"}})
+
+	assert.Equal(t, 0, rib.prompter.PromptSelectCallCount)
+	assert.Equal(t, 0, rib.cloudLinkingClient.LinkAccountCount)
+}
+
+func TestInstallShouldPromptForAWSRoleARN(t *testing.T) {
+	rib := NewRecipeInstallBuilder()
+	recipeInstall := rib.Build()
+	rib.prompter.PromptSelectVals["Link AWS account 123456789012 to New Relic to monitor its cloud services too?"] = awsLinkWithRole
+	rib.prompter.PromptInputVals["IAM role ARN"] = "arn:aws:iam::123456789012:role/NewRelic"
+
+	recipeInstall.offerAWSIntegration(context.Background(), &types.DiscoveryManifest{Cloud: &types.CloudHost{Provider: "aws", AccountID: "123456789012"}})
+
+	assert.Equal(t, 1, rib.cloudLinkingClient.LinkAccountCount)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/NewRelic", rib.cloudLinkingClient.LinkAccountsInputs[0].Aws[0].Arn)
+}
+
+func TestExecuteAndValidateShouldRollBackFailedExecution(t *testing.T) {
+	recipeRetryDelayMs = 0
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeExecutionError(errors.New("dpkg failed")).Build()
+	executor := recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor)
+	recipe := recipes.NewRecipeBuilder().Name("partial-recipe").Build()
+	recipe.Uninstall = "tasks:\n  default:\n    cmds:\n      - rm -f /etc/partial.yml\n"
+	recipe.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Files: []string{"/etc/partial.yml"}}
+	require.False(t, recipeInstall.isAlreadyInstalled(context.TODO(), recipe))
+
+	_, err := recipeInstall.executeAndValidate(context.TODO(), &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(executor.ExecutedRecipeNames))
+
+	executor.ExecutedRecipeNames = nil
+	recipeInstall.NoRollback = true
+
+	_, err = recipeInstall.executeAndValidate(context.TODO(), &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(executor.ExecutedRecipeNames))
+}
+
+func TestExecuteAndValidateShouldOnlyRestoreConfigOfRecipesThatMayHaveBeenInstalled(t *testing.T) {
+	recipeRetryDelayMs = 0
+	agentConfig := filepath.Join(t.TempDir(), "newrelic-infra.yml")
+	require.NoError(t, os.WriteFile(agentConfig, []byte("log_level: info\n"), 0600))
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeExecutionError(errors.New("dpkg failed")).Build()
+	recipeInstall.configFilePatterns = []string{agentConfig}
+	recipeInstall.configBackupPath = t.TempDir()
+	executor := recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor)
+	recipe := recipes.NewRecipeBuilder().Name("infrastructure-agent-installer").Build()
+	recipe.Uninstall = "tasks:\n  default:\n    cmds:\n      - apt-get remove -y newrelic-infra\n"
+
+	snapshot := recipeInstall.snapshotConfigFiles()
+	require.NoError(t, os.WriteFile(agentConfig, []byte("log_level: debug\n"), 0600))
+	recipeInstall.backupChangedConfigFiles(recipe.Name, snapshot)
+
+	_, err := recipeInstall.executeAndValidate(context.TODO(), &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{recipe.Name}, executor.ExecutedRecipeNames)
+	data, err := os.ReadFile(agentConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "log_level: info\n", string(data))
+}
+
+func TestExecuteAndValidateShouldBeInterruptedWhenCanceled(t *testing.T) {
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).WithRecipeExecutionError(errors.New("signal: interrupt")).Build()
+	recipe := recipes.NewRecipeBuilder().Name("canceled-recipe").Build()
+	recipe.Uninstall = "tasks:\n  default:\n    cmds:\n      - rm -f /etc/canceled.yml\n"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := recipeInstall.executeAndValidate(ctx, &types.DiscoveryManifest{}, recipe, types.RecipeVars{}, true)
+
+	assert.ErrorIs(t, err, types.ErrInterrupt)
+	assert.Equal(t, 0, statusReporter.RecipeFailedCallCount, "Failed Count")
+	assert.Equal(t, 1, len(recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecutedRecipeNames))
+}
+
+func TestCloneForConcurrentInstallShouldShareStatusButNotLogForwarder(t *testing.T) {
+	i := NewRecipeInstaller(types.InstallerContext{}, &newrelic.NewRelic{}, segment.NewNoOp())
+	i.recipeLogForwarder.SetUserOptedIn(true)
+
+	c := i.cloneForConcurrentInstall().(*RecipeInstall)
+
+	assert.Same(t, i.status, c.status)
+	assert.NotSame(t, i.recipeExecutor, c.recipeExecutor)
+	assert.NotSame(t, i.recipeLogForwarder, c.recipeLogForwarder)
+	assert.True(t, c.recipeLogForwarder.HasUserOptedIn())
+	assert.NotEmpty(t, i.configFilePatterns)
+	assert.Nil(t, c.snapshotConfigFiles())
+}
+
+func TestPostInstallHookContextShouldEndGracePeriodAfterInstallIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	hookCtx, cancelHook := postInstallHookContext(ctx, 100*time.Millisecond)
+	defer cancelHook()
+
+	cancel()
+	assert.NoError(t, hookCtx.Err())
+
+	select {
+	case <-hookCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the hook context didn't end after the grace period")
+	}
+}
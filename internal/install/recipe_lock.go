@@ -0,0 +1,175 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DefaultRecipeLockFile is the lockfile installs use when it's in the working directory.
+const DefaultRecipeLockFile = "newrelic-recipes.lock"
+
+const recipeLockHeader = "# This file is generated by \"newrelic install lock generate\", do not edit it by hand.\n"
+
+// RecipeLock pins recipes to the version of the recipe library they're installed from, along with
+// the checksum of each recipe, so repeated installs use exactly the same recipes.
+type RecipeLock struct {
+	Recipes []*LockedRecipe `yaml:"recipes"`
+}
+
+// LockedRecipe is the version and checksum a recipe is locked to.
+type LockedRecipe struct {
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version,omitempty"`
+	Checksum string `yaml:"checksum"`
+}
+
+// LoadRecipeLock reads a lockfile written by "newrelic install lock generate".
+func LoadRecipeLock(path string) (*RecipeLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read recipe lockfile %s: %w", path, err)
+	}
+
+	lock := &RecipeLock{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("could not parse recipe lockfile %s: %w", path, err)
+	}
+
+	for _, r := range lock.Recipes {
+		if r.Name == "" || r.Checksum == "" {
+			return nil, fmt.Errorf("recipe lockfile %s has a recipe without a name or checksum", path)
+		}
+	}
+
+	return lock, nil
+}
+
+// GenerateRecipeLock locks the named recipes, or every recipe when no names are given, to the
+// version they're fetched from.
+func GenerateRecipeLock(ctx context.Context, fetcher recipes.RecipeFetcher, names []string, versions map[string]string) (*RecipeLock, error) {
+	fetched, err := fetcher.FetchRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*types.OpenInstallationRecipe{}
+	for _, r := range fetched {
+		byName[r.Name] = r
+	}
+
+	if len(names) == 0 {
+		for name := range byName {
+			names = append(names, name)
+		}
+	}
+
+	libraryVersion := fetcher.FetchLibraryVersion(ctx)
+	lock := &RecipeLock{Recipes: []*LockedRecipe{}}
+	for _, name := range names {
+		r, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("recipe %s could not be found", name)
+		}
+
+		version := libraryVersion
+		if v, ok := versions[name]; ok {
+			version = v
+		}
+
+		lock.Recipes = append(lock.Recipes, &LockedRecipe{
+			Name:     name,
+			Version:  version,
+			Checksum: recipeChecksum(r),
+		})
+	}
+
+	sort.Slice(lock.Recipes, func(a, b int) bool {
+		return lock.Recipes[a].Name < lock.Recipes[b].Name
+	})
+
+	return lock, nil
+}
+
+// VerifyRecipeLock fetches each locked recipe at its locked version, and returns the names of the
+// recipes that no longer match their checksum.
+func VerifyRecipeLock(ctx context.Context, fetcher recipes.RecipeFetcher, lock *RecipeLock) ([]string, error) {
+	fetched, err := fetcher.FetchRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*types.OpenInstallationRecipe{}
+	for _, r := range fetched {
+		byName[r.Name] = r
+	}
+
+	mismatched := []string{}
+	for _, l := range lock.Recipes {
+		r, ok := byName[l.Name]
+		if !ok || recipeChecksum(r) != l.Checksum {
+			mismatched = append(mismatched, l.Name)
+		}
+	}
+
+	return mismatched, nil
+}
+
+// Write writes the lockfile, as read by LoadRecipeLock.
+func (l *RecipeLock) Write(w io.Writer) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, recipeLockHeader); err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// Versions returns the version each recipe is locked to, by recipe name.
+func (l *RecipeLock) Versions() map[string]string {
+	versions := map[string]string{}
+	for _, r := range l.Recipes {
+		if r.Version != "" {
+			versions[r.Name] = r.Version
+		}
+	}
+
+	return versions
+}
+
+// applyRecipeLock pins the install's recipes to the versions and checksums of the lockfile. Recipes
+// pinned to a version with --recipe name@version are installed at that version instead.
+func applyRecipeLock(ic *types.InstallerContext, lock *RecipeLock) {
+	if ic.RecipeVersions == nil {
+		ic.RecipeVersions = map[string]string{}
+	}
+
+	if ic.RecipeChecksums == nil {
+		ic.RecipeChecksums = map[string]string{}
+	}
+
+	for _, r := range lock.Recipes {
+		if v, ok := ic.RecipeVersions[r.Name]; ok && v != r.Version {
+			log.Debugf("recipe %s is pinned to version %s instead of the locked version %s", r.Name, v, r.Version)
+			continue
+		}
+
+		if r.Version != "" {
+			ic.RecipeVersions[r.Name] = r.Version
+		}
+		ic.RecipeChecksums[r.Name] = r.Checksum
+	}
+}
@@ -0,0 +1,78 @@
+package install
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestRecipeLockShouldRoundTripThroughFile(t *testing.T) {
+	fetcher := recipes.NewMockRecipeFetcher()
+	fetcher.LibraryVersion = "0.120.0"
+	fetcher.FetchRecipesVal = []*types.OpenInstallationRecipe{
+		recipes.NewRecipeBuilder().Name("recipe2").Build(),
+		recipes.NewRecipeBuilder().Name("recipe1").Build(),
+	}
+
+	lock, err := GenerateRecipeLock(context.Background(), fetcher, nil, map[string]string{"recipe2": "0.110.0"})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), DefaultRecipeLockFile)
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Write(f))
+	f.Close()
+
+	loaded, err := LoadRecipeLock(path)
+	require.NoError(t, err)
+	assert.Equal(t, "recipe1", loaded.Recipes[0].Name)
+	assert.Equal(t, map[string]string{"recipe1": "0.120.0", "recipe2": "0.110.0"}, loaded.Versions())
+	assert.Equal(t, recipeChecksum(fetcher.FetchRecipesVal[1]), loaded.Recipes[0].Checksum)
+}
+
+func TestGenerateRecipeLockShouldFailForUnknownRecipe(t *testing.T) {
+	fetcher := recipes.NewMockRecipeFetcher()
+
+	_, err := GenerateRecipeLock(context.Background(), fetcher, []string{"recipe1"}, nil)
+
+	assert.EqualError(t, err, "recipe recipe1 could not be found")
+}
+
+func TestVerifyRecipeLockShouldReportChangedRecipes(t *testing.T) {
+	unchanged := recipes.NewRecipeBuilder().Name("recipe1").Build()
+	fetcher := recipes.NewMockRecipeFetcher()
+	fetcher.FetchRecipesVal = []*types.OpenInstallationRecipe{
+		unchanged,
+		recipes.NewRecipeBuilder().Name("recipe2").Build(),
+	}
+	lock := &RecipeLock{Recipes: []*LockedRecipe{
+		{Name: "recipe1", Checksum: recipeChecksum(unchanged)},
+		{Name: "recipe2", Checksum: "stale"},
+		{Name: "recipe3", Checksum: "missing"},
+	}}
+
+	mismatched, err := VerifyRecipeLock(context.Background(), fetcher, lock)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"recipe2", "recipe3"}, mismatched)
+}
+
+func TestApplyRecipeLockShouldPreferExplicitVersions(t *testing.T) {
+	ic := types.InstallerContext{RecipeVersions: map[string]string{"recipe1": "0.100.0"}}
+	lock := &RecipeLock{Recipes: []*LockedRecipe{
+		{Name: "recipe1", Version: "0.110.0", Checksum: "abc"},
+		{Name: "recipe2", Version: "0.110.0", Checksum: "def"},
+	}}
+
+	applyRecipeLock(&ic, lock)
+
+	assert.Equal(t, map[string]string{"recipe1": "0.100.0", "recipe2": "0.110.0"}, ic.RecipeVersions)
+	assert.Equal(t, map[string]string{"recipe2": "def"}, ic.RecipeChecksums)
+}
@@ -0,0 +1,137 @@
+package install
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+const (
+	reviewApprove = "Install"
+	reviewSkip    = "Skip"
+	reviewEdit    = "Edit a variable"
+)
+
+var (
+	templateActionRegex    = regexp.MustCompile(`{{[^}]*}}`)
+	templateVariableRegex  = regexp.MustCompile(`\.([A-Za-z_][A-Za-z0-9_]*)`)
+	reviewOptions          = []string{reviewApprove, reviewSkip, reviewEdit}
+	reviewOptionsNoEditing = []string{reviewApprove, reviewSkip}
+)
+
+// reviewRecipe prints the shell the install tasks of the recipe run, with its variables resolved,
+// and asks whether to install the recipe, skip it, or edit the variables it's rendered with first.
+// It returns the variables to run the recipe with, and whether it was approved.
+func (i *RecipeInstall) reviewRecipe(m *types.DiscoveryManifest, r *types.OpenInstallationRecipe, upgrading bool) (types.RecipeVars, bool, error) {
+	vars, err := i.recipeVarPreparer.Prepare(*m, *r, false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rendered := *r
+	if upgrading {
+		rendered = r.ToUpgradeRecipe()
+	}
+
+	secrets := map[string]bool{}
+	for _, iv := range r.InputVars {
+		if iv.Secret {
+			secrets[iv.Name] = true
+		}
+	}
+
+	editable := recipeTemplateVars(rendered, vars)
+	options := reviewOptions
+	if len(editable) == 0 {
+		options = reviewOptionsNoEditing
+	}
+
+	for {
+		printRecipeReview(rendered, reviewVars(vars, secrets))
+
+		choice, err := i.prompter.Select(fmt.Sprintf("Install %s with these commands?", r.DisplayName), options, reviewApprove)
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch choice {
+		case reviewApprove:
+			return vars, true, nil
+		case reviewSkip:
+			return nil, false, nil
+		}
+
+		name, err := i.prompter.Select("Which variable should be edited?", editable, editable[0])
+		if err != nil {
+			return nil, false, err
+		}
+
+		current := vars[name]
+		if sensitiveRecipeVars[name] || secrets[name] {
+			current = ""
+		}
+
+		value, err := i.prompter.Input(fmt.Sprintf("Value of %s", name), current)
+		if err != nil {
+			return nil, false, err
+		}
+
+		log.Debugf("edited variable %s of recipe %s", name, r.Name)
+		vars[name] = value
+	}
+}
+
+// recipeTemplateVars returns the names of the variables the recipe's install tasks are templated
+// with, in order.
+func recipeTemplateVars(r types.OpenInstallationRecipe, vars types.RecipeVars) []string {
+	found := map[string]bool{}
+	for _, action := range templateActionRegex.FindAllString(r.Install, -1) {
+		for _, match := range templateVariableRegex.FindAllStringSubmatch(action, -1) {
+			if _, ok := vars[match[1]]; ok {
+				found[match[1]] = true
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// reviewVars returns the variables with the sensitive ones obfuscated, so they aren't printed.
+func reviewVars(vars types.RecipeVars, secrets map[string]bool) types.RecipeVars {
+	obfuscated := types.RecipeVars{}
+	for k, v := range vars {
+		if sensitiveRecipeVars[k] || secrets[k] {
+			v = utils.Obfuscate(v)
+		}
+		obfuscated[k] = v
+	}
+
+	return obfuscated
+}
+
+func printRecipeReview(r types.OpenInstallationRecipe, vars types.RecipeVars) {
+	script, err := execution.RenderRecipe(r, vars)
+	if err != nil {
+		log.Debugf("could not render tasks for recipe %s: %s", r.Name, err)
+		script = fmt.Sprintf("# could not render the tasks: %s\n", err)
+	}
+
+	fmt.Printf("\n  The install of %s runs:\n\n", r.DisplayName)
+	for _, line := range strings.Split(strings.TrimRight(script, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+	fmt.Println()
+}
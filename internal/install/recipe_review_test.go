@@ -0,0 +1,58 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+)
+
+func TestReviewRecipeShouldEditVariables(t *testing.T) {
+	recipeInstall := NewRecipeInstallBuilder().WithRecipeVarValues(map[string]string{"NR_CLI_PORT": "3306", "HOME": "/root"}, nil).Build()
+	prompter := ux.NewMockPrompter()
+	prompter.PromptSelectQueues["Install MySQL with these commands?"] = []string{reviewEdit, reviewApprove}
+	prompter.PromptSelectVals["Which variable should be edited?"] = "NR_CLI_PORT"
+	prompter.PromptInputVals["Value of NR_CLI_PORT"] = "3307"
+	recipeInstall.prompter = prompter
+	recipe := recipes.NewRecipeBuilder().Name("mysql").InstallShell("echo {{.NR_CLI_PORT}}").Build()
+	recipe.DisplayName = "MySQL"
+
+	vars, approved, err := recipeInstall.reviewRecipe(&types.DiscoveryManifest{}, recipe, false)
+
+	require.NoError(t, err)
+	assert.True(t, approved)
+	assert.Equal(t, "3307", vars["NR_CLI_PORT"])
+	assert.Equal(t, 3, prompter.PromptSelectCallCount)
+}
+
+func TestExecuteAndValidateWithProgressShouldSkipRecipesNotApprovedInReview(t *testing.T) {
+	statusReporter := execution.NewMockStatusReporter()
+	recipeInstall := NewRecipeInstallBuilder().WithStatusReporter(statusReporter).WithRecipeVarValues(map[string]string{}, nil).Build()
+	recipeInstall.Review = true
+	prompter := ux.NewMockPrompter()
+	prompter.PromptSelectVals["Install MySQL with these commands?"] = reviewSkip
+	recipeInstall.prompter = prompter
+	recipe := recipes.NewRecipeBuilder().Name("mysql").InstallShell("echo mysql").Build()
+	recipe.DisplayName = "MySQL"
+
+	_, err := recipeInstall.executeAndValidateWithProgress(context.TODO(), &types.DiscoveryManifest{}, recipe, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, statusReporter.RecipeSkippedCallCount)
+	assert.Equal(t, 0, statusReporter.RecipeInstalledCallCount)
+	assert.Empty(t, recipeInstall.recipeExecutor.(*execution.MockRecipeExecutor).ExecutedRecipeNames)
+}
+
+func TestRecipeTemplateVars(t *testing.T) {
+	recipe := recipes.NewRecipeBuilder().InstallShell(`echo {{.NR_CLI_PORT}} {{ if eq .NR_CLI_SSL "true" }}--ssl{{ end }} .HOME`).Build()
+
+	names := recipeTemplateVars(*recipe, types.RecipeVars{"NR_CLI_PORT": "3306", "NR_CLI_SSL": "true", "HOME": "/root"})
+
+	assert.Equal(t, []string{"NR_CLI_PORT", "NR_CLI_SSL"}, names)
+}
@@ -0,0 +1,164 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+// Uninstall reverses previously installed recipes by executing their uninstall tasks.
+// Recipes are removed in the opposite order to which they are installed, so integrations
+// are removed before the logging and infrastructure agent recipes they rely on.
+func (i *RecipeInstall) Uninstall() error {
+	ctx, cancel := context.WithCancel(utils.SignalCtx)
+	defer cancel()
+
+	m, err := i.discoverer.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("there was an error discovering system info: %s", err)
+	}
+
+	repo := recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
+		return i.recipeFetcher.FetchRecipes(ctx)
+	}, m)
+
+	toUninstall, err := i.findRecipesToUninstall(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	if len(toUninstall) == 0 {
+		fmt.Println("\nNo installed recipes with uninstall steps were found for this system.")
+		return nil
+	}
+
+	fmt.Println("\nThe following will be uninstalled:")
+	for _, r := range toUninstall {
+		fmt.Printf("  %s\n", recipeDisplayName(r))
+	}
+	fmt.Println()
+
+	if !i.AssumeYes {
+		isConfirmed, err := i.prompter.PromptYesNo("Continue uninstalling? ")
+		if err != nil {
+			log.Debug(err)
+			isConfirmed = false
+		}
+
+		if !isConfirmed {
+			return nil
+		}
+	}
+
+	failed := []string{}
+	for _, r := range toUninstall {
+		if err := i.uninstallRecipe(ctx, m, r); err != nil {
+			if err == types.ErrInterrupt {
+				return err
+			}
+
+			log.Debugf("error uninstalling recipe %s: %s", r.Name, err)
+			failed = append(failed, r.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return &types.UncaughtError{
+			Err: fmt.Errorf("one or more recipes could not be uninstalled: %s", strings.Join(failed, ", ")),
+		}
+	}
+
+	return nil
+}
+
+// findRecipesToUninstall returns the recipes given with --recipe or, without them, the recipes with
+// uninstall steps the install state records as installed, or whose installed check finds them.
+func (i *RecipeInstall) findRecipesToUninstall(ctx context.Context, repo *recipes.RecipeRepository) ([]*types.OpenInstallationRecipe, error) {
+	found := []*types.OpenInstallationRecipe{}
+
+	if i.RecipeNamesProvided() {
+		for _, name := range i.RecipeNames {
+			r := repo.FindRecipeByName(name)
+			if r == nil {
+				return nil, fmt.Errorf("could not find recipe %s for this system", name)
+			}
+
+			if !r.HasUninstall() {
+				return nil, fmt.Errorf("recipe %s does not define uninstall steps", name)
+			}
+
+			found = append(found, r)
+		}
+	} else {
+		all, err := repo.FindAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range all {
+			if !r.HasUninstall() {
+				continue
+			}
+
+			if i.installState.IsInstalled(r.Name) || i.isAlreadyInstalled(ctx, r) {
+				found = append(found, r)
+			}
+		}
+	}
+
+	return sortRecipesForUninstall(found), nil
+}
+
+// sortRecipesForUninstall returns recipes in the reverse of their install order.
+func sortRecipesForUninstall(recipesIn []*types.OpenInstallationRecipe) []*types.OpenInstallationRecipe {
+	out := make([]*types.OpenInstallationRecipe, len(recipesIn))
+	copy(out, recipesIn)
+
+	for a := 1; a < len(out); a++ {
+		for b := a; b > 0 && out[b].GetOrderKey() > out[b-1].GetOrderKey(); b-- {
+			out[b], out[b-1] = out[b-1], out[b]
+		}
+	}
+
+	return out
+}
+
+func (i *RecipeInstall) uninstallRecipe(ctx context.Context, m *types.DiscoveryManifest, r *types.OpenInstallationRecipe) error {
+	msg := fmt.Sprintf("Uninstalling %s", recipeDisplayName(r))
+
+	vars, err := i.recipeVarPreparer.Prepare(*m, *r, i.AssumeYes)
+	if err != nil {
+		return err
+	}
+	vars["assumeYes"] = fmt.Sprintf("%v", i.AssumeYes)
+
+	i.progressIndicator.ShowSpinner(i.AssumeYes)
+	i.progressIndicator.Start(msg)
+
+	if err := i.recipeExecutor.Execute(ctx, r.ToUninstallRecipe(), vars); err != nil {
+		if err == types.ErrInterrupt {
+			i.progressIndicator.Canceled(msg)
+			return err
+		}
+
+		i.progressIndicator.Fail(msg)
+		return err
+	}
+
+	i.progressIndicator.Success(msg)
+	return nil
+}
+
+func recipeDisplayName(r *types.OpenInstallationRecipe) string {
+	if r.DisplayName != "" {
+		return r.DisplayName
+	}
+
+	return r.Name
+}
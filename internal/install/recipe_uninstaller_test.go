@@ -0,0 +1,120 @@
+package install
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func newUninstallableRecipe(name string) *types.OpenInstallationRecipe {
+	return &types.OpenInstallationRecipe{
+		Name:      name,
+		Install:   "version: '3'\ntasks:\n  default:\n    cmds:\n      - echo install\n",
+		Uninstall: "version: '3'\ntasks:\n  default:\n    cmds:\n      - echo uninstall\n",
+	}
+}
+
+func TestUninstallShouldRunRecipesInReverseInstallOrder(t *testing.T) {
+	recipesVal := []*types.OpenInstallationRecipe{
+		newUninstallableRecipe(types.InfraAgentRecipeName),
+		newUninstallableRecipe("mysql-open-source-integration"),
+		newUninstallableRecipe(types.LoggingRecipeName),
+		newUninstallableRecipe("not-installed"),
+		{Name: "no-uninstall-steps"},
+	}
+	state := newInstalledState(types.InfraAgentRecipeName, "mysql-open-source-integration", types.LoggingRecipeName, "no-uninstall-steps")
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal(recipesVal).WithInstallState(state).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Uninstall()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mysql-open-source-integration", types.LoggingRecipeName, types.InfraAgentRecipeName}, mockExecutedRecipeNames(recipeInstall))
+}
+
+func TestUninstallShouldRunRecipesFoundByInstalledCheck(t *testing.T) {
+	installed := newUninstallableRecipe(types.InfraAgentRecipeName)
+	installed.PreInstall.InstalledCheck = types.OpenInstallationInstalledCheck{Files: []string{"/etc/newrelic-infra.yml"}}
+	recipesVal := []*types.OpenInstallationRecipe{installed, newUninstallableRecipe(types.LoggingRecipeName)}
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal(recipesVal).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Uninstall()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{types.InfraAgentRecipeName}, mockExecutedRecipeNames(recipeInstall))
+}
+
+func TestUninstallShouldNotRunRecipesThatArentInstalled(t *testing.T) {
+	recipesVal := []*types.OpenInstallationRecipe{newUninstallableRecipe(types.InfraAgentRecipeName)}
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal(recipesVal).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Uninstall()
+
+	require.NoError(t, err)
+	assert.Empty(t, mockExecutedRecipeNames(recipeInstall))
+}
+
+func TestUninstallShouldOnlyRunTargetedRecipes(t *testing.T) {
+	recipesVal := []*types.OpenInstallationRecipe{
+		newUninstallableRecipe(types.InfraAgentRecipeName),
+		newUninstallableRecipe(types.LoggingRecipeName),
+	}
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal(recipesVal).Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.RecipeNames = []string{types.LoggingRecipeName}
+
+	err := recipeInstall.Uninstall()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{types.LoggingRecipeName}, mockExecutedRecipeNames(recipeInstall))
+}
+
+func TestUninstallShouldErrorWhenTargetedRecipeHasNoUninstallSteps(t *testing.T) {
+	recipesVal := []*types.OpenInstallationRecipe{{Name: "no-uninstall-steps"}}
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal(recipesVal).Build()
+	recipeInstall.AssumeYes = true
+	recipeInstall.RecipeNames = []string{"no-uninstall-steps"}
+
+	err := recipeInstall.Uninstall()
+
+	assert.Error(t, err)
+	assert.Empty(t, mockExecutedRecipeNames(recipeInstall))
+}
+
+func TestUninstallShouldContinueAndReportFailedRecipes(t *testing.T) {
+	recipesVal := []*types.OpenInstallationRecipe{
+		newUninstallableRecipe(types.InfraAgentRecipeName),
+		newUninstallableRecipe(types.LoggingRecipeName),
+	}
+	state := newInstalledState(types.InfraAgentRecipeName, types.LoggingRecipeName)
+	recipeInstall := NewRecipeInstallBuilder().WithFetchRecipesVal(recipesVal).WithInstallState(state).WithRecipeExecutionError(errors.New("boom")).Build()
+	recipeInstall.AssumeYes = true
+
+	err := recipeInstall.Uninstall()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), types.InfraAgentRecipeName)
+	assert.Contains(t, err.Error(), types.LoggingRecipeName)
+	assert.Equal(t, 2, len(mockExecutedRecipeNames(recipeInstall)))
+}
+
+// newInstalledState returns an install state recording the recipes as installed.
+func newInstalledState(names ...string) *execution.InstallState {
+	s := execution.NewInstallState("")
+	for _, name := range names {
+		s.SetRecipeStatus(name, execution.RecipeStatusTypes.INSTALLED, "")
+	}
+
+	return s
+}
+
+func mockExecutedRecipeNames(i *RecipeInstall) []string {
+	return i.recipeExecutor.(*execution.MockRecipeExecutor).ExecutedRecipeNames
+}
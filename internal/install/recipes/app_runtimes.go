@@ -0,0 +1,377 @@
+package recipes
+
+import (
+	"debug/buildinfo"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// knownRuntimeRecipes are the APM agent recipes of the languages whose applications they
+// instrument. Go applications are instrumented in their code, so they have none.
+var knownRuntimeRecipes = map[string]string{
+	"java-agent-installer":   types.RuntimeJava,
+	"node-agent-installer":   types.RuntimeNode,
+	"python-agent-installer": types.RuntimePython,
+	"ruby-agent-installer":   types.RuntimeRuby,
+	"dotnet-agent-installer": types.RuntimeDotnet,
+	"php-agent-installer":    types.RuntimePHP,
+}
+
+// systemBinaryDirs are the directories of the executables of the OS's packages, whose Go programs
+// are daemons of the host rather than applications to instrument.
+var systemBinaryDirs = []string{"/bin/", "/sbin/", "/usr/bin/", "/usr/sbin/", "/usr/lib/", "/usr/libexec/", "/snap/"}
+
+// runtimeSpec is how the processes of a language's applications are recognized.
+type runtimeSpec struct {
+	language string
+	// executable matches the name of the executable or process title of the application.
+	executable *regexp.Regexp
+	// version matches the version of the runtime in the command line, such as in the path of the JDK.
+	version *regexp.Regexp
+	// inspect guesses the app server and service name of the application from its arguments.
+	inspect func(rt *types.AppRuntime, args []string)
+}
+
+var runtimeSpecs = []runtimeSpec{
+	{
+		language:   types.RuntimeJava,
+		executable: regexp.MustCompile(`^javaw?$`),
+		version:    regexp.MustCompile(`(?:jdk|jre|java|openjdk)[-_@]?(\d+(?:\.\d+)*)`),
+		inspect:    inspectJava,
+	},
+	{
+		language:   types.RuntimeNode,
+		executable: regexp.MustCompile(`^node(?:js)?$`),
+		version:    regexp.MustCompile(`(?:/v?(\d+\.\d+\.\d+)/bin/node|node@(\d+))`),
+		inspect:    inspectNode,
+	},
+	{
+		language:   types.RuntimePython,
+		executable: regexp.MustCompile(`^(?:python|pypy)\d*(?:\.\d+)?$|^(?:gunicorn|uwsgi|uvicorn|celery)$`),
+		version:    regexp.MustCompile(`python(\d+\.\d+)`),
+		inspect:    inspectPython,
+	},
+	{
+		language:   types.RuntimeRuby,
+		executable: regexp.MustCompile(`^ruby\d*(?:\.\d+)*$|^(?:puma|unicorn|passenger|sidekiq)$`),
+		version:    regexp.MustCompile(`(?:ruby[-/]|/versions/)(\d+\.\d+(?:\.\d+)?)`),
+		inspect:    inspectRuby,
+	},
+	{
+		language:   types.RuntimeDotnet,
+		executable: regexp.MustCompile(`^(?:dotnet|w3wp)$`),
+		version:    regexp.MustCompile(`Microsoft\.(?:NETCore|AspNetCore)\.App[/\\](\d+\.\d+\.\d+)`),
+		inspect:    inspectDotnet,
+	},
+	{
+		language:   types.RuntimePHP,
+		executable: regexp.MustCompile(`^php(?:-fpm|-cgi)?\d*(?:\.\d+)?$`),
+		version:    regexp.MustCompile(`php(?:-fpm)?/?(\d+\.\d+)`),
+		inspect:    inspectPHP,
+	},
+}
+
+var (
+	javaAppNameRegex   = regexp.MustCompile(`^-?-D?(?:newrelic\.config\.app_name|spring\.application\.name)=(.+)$`)
+	jarVersionRegex    = regexp.MustCompile(`[-_]v?\d+(?:\.\d+)*(?:[-.][A-Za-z0-9]+)*$`)
+	pumaAppRegex       = regexp.MustCompile(`\[([^\]]+)\]`)
+	fpmPoolRegex       = regexp.MustCompile(`pool (\S+)`)
+	genericScriptNames = map[string]bool{"index": true, "server": true, "app": true, "main": true, "start": true, "manage": true, "application": true, "wsgi": true, "asgi": true}
+)
+
+// RuntimeDetector finds the applications of the languages APM agents instrument among the processes
+// of a host.
+type RuntimeDetector struct {
+	readBuildInfo func(path string) (*buildinfo.BuildInfo, error)
+	buildInfos    map[string]*buildinfo.BuildInfo
+}
+
+// NewRuntimeDetector returns a detector of the applications running in processes. Go applications
+// are only detected when readBinaries is set, as their executables are read for the version of Go
+// they were built with, which is only possible for the processes of the host the CLI runs on.
+func NewRuntimeDetector(readBinaries bool) *RuntimeDetector {
+	d := &RuntimeDetector{
+		buildInfos: map[string]*buildinfo.BuildInfo{},
+	}
+	if readBinaries {
+		d.readBuildInfo = buildinfo.ReadFile
+	}
+
+	return d
+}
+
+// Detect returns the applications running in the processes, once for the processes of the same
+// application, like the workers of an app server.
+func (d *RuntimeDetector) Detect(processes []types.GenericProcess) []types.AppRuntime {
+	seen := map[types.AppRuntime]bool{}
+	runtimes := []types.AppRuntime{}
+
+	for _, p := range processes {
+		rt, ok := d.detectProcess(p)
+		if !ok {
+			continue
+		}
+
+		key := types.AppRuntime{Language: rt.Language, Version: rt.Version, AppServer: rt.AppServer, ServiceName: rt.ServiceName}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		runtimes = append(runtimes, rt)
+	}
+
+	sort.SliceStable(runtimes, func(i, j int) bool {
+		if runtimes[i].Language != runtimes[j].Language {
+			return runtimes[i].Language < runtimes[j].Language
+		}
+		return runtimes[i].PID < runtimes[j].PID
+	})
+
+	return runtimes
+}
+
+func (d *RuntimeDetector) detectProcess(p types.GenericProcess) (types.AppRuntime, bool) {
+	name, _ := p.Name()
+	cmd, _ := p.Cmd()
+	args := strings.Fields(cmd)
+	if len(args) == 0 {
+		// Kernel threads have no command line.
+		return types.AppRuntime{}, false
+	}
+
+	exe := executableName(args[0])
+	rt := types.AppRuntime{PID: p.PID(), Command: cmd}
+
+	for _, spec := range runtimeSpecs {
+		if !spec.executable.MatchString(exe) && !spec.executable.MatchString(executableName(name)) {
+			continue
+		}
+
+		rt.Language = spec.language
+		if m := spec.version.FindStringSubmatch(cmd); m != nil {
+			rt.Version = firstGroup(m)
+		}
+		spec.inspect(&rt, args)
+
+		return rt, true
+	}
+
+	return d.detectGo(rt, args[0])
+}
+
+// detectGo detects a Go application by the build info of its executable.
+func (d *RuntimeDetector) detectGo(rt types.AppRuntime, exe string) (types.AppRuntime, bool) {
+	if d.readBuildInfo == nil || !filepath.IsAbs(exe) || rt.PID == int32(os.Getpid()) {
+		return rt, false
+	}
+
+	for _, dir := range systemBinaryDirs {
+		if strings.HasPrefix(exe, dir) {
+			return rt, false
+		}
+	}
+
+	info, ok := d.buildInfos[exe]
+	if !ok {
+		info, _ = d.readBuildInfo(exe)
+		d.buildInfos[exe] = info
+	}
+	if info == nil {
+		return rt, false
+	}
+
+	rt.Language = types.RuntimeGo
+	rt.Version = strings.TrimPrefix(info.GoVersion, "go")
+	rt.ServiceName = filepath.Base(exe)
+	if info.Main.Path != "" {
+		rt.ServiceName = path.Base(info.Main.Path)
+	}
+
+	return rt, true
+}
+
+// RuntimeMatchers returns a runtime matcher for the APM agent recipe of each language.
+func RuntimeMatchers() []types.DiscoveryMatcher {
+	matchers := []types.DiscoveryMatcher{}
+	for recipe, language := range knownRuntimeRecipes {
+		matchers = append(matchers, types.DiscoveryMatcher{
+			Recipe: recipe,
+			Type:   MatcherTypeRuntime,
+			Value:  language,
+		})
+	}
+
+	return matchers
+}
+
+// RuntimeLanguage returns the language of the applications an APM agent recipe instruments, if it's
+// the recipe of a known agent.
+func RuntimeLanguage(name string) string {
+	return knownRuntimeRecipes[name]
+}
+
+func inspectJava(rt *types.AppRuntime, args []string) {
+	cmd := strings.Join(args, " ")
+	switch {
+	case strings.Contains(cmd, "org.apache.catalina.startup.Bootstrap"):
+		rt.AppServer = "tomcat"
+	case strings.Contains(cmd, "jboss-modules.jar"), strings.Contains(cmd, "org.jboss.as"):
+		rt.AppServer = "jboss"
+	case strings.Contains(cmd, "org.eclipse.jetty"), strings.Contains(cmd, "jetty") && strings.Contains(cmd, "start.jar"):
+		rt.AppServer = "jetty"
+	case strings.Contains(cmd, "weblogic.Server"):
+		rt.AppServer = "weblogic"
+	case strings.Contains(cmd, "com.ibm.ws."):
+		rt.AppServer = "websphere"
+	}
+
+	for i, a := range args {
+		if m := javaAppNameRegex.FindStringSubmatch(a); m != nil {
+			rt.ServiceName = strings.Trim(m[1], `"'`)
+			return
+		}
+		if a == "-jar" && i+1 < len(args) && rt.ServiceName == "" {
+			rt.ServiceName = jarVersionRegex.ReplaceAllString(strings.TrimSuffix(filepath.Base(args[i+1]), ".jar"), "")
+		}
+	}
+}
+
+func inspectNode(rt *types.AppRuntime, args []string) {
+	if strings.Contains(strings.Join(args, " "), "pm2") {
+		rt.AppServer = "pm2"
+	}
+
+	rt.ServiceName = scriptName(args, ".js", ".mjs", ".cjs", ".ts")
+}
+
+func inspectPython(rt *types.AppRuntime, args []string) {
+	for _, a := range args {
+		switch server := executableName(a); server {
+		case "gunicorn", "uwsgi", "uvicorn", "celery":
+			rt.AppServer = server
+		}
+	}
+
+	for i, a := range args[1:] {
+		switch {
+		case rt.AppServer == "" && strings.HasSuffix(a, "manage.py") && i+2 < len(args) && args[i+2] == "runserver":
+			rt.AppServer = "django"
+		case rt.AppServer == "celery" && (a == "-A" || a == "--app") && i+2 < len(args):
+			rt.ServiceName = strings.Split(args[i+2], ".")[0]
+		case strings.HasPrefix(a, "--app="):
+			rt.ServiceName = strings.Split(strings.TrimPrefix(a, "--app="), ".")[0]
+		case rt.ServiceName == "" && (rt.AppServer == "gunicorn" || rt.AppServer == "uvicorn") && !strings.HasPrefix(a, "-") && strings.Contains(a, ":"):
+			// The WSGI application, such as myapp.wsgi:application.
+			rt.ServiceName = strings.Split(strings.Trim(a, "[]"), ".")[0]
+		}
+	}
+
+	if rt.ServiceName == "" {
+		rt.ServiceName = scriptName(args, ".py")
+	}
+}
+
+func inspectRuby(rt *types.AppRuntime, args []string) {
+	cmd := strings.Join(args, " ")
+	for _, a := range args {
+		switch server := executableName(a); server {
+		case "puma", "unicorn", "passenger", "sidekiq":
+			rt.AppServer = server
+		case "rails":
+			if rt.AppServer == "" {
+				rt.AppServer = "rails"
+			}
+		}
+	}
+
+	// Puma and Sidekiq name their processes after the application, in brackets.
+	if m := pumaAppRegex.FindStringSubmatch(cmd); m != nil {
+		rt.ServiceName = m[1]
+		return
+	}
+
+	rt.ServiceName = scriptName(args, ".rb")
+}
+
+func inspectDotnet(rt *types.AppRuntime, args []string) {
+	exe := executableName(args[0])
+	if exe == "w3wp" {
+		rt.AppServer = "iis"
+		for i, a := range args {
+			if a == "-ap" && i+1 < len(args) {
+				rt.ServiceName = strings.Trim(args[i+1], `"`)
+			}
+		}
+		return
+	}
+
+	if len(args) > 1 && strings.HasSuffix(strings.ToLower(args[1]), ".dll") {
+		rt.ServiceName = strings.TrimSuffix(filepath.Base(args[1]), filepath.Ext(args[1]))
+	}
+}
+
+func inspectPHP(rt *types.AppRuntime, args []string) {
+	cmd := strings.Join(args, " ")
+	if strings.HasPrefix(executableName(args[0]), "php-fpm") {
+		rt.AppServer = "php-fpm"
+	}
+
+	if m := fpmPoolRegex.FindStringSubmatch(cmd); m != nil {
+		rt.ServiceName = m[1]
+		return
+	}
+
+	rt.ServiceName = scriptName(args, ".php")
+}
+
+// scriptName guesses the name of an application from the first script of its arguments, which is
+// the name of its directory when the script has a generic name, like server.js.
+func scriptName(args []string, extensions ...string) string {
+	for _, a := range args[1:] {
+		if strings.HasPrefix(a, "-") {
+			continue
+		}
+
+		for _, ext := range extensions {
+			if !strings.HasSuffix(a, ext) {
+				continue
+			}
+
+			name := strings.TrimSuffix(filepath.Base(a), ext)
+			if !genericScriptNames[name] {
+				return name
+			}
+			// A script run from its directory doesn't tell the name of the application.
+			if dir := filepath.Base(filepath.Dir(a)); dir != "." && dir != "/" {
+				return dir
+			}
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// executableName returns the name of an executable without its directory and .exe extension, and
+// without the colon of a process title like gunicorn: master.
+func executableName(s string) string {
+	s = s[strings.LastIndexAny(s, `/\`)+1:]
+	s = strings.TrimSuffix(s, ":")
+	return strings.TrimSuffix(strings.ToLower(s), ".exe")
+}
+
+func firstGroup(m []string) string {
+	for _, g := range m[1:] {
+		if g != "" {
+			return g
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,100 @@
+package recipes
+
+import (
+	"context"
+	"debug/buildinfo"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestRuntimeDetectorShouldDetectApplications(t *testing.T) {
+	processes := []types.GenericProcess{
+		NewMockProcess("/usr/lib/jvm/java-17-openjdk-amd64/bin/java -Dcatalina.base=/opt/tomcat -classpath bootstrap.jar org.apache.catalina.startup.Bootstrap start", "java", 1),
+		NewMockProcess("/opt/jdk1.8.0_202/bin/java -Xmx2g -jar /srv/orders/orders-service-1.4.2.jar", "java", 2),
+		NewMockProcess("java -Dnewrelic.config.app_name=Billing -jar billing.jar", "java", 3),
+		NewMockProcess("/home/app/.nvm/versions/node/v18.12.0/bin/node /srv/checkout/server.js", "node", 4),
+		NewMockProcess("/usr/bin/python3.9 /usr/local/bin/gunicorn --workers 4 inventory.wsgi:application", "gunicorn", 5),
+		NewMockProcess("python3 manage.py runserver 0.0.0.0:8000", "python3", 6),
+		NewMockProcess("celery -A reports worker --loglevel=info", "celery", 7),
+		NewMockProcess("puma 5.6.4 (tcp://0.0.0.0:3000) [storefront]", "ruby", 8),
+		NewMockProcess("dotnet /app/Orders.Api.dll", "dotnet", 9),
+		NewMockProcess(`c:\windows\system32\inetsrv\w3wp.exe -ap "DefaultAppPool" -v "v4.0"`, "w3wp.exe", 10),
+		NewMockProcess("php-fpm: master process (/etc/php/8.1/fpm/php-fpm.conf)", "php-fpm8.1", 11),
+		NewMockProcess("php-fpm: pool www", "php-fpm8.1", 12),
+		NewMockProcess("php-fpm: pool www", "php-fpm8.1", 13),
+		NewMockProcess("/usr/sbin/sshd -D", "sshd", 14),
+		NewMockProcess("", "kworker/0:1", 15),
+	}
+
+	runtimes := NewRuntimeDetector(false).Detect(processes)
+
+	require.Equal(t, []types.AppRuntime{
+		{PID: 9, Language: "dotnet", ServiceName: "Orders.Api"},
+		{PID: 10, Language: "dotnet", AppServer: "iis", ServiceName: "DefaultAppPool"},
+		{PID: 1, Language: "java", Version: "17", AppServer: "tomcat"},
+		{PID: 2, Language: "java", Version: "1.8.0", ServiceName: "orders-service"},
+		{PID: 3, Language: "java", ServiceName: "Billing"},
+		{PID: 4, Language: "node", Version: "18.12.0", ServiceName: "checkout"},
+		{PID: 11, Language: "php", Version: "8.1", AppServer: "php-fpm"},
+		{PID: 12, Language: "php", AppServer: "php-fpm", ServiceName: "www"},
+		{PID: 5, Language: "python", Version: "3.9", AppServer: "gunicorn", ServiceName: "inventory"},
+		{PID: 6, Language: "python", AppServer: "django"},
+		{PID: 7, Language: "python", AppServer: "celery", ServiceName: "reports"},
+		{PID: 8, Language: "ruby", AppServer: "puma", ServiceName: "storefront"},
+	}, withoutCommands(runtimes))
+}
+
+func TestRuntimeDetectorShouldDetectGoApplicationsByTheirBuildInfo(t *testing.T) {
+	d := NewRuntimeDetector(true)
+	reads := 0
+	d.readBuildInfo = func(path string) (*buildinfo.BuildInfo, error) {
+		reads++
+		if path != "/opt/ledger/bin/ledger" {
+			return nil, errors.New("not a Go executable")
+		}
+		info := &buildinfo.BuildInfo{GoVersion: "go1.21.3"}
+		info.Main.Path = "github.com/acme/ledger-service"
+		return info, nil
+	}
+
+	runtimes := d.Detect([]types.GenericProcess{
+		NewMockProcess("/opt/ledger/bin/ledger --port 8080", "ledger", 1),
+		NewMockProcess("/opt/ledger/bin/ledger --port 8081", "ledger", 2),
+		NewMockProcess("/opt/acme/bin/acme-cron", "acme-cron", 3),
+		NewMockProcess("/usr/bin/containerd", "containerd", 4),
+	})
+
+	require.Equal(t, []types.AppRuntime{
+		{PID: 1, Language: "go", Version: "1.21.3", ServiceName: "ledger-service"},
+	}, withoutCommands(runtimes))
+	require.Equal(t, 2, reads)
+}
+
+func TestRuntimeMatcherShouldMatchApplicationsOfItsLanguage(t *testing.T) {
+	host := NewProcessMatchHost(func(context.Context) []types.GenericProcess {
+		return []types.GenericProcess{NewMockProcess("/usr/bin/node /srv/api/index.js", "node", 1)}
+	})
+
+	matchers, err := NewMatcherRegistry().Build(RuntimeMatchers())
+	require.NoError(t, err)
+
+	require.True(t, matchers["node-agent-installer"][0].Matches(context.Background(), host))
+	require.False(t, matchers["java-agent-installer"][0].Matches(context.Background(), host))
+	require.Equal(t, types.RuntimeNode, RuntimeLanguage("node-agent-installer"))
+	require.Empty(t, RuntimeLanguage("mysql-open-source-integration"))
+
+	_, err = NewMatcherRegistry().Build([]types.DiscoveryMatcher{{Recipe: "acme", Type: MatcherTypeRuntime, Value: "cobol"}})
+	require.Error(t, err)
+}
+
+func withoutCommands(runtimes []types.AppRuntime) []types.AppRuntime {
+	for i := range runtimes {
+		runtimes[i].Command = ""
+	}
+
+	return runtimes
+}
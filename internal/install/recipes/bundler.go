@@ -2,6 +2,7 @@ package recipes
 
 import (
 	"context"
+	"sort"
 
 	log "github.com/sirupsen/logrus"
 
@@ -13,10 +14,17 @@ var coreRecipeMap = map[string]bool{
 	types.LoggingRecipeName:    true,
 }
 
+// coreRecipeNames are the core recipes in the order they're installed in.
+var coreRecipeNames = []string{
+	types.InfraAgentRecipeName,
+	types.LoggingRecipeName,
+}
+
 type Bundler struct {
 	AvailableRecipes    RecipeDetectionResults
 	Context             context.Context
 	cachedBundleRecipes map[string]*BundleRecipe
+	recipeOrder         []string
 }
 
 func NewBundler(context context.Context, availableRecipes RecipeDetectionResults) *Bundler {
@@ -27,10 +35,64 @@ func NewBundler(context context.Context, availableRecipes RecipeDetectionResults
 	}
 }
 
+// WithRecipeOrder sets the names of the recipes to install first, in order, ahead of the order
+// given by the priority of recipes.
+func (b *Bundler) WithRecipeOrder(order []string) *Bundler {
+	b.recipeOrder = order
+	return b
+}
+
 func (b *Bundler) CreateCoreBundle() *Bundle {
 	return b.createBundle(b.getCoreRecipeNames(), BundleTypes.CORE)
 }
 
+// SplitEarlyBundle separates the recipes of an additional bundle that are installed before the
+// core bundle, which are the recipes named in the recipe order and those with a priority above 0,
+// from the rest. Both bundles keep the type of the given bundle.
+func (b *Bundler) SplitEarlyBundle(bundle *Bundle) (*Bundle, *Bundle) {
+	early := &Bundle{Type: bundle.Type}
+	rest := &Bundle{Type: bundle.Type}
+
+	for _, br := range bundle.BundleRecipes {
+		if !coreRecipeMap[br.Recipe.Name] && b.isEarlyRecipe(br.Recipe) {
+			early.AddRecipe(br)
+		} else {
+			rest.AddRecipe(br)
+		}
+	}
+
+	return early, rest
+}
+
+func (b *Bundler) isEarlyRecipe(r *types.OpenInstallationRecipe) bool {
+	return b.orderIndex(r.Name) < len(b.recipeOrder) || r.Priority > 0
+}
+
+// orderIndex returns the position of the recipe in the recipe order, or the length of the order
+// when it isn't in it.
+func (b *Bundler) orderIndex(name string) int {
+	for i, n := range b.recipeOrder {
+		if n == name {
+			return i
+		}
+	}
+
+	return len(b.recipeOrder)
+}
+
+// sortBundleRecipes orders the recipes of a bundle by their position in the recipe order, then
+// by highest priority, keeping the bundle's order otherwise.
+func (b *Bundler) sortBundleRecipes(bundle *Bundle) {
+	sort.SliceStable(bundle.BundleRecipes, func(i, j int) bool {
+		ri, rj := bundle.BundleRecipes[i].Recipe, bundle.BundleRecipes[j].Recipe
+		if oi, oj := b.orderIndex(ri.Name), b.orderIndex(rj.Name); oi != oj {
+			return oi < oj
+		}
+
+		return ri.Priority > rj.Priority
+	})
+}
+
 func (b *Bundler) CreateAdditionalGuidedBundle() *Bundle {
 	var recipes []string
 
@@ -48,10 +110,6 @@ func (b *Bundler) CreateAdditionalTargetedBundle(recipes []string) *Bundle {
 }
 
 func (b *Bundler) getCoreRecipeNames() []string {
-	coreRecipeNames := make([]string, 0, len(coreRecipeMap))
-	for k := range coreRecipeMap {
-		coreRecipeNames = append(coreRecipeNames, k)
-	}
 	return coreRecipeNames
 }
 
@@ -68,6 +126,8 @@ func (b *Bundler) createBundle(recipes []string, bType BundleType) *Bundle {
 		}
 	}
 
+	b.sortBundleRecipes(bundle)
+
 	return bundle
 }
 
@@ -76,6 +136,9 @@ func (b *Bundler) getBundleRecipeWithDependencies(recipe *types.OpenInstallation
 		return br
 	}
 
+	// Recipes depending on a recipe being resolved form a cycle, and aren't bundled.
+	b.cachedBundleRecipes[recipe.Name] = nil
+
 	bundleRecipe := &BundleRecipe{
 		Recipe: recipe,
 	}
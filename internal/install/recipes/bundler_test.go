@@ -262,6 +262,50 @@ func newBundler(context context.Context, availableRecipes RecipeDetectionResults
 	}
 }
 
+func TestCreateAdditionalGuidedBundleShouldSortByOrderThenPriority(t *testing.T) {
+	aRecipe := NewRecipeBuilder().Name("a").Build()
+	bRecipe := NewRecipeBuilder().Name("b").Build()
+	bRecipe.Priority = 5
+	cRecipe := NewRecipeBuilder().Name("c").Build()
+	dRecipe := NewRecipeBuilder().Name("d").Build()
+	dRecipe.Priority = -1
+
+	bundler := createTestBundler().WithRecipeOrder([]string{"c"})
+	withAvailableRecipe(bundler, "d", execution.RecipeStatusTypes.AVAILABLE, dRecipe)
+	withAvailableRecipe(bundler, "a", execution.RecipeStatusTypes.AVAILABLE, aRecipe)
+	withAvailableRecipe(bundler, "b", execution.RecipeStatusTypes.AVAILABLE, bRecipe)
+	withAvailableRecipe(bundler, "c", execution.RecipeStatusTypes.AVAILABLE, cRecipe)
+
+	bundle := bundler.CreateAdditionalGuidedBundle()
+
+	require.Equal(t, []string{"c", "b", "a", "d"}, bundleRecipeNames(bundle))
+}
+
+func TestSplitEarlyBundleShouldKeepCoreRecipesForCoreBundle(t *testing.T) {
+	infraRecipe := NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	firewallRecipe := NewRecipeBuilder().Name("firewall-open").Build()
+	mysqlRecipe := NewRecipeBuilder().Name("mysql").Build()
+
+	bundler := createTestBundler().WithRecipeOrder([]string{"firewall-open", types.InfraAgentRecipeName})
+	withAvailableRecipe(bundler, types.InfraAgentRecipeName, execution.RecipeStatusTypes.AVAILABLE, infraRecipe)
+	withAvailableRecipe(bundler, "firewall-open", execution.RecipeStatusTypes.AVAILABLE, firewallRecipe)
+	withAvailableRecipe(bundler, "mysql", execution.RecipeStatusTypes.AVAILABLE, mysqlRecipe)
+
+	early, rest := bundler.SplitEarlyBundle(bundler.CreateAdditionalTargetedBundle([]string{"mysql", types.InfraAgentRecipeName, "firewall-open"}))
+
+	require.Equal(t, []string{"firewall-open"}, bundleRecipeNames(early))
+	require.Equal(t, []string{types.InfraAgentRecipeName, "mysql"}, bundleRecipeNames(rest))
+	require.True(t, early.IsAdditionalTargeted())
+}
+
+func bundleRecipeNames(bundle *Bundle) []string {
+	names := []string{}
+	for _, br := range bundle.BundleRecipes {
+		names = append(names, br.Recipe.Name)
+	}
+	return names
+}
+
 func createTestBundler() *Bundler {
 
 	d := RecipeDetectionResults{}
@@ -277,3 +321,16 @@ func withAvailableRecipe(bundler *Bundler, recipeName string, status execution.R
 		Recipe: recipe,
 	})
 }
+
+func TestCreateAdditionalTargetedBundleShouldNotIncludeRecipesWithCyclicDependencies(t *testing.T) {
+	aRecipe := NewRecipeBuilder().Name("a").DependencyName("b").Build()
+	bRecipe := NewRecipeBuilder().Name("b").DependencyName("a").Build()
+
+	bundler := createTestBundler()
+	withAvailableRecipe(bundler, "a", execution.RecipeStatusTypes.AVAILABLE, aRecipe)
+	withAvailableRecipe(bundler, "b", execution.RecipeStatusTypes.AVAILABLE, bRecipe)
+
+	bundle := bundler.CreateAdditionalTargetedBundle([]string{"a", "b"})
+
+	require.Equal(t, 0, len(bundle.BundleRecipes))
+}
@@ -0,0 +1,62 @@
+package recipes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependencyCycleError is returned when recipes depend on each other, so that none of them can be
+// installed first.
+type DependencyCycleError struct {
+	// Cycle are the names of the recipes of the cycle, starting and ending with the same recipe.
+	Cycle []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return fmt.Sprintf("recipe dependency cycle detected: %s", strings.Join(e.Cycle, " -> "))
+}
+
+// SortByDependencies orders the detected recipes so that each recipe comes after the recipes it
+// depends on, keeping the given order otherwise. Dependencies that aren't detected are left to the
+// bundler, which drops the recipes that need them.
+func SortByDependencies(results RecipeDetectionResults) (RecipeDetectionResults, error) {
+	sorted := RecipeDetectionResults{}
+	visited := map[string]bool{}
+	path := []string{}
+
+	var visit func(r *RecipeDetectionResult) error
+	visit = func(r *RecipeDetectionResult) error {
+		name := r.Recipe.Name
+		for i, p := range path {
+			if p == name {
+				return &DependencyCycleError{Cycle: append(append([]string{}, path[i:]...), name)}
+			}
+		}
+
+		if visited[name] {
+			return nil
+		}
+
+		path = append(path, name)
+		for _, d := range r.Recipe.Dependencies {
+			if dr, ok := results.GetRecipeDetection(d); ok {
+				if err := visit(dr); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[name] = true
+		sorted = append(sorted, r)
+		return nil
+	}
+
+	for _, r := range results {
+		if err := visit(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,72 @@
+package recipes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestSortByDependenciesShouldOrderDependenciesFirst(t *testing.T) {
+	apache := NewRecipeBuilder().Name("apache-open-source-integration").DependencyName(types.InfraAgentRecipeName).Build()
+	infra := NewRecipeBuilder().Name(types.InfraAgentRecipeName).Build()
+	mysql := NewRecipeBuilder().Name("mysql-open-source-integration").DependencyName("apache-open-source-integration").Build()
+
+	sorted, err := SortByDependencies(detectionResults(mysql, apache, infra))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{types.InfraAgentRecipeName, "apache-open-source-integration", "mysql-open-source-integration"}, detectionResultNames(sorted))
+}
+
+func TestSortByDependenciesShouldKeepOrderWithoutDependencies(t *testing.T) {
+	a := NewRecipeBuilder().Name("a").Build()
+	b := NewRecipeBuilder().Name("b").Build()
+	c := NewRecipeBuilder().Name("c").DependencyName("not-detected").Build()
+
+	sorted, err := SortByDependencies(detectionResults(a, b, c))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, detectionResultNames(sorted))
+}
+
+func TestSortByDependenciesShouldDetectCycles(t *testing.T) {
+	a := NewRecipeBuilder().Name("a").DependencyName("b").Build()
+	b := NewRecipeBuilder().Name("b").DependencyName("c").Build()
+	c := NewRecipeBuilder().Name("c").DependencyName("a").Build()
+
+	_, err := SortByDependencies(detectionResults(a, b, c))
+
+	var cycleErr *DependencyCycleError
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, []string{"a", "b", "c", "a"}, cycleErr.Cycle)
+	require.Equal(t, "recipe dependency cycle detected: a -> b -> c -> a", err.Error())
+}
+
+func TestSortByDependenciesShouldDetectRecipeDependingOnItself(t *testing.T) {
+	a := NewRecipeBuilder().Name("a").DependencyName("a").Build()
+
+	_, err := SortByDependencies(detectionResults(a))
+
+	require.EqualError(t, err, "recipe dependency cycle detected: a -> a")
+}
+
+func detectionResults(recipes ...*types.OpenInstallationRecipe) RecipeDetectionResults {
+	results := RecipeDetectionResults{}
+	for _, r := range recipes {
+		results = append(results, &RecipeDetectionResult{Recipe: r, Status: execution.RecipeStatusTypes.AVAILABLE})
+	}
+
+	return results
+}
+
+func detectionResultNames(results RecipeDetectionResults) []string {
+	names := []string{}
+	for _, r := range results {
+		names = append(names, r.Recipe.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,264 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DefaultDiscoveryMatchersFile is the file, in the directory of the CLI's configuration, the
+// discovery matchers of in-house services are loaded from when --discoveryMatchers isn't set.
+const DefaultDiscoveryMatchersFile = "discovery-matchers.yml"
+
+// The types of the built-in discovery matchers.
+const (
+	MatcherTypeProcessName = "processName"
+	MatcherTypePort        = "port"
+	MatcherTypeConfigFile  = "configFile"
+	MatcherTypePackage     = "package"
+	MatcherTypeRuntime     = "runtime"
+)
+
+// Matcher matches a host that has what a discovery matcher looks for.
+type Matcher interface {
+	Matches(ctx context.Context, host *MatchHost) bool
+}
+
+// MatcherFactory builds the matcher of a discovery matcher's value, returning an error when the
+// value isn't valid for its type.
+type MatcherFactory func(value string) (Matcher, error)
+
+// MatcherRegistry is the types of discovery matchers, by name.
+type MatcherRegistry struct {
+	factories map[string]MatcherFactory
+}
+
+// MatchHost is what matchers inspect the host with. A check that isn't set can't be made on the
+// host, such as the ports of a host discovered elsewhere, and never matches.
+type MatchHost struct {
+	Processes        func(ctx context.Context) []types.GenericProcess
+	ListeningPorts   func(ctx context.Context) ([]uint32, error)
+	PathExists       func(pattern string) bool
+	PackageInstalled func(ctx context.Context, name string) bool
+	Runtimes         func(ctx context.Context) []types.AppRuntime
+}
+
+// NewMatcherRegistry returns a registry of the built-in processName, port, configFile, package and
+// runtime matchers.
+func NewMatcherRegistry() *MatcherRegistry {
+	r := &MatcherRegistry{
+		factories: map[string]MatcherFactory{},
+	}
+	r.Register(MatcherTypeProcessName, newProcessNameMatcher)
+	r.Register(MatcherTypePort, newPortMatcher)
+	r.Register(MatcherTypeConfigFile, newConfigFileMatcher)
+	r.Register(MatcherTypePackage, newPackageMatcher)
+	r.Register(MatcherTypeRuntime, newRuntimeMatcher)
+
+	return r
+}
+
+// Register adds a type of matcher to the registry, replacing any of the same name.
+func (r *MatcherRegistry) Register(matcherType string, factory MatcherFactory) {
+	r.factories[matcherType] = factory
+}
+
+// Types returns the names of the registered types of matchers.
+func (r *MatcherRegistry) Types() []string {
+	names := []string{}
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Build returns the matchers of the discovery matchers, by the name of the recipe they recommend.
+func (r *MatcherRegistry) Build(definitions []types.DiscoveryMatcher) (map[string][]Matcher, error) {
+	matchers := map[string][]Matcher{}
+	for _, d := range definitions {
+		if d.Recipe == "" {
+			return nil, fmt.Errorf("the %s matcher %q has no recipe", d.Type, d.Value)
+		}
+
+		factory, ok := r.factories[d.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown matcher type %q for recipe %s, expected one of %s", d.Type, d.Recipe, strings.Join(r.Types(), ", "))
+		}
+
+		m, err := factory(d.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s matcher for recipe %s: %s", d.Type, d.Recipe, err)
+		}
+		matchers[d.Recipe] = append(matchers[d.Recipe], m)
+	}
+
+	return matchers, nil
+}
+
+// LoadDiscoveryMatchers reads the discovery matchers of a YAML matchers file, checking that each of
+// them is a valid matcher of the registry.
+func LoadDiscoveryMatchers(path string, registry *MatcherRegistry) ([]types.DiscoveryMatcher, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read discovery matchers file %s: %w", path, err)
+	}
+
+	file := struct {
+		Matchers []types.DiscoveryMatcher `yaml:"matchers"`
+	}{}
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse discovery matchers file %s: %w", path, err)
+	}
+
+	if _, err := registry.Build(file.Matchers); err != nil {
+		return nil, fmt.Errorf("invalid discovery matchers file %s: %w", path, err)
+	}
+
+	return file.Matchers, nil
+}
+
+// processNameMatcher matches a running process whose executable name matches its pattern.
+type processNameMatcher struct {
+	pattern *regexp.Regexp
+}
+
+func newProcessNameMatcher(value string) (Matcher, error) {
+	if value == "" {
+		return nil, fmt.Errorf("no process name pattern")
+	}
+
+	pattern, err := regexp.Compile(`^(?:` + value + `)$`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &processNameMatcher{pattern: pattern}, nil
+}
+
+func (m *processNameMatcher) Matches(ctx context.Context, host *MatchHost) bool {
+	if host.Processes == nil {
+		return false
+	}
+
+	for _, p := range host.Processes(ctx) {
+		name, err := p.Name()
+		if err == nil && m.pattern.MatchString(filepath.Base(name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// portMatcher matches a host with a process listening on its port.
+type portMatcher struct {
+	port uint32
+}
+
+func newPortMatcher(value string) (Matcher, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil || port == 0 {
+		return nil, fmt.Errorf("%q isn't a port number", value)
+	}
+
+	return &portMatcher{port: uint32(port)}, nil
+}
+
+func (m *portMatcher) Matches(ctx context.Context, host *MatchHost) bool {
+	if host.ListeningPorts == nil {
+		return false
+	}
+
+	ports, err := host.ListeningPorts(ctx)
+	if err != nil {
+		log.Debugf("could not list the listening ports: %s", err)
+		return false
+	}
+
+	for _, p := range ports {
+		if p == m.port {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configFileMatcher matches a host with a file at its path, which may be a glob pattern.
+type configFileMatcher struct {
+	pattern string
+}
+
+func newConfigFileMatcher(value string) (Matcher, error) {
+	if value == "" {
+		return nil, fmt.Errorf("no configuration file path")
+	}
+
+	if _, err := filepath.Match(value, ""); err != nil {
+		return nil, err
+	}
+
+	return &configFileMatcher{pattern: value}, nil
+}
+
+func (m *configFileMatcher) Matches(ctx context.Context, host *MatchHost) bool {
+	return host.PathExists != nil && host.PathExists(m.pattern)
+}
+
+// packageMatcher matches a host with its package installed by the system package manager.
+type packageMatcher struct {
+	name string
+}
+
+func newPackageMatcher(value string) (Matcher, error) {
+	if value == "" || strings.HasPrefix(value, "-") || strings.ContainsAny(value, " \t;&|$`'\"") {
+		return nil, fmt.Errorf("%q isn't a package name", value)
+	}
+
+	return &packageMatcher{name: value}, nil
+}
+
+func (m *packageMatcher) Matches(ctx context.Context, host *MatchHost) bool {
+	return host.PackageInstalled != nil && host.PackageInstalled(ctx, m.name)
+}
+
+// runtimeMatcher matches a host with an application of its language running on it.
+type runtimeMatcher struct {
+	language string
+}
+
+func newRuntimeMatcher(value string) (Matcher, error) {
+	for _, l := range types.RuntimeLanguages {
+		if value == l {
+			return &runtimeMatcher{language: value}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown language %q, expected one of %s", value, strings.Join(types.RuntimeLanguages, ", "))
+}
+
+func (m *runtimeMatcher) Matches(ctx context.Context, host *MatchHost) bool {
+	if host.Runtimes == nil {
+		return false
+	}
+
+	for _, rt := range host.Runtimes(ctx) {
+		if rt.Language == m.language {
+			return true
+		}
+	}
+
+	return false
+}
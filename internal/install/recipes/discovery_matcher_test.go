@@ -0,0 +1,155 @@
+package recipes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestMatcherRegistryShouldMatchHost(t *testing.T) {
+	host := &MatchHost{
+		Processes: func(context.Context) []types.GenericProcess {
+			return []types.GenericProcess{NewMockProcess("/opt/acme/bin/acme-billing --config billing.yml", "/opt/acme/bin/acme-billing", 42)}
+		},
+		ListeningPorts: func(context.Context) ([]uint32, error) {
+			return []uint32{22, 5671}, nil
+		},
+		PathExists: func(pattern string) bool {
+			return pattern == "/etc/acme/*.yml"
+		},
+		PackageInstalled: func(ctx context.Context, name string) bool {
+			return name == "acme-db-server"
+		},
+	}
+
+	matchers, err := NewMatcherRegistry().Build([]types.DiscoveryMatcher{
+		{Recipe: "acme-billing", Type: MatcherTypeProcessName, Value: "acme-bill.*"},
+		{Recipe: "acme-queue", Type: MatcherTypePort, Value: "5671"},
+		{Recipe: "acme-cache", Type: MatcherTypeConfigFile, Value: "/etc/acme/*.yml"},
+		{Recipe: "acme-db", Type: MatcherTypePackage, Value: "acme-db-server"},
+		{Recipe: "acme-web", Type: MatcherTypePort, Value: "8080"},
+		{Recipe: "acme-web", Type: MatcherTypeProcessName, Value: "acme"},
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"acme-billing", "acme-queue", "acme-cache", "acme-db"} {
+		require.Len(t, matchers[name], 1)
+		require.True(t, matchers[name][0].Matches(context.Background(), host), name)
+	}
+	require.Len(t, matchers["acme-web"], 2)
+	require.False(t, matchers["acme-web"][0].Matches(context.Background(), host))
+	require.False(t, matchers["acme-web"][1].Matches(context.Background(), host))
+}
+
+func TestMatcherRegistryShouldNotMatchUnknownChecks(t *testing.T) {
+	host := NewProcessMatchHost(func(context.Context) []types.GenericProcess {
+		return []types.GenericProcess{}
+	})
+
+	matchers, err := NewMatcherRegistry().Build([]types.DiscoveryMatcher{
+		{Recipe: "acme-queue", Type: MatcherTypePort, Value: "5671"},
+		{Recipe: "acme-cache", Type: MatcherTypeConfigFile, Value: "/etc/acme/cache.yml"},
+		{Recipe: "acme-db", Type: MatcherTypePackage, Value: "acme-db-server"},
+	})
+	require.NoError(t, err)
+
+	for name, m := range matchers {
+		require.False(t, m[0].Matches(context.Background(), host), name)
+	}
+}
+
+func TestMatcherRegistryShouldRejectInvalidMatchers(t *testing.T) {
+	invalid := []types.DiscoveryMatcher{
+		{Type: MatcherTypePort, Value: "5671"},
+		{Recipe: "acme", Type: "socket", Value: "/run/acme.sock"},
+		{Recipe: "acme", Type: MatcherTypePort, Value: "http"},
+		{Recipe: "acme", Type: MatcherTypePort, Value: "70000"},
+		{Recipe: "acme", Type: MatcherTypeProcessName, Value: "acme("},
+		{Recipe: "acme", Type: MatcherTypeConfigFile, Value: ""},
+		{Recipe: "acme", Type: MatcherTypePackage, Value: "acme; rm -rf /"},
+		{Recipe: "acme", Type: MatcherTypePackage, Value: "--version"},
+	}
+
+	for _, d := range invalid {
+		_, err := NewMatcherRegistry().Build([]types.DiscoveryMatcher{d})
+		require.Error(t, err, d)
+	}
+}
+
+func TestMatcherRegistryShouldRegisterMatchers(t *testing.T) {
+	r := NewMatcherRegistry()
+	r.Register("always", func(string) (Matcher, error) {
+		return &configFileMatcher{pattern: "*"}, nil
+	})
+	r.Register("never", func(string) (Matcher, error) {
+		return nil, errors.New("not supported")
+	})
+
+	require.Equal(t, []string{"always", "configFile", "never", "package", "port", "processName", "runtime"}, r.Types())
+
+	_, err := r.Build([]types.DiscoveryMatcher{{Recipe: "acme", Type: "always"}})
+	require.NoError(t, err)
+	_, err = r.Build([]types.DiscoveryMatcher{{Recipe: "acme", Type: "never"}})
+	require.Error(t, err)
+}
+
+func TestLoadDiscoveryMatchersShouldReadMatchersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultDiscoveryMatchersFile)
+	require.NoError(t, os.WriteFile(path, []byte(`matchers:
+  - recipe: acme-billing
+    type: processName
+    value: acme-billing
+  - recipe: acme-queue
+    type: port
+    value: "5671"
+`), 0600))
+
+	matchers, err := LoadDiscoveryMatchers(path, NewMatcherRegistry())
+
+	require.NoError(t, err)
+	require.Equal(t, []types.DiscoveryMatcher{
+		{Recipe: "acme-billing", Type: MatcherTypeProcessName, Value: "acme-billing"},
+		{Recipe: "acme-queue", Type: MatcherTypePort, Value: "5671"},
+	}, matchers)
+}
+
+func TestLoadDiscoveryMatchersShouldRejectInvalidMatchers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultDiscoveryMatchersFile)
+	require.NoError(t, os.WriteFile(path, []byte(`matchers:
+  - recipe: acme-queue
+    type: port
+    value: amqp
+`), 0600))
+
+	_, err := LoadDiscoveryMatchers(path, NewMatcherRegistry())
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "isn't a port number")
+}
+
+func TestRecipeDetectorShouldRecommendRecipesMatchingDiscoveryMatchers(t *testing.T) {
+	recipe := NewRecipeBuilder().Name("acme-billing").Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	matchers, err := NewMatcherRegistry().Build([]types.DiscoveryMatcher{{Recipe: "acme-billing", Type: MatcherTypeConfigFile, Value: "/etc/acme/billing.yml"}})
+	require.NoError(t, err)
+	host := &MatchHost{
+		PathExists: func(string) bool {
+			return true
+		},
+	}
+	detector := b.Build().WithMatchers(matchers, host)
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
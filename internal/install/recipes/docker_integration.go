@@ -0,0 +1,42 @@
+package recipes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// The variables of the Docker integration recipe, set from what discovery found about the host.
+const (
+	dockerCgroupVersionVar = "NR_DOCKER_CGROUP_VERSION"
+	dockerContainersVar    = "NR_DOCKER_CONTAINERS"
+	dockerConfigVar        = "NR_DOCKER_INTEGRATION_CONFIG"
+)
+
+// dockerIntegrationVars returns the variables of the Docker integration recipe, including the
+// nri-docker configuration it writes to the agent's integrations.d directory.
+func dockerIntegrationVars(h *types.DockerHost) map[string]string {
+	return map[string]string{
+		dockerCgroupVersionVar: strconv.Itoa(h.CgroupVersion),
+		dockerContainersVar:    strconv.Itoa(len(h.Containers)),
+		dockerConfigVar:        dockerIntegrationConfig(h),
+	}
+}
+
+func dockerIntegrationConfig(h *types.DockerHost) string {
+	var b strings.Builder
+	b.WriteString("integrations:\n")
+	b.WriteString("  - name: nri-docker\n")
+	b.WriteString("    when:\n")
+	b.WriteString("      file_exists: /var/run/docker.sock\n")
+	b.WriteString("    interval: 15s\n")
+	b.WriteString("    env:\n")
+	fmt.Fprintf(&b, "      CGROUP_VERSION: \"%d\"\n", h.CgroupVersion)
+	if h.APIVersion != "" {
+		fmt.Fprintf(&b, "      DOCKER_API_VERSION: \"%s\"\n", h.APIVersion)
+	}
+
+	return b.String()
+}
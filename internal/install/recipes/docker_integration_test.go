@@ -0,0 +1,43 @@
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestDockerIntegrationVars(t *testing.T) {
+	h := &types.DockerHost{
+		APIVersion:    "1.43",
+		CgroupVersion: 2,
+		Containers:    []types.DockerContainer{{ID: "4a1b", Name: "web", Image: "nginx:1.25"}},
+	}
+
+	vars := dockerIntegrationVars(h)
+
+	require.Equal(t, "2", vars[dockerCgroupVersionVar])
+	require.Equal(t, "1", vars[dockerContainersVar])
+	require.Equal(t, `integrations:
+  - name: nri-docker
+    when:
+      file_exists: /var/run/docker.sock
+    interval: 15s
+    env:
+      CGROUP_VERSION: "2"
+      DOCKER_API_VERSION: "1.43"
+`, vars[dockerConfigVar])
+}
+
+func TestRecipeRepository_ShouldEnrichDockerRecipe(t *testing.T) {
+	Setup()
+	givenCachedRecipe("id1", types.DockerRecipeName)
+	discoveryManifest.Docker = &types.DockerHost{CgroupVersion: 1}
+
+	_, err := repository.FindAll()
+
+	require.NoError(t, err)
+	require.Equal(t, "1", types.RecipeVariables[dockerCgroupVersionVar])
+	require.Contains(t, types.RecipeVariables[dockerConfigVar], "nri-docker")
+}
@@ -0,0 +1,146 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// RecipeSource is a named source of recipes for the FailoverRecipeFetcher.
+type RecipeSource struct {
+	Name    string
+	Fetcher RecipeFetcher
+}
+
+// FailoverRecipeFetcher fetches recipes from the first of an ordered list of sources that can
+// serve them, failing over to the next source when one can't be reached.
+type FailoverRecipeFetcher struct {
+	sources []RecipeSource
+	served  *RecipeSource
+	recipes []*types.OpenInstallationRecipe
+}
+
+func NewFailoverRecipeFetcher(sources ...RecipeSource) *FailoverRecipeFetcher {
+	return &FailoverRecipeFetcher{
+		sources: sources,
+	}
+}
+
+// FetchLibraryVersion returns the library version of the source that served the recipes.
+func (f *FailoverRecipeFetcher) FetchLibraryVersion(ctx context.Context) string {
+	if f.served == nil {
+		if _, err := f.FetchRecipes(ctx); err != nil {
+			log.Debugf("Unable to fetch library version, detail: %s", err)
+			return ""
+		}
+	}
+
+	return f.served.Fetcher.FetchLibraryVersion(ctx)
+}
+
+func (f *FailoverRecipeFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenInstallationRecipe, error) {
+	if f.served != nil {
+		return f.recipes, nil
+	}
+
+	failures := []string{}
+	for idx := range f.sources {
+		source := &f.sources[idx]
+
+		recipes, err := source.Fetcher.FetchRecipes(ctx)
+		if err == nil && len(recipes) == 0 {
+			err = fmt.Errorf("no recipes found")
+		}
+
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", source.Name, err))
+			if idx < len(f.sources)-1 {
+				log.Warnf("Could not fetch recipes from %s, trying %s: %s", source.Name, f.sources[idx+1].Name, err)
+			}
+			continue
+		}
+
+		if idx > 0 {
+			log.Warnf("Installing with the recipes from %s, which may be out of date, since they could not be fetched from %s", source.Name, f.sources[0].Name)
+		}
+
+		log.Debugf("Using %d recipes from %s", len(recipes), source.Name)
+		for _, r := range recipes {
+			log.Debugf("Recipe %s served by %s", r.Name, source.Name)
+		}
+
+		f.served = source
+		f.recipes = recipes
+		return recipes, nil
+	}
+
+	return nil, fmt.Errorf("could not fetch recipes from any source: %s", strings.Join(failures, "; "))
+}
+
+// CachedLibraryRecipeFetcher fetches the recipes of the most recent recipe library version that was
+// downloaded before, from any source, without contacting it again.
+type CachedLibraryRecipeFetcher struct {
+	CachePath string
+	verifier  *SignatureVerifier
+	fetcher   *LibraryRecipeFetcher
+}
+
+func NewCachedLibraryRecipeFetcher() *CachedLibraryRecipeFetcher {
+	return &CachedLibraryRecipeFetcher{
+		CachePath: filepath.Join(GetDefaultRecipeCachePath(), "library"),
+	}
+}
+
+// WithVerifier verifies the signature of the cached recipes before they're used.
+func (f *CachedLibraryRecipeFetcher) WithVerifier(verifier *SignatureVerifier) *CachedLibraryRecipeFetcher {
+	f.verifier = verifier
+	return f
+}
+
+func (f *CachedLibraryRecipeFetcher) FetchLibraryVersion(ctx context.Context) string {
+	if f.fetcher == nil {
+		return ""
+	}
+
+	return f.fetcher.FetchLibraryVersion(ctx)
+}
+
+func (f *CachedLibraryRecipeFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenInstallationRecipe, error) {
+	latest := ""
+	var latestTime time.Time
+
+	err := filepath.Walk(f.CachePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && filepath.Ext(path) == ".zip" && info.ModTime().After(latestTime) {
+			latest = path
+			latestTime = info.ModTime()
+		}
+
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not read the recipe cache %s: %w", f.CachePath, err)
+	}
+
+	if latest == "" {
+		return nil, fmt.Errorf("no recipes are cached")
+	}
+
+	f.fetcher = NewLibraryRecipeFetcher(strings.TrimSuffix(filepath.Base(latest), ".zip"))
+	f.fetcher.CachePath = filepath.Dir(latest)
+	f.fetcher.HTTPGetFunc = defaultHTTPGetFunc
+	f.fetcher.verifier = f.verifier
+
+	log.Debugf("Using the recipes of version %s cached at %s", f.fetcher.Version, latestTime.Format(time.RFC3339))
+	return f.fetcher.FetchRecipes(ctx)
+}
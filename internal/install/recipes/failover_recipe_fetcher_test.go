@@ -0,0 +1,93 @@
+package recipes
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestFailoverRecipeFetcherShouldUseFirstAvailableSource(t *testing.T) {
+	primary := NewMockRecipeFetcher()
+	primary.FetchRecipesErr = errors.New("connection refused")
+	mirror := NewMockRecipeFetcher()
+	mirror.LibraryVersion = "0.120.0"
+	mirror.FetchRecipesVal = []*types.OpenInstallationRecipe{{Name: "recipe1"}}
+	fallback := NewMockRecipeFetcher()
+
+	f := NewFailoverRecipeFetcher(
+		RecipeSource{Name: "primary", Fetcher: primary},
+		RecipeSource{Name: "mirror", Fetcher: mirror},
+		RecipeSource{Name: "fallback", Fetcher: fallback},
+	)
+
+	require.Equal(t, "0.120.0", f.FetchLibraryVersion(context.Background()))
+
+	recipes, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "recipe1", recipes[0].Name)
+	require.Equal(t, 1, primary.FetchRecipesCallCount)
+	require.Equal(t, 1, mirror.FetchRecipesCallCount)
+	require.Equal(t, 0, fallback.FetchRecipesCallCount)
+}
+
+func TestFailoverRecipeFetcherShouldFailWhenNoSourceServesRecipes(t *testing.T) {
+	primary := NewMockRecipeFetcher()
+	primary.FetchRecipesErr = errors.New("connection refused")
+	empty := NewMockRecipeFetcher()
+
+	f := NewFailoverRecipeFetcher(
+		RecipeSource{Name: "primary", Fetcher: primary},
+		RecipeSource{Name: "empty", Fetcher: empty},
+	)
+
+	_, err := f.FetchRecipes(context.Background())
+
+	require.EqualError(t, err, "could not fetch recipes from any source: primary: connection refused; empty: no recipes found")
+}
+
+func TestCachedLibraryRecipeFetcherShouldUseLatestCachedVersion(t *testing.T) {
+	cachePath := t.TempDir()
+	writeCachedLibrary := func(dir string, version string, name string, modTime time.Time) {
+		require.NoError(t, os.MkdirAll(filepath.Join(cachePath, dir), 0750))
+		path := filepath.Join(cachePath, dir, version+".zip")
+
+		file, err := os.Create(path)
+		require.NoError(t, err)
+		zw := zip.NewWriter(file)
+		w, err := zw.Create("recipes/recipe.yml")
+		require.NoError(t, err)
+		_, err = w.Write([]byte("name: " + name + "\n"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		require.NoError(t, file.Close())
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	writeCachedLibrary("", "0.110.0", "old", time.Now().Add(-time.Hour))
+	writeCachedLibrary("mirror", "0.120.0", "new", time.Now())
+
+	f := NewCachedLibraryRecipeFetcher()
+	f.CachePath = cachePath
+
+	recipes, err := f.FetchRecipes(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "new", recipes[0].Name)
+	require.Equal(t, "0.120.0", f.FetchLibraryVersion(context.Background()))
+}
+
+func TestCachedLibraryRecipeFetcherShouldFailWhenNothingIsCached(t *testing.T) {
+	f := NewCachedLibraryRecipeFetcher()
+	f.CachePath = filepath.Join(t.TempDir(), "missing")
+
+	_, err := f.FetchRecipes(context.Background())
+
+	require.EqualError(t, err, "no recipes are cached")
+}
@@ -0,0 +1,37 @@
+package recipes
+
+import (
+	"fmt"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// knownGPURecipes maps the recipes of GPU integrations to the vendor of the GPUs they monitor.
+var knownGPURecipes = map[string]string{
+	types.NVIDIAGPURecipeName: types.GPUVendorNVIDIA,
+}
+
+var gpuVendorNames = map[string]string{
+	types.GPUVendorNVIDIA: "NVIDIA",
+}
+
+// GPUMatch returns why the GPUs of a host recommend the recipe, or "" when they don't.
+func GPUMatch(r *types.OpenInstallationRecipe, h *types.GPUHost) string {
+	vendor, ok := knownGPURecipes[r.Name]
+	if !ok {
+		return ""
+	}
+
+	gpus := h.VendorGPUs(vendor)
+	name := gpuVendorNames[vendor]
+	switch {
+	case len(gpus) == 0:
+		return ""
+	case len(gpus) > 1:
+		return fmt.Sprintf("the host has %d %s GPUs", len(gpus), name)
+	case gpus[0].Model != "":
+		return fmt.Sprintf("the host has an %s GPU, %s", name, gpus[0].Model)
+	}
+
+	return fmt.Sprintf("the host has an %s GPU at %s", name, gpus[0].BusID)
+}
@@ -0,0 +1,37 @@
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestGPUMatchShouldRecommendNVIDIAIntegration(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(types.NVIDIAGPURecipeName).Build()
+	other := NewRecipeBuilder().Name("mysql-open-source-integration").Build()
+	t4 := types.GPU{Vendor: types.GPUVendorNVIDIA, Model: "Tesla T4", BusID: "0000:3b:00.0"}
+
+	require.Equal(t, "the host has an NVIDIA GPU, Tesla T4", GPUMatch(recipe, &types.GPUHost{GPUs: []types.GPU{t4}}))
+	require.Equal(t, "the host has 2 NVIDIA GPUs", GPUMatch(recipe, &types.GPUHost{GPUs: []types.GPU{t4, t4}}))
+	require.Equal(t, "the host has an NVIDIA GPU at 0000:3b:00.0", GPUMatch(recipe, &types.GPUHost{GPUs: []types.GPU{{Vendor: types.GPUVendorNVIDIA, BusID: "0000:3b:00.0"}}}))
+	require.Empty(t, GPUMatch(other, &types.GPUHost{GPUs: []types.GPU{t4}}))
+	require.Empty(t, GPUMatch(recipe, &types.GPUHost{GPUs: []types.GPU{{Vendor: "amd"}}}))
+	require.Empty(t, GPUMatch(recipe, nil))
+}
+
+func TestRecipeDetectorShouldRecommendRecipesOfGPUs(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(types.NVIDIAGPURecipeName).Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.gpuHost = &types.GPUHost{GPUs: []types.GPU{{Vendor: types.GPUVendorNVIDIA, Model: "Tesla T4"}}}
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
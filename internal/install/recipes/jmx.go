@@ -0,0 +1,22 @@
+package recipes
+
+import (
+	"fmt"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// JMXMatch returns why the JMX endpoints of a host recommend the recipe, or "" when they don't.
+func JMXMatch(r *types.OpenInstallationRecipe, endpoints []types.JMXEndpoint) string {
+	if r.Name != types.JMXRecipeName || len(endpoints) == 0 {
+		return ""
+	}
+
+	e := endpoints[0]
+	name := e.Name
+	if name == "" {
+		name = "java"
+	}
+
+	return fmt.Sprintf("the Java process %s (pid %d) serves JMX on port %d", name, e.PID, e.Port)
+}
@@ -0,0 +1,35 @@
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestJMXMatchShouldRecommendJMXIntegration(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(types.JMXRecipeName).Build()
+	other := NewRecipeBuilder().Name("java-agent-installer").Build()
+	endpoints := []types.JMXEndpoint{{PID: 1200, Name: "billing.jar", Host: "localhost", Port: 9010}}
+
+	require.Equal(t, "the Java process billing.jar (pid 1200) serves JMX on port 9010", JMXMatch(recipe, endpoints))
+	require.Equal(t, "the Java process java (pid 1300) serves JMX on port 9011", JMXMatch(recipe, []types.JMXEndpoint{{PID: 1300, Host: "localhost", Port: 9011}}))
+	require.Empty(t, JMXMatch(other, endpoints))
+	require.Empty(t, JMXMatch(recipe, nil))
+}
+
+func TestRecipeDetectorShouldRecommendRecipesOfJMXEndpoints(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(types.JMXRecipeName).Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.jmxEndpoints = []types.JMXEndpoint{{PID: 1200, Host: "localhost", Port: 9010}}
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
@@ -0,0 +1,214 @@
+package recipes
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// DefaultRecipeLibraryURL is where the open install library publishes the recipes of each release,
+// as <version>/recipes.zip, along with the current version in currentVersion.txt. Mirrors of the
+// library use the same layout.
+const DefaultRecipeLibraryURL = "https://s3.us-east-1.amazonaws.com/nr-downloads-main/install/open-install-library"
+
+// LibraryRecipeFetcher fetches the recipes released with a specific version of the open install
+// library, or its current version when none is given. Released versions never change, so each
+// one is only downloaded once.
+type LibraryRecipeFetcher struct {
+	HTTPGetFunc func(string) (*http.Response, error)
+	Version     string
+	BaseURL     string
+	CachePath   string
+	verifier    *SignatureVerifier
+}
+
+func NewLibraryRecipeFetcher(version string) *LibraryRecipeFetcher {
+	return &LibraryRecipeFetcher{
+		HTTPGetFunc: NewRetryingHTTPGetFunc(defaultHTTPGetFunc),
+		Version:     strings.TrimPrefix(version, "v"),
+		BaseURL:     DefaultRecipeLibraryURL,
+		CachePath:   filepath.Join(GetDefaultRecipeCachePath(), "library"),
+	}
+}
+
+// WithSource fetches the recipes from a mirror of the open install library instead, using the
+// given func to make its requests.
+func (f *LibraryRecipeFetcher) WithSource(baseURL string, httpGetFunc func(string) (*http.Response, error)) *LibraryRecipeFetcher {
+	f.BaseURL = strings.TrimSuffix(baseURL, "/")
+	f.HTTPGetFunc = httpGetFunc
+
+	sum := sha256.Sum256([]byte(f.BaseURL))
+	f.CachePath = filepath.Join(GetDefaultRecipeCachePath(), "library", hex.EncodeToString(sum[:8]))
+
+	return f
+}
+
+// WithVerifier verifies the signature of the recipes of the version before they're used.
+func (f *LibraryRecipeFetcher) WithVerifier(verifier *SignatureVerifier) *LibraryRecipeFetcher {
+	f.verifier = verifier
+	return f
+}
+
+func (f *LibraryRecipeFetcher) FetchLibraryVersion(ctx context.Context) string {
+	if err := f.resolveVersion(); err != nil {
+		log.Debugf("Unable to fetch library version, detail: %s", err)
+	}
+
+	return f.Version
+}
+
+func (f *LibraryRecipeFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenInstallationRecipe, error) {
+	if err := f.resolveVersion(); err != nil {
+		return nil, err
+	}
+
+	data, err := f.fetchArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	if f.verifier != nil {
+		if err := f.verifier.Verify(f.archiveURL(), data); err != nil {
+			return nil, err
+		}
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("could not read recipes of version %s: %w", f.Version, err)
+	}
+
+	out := []*types.OpenInstallationRecipe{}
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || !isYAMLFile(file.Name) {
+			continue
+		}
+
+		b, err := readZipFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read recipe file %s of version %s: %w", file.Name, f.Version, err)
+		}
+
+		var r types.OpenInstallationRecipe
+		if err := yaml.Unmarshal(b, &r); err != nil {
+			return nil, fmt.Errorf("could not unmarshal recipe file %s of version %s: %w", file.Name, f.Version, err)
+		}
+
+		out = append(out, &r)
+	}
+
+	return out, nil
+}
+
+// resolveVersion fetches the current version of the library, when no version was given.
+func (f *LibraryRecipeFetcher) resolveVersion() error {
+	if f.Version != "" {
+		return nil
+	}
+
+	versionURL := f.BaseURL + "/currentVersion.txt"
+	response, err := f.HTTPGetFunc(versionURL)
+	if err != nil {
+		return fmt.Errorf("could not fetch the current recipe library version from %s: %w", versionURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("could not fetch the current recipe library version from %s: received non-2xx Status code %d", versionURL, response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("could not fetch the current recipe library version from %s: %w", versionURL, err)
+	}
+
+	f.Version = strings.TrimPrefix(strings.TrimSpace(string(data)), "v")
+	if f.Version == "" {
+		return fmt.Errorf("the current recipe library version is missing from %s", versionURL)
+	}
+
+	return nil
+}
+
+func (f *LibraryRecipeFetcher) archiveURL() string {
+	return fmt.Sprintf("%s/v%s/recipes.zip", f.BaseURL, f.Version)
+}
+
+func (f *LibraryRecipeFetcher) fetchArchive() ([]byte, error) {
+	cached := filepath.Join(f.CachePath, f.Version+".zip")
+	if data, err := os.ReadFile(cached); err == nil {
+		log.Debugf("Using cached recipes of version %s", f.Version)
+		return data, nil
+	}
+
+	archiveURL := f.archiveURL()
+	log.Debugf("Fetching recipes of version %s from %s", f.Version, archiveURL)
+
+	response, err := f.HTTPGetFunc(archiveURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch recipes of version %s: %w", f.Version, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("could not fetch recipes of version %s: received non-2xx Status code %d", f.Version, response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch recipes of version %s: %w", f.Version, err)
+	}
+
+	if err := os.MkdirAll(f.CachePath, 0750); err != nil {
+		log.Debugf("could not create recipe library cache %s: %s", f.CachePath, err)
+	} else if err := os.WriteFile(cached, data, 0600); err != nil {
+		log.Debugf("could not cache recipes of version %s: %s", f.Version, err)
+	}
+
+	return data, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// NewSourceHTTPGetFunc returns a func that makes GET requests with the given headers, such as the
+// authorization a mirror of the open install library requires. The headers are only sent with
+// requests beneath baseURL.
+func NewSourceHTTPGetFunc(baseURL string, headers map[string]string) func(string) (*http.Response, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/") + "/"
+
+	return func(requestURL string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(requestURL, baseURL) {
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+		}
+
+		return http.DefaultClient.Do(req)
+	}
+}
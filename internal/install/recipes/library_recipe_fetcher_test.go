@@ -0,0 +1,104 @@
+package recipes
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryRecipeFetcherShouldFetchAndCacheVersion(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, err := zw.Create("recipes/newrelic/mysql.yml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("name: mysql-open-source-integration\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	requested := []string{}
+	f := NewLibraryRecipeFetcher("v0.110.0")
+	f.CachePath = t.TempDir()
+	f.HTTPGetFunc = func(url string) (*http.Response, error) {
+		requested = append(requested, url)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(archive.Bytes())),
+		}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		recipes, err := f.FetchRecipes(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, len(recipes))
+		require.Equal(t, "mysql-open-source-integration", recipes[0].Name)
+	}
+
+	require.Equal(t, []string{"https://s3.us-east-1.amazonaws.com/nr-downloads-main/install/open-install-library/v0.110.0/recipes.zip"}, requested)
+	require.Equal(t, "0.110.0", f.FetchLibraryVersion(context.Background()))
+}
+
+func TestLibraryRecipeFetcherShouldFailForUnknownVersion(t *testing.T) {
+	f := NewLibraryRecipeFetcher("0.0.1")
+	f.CachePath = t.TempDir()
+	f.HTTPGetFunc = func(url string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	}
+
+	_, err := f.FetchRecipes(context.Background())
+
+	require.Error(t, err)
+}
+
+func TestLibraryRecipeFetcherShouldFetchCurrentVersionFromSource(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	require.NoError(t, zw.Close())
+
+	requested := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path+" "+r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/mirror/currentVersion.txt":
+			_, _ = w.Write([]byte("v0.130.0\n"))
+		case "/mirror/v0.130.0/recipes.zip":
+			_, _ = w.Write(archive.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	f := NewLibraryRecipeFetcher("").WithSource(server.URL+"/mirror/", NewSourceHTTPGetFunc(server.URL+"/mirror", map[string]string{"Authorization": "Bearer token"}))
+	f.CachePath = t.TempDir()
+
+	_, err := f.FetchRecipes(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "0.130.0", f.FetchLibraryVersion(context.Background()))
+	require.Equal(t, []string{"/mirror/currentVersion.txt Bearer token", "/mirror/v0.130.0/recipes.zip Bearer token"}, requested)
+}
+
+func TestSourceHTTPGetFuncShouldOnlySendHeadersToSource(t *testing.T) {
+	auth := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	get := NewSourceHTTPGetFunc(server.URL+"/mirror", map[string]string{"Authorization": "Bearer token"})
+
+	response, err := get(server.URL + "/other/recipe.yml")
+	require.NoError(t, err)
+	response.Body.Close()
+
+	require.Equal(t, "", auth)
+}
@@ -2,11 +2,16 @@ package recipes
 
 import (
 	"context"
+	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
 )
 
 type LogMatchFinder struct{}
@@ -36,8 +41,27 @@ func (f *LogMatchFinder) GetPaths(ctx context.Context, recipes []*types.OpenInst
 	return fileMatches
 }
 
+var windowsEnvVarRegex = regexp.MustCompile(`%([A-Za-z_][A-Za-z0-9_()]*)%`)
+
+// expandLogPath expands the %VAR% environment variables of Windows log paths, such as
+// %ProgramData%, and converts their forward slashes to the Windows separator.
+func expandLogPath(path string, goos string) string {
+	if goos != "windows" {
+		return path
+	}
+
+	path = windowsEnvVarRegex.ReplaceAllStringFunc(path, func(v string) string {
+		if value, ok := os.LookupEnv(v[1 : len(v)-1]); ok {
+			return value
+		}
+		return v
+	})
+
+	return strings.ReplaceAll(path, "/", `\`)
+}
+
 func matchLogFilesFromRecipe(matcher types.OpenInstallationLogMatch) (bool, []string) {
-	matches, err := filepath.Glob(matcher.File)
+	matches, err := filepath.Glob(expandLogPath(matcher.File, runtime.GOOS))
 	if err != nil {
 		log.Errorf("error matching logfiles: %s", err)
 		return false, nil
@@ -49,3 +73,61 @@ func matchLogFilesFromRecipe(matcher types.OpenInstallationLogMatch) (bool, []st
 
 	return false, nil
 }
+
+// ExcludeLogMatches returns the log matches without the log files that match any of the glob
+// patterns, along with the excluded log files. A log match whose glob includes an excluded file
+// is replaced by the files it matches that aren't excluded.
+func ExcludeLogMatches(matches []types.OpenInstallationLogMatch, patterns []string) ([]types.OpenInstallationLogMatch, []string) {
+	if len(patterns) == 0 {
+		return matches, []string{}
+	}
+
+	kept := []types.OpenInstallationLogMatch{}
+	excluded := []string{}
+	exclude := func(path string) {
+		if !utils.StringInSlice(path, excluded) {
+			excluded = append(excluded, path)
+		}
+	}
+
+	for _, m := range matches {
+		if IsLogFileExcluded(m.File, patterns) {
+			exclude(m.File)
+			continue
+		}
+
+		_, files := matchLogFilesFromRecipe(m)
+		remaining := []string{}
+		for _, f := range files {
+			if IsLogFileExcluded(f, patterns) {
+				exclude(f)
+			} else {
+				remaining = append(remaining, f)
+			}
+		}
+
+		if len(remaining) == len(files) {
+			kept = append(kept, m)
+			continue
+		}
+
+		for _, f := range remaining {
+			lm := m
+			lm.File = f
+			kept = append(kept, lm)
+		}
+	}
+
+	return kept, excluded
+}
+
+// IsLogFileExcluded returns whether the log file, or glob of log files, matches any of the glob patterns.
+func IsLogFileExcluded(path string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package recipes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestExpandLogPathShouldExpandWindowsEnvVars(t *testing.T) {
+	t.Setenv("ProgramData", `C:\ProgramData`)
+
+	path := expandLogPath("%ProgramData%/New Relic/*.log", "windows")
+
+	require.Equal(t, `C:\ProgramData\New Relic\*.log`, path)
+}
+
+func TestExpandLogPathShouldKeepUnknownWindowsEnvVars(t *testing.T) {
+	path := expandLogPath(`%NR_UNSET_LOG_DIR%\*.log`, "windows")
+
+	require.Equal(t, `%NR_UNSET_LOG_DIR%\*.log`, path)
+}
+
+func TestExpandLogPathShouldNotChangeOtherPaths(t *testing.T) {
+	path := expandLogPath("/var/log/%h/*.log", "linux")
+
+	require.Equal(t, "/var/log/%h/*.log", path)
+}
+
+func TestExcludeLogMatchesShouldRemoveExcludedFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"secure", "secure-20230101", "messages"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0600))
+	}
+
+	matches := []types.OpenInstallationLogMatch{
+		{Name: "secure", File: filepath.Join(dir, "secure")},
+		{Name: "system", File: filepath.Join(dir, "*")},
+	}
+
+	kept, excluded := ExcludeLogMatches(matches, []string{filepath.Join(dir, "secure*")})
+
+	require.Equal(t, []types.OpenInstallationLogMatch{{Name: "system", File: filepath.Join(dir, "messages")}}, kept)
+	require.Equal(t, []string{filepath.Join(dir, "secure"), filepath.Join(dir, "secure-20230101")}, excluded)
+}
+
+func TestExcludeLogMatchesShouldKeepMatchesWithoutExclusions(t *testing.T) {
+	matches := []types.OpenInstallationLogMatch{{Name: "syslog", File: "/var/log/syslog"}}
+
+	kept, excluded := ExcludeLogMatches(matches, []string{"/var/log/secure*"})
+
+	require.Equal(t, matches, kept)
+	require.Empty(t, excluded)
+}
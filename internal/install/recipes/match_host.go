@@ -0,0 +1,113 @@
+package recipes
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v3/net"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// packageQueries are the commands that exit successfully when a package is installed, by OS. The
+// first one found on the PATH is used.
+var packageQueries = map[string][][]string{
+	"linux": {
+		{"dpkg-query", "--show", "--showformat=${db:Status-Status}"},
+		{"rpm", "--query", "--quiet"},
+		{"apk", "info", "--installed"},
+	},
+	"darwin": {
+		{"brew", "list", "--versions"},
+	},
+}
+
+// NewLocalMatchHost returns the host the CLI runs on to match discovery matchers against, with the
+// processes of processes. Its ports and applications are found once, for all the matchers.
+func NewLocalMatchHost(processes func(context.Context) []types.GenericProcess) *MatchHost {
+	var once sync.Once
+	var ports []uint32
+	var portsErr error
+
+	return &MatchHost{
+		Processes: processes,
+		ListeningPorts: func(ctx context.Context) ([]uint32, error) {
+			once.Do(func() {
+				ports, portsErr = localListeningPorts(ctx)
+			})
+			return ports, portsErr
+		},
+		PathExists:       localPathExists,
+		PackageInstalled: localPackageInstalled,
+		Runtimes:         processRuntimes(processes, NewRuntimeDetector(true)),
+	}
+}
+
+// NewProcessMatchHost returns a host that only its processes are known of, such as a remote host or
+// one discovered elsewhere, so only processName and runtime matchers can match it.
+func NewProcessMatchHost(processes func(context.Context) []types.GenericProcess) *MatchHost {
+	return &MatchHost{
+		Processes: processes,
+		Runtimes:  processRuntimes(processes, NewRuntimeDetector(false)),
+	}
+}
+
+// processRuntimes returns the applications running in the processes, detected once.
+func processRuntimes(processes func(context.Context) []types.GenericProcess, d *RuntimeDetector) func(context.Context) []types.AppRuntime {
+	var once sync.Once
+	var runtimes []types.AppRuntime
+
+	return func(ctx context.Context) []types.AppRuntime {
+		once.Do(func() {
+			runtimes = d.Detect(processes(ctx))
+		})
+		return runtimes
+	}
+}
+
+// localListeningPorts returns the TCP ports listened on, and the UDP ports bound without a remote
+// address, on any interface.
+func localListeningPorts(ctx context.Context) ([]uint32, error) {
+	connections, err := net.ConnectionsWithContext(ctx, "inet")
+	if err != nil {
+		return nil, err
+	}
+
+	ports := []uint32{}
+	for _, c := range connections {
+		listening := c.Type == syscall.SOCK_STREAM && c.Status == "LISTEN"
+		bound := c.Type == syscall.SOCK_DGRAM && c.Raddr.Port == 0 && c.Laddr.Port != 0
+		if listening || bound {
+			ports = append(ports, c.Laddr.Port)
+		}
+	}
+
+	return ports, nil
+}
+
+func localPathExists(pattern string) bool {
+	matches, err := filepath.Glob(pattern)
+	return err == nil && len(matches) > 0
+}
+
+func localPackageInstalled(ctx context.Context, name string) bool {
+	for _, query := range packageQueries[runtime.GOOS] {
+		if _, err := exec.LookPath(query[0]); err != nil {
+			continue
+		}
+
+		out, err := exec.CommandContext(ctx, query[0], append(query[1:], name)...).Output()
+		if err != nil {
+			return false
+		}
+
+		// dpkg-query also lists packages that were removed but whose configuration files remain.
+		return query[0] != "dpkg-query" || string(out) == "installed"
+	}
+
+	return false
+}
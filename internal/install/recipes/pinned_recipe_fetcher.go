@@ -0,0 +1,103 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// PinnedRecipeFetcher fetches recipes from another fetcher, replacing the recipes pinned to a
+// version with the recipe released in that version of the open install library.
+type PinnedRecipeFetcher struct {
+	fetcher        RecipeFetcher
+	versions       map[string]string
+	libraryFetcher func(version string) RecipeFetcher
+}
+
+func NewPinnedRecipeFetcher(fetcher RecipeFetcher, versions map[string]string) *PinnedRecipeFetcher {
+	return &PinnedRecipeFetcher{
+		fetcher:  fetcher,
+		versions: versions,
+		libraryFetcher: func(version string) RecipeFetcher {
+			return NewLibraryRecipeFetcher(version)
+		},
+	}
+}
+
+// WithLibraryFetcher sets how the recipes of a library version are fetched.
+func (f *PinnedRecipeFetcher) WithLibraryFetcher(libraryFetcher func(version string) RecipeFetcher) *PinnedRecipeFetcher {
+	f.libraryFetcher = libraryFetcher
+	return f
+}
+
+func (f *PinnedRecipeFetcher) FetchLibraryVersion(ctx context.Context) string {
+	return f.fetcher.FetchLibraryVersion(ctx)
+}
+
+func (f *PinnedRecipeFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenInstallationRecipe, error) {
+	recipes, err := f.fetcher.FetchRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := strings.TrimPrefix(f.fetcher.FetchLibraryVersion(ctx), "v")
+
+	names := make([]string, 0, len(f.versions))
+	for name := range f.versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	libraries := map[string][]*types.OpenInstallationRecipe{}
+	for _, name := range names {
+		version := strings.TrimPrefix(f.versions[name], "v")
+		if version == "" || version == current {
+			continue
+		}
+
+		library, ok := libraries[version]
+		if !ok {
+			library, err = f.libraryFetcher(version).FetchRecipes(ctx)
+			if err != nil {
+				return nil, err
+			}
+			libraries[version] = library
+		}
+
+		pinned := findRecipe(library, name)
+		if pinned == nil {
+			return nil, fmt.Errorf("recipe %s is not in version %s of the recipe library", name, version)
+		}
+
+		log.Debugf("Using recipe %s from version %s of the recipe library", name, version)
+		recipes = replaceRecipe(recipes, pinned)
+	}
+
+	return recipes, nil
+}
+
+func findRecipe(recipes []*types.OpenInstallationRecipe, name string) *types.OpenInstallationRecipe {
+	for _, r := range recipes {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func replaceRecipe(recipes []*types.OpenInstallationRecipe, recipe *types.OpenInstallationRecipe) []*types.OpenInstallationRecipe {
+	for idx, r := range recipes {
+		if r.Name == recipe.Name {
+			recipes[idx] = recipe
+			return recipes
+		}
+	}
+
+	return append(recipes, recipe)
+}
@@ -0,0 +1,53 @@
+package recipes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestPinnedRecipeFetcherShouldReplacePinnedRecipes(t *testing.T) {
+	current := NewMockRecipeFetcher()
+	current.LibraryVersion = "0.120.0"
+	current.FetchRecipesVal = []*types.OpenInstallationRecipe{
+		{Name: "recipe1", Install: "current"},
+		{Name: "recipe2", Install: "current"},
+	}
+
+	library := NewMockRecipeFetcher()
+	library.FetchRecipesVal = []*types.OpenInstallationRecipe{
+		{Name: "recipe1", Install: "pinned"},
+		{Name: "recipe2", Install: "pinned"},
+	}
+
+	requested := []string{}
+	f := NewPinnedRecipeFetcher(current, map[string]string{"recipe1": "0.110.0", "recipe2": "v0.120.0"}).
+		WithLibraryFetcher(func(version string) RecipeFetcher {
+			requested = append(requested, version)
+			return library
+		})
+
+	recipes, err := f.FetchRecipes(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.110.0"}, requested)
+	require.Equal(t, "pinned", recipes[0].Install)
+	require.Equal(t, "current", recipes[1].Install)
+}
+
+func TestPinnedRecipeFetcherShouldFailWhenVersionHasNoSuchRecipe(t *testing.T) {
+	current := NewMockRecipeFetcher()
+	current.LibraryVersion = "0.120.0"
+
+	f := NewPinnedRecipeFetcher(current, map[string]string{"recipe1": "0.110.0"}).
+		WithLibraryFetcher(func(version string) RecipeFetcher {
+			return NewMockRecipeFetcher()
+		})
+
+	_, err := f.FetchRecipes(context.Background())
+
+	require.EqualError(t, err, "recipe recipe1 is not in version 0.110.0 of the recipe library")
+}
@@ -28,6 +28,12 @@ func NewProcessEvaluator() *ProcessEvaluator {
 	return newProcessEvaluator(NewRegexProcessMatchFinder(), GetPsUtilCommandLines, true)
 }
 
+// NewProcessEvaluatorWithFetcher matches recipes against the processes returned by processFetcher,
+// such as the processes of a remote host.
+func NewProcessEvaluatorWithFetcher(processFetcher func(context.Context) []types.GenericProcess) *ProcessEvaluator {
+	return newProcessEvaluator(NewRegexProcessMatchFinder(), processFetcher, true)
+}
+
 func newProcessEvaluator(processMatchFinder ProcessMatchFinder, processFetcher func(context.Context) []types.GenericProcess, isCached bool) *ProcessEvaluator {
 	return &ProcessEvaluator{
 		processMatchFinder: processMatchFinder,
@@ -37,6 +43,12 @@ func newProcessEvaluator(processMatchFinder ProcessMatchFinder, processFetcher f
 	}
 }
 
+// WithProcessFilter scopes the processes recipes are matched against to those the filter keeps.
+func (pe *ProcessEvaluator) WithProcessFilter(f *ProcessFilter) *ProcessEvaluator {
+	pe.processFetcher = f.Fetcher(pe.processFetcher)
+	return pe
+}
+
 func GetPsUtilCommandLines(ctx context.Context) []types.GenericProcess {
 	pids, err := process.PidsWithContext(ctx)
 
@@ -0,0 +1,100 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ProcessFilter scopes discovery to the processes of the services of interest, so the many
+// short-lived processes of a busy host don't match recipes by accident.
+type ProcessFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// NewProcessFilter returns a filter of the processes whose command line matches one of the include
+// patterns, when there are any, and none of the exclude patterns.
+func NewProcessFilter(include []string, exclude []string) (*ProcessFilter, error) {
+	f := &ProcessFilter{}
+
+	for _, p := range include {
+		r, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --discoveryInclude pattern %q: %s", p, err)
+		}
+		f.include = append(f.include, r)
+	}
+
+	for _, p := range exclude {
+		r, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --discoveryExclude pattern %q: %s", p, err)
+		}
+		f.exclude = append(f.exclude, r)
+	}
+
+	return f, nil
+}
+
+// IsEmpty returns whether the filter keeps every process.
+func (f *ProcessFilter) IsEmpty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// Filter returns the processes the filter keeps.
+func (f *ProcessFilter) Filter(processes []types.GenericProcess) []types.GenericProcess {
+	if f.IsEmpty() {
+		return processes
+	}
+
+	kept := []types.GenericProcess{}
+	for _, p := range processes {
+		if f.keeps(p) {
+			kept = append(kept, p)
+		}
+	}
+
+	return kept
+}
+
+// Fetcher returns a fetcher of the processes of fetcher the filter keeps.
+func (f *ProcessFilter) Fetcher(fetcher func(context.Context) []types.GenericProcess) func(context.Context) []types.GenericProcess {
+	if f.IsEmpty() {
+		return fetcher
+	}
+
+	return func(ctx context.Context) []types.GenericProcess {
+		return f.Filter(fetcher(ctx))
+	}
+}
+
+func (f *ProcessFilter) keeps(p types.GenericProcess) bool {
+	// Processes whose command line can't be read, like those of other users, are matched by name.
+	cmd, err := p.Cmd()
+	if err != nil || cmd == "" {
+		if cmd, err = p.Name(); err != nil {
+			return len(f.include) == 0
+		}
+	}
+
+	for _, r := range f.exclude {
+		if r.MatchString(cmd) {
+			return false
+		}
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+
+	for _, r := range f.include {
+		if r.MatchString(cmd) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func givenFilterProcesses() []types.GenericProcess {
+	return []types.GenericProcess{
+		NewMockProcess("/usr/sbin/mysqld --defaults-file=/etc/mysql/my.cnf", "mysqld", 1),
+		NewMockProcess("nginx: master process /usr/sbin/nginx", "nginx", 2),
+		NewMockProcess("/usr/bin/python3 /opt/jobs/report.py", "python3", 3),
+		NewMockProcess("", "redis-server", 4),
+	}
+}
+
+func TestProcessFilterShouldKeepAllProcessesWithoutPatterns(t *testing.T) {
+	f, err := NewProcessFilter(nil, nil)
+	require.NoError(t, err)
+	require.True(t, f.IsEmpty())
+
+	require.Len(t, f.Filter(givenFilterProcesses()), 4)
+}
+
+func TestProcessFilterShouldKeepIncludedProcesses(t *testing.T) {
+	f, err := NewProcessFilter([]string{"mysqld", "^redis"}, nil)
+	require.NoError(t, err)
+
+	processes := f.Filter(givenFilterProcesses())
+
+	require.Len(t, processes, 2)
+	require.Equal(t, int32(1), processes[0].PID())
+	require.Equal(t, int32(4), processes[1].PID())
+}
+
+func TestProcessFilterShouldRemoveExcludedProcesses(t *testing.T) {
+	f, err := NewProcessFilter([]string{"/usr/"}, []string{"^/usr/bin/python3 /opt/jobs/"})
+	require.NoError(t, err)
+
+	processes := f.Filter(givenFilterProcesses())
+
+	require.Len(t, processes, 2)
+	require.Equal(t, int32(1), processes[0].PID())
+	require.Equal(t, int32(2), processes[1].PID())
+}
+
+func TestProcessFilterShouldRejectInvalidPatterns(t *testing.T) {
+	_, err := NewProcessFilter([]string{"mysqld("}, nil)
+	require.ErrorContains(t, err, "--discoveryInclude")
+
+	_, err = NewProcessFilter(nil, []string{"[python"})
+	require.ErrorContains(t, err, "--discoveryExclude")
+}
+
+func TestProcessEvaluatorShouldOnlyMatchFilteredProcesses(t *testing.T) {
+	f, err := NewProcessFilter(nil, []string{"python3"})
+	require.NoError(t, err)
+	pe := NewProcessEvaluatorWithFetcher(func(context.Context) []types.GenericProcess {
+		return givenFilterProcesses()
+	}).WithProcessFilter(f)
+
+	require.Len(t, pe.GetOrLoadProcesses(context.Background()), 3)
+}
@@ -0,0 +1,236 @@
+package recipes
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// A recipe archive is a gzipped tarball holding everything an offline install needs:
+// the recipes (which carry their own validation config), the recipe library version,
+// and any agent packages the recipes install from disk instead of downloading.
+const (
+	RecipeArchiveRecipesDir  = "recipes"
+	RecipeArchivePackagesDir = "packages"
+	RecipeArchiveVersionFile = "version.txt"
+)
+
+// WriteRecipeArchive writes the embedded recipes and library version to w as a recipe
+// archive. When packagesPath is not empty, the files beneath it are added as agent packages.
+func WriteRecipeArchive(w io.Writer, packagesPath string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	f := NewEmbeddedRecipeFetcher()
+	files, err := f.getYAMLFiles(embeddedRecipesPath)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := EmbeddedFS.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("could not read embedded file %s: %w", file, err)
+		}
+
+		name := path.Join(RecipeArchiveRecipesDir, strings.TrimPrefix(file, embeddedRecipesPath+"/"))
+		if err := writeArchiveFile(tw, name, data); err != nil {
+			return err
+		}
+	}
+
+	version, err := EmbeddedFS.ReadFile(embeddedRecipesPath + "/" + RecipeArchiveVersionFile)
+	if err != nil {
+		log.Debugf("Unable to read library version, detail: %s", err)
+	} else if err := writeArchiveFile(tw, RecipeArchiveVersionFile, version); err != nil {
+		return err
+	}
+
+	if packagesPath != "" {
+		if err := writePackagesToArchive(tw, packagesPath); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func writePackagesToArchive(tw *tar.Writer, packagesPath string) error {
+	return filepath.Walk(packagesPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(packagesPath, p)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("could not read package %s: %w", p, err)
+		}
+
+		return writeArchiveFile(tw, path.Join(RecipeArchivePackagesDir, filepath.ToSlash(rel)), data)
+	})
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+	return err
+}
+
+// RecipeArchiveFetcher loads recipes from a recipe archive, for installs that can't
+// reach the recipe service.
+type RecipeArchiveFetcher struct {
+	Path string
+}
+
+func NewRecipeArchiveFetcher(path string) *RecipeArchiveFetcher {
+	return &RecipeArchiveFetcher{
+		Path: path,
+	}
+}
+
+func (f *RecipeArchiveFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenInstallationRecipe, error) {
+	out := []*types.OpenInstallationRecipe{}
+
+	err := f.walk(func(name string, r io.Reader) error {
+		if !strings.HasPrefix(name, RecipeArchiveRecipesDir+"/") || !isYAMLFile(name) {
+			return nil
+		}
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		var recipe types.OpenInstallationRecipe
+		if err := yaml.Unmarshal(data, &recipe); err != nil {
+			return fmt.Errorf("could not unmarshal archived file %s: %w", name, err)
+		}
+
+		out = append(out, &recipe)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (f *RecipeArchiveFetcher) FetchLibraryVersion(ctx context.Context) string {
+	version := ""
+
+	err := f.walk(func(name string, r io.Reader) error {
+		if name != RecipeArchiveVersionFile {
+			return nil
+		}
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		version = strings.Trim(strings.TrimSpace(string(data)), "v")
+		return nil
+	})
+	if err != nil {
+		log.Debugf("Unable to read library version, detail: %s", err)
+	}
+
+	return version
+}
+
+// ExtractPackages writes the agent packages held in the archive beneath dest.
+func (f *RecipeArchiveFetcher) ExtractPackages(dest string) error {
+	return f.walk(func(name string, r io.Reader) error {
+		if !strings.HasPrefix(name, RecipeArchivePackagesDir+"/") {
+			return nil
+		}
+
+		rel := strings.TrimPrefix(name, RecipeArchivePackagesDir+"/")
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid package path %s in recipe archive", name)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return err
+		}
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0750)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, r)
+		return err
+	})
+}
+
+func (f *RecipeArchiveFetcher) walk(fn func(name string, r io.Reader) error) error {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return fmt.Errorf("could not open recipe archive %s: %w", f.Path, err)
+	}
+	defer file.Close()
+
+	gr, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("could not read recipe archive %s: %w", f.Path, err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read recipe archive %s: %w", f.Path, err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := fn(path.Clean(hdr.Name), tr); err != nil {
+			return err
+		}
+	}
+}
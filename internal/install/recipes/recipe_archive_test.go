@@ -0,0 +1,47 @@
+package recipes
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeArchiveShouldRoundTripRecipesAndPackages(t *testing.T) {
+	packagesDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(packagesDir, "linux"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(packagesDir, "linux", "agent.rpm"), []byte("package"), 0600))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRecipeArchive(&buf, packagesDir))
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(t, os.WriteFile(archivePath, buf.Bytes(), 0600))
+
+	embedded, err := NewEmbeddedRecipeFetcher().FetchRecipes(context.Background())
+	require.NoError(t, err)
+
+	f := NewRecipeArchiveFetcher(archivePath)
+	archived, err := f.FetchRecipes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, len(embedded), len(archived))
+	require.Equal(t, NewEmbeddedRecipeFetcher().FetchLibraryVersion(context.Background()), f.FetchLibraryVersion(context.Background()))
+
+	dest := t.TempDir()
+	require.NoError(t, f.ExtractPackages(dest))
+
+	data, err := os.ReadFile(filepath.Join(dest, "linux", "agent.rpm"))
+	require.NoError(t, err)
+	require.Equal(t, "package", string(data))
+}
+
+func TestRecipeArchiveFetcherShouldFailForMissingArchive(t *testing.T) {
+	f := NewRecipeArchiveFetcher(filepath.Join(t.TempDir(), "missing.tar.gz"))
+
+	_, err := f.FetchRecipes(context.Background())
+
+	require.Error(t, err)
+}
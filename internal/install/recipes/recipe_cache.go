@@ -0,0 +1,85 @@
+package recipes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+)
+
+const DefaultRecipeCacheDir = "recipe-cache"
+
+// RecipeCache stores fetched recipe files on disk, along with the metadata needed
+// to revalidate them, so repeated installs don't need to download them again.
+type RecipeCache struct {
+	Path string
+}
+
+// RecipeCacheEntry is the metadata stored alongside a cached recipe file.
+type RecipeCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+func GetDefaultRecipeCachePath() string {
+	return filepath.Join(config.BasePath, DefaultRecipeCacheDir)
+}
+
+func NewRecipeCache(path string) *RecipeCache {
+	return &RecipeCache{
+		Path: path,
+	}
+}
+
+// Get returns the cached recipe file and metadata for the given URL, if present.
+func (c *RecipeCache) Get(recipeURL string) ([]byte, *RecipeCacheEntry, bool) {
+	key := c.key(recipeURL)
+
+	meta, err := os.ReadFile(filepath.Join(c.Path, key+".json"))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var entry RecipeCacheEntry
+	if err := json.Unmarshal(meta, &entry); err != nil {
+		return nil, nil, false
+	}
+
+	body, err := os.ReadFile(filepath.Join(c.Path, key+".yml"))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return body, &entry, true
+}
+
+// Put stores a recipe file and its metadata, replacing any previous entry for the URL.
+func (c *RecipeCache) Put(body []byte, entry *RecipeCacheEntry) error {
+	if err := os.MkdirAll(c.Path, 0750); err != nil {
+		return err
+	}
+
+	key := c.key(entry.URL)
+
+	if err := os.WriteFile(filepath.Join(c.Path, key+".yml"), body, 0600); err != nil {
+		return err
+	}
+
+	meta, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(c.Path, key+".json"), meta, 0600)
+}
+
+func (c *RecipeCache) key(recipeURL string) string {
+	sum := sha256.Sum256([]byte(recipeURL))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,32 @@
+package recipes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeCacheShouldReturnStoredEntry(t *testing.T) {
+	c := NewRecipeCache(t.TempDir())
+	entry := &RecipeCacheEntry{
+		URL:       "https://localhost/recipe.yml",
+		ETag:      `"abc"`,
+		FetchedAt: time.Now(),
+	}
+
+	err := c.Put([]byte("name: testName"), entry)
+	require.NoError(t, err)
+
+	body, actual, ok := c.Get(entry.URL)
+	require.True(t, ok)
+	require.Equal(t, "name: testName", string(body))
+	require.Equal(t, `"abc"`, actual.ETag)
+}
+
+func TestRecipeCacheShouldMissForUnknownURL(t *testing.T) {
+	c := NewRecipeCache(t.TempDir())
+
+	_, _, ok := c.Get("https://localhost/recipe.yml")
+	require.False(t, ok)
+}
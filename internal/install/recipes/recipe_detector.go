@@ -50,16 +50,57 @@ type RecipeDetector struct {
 	context          context.Context
 	repo             Finder
 	installerContext *types.InstallerContext
+	dockerHost       *types.DockerHost
+	containers       []types.DockerContainer
+	systemdUnits     []types.SystemdUnit
+	windowsHost      *types.WindowsHost
+	gpuHost          *types.GPUHost
+	jmxEndpoints     []types.JMXEndpoint
+	// skipScripts is set when the pre-install scripts can't run on the discovered host.
+	skipScripts bool
+	matchers    map[string][]Matcher
+	matchHost   *MatchHost
 }
 
 func NewRecipeDetector(contex context.Context, repo *RecipeRepository, peval ProcessEvaluatorInterface, ic *types.InstallerContext) *RecipeDetector {
-	return &RecipeDetector{
+	dt := &RecipeDetector{
 		processEvaluator: peval,
 		scriptEvaluator:  NewScriptEvaluator(),
 		context:          contex,
 		repo:             repo,
 		installerContext: ic,
 	}
+	if repo != nil && repo.discoveryManifest != nil {
+		dt.dockerHost = repo.discoveryManifest.Docker
+		dt.containers = repo.discoveryManifest.Containers()
+		dt.systemdUnits = repo.discoveryManifest.SystemdUnits()
+		dt.windowsHost = repo.discoveryManifest.Windows
+		dt.gpuHost = repo.discoveryManifest.GPU
+		dt.jmxEndpoints = repo.discoveryManifest.JMXEndpoints
+	}
+
+	return dt
+}
+
+// WithScriptExecutor sets the executor of the recipes' pre-install scripts, such as one running them on a remote host.
+func (dt *RecipeDetector) WithScriptExecutor(executor execution.RecipeExecutor) *RecipeDetector {
+	dt.scriptEvaluator = newScriptEvaluator(executor)
+	return dt
+}
+
+// WithoutDiscoveryScripts detects the recipes without running their requireAtDiscovery scripts, such
+// as for a host discovered elsewhere, so they're detected by their process matches alone.
+func (dt *RecipeDetector) WithoutDiscoveryScripts() *RecipeDetector {
+	dt.skipScripts = true
+	return dt
+}
+
+// WithMatchers recommends the recipes that the discovery matchers of host match, by recipe name, in
+// addition to those matching their processes.
+func (dt *RecipeDetector) WithMatchers(matchers map[string][]Matcher, host *MatchHost) *RecipeDetector {
+	dt.matchers = matchers
+	dt.matchHost = host
+	return dt
 }
 
 func (dt *RecipeDetector) GetDetectedRecipes() (RecipeDetectionResults, RecipeDetectionResults, error) {
@@ -81,6 +122,11 @@ func (dt *RecipeDetector) GetDetectedRecipes() (RecipeDetectionResults, RecipeDe
 	}
 	sort.Sort(availableRecipes)
 
+	availableRecipes, err = SortByDependencies(availableRecipes)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return availableRecipes, unavailableRecipes, nil
 }
 
@@ -97,6 +143,15 @@ func (dt *RecipeDetector) shouldDiscover(recipe *types.OpenInstallationRecipe) b
 func (dt *RecipeDetector) detectRecipe(recipe *types.OpenInstallationRecipe) *RecipeDetectionResult {
 	start := time.Now()
 
+	if dt.installerContext.IsRecipeSkipped(recipe.Name) {
+		log.Debugf("Skipping detection for recipe:%s, excluded by --skipRecipes", recipe.Name)
+		return &RecipeDetectionResult{
+			recipe,
+			execution.RecipeStatusTypes.SKIPPED,
+			time.Since(start).Milliseconds(),
+		}
+	}
+
 	if !dt.shouldDiscover(recipe) {
 		durationMs := time.Since(start).Milliseconds()
 		return &RecipeDetectionResult{
@@ -109,7 +164,62 @@ func (dt *RecipeDetector) detectRecipe(recipe *types.OpenInstallationRecipe) *Re
 	status := dt.processEvaluator.DetectionStatus(dt.context, recipe)
 	durationMs := time.Since(start).Milliseconds()
 
-	if status == execution.RecipeStatusTypes.AVAILABLE && recipe.PreInstall.RequireAtDiscovery != "" {
+	// The Docker Engine may run where its processes aren't visible, such as rootless, so running
+	// containers found by discovery recommend the Docker integration too.
+	if status != execution.RecipeStatusTypes.AVAILABLE && recipe.Name == types.DockerRecipeName &&
+		dt.dockerHost != nil && len(dt.dockerHost.Containers) > 0 {
+		log.Debugf("Recommending recipe:%s for %d running containers", recipe.Name, len(dt.dockerHost.Containers))
+		status = execution.RecipeStatusTypes.AVAILABLE
+	}
+
+	// Services running in containers recommend their integrations, as their processes may not be
+	// visible or may run under generic names.
+	if status != execution.RecipeStatusTypes.AVAILABLE && recipe.Name != types.DockerRecipeName {
+		if running := ServiceContainers(recipe.Name, dt.containers); len(running) > 0 {
+			log.Debugf("Recommending recipe:%s for its service running in container %s", recipe.Name, running[0].Name)
+			status = execution.RecipeStatusTypes.AVAILABLE
+		}
+	}
+
+	// Services that are installed but idle aren't running, but their systemd units start them.
+	if status != execution.RecipeStatusTypes.AVAILABLE {
+		if units := ServiceUnits(dt.context, recipe, dt.systemdUnits); len(units) > 0 {
+			log.Debugf("Recommending recipe:%s for its %s systemd unit", recipe.Name, units[0].Name)
+			status = execution.RecipeStatusTypes.AVAILABLE
+		}
+	}
+
+	if status != execution.RecipeStatusTypes.AVAILABLE {
+		if reason := WindowsServiceMatch(dt.context, recipe, dt.windowsHost); reason != "" {
+			log.Debugf("Recommending recipe:%s, %s", recipe.Name, reason)
+			status = execution.RecipeStatusTypes.AVAILABLE
+		}
+	}
+
+	if status != execution.RecipeStatusTypes.AVAILABLE {
+		if reason := GPUMatch(recipe, dt.gpuHost); reason != "" {
+			log.Debugf("Recommending recipe:%s, %s", recipe.Name, reason)
+			status = execution.RecipeStatusTypes.AVAILABLE
+		}
+	}
+
+	if status != execution.RecipeStatusTypes.AVAILABLE {
+		if reason := JMXMatch(recipe, dt.jmxEndpoints); reason != "" {
+			log.Debugf("Recommending recipe:%s, %s", recipe.Name, reason)
+			status = execution.RecipeStatusTypes.AVAILABLE
+		}
+	}
+
+	if status != execution.RecipeStatusTypes.AVAILABLE && dt.matchesHost(recipe) {
+		log.Debugf("Recommending recipe:%s for a matching discovery matcher", recipe.Name)
+		status = execution.RecipeStatusTypes.AVAILABLE
+	}
+
+	if status == execution.RecipeStatusTypes.AVAILABLE && recipe.PreInstall.RequireAtDiscovery != "" && dt.skipScripts {
+		log.Debugf("Skipping the requireAtDiscovery script of recipe:%s, the host was discovered elsewhere", recipe.Name)
+	}
+
+	if status == execution.RecipeStatusTypes.AVAILABLE && recipe.PreInstall.RequireAtDiscovery != "" && !dt.skipScripts {
 		status = dt.scriptEvaluator.DetectionStatus(dt.context, recipe)
 		durationMs = time.Since(start).Milliseconds()
 		log.Debugf("ScriptEvaluation for recipe:%s completed in %dms with status:%s", recipe.Name, durationMs, status)
@@ -121,3 +231,17 @@ func (dt *RecipeDetector) detectRecipe(recipe *types.OpenInstallationRecipe) *Re
 		durationMs,
 	}
 }
+
+func (dt *RecipeDetector) matchesHost(recipe *types.OpenInstallationRecipe) bool {
+	if dt.matchHost == nil {
+		return false
+	}
+
+	for _, m := range dt.matchers[recipe.Name] {
+		if m.Matches(dt.context, dt.matchHost) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -68,6 +68,21 @@ func TestRecipeDetectorShouldDetectBecauseOfScriptEvaluation(t *testing.T) {
 	require.Equal(t, execution.RecipeStatusTypes.DETECTED, actual.Status)
 }
 
+func TestRecipeDetectorShouldNotRunScriptsWithoutDiscoveryScripts(t *testing.T) {
+	recipe := NewRecipeBuilder().WithPreInstallScript("pre-install script mock").Build()
+
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.AVAILABLE)
+	b.WithScriptEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build().WithoutDiscoveryScripts()
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
+
 func TestRecipeDetectorShouldBeAvailableBecauseOfScriptEvaluation(t *testing.T) {
 	recipe := NewRecipeBuilder().WithPreInstallScript("pre-install script mock").Build()
 
@@ -99,6 +114,34 @@ func TestDetectionResultsShouldSortByRecipeName(t *testing.T) {
 	require.Equal(t, detections[1].Recipe.Name, "b")
 }
 
+func TestRecipeDetectorShouldOrderDependenciesFirst(t *testing.T) {
+	infra := NewRecipeBuilder().Name("b").Build()
+	integration := NewRecipeBuilder().Name("a").DependencyName("b").Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(infra, execution.RecipeStatusTypes.AVAILABLE)
+	b.WithProcessEvaluatorRecipeStatus(integration, execution.RecipeStatusTypes.AVAILABLE)
+	detector := b.Build()
+
+	a, _, err := detector.GetDetectedRecipes()
+
+	require.NoError(t, err)
+	require.Equal(t, "b", a[0].Recipe.Name)
+	require.Equal(t, "a", a[1].Recipe.Name)
+}
+
+func TestRecipeDetectorShouldFailWhenDependenciesAreCyclic(t *testing.T) {
+	r1 := NewRecipeBuilder().Name("a").DependencyName("b").Build()
+	r2 := NewRecipeBuilder().Name("b").DependencyName("a").Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(r1, execution.RecipeStatusTypes.AVAILABLE)
+	b.WithProcessEvaluatorRecipeStatus(r2, execution.RecipeStatusTypes.AVAILABLE)
+	detector := b.Build()
+
+	_, _, err := detector.GetDetectedRecipes()
+
+	require.EqualError(t, err, "recipe dependency cycle detected: a -> b -> a")
+}
+
 func TestRecipeDetectorShouldExcludeIfNotTargeted(t *testing.T) {
 	recipe := NewRecipeBuilder().WithDiscoveryMode([]types.OpenInstallationDiscoveryMode{types.OpenInstallationDiscoveryModeTypes.TARGETED}).Build()
 
@@ -134,6 +177,26 @@ func TestRecipeDetectorShouldIncludeIfTargeted(t *testing.T) {
 	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
 }
 
+func TestRecipeDetectorShouldSkipExcludedRecipe(t *testing.T) {
+	recipe := NewRecipeBuilder().Name("R1").Build()
+
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.AVAILABLE)
+	b.WithScriptEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.AVAILABLE)
+	b.WithInstallContext(&types.InstallerContext{SkipRecipes: []string{
+		"R1",
+	}})
+	detector := b.Build()
+
+	a, ua, _ := detector.GetDetectedRecipes()
+	_, ok := a.GetRecipeDetection(recipe.Name)
+	require.False(t, ok)
+
+	actual, ok := ua.GetRecipeDetection(recipe.Name)
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.SKIPPED, actual.Status)
+}
+
 func TestRecipeDetectorShouldDiscover(t *testing.T) {
 	recipe := NewRecipeBuilder().Build()
 	b := NewRecipeDetectorTestBuilder()
@@ -168,6 +231,33 @@ func (mrf *MockRecipesFinder) FindAll() ([]*types.OpenInstallationRecipe, error)
 	return mrf.recipes, nil
 }
 
+func TestRecipeDetectorShouldRecommendDockerIntegrationForRunningContainers(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(types.DockerRecipeName).Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.dockerHost = &types.DockerHost{Containers: []types.DockerContainer{{ID: "4a1b"}}}
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
+
+func TestRecipeDetectorShouldNotRecommendDockerIntegrationWithoutContainers(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(types.DockerRecipeName).Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.dockerHost = &types.DockerHost{Containers: []types.DockerContainer{}}
+
+	_, ua, _ := detector.GetDetectedRecipes()
+	_, ok := ua.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+}
+
 type RecipeDetectorTestBuilder struct {
 	processEvaluator *MockRecipeEvaluator
 	scriptEvaluator  *MockRecipeEvaluator
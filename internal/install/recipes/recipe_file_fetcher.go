@@ -2,11 +2,16 @@ package recipes
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -15,26 +20,60 @@ import (
 	"github.com/newrelic/newrelic-cli/internal/install/types"
 )
 
+// ChecksumExtension is the extension of the file published next to a recipe with its SHA-256
+// checksum, in the format of sha256sum.
+const ChecksumExtension = ".sha256"
+
 type RecipeFileFetcher struct {
-	HTTPGetFunc  func(string) (*http.Response, error)
-	readFileFunc func(string) ([]byte, error)
-	Paths        []string
+	HTTPGetFunc        func(string) (*http.Response, error)
+	conditionalGetFunc func(string, string) (*http.Response, error)
+	readFileFunc       func(string) ([]byte, error)
+	Paths              []string
+	cache              *RecipeCache
+	verifier           *SignatureVerifier
+	checksums          []string
 }
 
 func NewRecipeFileFetcher(paths []string) *RecipeFileFetcher {
 	f := RecipeFileFetcher{}
-	f.HTTPGetFunc = defaultHTTPGetFunc
+	f.HTTPGetFunc = NewRetryingHTTPGetFunc(defaultHTTPGetFunc)
+	f.conditionalGetFunc = func(recipeURL string, etag string) (*http.Response, error) {
+		return retryRequest(recipeURL, func() (*http.Response, error) {
+			return defaultConditionalGetFunc(recipeURL, etag)
+		})
+	}
 	f.readFileFunc = defaultReadFileFunc
 	f.Paths = paths
 	return &f
 }
 
+// WithCache stores recipes fetched from a URL in the given cache, and falls back to it
+// when the recipe can't be downloaded.
+func (rff *RecipeFileFetcher) WithCache(cache *RecipeCache) *RecipeFileFetcher {
+	rff.cache = cache
+	return rff
+}
+
+// WithVerifier verifies the signature of recipes fetched from a URL before they're used.
+func (rff *RecipeFileFetcher) WithVerifier(verifier *SignatureVerifier) *RecipeFileFetcher {
+	rff.verifier = verifier
+	return rff
+}
+
+// WithChecksums only accepts recipes fetched from a URL with one of the given SHA-256 checksums,
+// instead of the checksum published next to the recipe.
+func (rff *RecipeFileFetcher) WithChecksums(checksums []string) *RecipeFileFetcher {
+	rff.checksums = checksums
+	return rff
+}
+
 func (rff *RecipeFileFetcher) FetchLibraryVersion(ctx context.Context) string {
 	return ""
 }
 func (rff *RecipeFileFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenInstallationRecipe, error) {
 
 	var recipesFromPath []*types.OpenInstallationRecipe
+	loaded := map[string]bool{}
 
 	for _, recipePath := range rff.Paths {
 		recipeURL, parseErr := url.Parse(recipePath)
@@ -48,23 +87,92 @@ func (rff *RecipeFileFetcher) FetchRecipes(ctx context.Context) ([]*types.OpenIn
 			if err != nil {
 				return recipesFromPath, fmt.Errorf("could not fetch file %s: %s", recipePath, err)
 			}
-		} else {
-			log.Debugf("Loading recipe from path:%s", recipePath)
-			recipe, err = rff.LoadRecipeFile(recipePath)
+			recipesFromPath = append(recipesFromPath, recipe)
+			continue
+		}
+
+		filePaths, err := expandRecipePath(recipePath)
+		if err != nil {
+			return recipesFromPath, err
+		}
+
+		for _, filePath := range filePaths {
+			if loaded[filePath] {
+				continue
+			}
+			loaded[filePath] = true
+
+			log.Debugf("Loading recipe from path:%s", filePath)
+			recipe, err = rff.LoadRecipeFile(filePath)
 			if err != nil {
-				return recipesFromPath, fmt.Errorf("could not load file %s: %s", recipePath, err)
+				return recipesFromPath, fmt.Errorf("could not load file %s: %s", filePath, err)
 			}
+			recipesFromPath = append(recipesFromPath, recipe)
 		}
-		recipesFromPath = append(recipesFromPath, recipe)
 	}
 
 	return recipesFromPath, nil
 }
 
+// expandRecipePath resolves a local recipe path into the recipe files it refers to.
+// Glob patterns are expanded, and directories are searched recursively for *.yml and *.yaml files.
+func expandRecipePath(recipePath string) ([]string, error) {
+	matches := []string{recipePath}
+
+	if strings.ContainsAny(recipePath, "*?[") {
+		var err error
+		matches, err = filepath.Glob(recipePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipe path pattern %s: %s", recipePath, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no recipe files match %s", recipePath)
+		}
+	}
+
+	filePaths := []string{}
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil || !info.IsDir() {
+			filePaths = append(filePaths, m)
+			continue
+		}
+
+		err = filepath.Walk(m, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			ext := filepath.Ext(path)
+			if !info.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				filePaths = append(filePaths, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not read recipe directory %s: %s", m, err)
+		}
+	}
+
+	return filePaths, nil
+}
+
 func defaultHTTPGetFunc(recipeURL string) (*http.Response, error) {
 	return http.Get(recipeURL)
 }
 
+func defaultConditionalGetFunc(recipeURL string, etag string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, recipeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("If-None-Match", etag)
+
+	return http.DefaultClient.Do(req)
+}
+
 func defaultReadFileFunc(filename string) ([]byte, error) {
 	return ioutil.ReadFile(filename)
 }
@@ -80,6 +188,10 @@ func NewRecipeFile(recipeFileString string) (*types.OpenInstallationRecipe, erro
 }
 
 func (rff *RecipeFileFetcher) FetchRecipeFile(recipeURL *url.URL) (*types.OpenInstallationRecipe, error) {
+	if rff.cache != nil {
+		return rff.fetchCachedRecipeFile(recipeURL.String())
+	}
+
 	response, err := rff.HTTPGetFunc(recipeURL.String())
 
 	if err != nil {
@@ -97,9 +209,154 @@ func (rff *RecipeFileFetcher) FetchRecipeFile(recipeURL *url.URL) (*types.OpenIn
 		return nil, err
 	}
 
+	return rff.newVerifiedRecipeFile(recipeURL.String(), body)
+}
+
+// newVerifiedRecipeFile parses a recipe fetched from a URL, once its checksum and signature are verified.
+func (rff *RecipeFileFetcher) newVerifiedRecipeFile(recipeURL string, body []byte) (*types.OpenInstallationRecipe, error) {
+	if err := rff.verifyChecksum(recipeURL, body); err != nil {
+		return nil, err
+	}
+
+	if rff.verifier != nil {
+		if err := rff.verifier.Verify(recipeURL, body); err != nil {
+			return nil, err
+		}
+	}
+
 	return NewRecipeFile(string(body))
 }
 
+// verifyChecksum checks the SHA-256 checksum of a recipe fetched from a URL against the checksums
+// it was fetched with or, without them, the checksum published next to it.
+func (rff *RecipeFileFetcher) verifyChecksum(recipeURL string, body []byte) error {
+	sum := sha256.Sum256(body)
+	checksum := hex.EncodeToString(sum[:])
+
+	if len(rff.checksums) > 0 {
+		for _, c := range rff.checksums {
+			if strings.EqualFold(c, checksum) {
+				return nil
+			}
+		}
+		return fmt.Errorf("the checksum of %s is %s, which doesn't match the checksums it's expected to have", recipeURL, checksum)
+	}
+
+	published, found := rff.fetchChecksum(recipeURL + ChecksumExtension)
+	if !found {
+		log.Debugf("No checksum is published for %s, using it without verifying it", recipeURL)
+		return nil
+	}
+
+	if !strings.EqualFold(published, checksum) {
+		return fmt.Errorf("the checksum of %s is %s, which doesn't match the checksum %s published with it", recipeURL, checksum, published)
+	}
+
+	log.Debugf("Verified the checksum of %s", recipeURL)
+	return nil
+}
+
+// fetchChecksum returns the checksum of a checksum file, which starts with the checksum in hex as
+// with sha256sum.
+func (rff *RecipeFileFetcher) fetchChecksum(checksumURL string) (string, bool) {
+	response, err := rff.HTTPGetFunc(checksumURL)
+	if err != nil {
+		log.Debugf("could not fetch checksum %s: %s", checksumURL, err)
+		return "", false
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return "", false
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		log.Debugf("could not read checksum %s: %s", checksumURL, err)
+		return "", false
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 || !IsSHA256Checksum(fields[0]) {
+		log.Debugf("%s is not a SHA-256 checksum", checksumURL)
+		return "", false
+	}
+
+	return fields[0], true
+}
+
+// IsSHA256Checksum returns whether the value is a SHA-256 checksum in hex.
+func IsSHA256Checksum(value string) bool {
+	if len(value) != sha256.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
+// fetchCachedRecipeFile revalidates a cached recipe file using its ETag, and falls back
+// to the cached copy when the recipe can't be downloaded.
+func (rff *RecipeFileFetcher) fetchCachedRecipeFile(recipeURL string) (*types.OpenInstallationRecipe, error) {
+	cached, entry, isCached := rff.cache.Get(recipeURL)
+
+	var response *http.Response
+	var err error
+	if isCached && entry.ETag != "" {
+		response, err = rff.conditionalGetFunc(recipeURL, entry.ETag)
+	} else {
+		response, err = rff.HTTPGetFunc(recipeURL)
+	}
+
+	if err != nil {
+		if isCached {
+			log.Warnf("Could not fetch recipe %s, using the copy cached at %s: %s", recipeURL, entry.FetchedAt.Format(time.RFC3339), err)
+			return rff.newVerifiedRecipeFile(recipeURL, cached)
+		}
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if isCached {
+		if response.StatusCode == http.StatusNotModified {
+			log.Debugf("Recipe %s is unchanged, using cached copy", recipeURL)
+			return rff.newVerifiedRecipeFile(recipeURL, cached)
+		}
+
+		if response.StatusCode >= 500 {
+			log.Warnf("Recipe service returned status code %d for %s, using the copy cached at %s", response.StatusCode, recipeURL, entry.FetchedAt.Format(time.RFC3339))
+			return rff.newVerifiedRecipeFile(recipeURL, cached)
+		}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("received non-2xx Status code %d when retrieving recipe", response.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe, err := rff.newVerifiedRecipeFile(recipeURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rff.cache.Put(body, &RecipeCacheEntry{
+		URL:          recipeURL,
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	})
+	if err != nil {
+		log.Debugf("could not cache recipe %s: %s", recipeURL, err)
+	}
+
+	return recipe, nil
+}
+
 func (rff *RecipeFileFetcher) LoadRecipeFile(filename string) (*types.OpenInstallationRecipe, error) {
 	out, err := rff.readFileFunc(filename)
 	if err != nil {
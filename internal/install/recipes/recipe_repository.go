@@ -31,6 +31,8 @@ type RecipeRepository struct {
 	filteredRecipes   []*types.OpenInstallationRecipe
 	discoveryManifest *types.DiscoveryManifest
 	logMatchFinder    LogMatchFinderDefinition
+	logExclusions     []string
+	excludedLogFiles  []string
 }
 
 type recipeMatch struct {
@@ -55,6 +57,18 @@ func newRecipeRepository(loaderFunc func() ([]*types.OpenInstallationRecipe, err
 	return &rr
 }
 
+// WithLogExclusions excludes the log files that match any of the glob patterns from the log files
+// discovered for the logs recipe.
+func (rf *RecipeRepository) WithLogExclusions(patterns []string) *RecipeRepository {
+	rf.logExclusions = patterns
+	return rf
+}
+
+// ExcludedLogFiles returns the discovered log files that were excluded from the logs recipe.
+func (rf *RecipeRepository) ExcludedLogFiles() []string {
+	return rf.excludedLogFiles
+}
+
 func (rf *RecipeRepository) FindRecipeByName(name string) *types.OpenInstallationRecipe {
 	recipes, err := rf.FindAll()
 	if err != nil {
@@ -102,6 +116,7 @@ func (rf *RecipeRepository) enrichLogRecipe() {
 	for _, recipe := range rf.filteredRecipes {
 		if recipe.Name == types.LoggingRecipeName || recipe.Name == types.LoggingSuperAgentRecipeName {
 			logMatches := rf.logMatchFinder.GetPaths(utils.SignalCtx, rf.filteredRecipes)
+			logMatches, rf.excludedLogFiles = ExcludeLogMatches(logMatches, rf.logExclusions)
 
 			var discoveredLogFilesString string
 			if len(logMatches) > 0 {
@@ -122,6 +137,23 @@ func (rf *RecipeRepository) enrichLogRecipe() {
 	}
 }
 
+// enrichDockerRecipe sets the variables of the Docker integration recipe from the Docker host found
+// by discovery.
+func (rf *RecipeRepository) enrichDockerRecipe() {
+	if rf.discoveryManifest == nil || rf.discoveryManifest.Docker == nil {
+		return
+	}
+
+	for _, recipe := range rf.filteredRecipes {
+		if recipe.Name == types.DockerRecipeName {
+			for k, v := range dockerIntegrationVars(rf.discoveryManifest.Docker) {
+				recipe.SetRecipeVar(k, v)
+			}
+			break
+		}
+	}
+}
+
 func (rf *RecipeRepository) FindAll() ([]*types.OpenInstallationRecipe, error) {
 	if rf.filteredRecipes != nil {
 		return rf.filteredRecipes, nil
@@ -138,6 +170,7 @@ func (rf *RecipeRepository) FindAll() ([]*types.OpenInstallationRecipe, error) {
 
 	rf.filteredRecipes = filterRecipes(rf.loadedRecipes, rf.discoveryManifest)
 	rf.enrichLogRecipe()
+	rf.enrichDockerRecipe()
 
 	return rf.filteredRecipes, nil
 }
@@ -254,6 +287,10 @@ func matchRecipeCriteria(hostMap map[string]string, rkey string, rvalue string)
 				return regex.MatchString(val)
 			}
 		}
+		// Recipes may name an architecture as uname or Go does, e.g. aarch64 or arm64.
+		if rkey == kernelArch {
+			return types.NormalizeArch(val) == types.NormalizeArch(rvalue)
+		}
 		return strings.EqualFold(val, rvalue)
 	}
 
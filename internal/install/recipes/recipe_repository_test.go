@@ -64,6 +64,19 @@ func TestRecipeRepository_ShouldFindMatchingByRegex(t *testing.T) {
 	require.Equal(t, results[0].ID, "id1")
 }
 
+func TestRecipeRepository_ShouldMatchArchitectureAliases(t *testing.T) {
+	Setup()
+	givenCachedRecipeOsPlatformVersionArch("id1", "arm-recipe", types.OpenInstallationOperatingSystemTypes.LINUX, "", "arm64")
+	givenCachedRecipeOsPlatformVersionArch("id2", "amd64-recipe", types.OpenInstallationOperatingSystemTypes.LINUX, "", "amd64")
+	discoveryManifest.OS = "linux"
+	discoveryManifest.KernelArch = "aarch64"
+
+	results, _ := repository.FindAll()
+
+	require.Len(t, results, 1)
+	require.Equal(t, results[0].ID, "id1")
+}
+
 func TestRecipeRepository_ShouldNotFindRegexWhenMissingParenthesis(t *testing.T) {
 	Setup()
 	givenCachedRecipeOsPlatformVersionArch("id1", "my-recipe", types.OpenInstallationOperatingSystemTypes.LINUX, "10\\.?.*", "aarch64")
@@ -0,0 +1,63 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+const fetchMaxAttempts = 4
+
+// fetchRetryDelayMs is the delay before the first retry of a failed request for recipes, which
+// doubles with each retry.
+var fetchRetryDelayMs = 500
+
+// NewRetryingHTTPGetFunc returns a func that makes GET requests with the given func, retrying the
+// requests that fail or are answered with a server error with a jittered exponential backoff.
+func NewRetryingHTTPGetFunc(httpGetFunc func(string) (*http.Response, error)) func(string) (*http.Response, error) {
+	return func(requestURL string) (*http.Response, error) {
+		return retryRequest(requestURL, func() (*http.Response, error) {
+			return httpGetFunc(requestURL)
+		})
+	}
+}
+
+// retryRequest makes a request until it gets a response that isn't a server error, or the attempts
+// run out. The response to the last attempt is returned, for the caller to handle its status code.
+func retryRequest(requestURL string, do func() (*http.Response, error)) (*http.Response, error) {
+	var response *http.Response
+	attempt := 0
+
+	retry := utils.NewRetry(fetchMaxAttempts, fetchRetryDelayMs, func() error {
+		attempt++
+		if response != nil {
+			response.Body.Close()
+		}
+
+		var err error
+		response, err = do()
+		if err == nil && isTransientStatusCode(response.StatusCode) {
+			err = fmt.Errorf("received Status code %d", response.StatusCode)
+		}
+
+		if err != nil && attempt < fetchMaxAttempts {
+			log.Debugf("could not fetch %s, retrying: %s", requestURL, err)
+		}
+		return err
+	}).WithBackoff(2).WithJitter()
+
+	retryCtx := retry.ExecWithRetries(context.Background())
+	if response != nil {
+		return response, nil
+	}
+
+	return nil, retryCtx.MostRecentError()
+}
+
+func isTransientStatusCode(statusCode int) bool {
+	return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,64 @@
+package recipes
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryingHTTPGetFuncShouldRetryServerErrors(t *testing.T) {
+	defer func(delay int) { fetchRetryDelayMs = delay }(fetchRetryDelayMs)
+	fetchRetryDelayMs = 0
+
+	statusCodes := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	calls := 0
+	get := NewRetryingHTTPGetFunc(func(string) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return &http.Response{StatusCode: statusCodes[calls-2], Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	response, err := get(testRecipeURL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 4, calls)
+}
+
+func TestRetryingHTTPGetFuncShouldReturnLastResponseWhenRetriesRunOut(t *testing.T) {
+	defer func(delay int) { fetchRetryDelayMs = delay }(fetchRetryDelayMs)
+	fetchRetryDelayMs = 0
+
+	calls := 0
+	get := NewRetryingHTTPGetFunc(func(string) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	response, err := get(testRecipeURL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, fetchMaxAttempts, calls)
+}
+
+func TestRetryingHTTPGetFuncShouldNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	get := NewRetryingHTTPGetFunc(func(string) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	response, err := get(testRecipeURL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Equal(t, 1, calls)
+}
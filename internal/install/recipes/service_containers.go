@@ -0,0 +1,75 @@
+package recipes
+
+import (
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// ContainerRecipeLabel is the container label that names the recipe of the service a container runs,
+// for images discovery doesn't know.
+const ContainerRecipeLabel = "com.newrelic.recipe"
+
+// knownServiceImages are the names of the images of the services of integration recipes, without
+// their registry, repository or tag.
+var knownServiceImages = map[string][]string{
+	"mysql-open-source-integration":         {"mysql", "mariadb", "percona", "percona-server"},
+	"postgres-open-source-integration":      {"postgres", "postgresql", "postgis"},
+	"redis-open-source-integration":         {"redis", "redis-stack-server"},
+	"kafka-open-source-integration":         {"kafka", "cp-kafka", "cp-server"},
+	"mongodb-open-source-integration":       {"mongo", "mongodb", "mongodb-community-server"},
+	"rabbitmq-open-source-integration":      {"rabbitmq"},
+	"elasticsearch-open-source-integration": {"elasticsearch"},
+	"memcached-open-source-integration":     {"memcached"},
+	"cassandra-open-source-integration":     {"cassandra"},
+	"couchbase-open-source-integration":     {"couchbase", "couchbase-server"},
+	"nginx-open-source-integration":         {"nginx", "nginx-unprivileged"},
+	"apache-open-source-integration":        {"httpd", "apache"},
+}
+
+// ServiceContainers returns the containers that run the service of a recipe, by its label, image
+// or the default ports of the service.
+func ServiceContainers(recipeName string, containers []types.DockerContainer) []types.DockerContainer {
+	matched := []types.DockerContainer{}
+	for _, c := range containers {
+		if runsService(recipeName, c) {
+			matched = append(matched, c)
+		}
+	}
+
+	return matched
+}
+
+func runsService(recipeName string, c types.DockerContainer) bool {
+	if recipe, ok := c.Labels[ContainerRecipeLabel]; ok {
+		return recipe == recipeName
+	}
+
+	image := imageName(c.Image)
+	for _, i := range knownServiceImages[recipeName] {
+		if image == i {
+			return true
+		}
+	}
+
+	for _, p := range c.Ports {
+		for _, servicePort := range knownServicePorts[recipeName] {
+			if p == servicePort {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// imageName returns the name of an image reference, such as mysql for docker.io/library/mysql:8.0.
+func imageName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+
+	return strings.ToLower(image)
+}
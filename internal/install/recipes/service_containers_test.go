@@ -0,0 +1,46 @@
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestServiceContainersShouldMatchServicesRunningInContainers(t *testing.T) {
+	containers := []types.DockerContainer{
+		{Name: "db", Image: "docker.io/library/mariadb:10.11"},
+		{Name: "cache", Image: "registry.example.com/platform/cache@sha256:9f86d08", Ports: []uint32{6379}},
+		{Name: "queue", Image: "ghcr.io/acme/queue:1.0", Labels: map[string]string{ContainerRecipeLabel: "kafka-open-source-integration"}},
+		{Name: "postgres-proxy", Image: "postgres:15", Labels: map[string]string{ContainerRecipeLabel: "pgbouncer-integration"}},
+	}
+
+	require.Equal(t, "db", ServiceContainers("mysql-open-source-integration", containers)[0].Name)
+	require.Equal(t, "cache", ServiceContainers("redis-open-source-integration", containers)[0].Name)
+	require.Equal(t, "queue", ServiceContainers("kafka-open-source-integration", containers)[0].Name)
+	require.Equal(t, "postgres-proxy", ServiceContainers("pgbouncer-integration", containers)[0].Name)
+	require.Empty(t, ServiceContainers("postgres-open-source-integration", containers))
+}
+
+func TestImageNameShouldStripRegistryRepositoryAndTag(t *testing.T) {
+	require.Equal(t, "mysql", imageName("mysql"))
+	require.Equal(t, "mysql", imageName("docker.io/library/mysql:8.0"))
+	require.Equal(t, "cp-kafka", imageName("confluentinc/cp-kafka:7.5.0"))
+	require.Equal(t, "redis", imageName("localhost:5000/redis@sha256:9f86d08"))
+}
+
+func TestRecipeDetectorShouldRecommendRecipesOfServicesInContainers(t *testing.T) {
+	recipe := NewRecipeBuilder().Name("redis-open-source-integration").Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.containers = []types.DockerContainer{{Name: "cache", Image: "redis:7"}}
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
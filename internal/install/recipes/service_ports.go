@@ -0,0 +1,43 @@
+package recipes
+
+import (
+	"strconv"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// knownServicePorts are the default ports of the services of integration recipes, which recommend
+// the recipes of services running under generic process names or inside wrappers.
+var knownServicePorts = map[string][]uint32{
+	"mysql-open-source-integration":         {3306},
+	"postgres-open-source-integration":      {5432},
+	"redis-open-source-integration":         {6379},
+	"kafka-open-source-integration":         {9092},
+	"mongodb-open-source-integration":       {27017},
+	"rabbitmq-open-source-integration":      {5672},
+	"elasticsearch-open-source-integration": {9200},
+	"memcached-open-source-integration":     {11211},
+	"cassandra-open-source-integration":     {9042},
+	"couchbase-open-source-integration":     {8091},
+}
+
+// ServicePortMatchers returns a port matcher for the default port of each known service.
+func ServicePortMatchers() []types.DiscoveryMatcher {
+	matchers := []types.DiscoveryMatcher{}
+	for recipe, ports := range knownServicePorts {
+		for _, p := range ports {
+			matchers = append(matchers, types.DiscoveryMatcher{
+				Recipe: recipe,
+				Type:   MatcherTypePort,
+				Value:  strconv.Itoa(int(p)),
+			})
+		}
+	}
+
+	return matchers
+}
+
+// ServicePorts returns the default ports of the service of a recipe, if it's a known service.
+func ServicePorts(recipeName string) []uint32 {
+	return knownServicePorts[recipeName]
+}
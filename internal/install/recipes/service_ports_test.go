@@ -0,0 +1,41 @@
+package recipes
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestServicePortMatchersShouldMatchListeningServices(t *testing.T) {
+	matchers, err := NewMatcherRegistry().Build(ServicePortMatchers())
+	require.NoError(t, err)
+
+	host := &MatchHost{
+		ListeningPorts: func(context.Context) ([]uint32, error) {
+			return []uint32{22, 3306, 9092}, nil
+		},
+	}
+
+	require.True(t, matchers["mysql-open-source-integration"][0].Matches(context.Background(), host))
+	require.True(t, matchers["kafka-open-source-integration"][0].Matches(context.Background(), host))
+	require.False(t, matchers["redis-open-source-integration"][0].Matches(context.Background(), host))
+	require.Equal(t, []uint32{6379}, ServicePorts("redis-open-source-integration"))
+}
+
+func TestLocalMatchHostShouldListListeningPorts(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	host := NewLocalMatchHost(func(context.Context) []types.GenericProcess {
+		return []types.GenericProcess{}
+	})
+	ports, err := host.ListeningPorts(context.Background())
+
+	require.NoError(t, err)
+	require.Contains(t, ports, uint32(l.Addr().(*net.TCPAddr).Port))
+}
@@ -0,0 +1,222 @@
+package recipes
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Detached signatures are published next to the signed file, with these extensions.
+const (
+	CosignSignatureExtension = ".sig"
+	GPGSignatureExtension    = ".asc"
+)
+
+// SignatureVerifier verifies the detached cosign or GPG signature of a downloaded recipe file
+// with the keys of the trust store, before the recipe is used.
+type SignatureVerifier struct {
+	HTTPGetFunc   func(string) (*http.Response, error)
+	trustStore    *TrustStore
+	required      bool
+	gpgVerifyFunc func(keys []*TrustedKey, data []byte, signature []byte) error
+}
+
+// NewSignatureVerifier returns a verifier that checks the signatures of recipes using the keys of the
+// trust store. When required, recipes without a valid signature are rejected, otherwise recipes are
+// only rejected when their signature doesn't match.
+func NewSignatureVerifier(trustStore *TrustStore, required bool) *SignatureVerifier {
+	return &SignatureVerifier{
+		HTTPGetFunc:   defaultHTTPGetFunc,
+		trustStore:    trustStore,
+		required:      required,
+		gpgVerifyFunc: gpgVerify,
+	}
+}
+
+// Verify checks the signature published next to fileURL against the contents of the file.
+func (v *SignatureVerifier) Verify(fileURL string, data []byte) error {
+	keys, err := v.trustStore.List()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		if v.required {
+			return fmt.Errorf("could not verify the signature of %s: no trusted keys, add one with \"newrelic install trust add\"", fileURL)
+		}
+		return nil
+	}
+
+	signature, found, err := v.fetchSignature(fileURL + CosignSignatureExtension)
+	if err != nil {
+		return err
+	}
+	if found {
+		if err := cosignVerify(keysOfType(keys, TrustedKeyTypeCosign), data, signature); err != nil {
+			return fmt.Errorf("invalid cosign signature for %s: %w", fileURL, err)
+		}
+		log.Debugf("Verified the cosign signature of %s", fileURL)
+		return nil
+	}
+
+	signature, found, err = v.fetchSignature(fileURL + GPGSignatureExtension)
+	if err != nil {
+		return err
+	}
+	if found {
+		if err := v.gpgVerifyFunc(keysOfType(keys, TrustedKeyTypeGPG), data, signature); err != nil {
+			return fmt.Errorf("invalid GPG signature for %s: %w", fileURL, err)
+		}
+		log.Debugf("Verified the GPG signature of %s", fileURL)
+		return nil
+	}
+
+	if v.required {
+		return fmt.Errorf("%s is not signed, no %s or %s signature was found", fileURL, CosignSignatureExtension, GPGSignatureExtension)
+	}
+
+	log.Debugf("%s is not signed, using it without verifying it", fileURL)
+	return nil
+}
+
+func (v *SignatureVerifier) fetchSignature(signatureURL string) ([]byte, bool, error) {
+	response, err := v.HTTPGetFunc(signatureURL)
+	if err != nil {
+		log.Debugf("could not fetch signature %s: %s", signatureURL, err)
+		return nil, false, nil
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusForbidden {
+		return nil, false, nil
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, false, fmt.Errorf("received non-2xx Status code %d when retrieving signature %s", response.StatusCode, signatureURL)
+	}
+
+	signature, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return signature, true, nil
+}
+
+func keysOfType(keys []*TrustedKey, keyType string) []*TrustedKey {
+	matching := []*TrustedKey{}
+	for _, k := range keys {
+		if k.Type == keyType {
+			matching = append(matching, k)
+		}
+	}
+
+	return matching
+}
+
+// cosignVerify verifies a base64 encoded signature, as written by "cosign sign-blob --key", with
+// any of the keys.
+func cosignVerify(keys []*TrustedKey, data []byte, signature []byte) error {
+	if len(keys) == 0 {
+		return errors.New("no trusted cosign keys")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("could not decode signature: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	for _, k := range keys {
+		block, _ := pem.Decode(k.Data)
+		if block == nil {
+			continue
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			continue
+		}
+
+		switch key := pub.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest[:], sig) {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, data, sig) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("the signature does not match any trusted cosign key")
+}
+
+// gpgVerify verifies an armored detached signature with the gpg binary, using a keyring of only the
+// trusted keys.
+func gpgVerify(keys []*TrustedKey, data []byte, signature []byte) error {
+	if len(keys) == 0 {
+		return errors.New("no trusted GPG keys")
+	}
+
+	home, err := os.MkdirTemp("", "newrelic-gpg")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(home)
+
+	for _, k := range keys {
+		keyFile := filepath.Join(home, k.Name+".asc")
+		if err := os.WriteFile(keyFile, k.Data, 0600); err != nil {
+			return err
+		}
+
+		if err := runGPG(home, "--import", keyFile); err != nil {
+			return fmt.Errorf("could not import trusted key %s: %w", k.Name, err)
+		}
+	}
+
+	dataFile := filepath.Join(home, "data")
+	signatureFile := dataFile + GPGSignatureExtension
+	if err := os.WriteFile(dataFile, data, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(signatureFile, signature, 0600); err != nil {
+		return err
+	}
+
+	if err := runGPG(home, "--verify", signatureFile, dataFile); err != nil {
+		return fmt.Errorf("the signature does not match any trusted GPG key: %w", err)
+	}
+
+	return nil
+}
+
+func runGPG(home string, args ...string) error {
+	out, err := exec.Command("gpg", append([]string{"--batch", "--homedir", home}, args...)...).CombinedOutput()
+	if err != nil && len(strings.TrimSpace(string(out))) > 0 {
+		return errors.New(strings.TrimSpace(string(out)))
+	}
+
+	return err
+}
@@ -0,0 +1,111 @@
+package recipes
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRecipeURL = "https://localhost/recipe.yml"
+
+func newTestSignatureVerifier(t *testing.T, required bool, signatures map[string]string) (*SignatureVerifier, *TrustStore) {
+	s := NewTrustStore(t.TempDir())
+	v := NewSignatureVerifier(s, required)
+	v.HTTPGetFunc = func(url string) (*http.Response, error) {
+		signature, ok := signatures[url]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(signature)))}, nil
+	}
+
+	return v, s
+}
+
+func signTestRecipe(t *testing.T, key *ecdsa.PrivateKey, data []byte) string {
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestSignatureVerifierShouldAcceptValidCosignSignature(t *testing.T) {
+	data := []byte("name: recipe1")
+	key, pub := newTestCosignKey(t)
+	v, s := newTestSignatureVerifier(t, true, map[string]string{
+		testRecipeURL + CosignSignatureExtension: signTestRecipe(t, key, data),
+	})
+	_, err := s.Add("team-a", pub)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(testRecipeURL, data))
+}
+
+func TestSignatureVerifierShouldRejectTamperedRecipe(t *testing.T) {
+	key, pub := newTestCosignKey(t)
+	v, s := newTestSignatureVerifier(t, false, map[string]string{
+		testRecipeURL + CosignSignatureExtension: signTestRecipe(t, key, []byte("name: recipe1")),
+	})
+	_, err := s.Add("team-a", pub)
+	require.NoError(t, err)
+
+	err = v.Verify(testRecipeURL, []byte("name: tampered"))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid cosign signature")
+}
+
+func TestSignatureVerifierShouldRequireSignature(t *testing.T) {
+	_, pub := newTestCosignKey(t)
+	v, s := newTestSignatureVerifier(t, true, map[string]string{})
+
+	err := v.Verify(testRecipeURL, []byte("name: recipe1"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no trusted keys")
+
+	_, err = s.Add("team-a", pub)
+	require.NoError(t, err)
+
+	err = v.Verify(testRecipeURL, []byte("name: recipe1"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not signed")
+}
+
+func TestSignatureVerifierShouldAllowUnsignedRecipesWhenNotRequired(t *testing.T) {
+	_, pub := newTestCosignKey(t)
+	v, s := newTestSignatureVerifier(t, false, map[string]string{})
+	_, err := s.Add("team-a", pub)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(testRecipeURL, []byte("name: recipe1")))
+}
+
+func TestSignatureVerifierShouldVerifyGPGSignature(t *testing.T) {
+	gpgKey := []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nabc\n-----END PGP PUBLIC KEY BLOCK-----\n")
+	v, s := newTestSignatureVerifier(t, true, map[string]string{
+		testRecipeURL + GPGSignatureExtension: "signature",
+	})
+	_, err := s.Add("team-a", gpgKey)
+	require.NoError(t, err)
+
+	verified := []string{}
+	v.gpgVerifyFunc = func(keys []*TrustedKey, data []byte, signature []byte) error {
+		verified = append(verified, keys[0].Name)
+		if string(signature) != "signature" {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+
+	require.NoError(t, v.Verify(testRecipeURL, []byte("name: recipe1")))
+	require.Equal(t, []string{"team-a"}, verified)
+}
@@ -0,0 +1,65 @@
+package recipes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// serviceProcess is the process a service starts, to match recipes against while it isn't running.
+type serviceProcess struct {
+	index int
+	cmd   string
+}
+
+func (p serviceProcess) Name() (string, error) {
+	name, _, _ := strings.Cut(p.cmd, " ")
+	return name, nil
+}
+
+func (p serviceProcess) Cmd() (string, error) {
+	return p.cmd, nil
+}
+
+func (p serviceProcess) PID() int32 {
+	return 0
+}
+
+// ServiceUnits returns the systemd units that start a process matching the recipe's process
+// patterns, whether or not they're running.
+func ServiceUnits(ctx context.Context, r *types.OpenInstallationRecipe, units []types.SystemdUnit) []types.SystemdUnit {
+	cmds := make([]string, len(units))
+	for idx, u := range units {
+		cmds[idx] = u.ExecStart
+	}
+
+	matched := []types.SystemdUnit{}
+	for _, idx := range matchServiceCommands(ctx, r, cmds) {
+		matched = append(matched, units[idx])
+	}
+
+	return matched
+}
+
+// matchServiceCommands returns the indexes of the service command lines matching the recipe's
+// process patterns.
+func matchServiceCommands(ctx context.Context, r *types.OpenInstallationRecipe, cmds []string) []int {
+	matched := []int{}
+	if len(r.ProcessMatch) == 0 {
+		return matched
+	}
+
+	processes := []types.GenericProcess{}
+	for idx, cmd := range cmds {
+		if cmd != "" {
+			processes = append(processes, serviceProcess{index: idx, cmd: cmd})
+		}
+	}
+
+	for _, m := range NewRegexProcessMatchFinder().FindMatches(ctx, processes, *r) {
+		matched = append(matched, m.GenericProcess.(serviceProcess).index)
+	}
+
+	return matched
+}
@@ -0,0 +1,40 @@
+package recipes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestServiceUnitsShouldMatchUnitsStartingRecipeProcesses(t *testing.T) {
+	units := []types.SystemdUnit{
+		{Name: "mysql.service", ExecStart: "/usr/sbin/mysqld", ActiveState: "inactive"},
+		{Name: "cups.service", ExecStart: "/usr/sbin/cupsd -l"},
+		{Name: "empty.service"},
+	}
+	recipe := NewRecipeBuilder().Name("mysql-open-source-integration").ProcessMatch("mysqld").Build()
+
+	matched := ServiceUnits(context.Background(), recipe, units)
+
+	require.Len(t, matched, 1)
+	require.Equal(t, "mysql.service", matched[0].Name)
+	require.Empty(t, ServiceUnits(context.Background(), NewRecipeBuilder().Build(), units))
+}
+
+func TestRecipeDetectorShouldRecommendRecipesOfIdleSystemdUnits(t *testing.T) {
+	recipe := NewRecipeBuilder().Name("mysql-open-source-integration").ProcessMatch("mysqld").Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.systemdUnits = []types.SystemdUnit{{Name: "mysql.service", ExecStart: "/usr/sbin/mysqld", ActiveState: "inactive"}}
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
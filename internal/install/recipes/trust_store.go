@@ -0,0 +1,168 @@
+package recipes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/config"
+)
+
+const DefaultTrustStoreDir = "trusted-keys"
+
+// The types of keys recipe signatures can be verified with.
+const (
+	TrustedKeyTypeCosign = "cosign"
+	TrustedKeyTypeGPG    = "gpg"
+)
+
+var (
+	trustedKeyExtensions = map[string]string{
+		TrustedKeyTypeCosign: ".pub",
+		TrustedKeyTypeGPG:    ".asc",
+	}
+	trustedKeyNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	pgpPublicKeyHeader    = []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")
+)
+
+// TrustStore holds the public keys that recipe signatures are verified with, each stored as a
+// file named after the key.
+type TrustStore struct {
+	Path string
+}
+
+// TrustedKey is a public key of the trust store.
+type TrustedKey struct {
+	Name        string
+	Type        string
+	Fingerprint string
+	Data        []byte
+}
+
+func GetDefaultTrustStorePath() string {
+	return filepath.Join(config.BasePath, DefaultTrustStoreDir)
+}
+
+func NewTrustStore(path string) *TrustStore {
+	return &TrustStore{
+		Path: path,
+	}
+}
+
+// Add stores a cosign PEM public key or an armored GPG public key under the given name,
+// replacing any key with the same name.
+func (s *TrustStore) Add(name string, data []byte) (*TrustedKey, error) {
+	if !trustedKeyNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid key name %q, use letters, digits, dots, dashes and underscores", name)
+	}
+
+	keyType, err := trustedKeyType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Remove(name); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(s.Path, 0750); err != nil {
+		return nil, fmt.Errorf("could not create trust store %s: %w", s.Path, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.Path, name+trustedKeyExtensions[keyType]), data, 0600); err != nil {
+		return nil, fmt.Errorf("could not add key %s to the trust store: %w", name, err)
+	}
+
+	return newTrustedKey(name, keyType, data), nil
+}
+
+// Remove deletes the key with the given name.
+func (s *TrustStore) Remove(name string) error {
+	removed := false
+	for _, ext := range trustedKeyExtensions {
+		err := os.Remove(filepath.Join(s.Path, name+ext))
+		if err == nil {
+			removed = true
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if !removed {
+		return os.ErrNotExist
+	}
+
+	return nil
+}
+
+// List returns the keys of the trust store, ordered by name.
+func (s *TrustStore) List() ([]*TrustedKey, error) {
+	entries, err := os.ReadDir(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*TrustedKey{}, nil
+		}
+		return nil, fmt.Errorf("could not read trust store %s: %w", s.Path, err)
+	}
+
+	keys := []*TrustedKey{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		for keyType, ext := range trustedKeyExtensions {
+			if !strings.HasSuffix(e.Name(), ext) {
+				continue
+			}
+
+			data, err := os.ReadFile(filepath.Join(s.Path, e.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("could not read trusted key %s: %w", e.Name(), err)
+			}
+
+			keys = append(keys, newTrustedKey(strings.TrimSuffix(e.Name(), ext), keyType, data))
+		}
+	}
+
+	sort.Slice(keys, func(a, b int) bool {
+		return keys[a].Name < keys[b].Name
+	})
+
+	return keys, nil
+}
+
+func newTrustedKey(name string, keyType string, data []byte) *TrustedKey {
+	sum := sha256.Sum256(data)
+
+	return &TrustedKey{
+		Name:        name,
+		Type:        keyType,
+		Fingerprint: hex.EncodeToString(sum[:]),
+		Data:        data,
+	}
+}
+
+func trustedKeyType(data []byte) (string, error) {
+	if bytes.Contains(data, pgpPublicKeyHeader) {
+		return TrustedKeyTypeGPG, nil
+	}
+
+	block, _ := pem.Decode(data)
+	if block != nil && block.Type == "PUBLIC KEY" {
+		if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return "", fmt.Errorf("could not parse public key: %w", err)
+		}
+		return TrustedKeyTypeCosign, nil
+	}
+
+	return "", fmt.Errorf("unsupported key, expected a cosign PEM public key or an armored GPG public key")
+}
@@ -0,0 +1,68 @@
+package recipes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCosignKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestTrustStoreShouldAddListAndRemoveKeys(t *testing.T) {
+	_, cosignKey := newTestCosignKey(t)
+	gpgKey := []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nabc\n-----END PGP PUBLIC KEY BLOCK-----\n")
+	s := NewTrustStore(t.TempDir())
+
+	_, err := s.Add("team-b", gpgKey)
+	require.NoError(t, err)
+	added, err := s.Add("team-a", cosignKey)
+	require.NoError(t, err)
+	require.Equal(t, TrustedKeyTypeCosign, added.Type)
+
+	keys, err := s.List()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	require.Equal(t, "team-a", keys[0].Name)
+	require.Equal(t, added.Fingerprint, keys[0].Fingerprint)
+	require.Equal(t, TrustedKeyTypeGPG, keys[1].Type)
+
+	require.NoError(t, s.Remove("team-a"))
+	require.True(t, os.IsNotExist(s.Remove("team-a")))
+
+	keys, err = s.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+}
+
+func TestTrustStoreShouldRejectUnsupportedKeys(t *testing.T) {
+	s := NewTrustStore(t.TempDir())
+
+	_, err := s.Add("team-a", []byte("not a key"))
+	require.Error(t, err)
+
+	_, err = s.Add("../team-a", []byte("not a key"))
+	require.Error(t, err)
+}
+
+func TestTrustStoreShouldListNothingWhenMissing(t *testing.T) {
+	s := NewTrustStore(t.TempDir() + "/missing")
+
+	keys, err := s.List()
+
+	require.NoError(t, err)
+	require.Equal(t, 0, len(keys))
+}
@@ -0,0 +1,48 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+// The recipes of the Windows services discovery finds without their processes.
+const (
+	IISRecipeName   = "iis-open-source-integration"
+	MSSQLRecipeName = "mssql-server-integration-installer"
+)
+
+// WindowsServiceMatch returns why the services of a Windows host recommend the recipe, or "" when
+// they don't. Disabled services never recommend their recipes.
+func WindowsServiceMatch(ctx context.Context, r *types.OpenInstallationRecipe, h *types.WindowsHost) string {
+	if h == nil {
+		return ""
+	}
+
+	switch {
+	case r.Name == IISRecipeName && len(h.IISSites) > 0:
+		return fmt.Sprintf("IIS serves the %s website", h.IISSites[0].Name)
+	case r.Name == MSSQLRecipeName && len(h.SQLServerInstances) > 0:
+		return fmt.Sprintf("the %s SQL Server instance is installed", h.SQLServerInstances[0])
+	}
+
+	services := []types.WindowsService{}
+	for _, s := range h.Services {
+		if !strings.EqualFold(s.StartMode, "Disabled") {
+			services = append(services, s)
+		}
+	}
+
+	cmds := make([]string, len(services))
+	for idx, s := range services {
+		cmds[idx] = s.PathName
+	}
+
+	if matched := matchServiceCommands(ctx, r, cmds); len(matched) > 0 {
+		return fmt.Sprintf("its service is installed as the %s Windows service", services[matched[0]].Name)
+	}
+
+	return ""
+}
@@ -0,0 +1,44 @@
+package recipes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestWindowsServiceMatchShouldRecommendWindowsRecipes(t *testing.T) {
+	h := &types.WindowsHost{
+		Services: []types.WindowsService{
+			{Name: "MySQL80", StartMode: "Auto", State: "Stopped", PathName: `"C:\Program Files\MySQL\MySQL Server 8.0\bin\mysqld.exe" --defaults-file="C:\ProgramData\MySQL\my.ini" MySQL80`},
+			{Name: "Redis", StartMode: "Disabled", PathName: `"C:\Program Files\Redis\redis-server.exe" --service-run`},
+		},
+		IISSites:           []types.IISSite{{Name: "Default Web Site"}},
+		SQLServerInstances: []string{"MSSQLSERVER"},
+	}
+	mysql := NewRecipeBuilder().Name("mysql-open-source-integration").ProcessMatch("mysqld").Build()
+	redis := NewRecipeBuilder().Name("redis-open-source-integration").ProcessMatch("redis-server").Build()
+
+	require.Equal(t, "IIS serves the Default Web Site website", WindowsServiceMatch(context.Background(), NewRecipeBuilder().Name(IISRecipeName).Build(), h))
+	require.Equal(t, "the MSSQLSERVER SQL Server instance is installed", WindowsServiceMatch(context.Background(), NewRecipeBuilder().Name(MSSQLRecipeName).Build(), h))
+	require.Equal(t, "its service is installed as the MySQL80 Windows service", WindowsServiceMatch(context.Background(), mysql, h))
+	require.Empty(t, WindowsServiceMatch(context.Background(), redis, h))
+	require.Empty(t, WindowsServiceMatch(context.Background(), mysql, nil))
+}
+
+func TestRecipeDetectorShouldRecommendRecipesOfWindowsServices(t *testing.T) {
+	recipe := NewRecipeBuilder().Name(IISRecipeName).Build()
+	b := NewRecipeDetectorTestBuilder()
+	b.WithProcessEvaluatorRecipeStatus(recipe, execution.RecipeStatusTypes.NULL)
+	detector := b.Build()
+	detector.windowsHost = &types.WindowsHost{IISSites: []types.IISSite{{Name: "Default Web Site"}}}
+
+	a, _, _ := detector.GetDetectedRecipes()
+	actual, ok := a.GetRecipeDetection(recipe.Name)
+
+	require.True(t, ok)
+	require.Equal(t, execution.RecipeStatusTypes.AVAILABLE, actual.Status)
+}
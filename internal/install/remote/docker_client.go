@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DockerClient runs scripts in a Docker container with the system's docker client, such as a
+// container started to test a recipe in.
+type DockerClient struct {
+	container  string
+	dockerPath string
+}
+
+// StartDockerContainer starts a container of the image that keeps running until it's removed,
+// whatever the command of the image.
+func StartDockerContainer(ctx context.Context, image string) (*DockerClient, error) {
+	c := &DockerClient{
+		dockerPath: "docker",
+	}
+
+	out, err := exec.CommandContext(ctx, c.dockerPath, "run", "--detach", "--rm", "--entrypoint", "tail", image, "-f", "/dev/null").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("could not start a container of %s: %w: %s", image, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("could not start a container of %s: %w", image, err)
+	}
+
+	c.container = strings.TrimSpace(string(out))
+	log.Debugf("started container %s of %s", c.container, image)
+
+	return c, nil
+}
+
+func (c *DockerClient) Target() Target {
+	container := c.container
+	if len(container) > 12 {
+		container = container[:12]
+	}
+
+	return Target{User: "root", Host: container, Port: defaultSSHPort}
+}
+
+// Run runs the script with sh in the container. The script is sent over stdin, like it is to
+// remote hosts.
+func (c *DockerClient) Run(ctx context.Context, script string, stdout io.Writer, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, c.dockerPath, "exec", "--interactive", c.container, "sh", "-s")
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	log.WithFields(log.Fields{
+		"container": c.container,
+	}).Trace("running container script")
+
+	return cmd.Run()
+}
+
+// Remove stops and removes the container.
+func (c *DockerClient) Remove(ctx context.Context) error {
+	if out, err := exec.CommandContext(ctx, c.dockerPath, "rm", "--force", c.container).CombinedOutput(); err != nil {
+		return fmt.Errorf("could not remove container %s: %w: %s", c.container, err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+type MockClient struct {
+	MockTarget Target
+	// Outputs are written to stdout for the scripts containing each key.
+	Outputs map[string]string
+	// Errors are returned for the scripts containing each key.
+	Errors       map[string]error
+	Scripts      []string
+	RunCallCount int
+}
+
+func NewMockClient() *MockClient {
+	return &MockClient{
+		MockTarget: Target{User: "root", Host: "example.com", Port: defaultSSHPort},
+		Outputs:    map[string]string{},
+		Errors:     map[string]error{},
+	}
+}
+
+func (c *MockClient) Target() Target {
+	return c.MockTarget
+}
+
+func (c *MockClient) Run(ctx context.Context, script string, stdout io.Writer, stderr io.Writer) error {
+	c.RunCallCount++
+	c.Scripts = append(c.Scripts, script)
+
+	for k, v := range c.Outputs {
+		if strings.Contains(script, k) {
+			_, _ = io.WriteString(stdout, v)
+		}
+	}
+
+	for k, err := range c.Errors {
+		if strings.Contains(script, k) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package remote
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+const processListScript = "ps -eo pid=,comm=,args="
+
+// Process is a process running on a remote host.
+type Process struct {
+	pid  int32
+	name string
+	cmd  string
+}
+
+func (p Process) Name() (string, error) {
+	return p.name, nil
+}
+
+func (p Process) Cmd() (string, error) {
+	return p.cmd, nil
+}
+
+func (p Process) PID() int32 {
+	return p.pid
+}
+
+// Processes returns the processes running on the remote host, so recipes can be
+// matched against them.
+func Processes(ctx context.Context, c Client) []types.GenericProcess {
+	processes := []types.GenericProcess{}
+
+	out, err := Output(ctx, c, processListScript)
+	if err != nil {
+		log.Errorf("cannot retrieve processes from %s: %s", c.Target(), err)
+		return processes
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		pid, err := strconv.ParseInt(fields[0], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		p := Process{
+			pid:  int32(pid),
+			name: fields[1],
+			cmd:  strings.Join(fields[2:], " "),
+		}
+		processes = append(processes, p)
+	}
+
+	return processes
+}
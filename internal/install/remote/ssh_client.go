@@ -0,0 +1,88 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Client runs shell scripts on a remote host.
+type Client interface {
+	Run(ctx context.Context, script string, stdout io.Writer, stderr io.Writer) error
+	Target() Target
+}
+
+// SSHClient runs scripts on a remote host with the system's ssh client, so the user's
+// ssh configuration, agent and known hosts apply.
+type SSHClient struct {
+	target  Target
+	keyPath string
+	sshPath string
+}
+
+func NewSSHClient(target Target, keyPath string) *SSHClient {
+	return &SSHClient{
+		target:  target,
+		keyPath: keyPath,
+		sshPath: "ssh",
+	}
+}
+
+func (c *SSHClient) Target() Target {
+	return c.target
+}
+
+// Run runs the script with sh on the remote host. The script is sent over stdin, since the
+// install isn't interactive on remote hosts.
+func (c *SSHClient) Run(ctx context.Context, script string, stdout io.Writer, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, c.sshPath, c.args()...)
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	log.WithFields(log.Fields{
+		"target": c.target.String(),
+	}).Trace("running remote script")
+
+	return cmd.Run()
+}
+
+func (c *SSHClient) args() []string {
+	args := []string{
+		"-o", "BatchMode=yes",
+		"-p", strconv.Itoa(c.target.Port),
+	}
+
+	if c.keyPath != "" {
+		args = append(args, "-i", c.keyPath)
+	}
+
+	return append(args, "--", fmt.Sprintf("%s@%s", c.target.User, c.target.Host), "sh", "-s")
+}
+
+// Output runs the script on the remote host and returns what it wrote to stdout.
+func Output(ctx context.Context, c Client, script string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	if err := c.Run(ctx, script, stdout, stderr); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
+
+// ShellQuote quotes a value to be used as a single word in a remote script.
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,59 @@
+package remote
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultSSHPort = 22
+
+// Target is a remote host to install onto, given as user@host[:port].
+type Target struct {
+	User string
+	Host string
+	Port int
+}
+
+// ParseTarget parses a target of the form user@host[:port].
+func ParseTarget(target string) (*Target, error) {
+	parts := strings.SplitN(target, "@", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid target %q, expected user@host[:port]", target)
+	}
+
+	t := Target{
+		User: parts[0],
+		Host: parts[1],
+		Port: defaultSSHPort,
+	}
+
+	if i := strings.LastIndex(t.Host, ":"); i >= 0 {
+		port, err := strconv.Atoi(t.Host[i+1:])
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port in target %q", target)
+		}
+
+		t.Host = t.Host[:i]
+		t.Port = port
+	}
+
+	if t.Host == "" {
+		return nil, fmt.Errorf("invalid target %q, expected user@host[:port]", target)
+	}
+
+	// A user or host starting with - would be read by ssh as an option.
+	if strings.HasPrefix(t.User, "-") || strings.HasPrefix(t.Host, "-") {
+		return nil, fmt.Errorf("invalid target %q, the user and host can't start with -", target)
+	}
+
+	return &t, nil
+}
+
+func (t Target) String() string {
+	if t.Port == defaultSSHPort {
+		return fmt.Sprintf("%s@%s", t.User, t.Host)
+	}
+
+	return fmt.Sprintf("%s@%s:%d", t.User, t.Host, t.Port)
+}
@@ -0,0 +1,71 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	target, err := ParseTarget("ubuntu@10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, Target{User: "ubuntu", Host: "10.0.0.1", Port: 22}, *target)
+	assert.Equal(t, "ubuntu@10.0.0.1", target.String())
+
+	target, err = ParseTarget("ec2-user@web-1.example.com:2222")
+	require.NoError(t, err)
+	assert.Equal(t, Target{User: "ec2-user", Host: "web-1.example.com", Port: 2222}, *target)
+	assert.Equal(t, "ec2-user@web-1.example.com:2222", target.String())
+}
+
+func TestParseTargetShouldFailForInvalidTargets(t *testing.T) {
+	for _, target := range []string{"", "host", "@host", "user@", "user@host:ssh", "user@host:70000", "user@:22", "-oProxyCommand=touch@host", "user@-oProxyCommand=touch"} {
+		_, err := ParseTarget(target)
+		assert.Error(t, err, target)
+	}
+}
+
+func TestSSHClientArgs(t *testing.T) {
+	c := NewSSHClient(Target{User: "ubuntu", Host: "10.0.0.1", Port: 2222}, "/home/me/.ssh/id_ed25519")
+
+	assert.Equal(t, []string{
+		"-o", "BatchMode=yes",
+		"-p", "2222",
+		"-i", "/home/me/.ssh/id_ed25519",
+		"--", "ubuntu@10.0.0.1", "sh", "-s",
+	}, c.args())
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'it'\''s'`, ShellQuote("it's"))
+	assert.Equal(t, `'$HOME'`, ShellQuote("$HOME"))
+}
+
+func TestProcesses(t *testing.T) {
+	c := NewMockClient()
+	c.Outputs[processListScript] = `    1 systemd         /sbin/init splash
+  842 mysqld          /usr/sbin/mysqld --daemonize
+invalid line
+`
+
+	processes := Processes(context.Background(), c)
+
+	require.Len(t, processes, 2)
+	name, _ := processes[1].Name()
+	cmd, _ := processes[1].Cmd()
+	assert.Equal(t, int32(842), processes[1].PID())
+	assert.Equal(t, "mysqld", name)
+	assert.Equal(t, "/usr/sbin/mysqld --daemonize", cmd)
+}
+
+func TestProcessesShouldBeEmptyWhenListingFails(t *testing.T) {
+	c := NewMockClient()
+	c.Errors[processListScript] = errors.New("connection refused")
+
+	processes := Processes(context.Background(), c)
+
+	assert.Empty(t, processes)
+}
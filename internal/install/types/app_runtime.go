@@ -0,0 +1,29 @@
+package types
+
+// The languages of the application runtimes discovery detects.
+const (
+	RuntimeJava   = "java"
+	RuntimeNode   = "node"
+	RuntimePython = "python"
+	RuntimeRuby   = "ruby"
+	RuntimeDotnet = "dotnet"
+	RuntimePHP    = "php"
+	RuntimeGo     = "go"
+)
+
+// RuntimeLanguages are the languages of the application runtimes discovery detects.
+var RuntimeLanguages = []string{RuntimeJava, RuntimeNode, RuntimePython, RuntimeRuby, RuntimeDotnet, RuntimePHP, RuntimeGo}
+
+// AppRuntime is an application running on the host, with what its process tells of it. The values
+// the process doesn't tell are empty.
+type AppRuntime struct {
+	PID      int32  `json:"pid"`
+	Language string `json:"language"`
+	Version  string `json:"version,omitempty"`
+	// AppServer is the server or framework the application runs in, such as tomcat or gunicorn.
+	AppServer string `json:"appServer,omitempty"`
+	// ServiceName is a guess of the name of the application, such as the name of its jar or script,
+	// to name it with in APM.
+	ServiceName string `json:"serviceName,omitempty"`
+	Command     string `json:"command,omitempty"`
+}
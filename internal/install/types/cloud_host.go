@@ -0,0 +1,37 @@
+package types
+
+// The tags added to the install and its entities for the cloud instance the host runs on.
+const (
+	CloudProviderTagKey   = "cloud.provider"
+	CloudRegionTagKey     = "cloud.region"
+	CloudAccountTagKey    = "cloud.account.id"
+	CloudInstanceIDTagKey = "cloud.instance.id"
+)
+
+// CloudHost is the cloud instance the host runs on, as read from the provider's instance
+// metadata service.
+type CloudHost struct {
+	// Provider is aws, gcp or azure.
+	Provider   string `json:"provider"`
+	InstanceID string `json:"instanceId"`
+	Region     string `json:"region"`
+	// AccountID is the AWS account, GCP project or Azure subscription of the instance.
+	AccountID string `json:"accountId"`
+}
+
+// Tags returns the key:value tags of the instance, leaving out what the metadata didn't provide.
+func (c *CloudHost) Tags() []string {
+	tags := []string{}
+	for _, t := range [][2]string{
+		{CloudProviderTagKey, c.Provider},
+		{CloudRegionTagKey, c.Region},
+		{CloudAccountTagKey, c.AccountID},
+		{CloudInstanceIDTagKey, c.InstanceID},
+	} {
+		if t[1] != "" {
+			tags = append(tags, t[0]+TagSeparator+t[1])
+		}
+	}
+
+	return tags
+}
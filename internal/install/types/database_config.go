@@ -0,0 +1,20 @@
+package types
+
+// The services whose configuration files discovery reads.
+const (
+	DatabaseServiceMySQL    = "mysql"
+	DatabaseServicePostgres = "postgres"
+	DatabaseServiceRedis    = "redis"
+)
+
+// DatabaseConfig is what discovery read of the configuration file of a database server installed
+// on the host. The values its file doesn't set are empty.
+type DatabaseConfig struct {
+	Service    string `json:"service"`
+	ConfigPath string `json:"configPath"`
+	Port       string `json:"port,omitempty"`
+	// Socket is the path of the server's Unix socket.
+	Socket string `json:"socket,omitempty"`
+	// DataDir is the directory the server keeps its data in.
+	DataDir string `json:"dataDir,omitempty"`
+}
@@ -10,12 +10,124 @@ import (
 type DiscoveryManifest struct {
 	Hostname        string `json:"hostname"`
 	KernelArch      string `json:"kernelArch"`
+	Arch            string `json:"arch"`
 	KernelVersion   string `json:"kernelVersion"`
 	OS              string `json:"os"`
 	Platform        string `json:"platform"`
 	PlatformFamily  string `json:"platformFamily"`
 	PlatformVersion string `json:"platformVersion"`
 	IsUnsupported   bool   `json:"isUnsupported"`
+	// FQDN is the fully qualified domain name of the host, when its hostname resolves to one.
+	FQDN string `json:"fqdn,omitempty"`
+	// IPAddresses are the addresses of the host's network interfaces, without those of loopback.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// VirtualizationSystem is the hypervisor or container runtime the host runs in, such as kvm or
+	// docker, and is empty on bare metal.
+	VirtualizationSystem string `json:"virtualizationSystem,omitempty"`
+	// VirtualizationRole is whether the host is a guest of the VirtualizationSystem, or its host.
+	VirtualizationRole string `json:"virtualizationRole,omitempty"`
+	TotalMemoryBytes   uint64 `json:"totalMemoryBytes,omitempty"`
+	CPUs               int    `json:"cpus,omitempty"`
+	// Docker is set when the Docker Engine runs on the host.
+	Docker *DockerHost `json:"docker,omitempty"`
+	// Containerd is set when containerd runs containers on the host outside of Docker.
+	Containerd *ContainerdHost `json:"containerd,omitempty"`
+	// Systemd is set when systemd manages the services of the host.
+	Systemd *SystemdHost `json:"systemd,omitempty"`
+	// Windows is set for Windows hosts, with their services, IIS sites and SQL Server instances.
+	Windows *WindowsHost `json:"windows,omitempty"`
+	// Databases are the configurations of the database servers installed on the host.
+	Databases []DatabaseConfig `json:"databases,omitempty"`
+	// WebServers are the configurations of the web servers installed on the host.
+	WebServers []WebServerConfig `json:"webServers,omitempty"`
+	// JMXEndpoints are the JMX remote ports served by the Java processes of the host.
+	JMXEndpoints []JMXEndpoint `json:"jmxEndpoints,omitempty"`
+	// GPU is set when the host has GPUs.
+	GPU *GPUHost `json:"gpu,omitempty"`
+	// Kubernetes is set when the host is a Kubernetes node or has access to a cluster.
+	Kubernetes *KubernetesHost `json:"kubernetes,omitempty"`
+	// Cloud is set when the host is an AWS, GCP or Azure instance.
+	Cloud *CloudHost `json:"cloud,omitempty"`
+	// SkippedProbes are the probes of the discovery that didn't complete in time, so what they'd
+	// have found is missing from the manifest.
+	SkippedProbes []string `json:"skippedProbes,omitempty"`
+}
+
+// Containers returns the containers running on the host, with Docker or containerd.
+func (d *DiscoveryManifest) Containers() []DockerContainer {
+	containers := []DockerContainer{}
+	if d.Docker != nil {
+		containers = append(containers, d.Docker.Containers...)
+	}
+	if d.Containerd != nil {
+		containers = append(containers, d.Containerd.Containers...)
+	}
+
+	return containers
+}
+
+// SystemdUnits returns the systemd service units installed on the host.
+func (d *DiscoveryManifest) SystemdUnits() []SystemdUnit {
+	if d.Systemd == nil {
+		return []SystemdUnit{}
+	}
+
+	return d.Systemd.Units
+}
+
+// DatabaseConfig returns the configuration discovered of a database service, or nil when none
+// was found.
+func (d *DiscoveryManifest) DatabaseConfig(service string) *DatabaseConfig {
+	for i := range d.Databases {
+		if d.Databases[i].Service == service {
+			return &d.Databases[i]
+		}
+	}
+
+	return nil
+}
+
+// WebServerConfig returns the configuration of the web server, or nil when none of its files was
+// found.
+func (d *DiscoveryManifest) WebServerConfig(service string) *WebServerConfig {
+	for i := range d.WebServers {
+		if d.WebServers[i].Service == service {
+			return &d.WebServers[i]
+		}
+	}
+
+	return nil
+}
+
+// archAliases maps the architectures reported by uname, gopsutil and recipe install targets to the
+// CPU architecture they name.
+var archAliases = map[string]string{
+	"x86_64":  "amd64",
+	"amd64":   "amd64",
+	"x64":     "amd64",
+	"aarch64": "arm64",
+	"arm64":   "arm64",
+	"armv8l":  "arm64",
+	"armv7l":  "armv7",
+	"armv7":   "armv7",
+	"armhf":   "armv7",
+	"armv6l":  "armv6",
+	"armv6":   "armv6",
+	"i386":    "386",
+	"i686":    "386",
+	"x86":     "386",
+	"386":     "386",
+}
+
+// NormalizeArch returns the CPU architecture of a kernel architecture, such as arm64 for aarch64,
+// or the kernel architecture in lower case when it's not known.
+func NormalizeArch(kernelArch string) string {
+	arch := strings.ToLower(strings.TrimSpace(kernelArch))
+	if a, ok := archAliases[arch]; ok {
+		return a
+	}
+
+	return arch
 }
 
 // GenericProcess is an abstracted representation of a process.
@@ -41,7 +153,7 @@ func (d *DiscoveryManifest) ConstrainRecipes(allRecipes []OpenInstallationRecipe
 
 		for _, target := range recipe.InstallTargets {
 			if target.KernelArch != "" {
-				if !strings.EqualFold(target.KernelArch, d.KernelArch) {
+				if NormalizeArch(target.KernelArch) != NormalizeArch(d.KernelArch) {
 					continue
 				}
 			}
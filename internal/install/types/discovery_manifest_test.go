@@ -153,3 +153,26 @@ func TestDiscoveryManifest_ConstrainRecipes(t *testing.T) {
 	}
 
 }
+
+func TestNormalizeArch(t *testing.T) {
+	cases := map[string]string{
+		"x86_64":  "amd64",
+		"AMD64":   "amd64",
+		"aarch64": "arm64",
+		"arm64":   "arm64",
+		"armv7l":  "armv7",
+		"armv6l":  "armv6",
+		"i686":    "386",
+		"s390x":   "s390x",
+	}
+
+	for kernelArch, expected := range cases {
+		require.Equal(t, expected, NormalizeArch(kernelArch), kernelArch)
+	}
+}
+
+func TestCloudHostTags(t *testing.T) {
+	c := CloudHost{Provider: "gcp", Region: "us-central1", InstanceID: "4520031799277581759"}
+
+	require.Equal(t, []string{"cloud.provider:gcp", "cloud.region:us-central1", "cloud.instance.id:4520031799277581759"}, c.Tags())
+}
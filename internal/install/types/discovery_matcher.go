@@ -0,0 +1,13 @@
+package types
+
+// DiscoveryMatcher recommends a recipe when the host has what it matches, such as a running process,
+// a listening port, a configuration file or an installed package, so discovery can find in-house
+// services and map them to custom recipes.
+type DiscoveryMatcher struct {
+	// Recipe is the name of the recipe recommended when the matcher matches.
+	Recipe string `yaml:"recipe"`
+	// Type is the kind of matcher, one of those of the matcher registry such as processName or port.
+	Type string `yaml:"type"`
+	// Value is what the matcher matches, such as a process name pattern or a port number.
+	Value string `yaml:"value"`
+}
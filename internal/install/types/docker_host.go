@@ -0,0 +1,30 @@
+package types
+
+// DockerRecipeName is the recipe of the infrastructure agent's Docker integration, nri-docker.
+const DockerRecipeName = "docker-integration"
+
+// DockerHost is what discovery found about the Docker Engine running on the host.
+type DockerHost struct {
+	// APIVersion is the version of the Docker Engine API served on the socket.
+	APIVersion string `json:"apiVersion,omitempty"`
+	// CgroupVersion is the version of the cgroup hierarchy the containers' metrics are read from,
+	// which nri-docker needs to know for cgroup v2 hosts.
+	CgroupVersion int               `json:"cgroupVersion"`
+	Containers    []DockerContainer `json:"containers"`
+}
+
+// DockerContainer is a container running on a DockerHost or a ContainerdHost.
+type DockerContainer struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	// Ports are the ports the container exposes, inside the container.
+	Ports  []uint32          `json:"ports,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ContainerdHost is what discovery found about the containers containerd runs on the host without
+// Docker, such as with nerdctl.
+type ContainerdHost struct {
+	Containers []DockerContainer `json:"containers"`
+}
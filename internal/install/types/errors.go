@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 var (
@@ -39,6 +40,7 @@ var EventTypes = struct {
 	UnableToLocatePostedData   EventType
 	InvalidUserAPIKeyFormat    EventType
 	InvalidRegion              EventType
+	InaccessibleAccount        EventType
 }{
 	InstallStarted:             "InstallStarted",
 	AccountIDMissing:           "AccountIDMissing",
@@ -58,6 +60,7 @@ var EventTypes = struct {
 	OtherError:                 "OtherError",
 	InvalidUserAPIKeyFormat:    "InvalidUserAPIKeyFormat",
 	InvalidRegion:              "InvalidRegion",
+	InaccessibleAccount:        "InaccessibleAccount",
 }
 
 func TryParseEventType(e string) (EventType, bool) {
@@ -92,6 +95,8 @@ func TryParseEventType(e string) (EventType, bool) {
 		return EventTypes.InvalidUserAPIKeyFormat, true
 	case "InvalidRegion":
 		return EventTypes.InvalidRegion, true
+	case "InaccessibleAccount":
+		return EventTypes.InaccessibleAccount, true
 	}
 
 	return "", false
@@ -176,6 +181,88 @@ func (e *UnsupportedOperatingSystemError) Error() string {
 	return e.Err.Error()
 }
 
+// RecipeTimeoutError represents a recipe whose install tasks did not complete within the allowed time.
+type RecipeTimeoutError struct {
+	RecipeName string
+	Timeout    time.Duration
+}
+
+func (e *RecipeTimeoutError) Error() string {
+	return fmt.Sprintf("recipe %s did not complete within %s", e.RecipeName, e.Timeout)
+}
+
+// RecipeFailuresError represents an install that completed, but where one or more recipes failed to install.
+type RecipeFailuresError struct {
+	RecipeNames []string
+	// Errors holds the error each failed recipe returned, when known.
+	Errors []error
+}
+
+func (e *RecipeFailuresError) Error() string {
+	return fmt.Sprintf("one or more recipes failed to install: %s", strings.Join(e.RecipeNames, ", "))
+}
+
+// PreflightError represents an install stopped before any recipe ran, as pre-flight checks failed.
+type PreflightError struct {
+	FailedChecks []string
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("pre-flight checks failed: %s", strings.Join(e.FailedChecks, ", "))
+}
+
+// DiscoveryError represents a failure to discover the host system.
+type DiscoveryError struct {
+	Err error
+}
+
+func (e *DiscoveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DiscoveryError) Unwrap() error {
+	return e.Err
+}
+
+// RecipeFetchError represents a failure to fetch the recipes available for install.
+type RecipeFetchError struct {
+	Err error
+}
+
+func (e *RecipeFetchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecipeFetchError) Unwrap() error {
+	return e.Err
+}
+
+// ExecutionError represents a failure running a recipe's install tasks.
+type ExecutionError struct {
+	Err error
+}
+
+func (e *ExecutionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError represents a recipe that executed, but whose data could not be validated.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
 // UpdateRequiredError represents when a user is using an older version
 // of the CLI and is required to update when running the `newrelic install` command.
 type UpdateRequiredError struct {
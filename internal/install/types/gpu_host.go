@@ -0,0 +1,45 @@
+package types
+
+// NVIDIAGPURecipeName is the recipe of the integration monitoring NVIDIA GPUs with nvidia-smi.
+const NVIDIAGPURecipeName = "nvidia-gpu-integration"
+
+// The vendors of the GPUs discovery finds.
+const (
+	GPUVendorNVIDIA = "nvidia"
+)
+
+// GPUHost is what discovery found about the GPUs of the host.
+type GPUHost struct {
+	GPUs []GPU `json:"gpus"`
+	// NvidiaSMIPath is the path of the nvidia-smi of the NVIDIA driver, when it's installed.
+	NvidiaSMIPath string `json:"nvidiaSmiPath,omitempty"`
+}
+
+// VendorGPUs returns the GPUs of the host made by the vendor.
+func (h *GPUHost) VendorGPUs(vendor string) []GPU {
+	gpus := []GPU{}
+	if h == nil {
+		return gpus
+	}
+
+	for _, g := range h.GPUs {
+		if g.Vendor == vendor {
+			gpus = append(gpus, g)
+		}
+	}
+
+	return gpus
+}
+
+// GPU is a graphics card of a GPUHost. The values only its driver reports, such as its model and
+// memory, are empty when the card was found on the PCI bus without its driver.
+type GPU struct {
+	Vendor string `json:"vendor"`
+	Model  string `json:"model,omitempty"`
+	// BusID is the PCI address of the card, such as 0000:3b:00.0.
+	BusID string `json:"busId,omitempty"`
+	// DeviceID is the PCI device ID of the card, such as 0x1eb8.
+	DeviceID      string `json:"deviceId,omitempty"`
+	DriverVersion string `json:"driverVersion,omitempty"`
+	MemoryMB      uint64 `json:"memoryMB,omitempty"`
+}
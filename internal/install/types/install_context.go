@@ -1,8 +1,11 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 )
 
 const (
@@ -12,6 +15,11 @@ const (
 	TagSeparator               = ":"
 	BuiltinTags                = DeployedByTagKey + TagSeparator + DefaultDeployedBy
 	EnvInstallCustomAttributes = "INSTALL_CUSTOM_ATTRIBUTES"
+	EnvNriaCustomAttributes    = "NRIA_CUSTOM_ATTRIBUTES"
+	EnvOfflinePackagesPath     = "NEW_RELIC_CLI_OFFLINE_PACKAGES_PATH"
+	EnvRecipeSource            = "NEW_RELIC_RECIPE_SOURCE"
+	EnvRecipeSourceAuth        = "NEW_RELIC_RECIPE_SOURCE_AUTH"
+	EnvNotifyWebhookSecret     = "NEW_RELIC_NOTIFY_WEBHOOK_SECRET"
 )
 
 // nolint: maligned
@@ -21,7 +29,129 @@ type InstallerContext struct {
 	RecipePaths []string
 	// LocalRecipes is the path to a local recipe directory from which to load recipes.
 	LocalRecipes string
-	deployedBy   string
+	// DryRun builds and prints the install plan without executing any recipe.
+	DryRun bool
+	// RenderOnly prints the shell the tasks of the recipes of a dry run would run, with their
+	// variables rendered, instead of the install plan.
+	RenderOnly bool
+	// Uninstall reverses previously installed recipes instead of installing them.
+	Uninstall bool
+	// Upgrade runs the upgrade steps of recipes that are already installed at an older version,
+	// instead of skipping them.
+	Upgrade bool
+	// Resume skips recipes recorded as installed by a previous, interrupted install.
+	Resume bool
+	// MaxConcurrency is the number of additional recipes that may be installed at the same time.
+	MaxConcurrency int
+	// RecipeTimeout is how long a recipe's install tasks may run for, unless the recipe defines its own.
+	RecipeTimeout time.Duration
+	// MaxRetries is how many times a failed recipe is retried before it's reported as failed.
+	MaxRetries int
+	// StatusFile is the path of a JSON file to write the outcome of each attempted recipe to.
+	StatusFile string
+	// RecipeOrder are the names of recipes to install first, in order, before the core recipes and
+	// ahead of the order given by the priority of recipes.
+	RecipeOrder []string
+	// SendInstallLogs sends the install log to New Relic Logs once the install completes.
+	SendInstallLogs bool
+	// AcceptAllLogs forwards every log file discovered for the logs recipe, without prompting.
+	AcceptAllLogs bool
+	// ExcludeLogs are the glob patterns of log files that are never offered or forwarded.
+	ExcludeLogs []string
+	// CustomAttributes are the custom attributes the infrastructure agent is configured with.
+	CustomAttributes map[string]string
+	// Nice runs the recipe tasks with a lower CPU and I/O priority, and the recipes installed at the same
+	// time one package manager at a time, so the install doesn't compete with the workloads of the host.
+	Nice bool
+	// NoRollback leaves the changes of a recipe that failed partway through its install in place,
+	// instead of reversing them with its uninstall tasks or restoring the configuration files it changed.
+	NoRollback bool
+	// NoTelemetry keeps the status of the install and its recipes from being reported to New Relic.
+	NoTelemetry bool
+	// SkipRecipes are the names of recipes to exclude from the install, even when recommended.
+	SkipRecipes []string
+	// RecipeVars are variables provided with --var, which take precedence over the recipes' own.
+	RecipeVars map[string]string
+	// RecipeFileVars are variables loaded with --varFile, which --var flags take precedence over.
+	RecipeFileVars map[string]string
+	// Offline installs without contacting New Relic, loading everything from RecipeBundle.
+	Offline bool
+	// RecipeBundle is the path of a recipe archive to load recipes and agent packages from.
+	RecipeBundle string
+	// SkipPreflightChecks installs without first checking the host and the recipes' prerequisites.
+	SkipPreflightChecks bool
+	// Advanced skips the welcome copy and asks for the settings the guided install chooses itself.
+	Advanced bool
+	// Review prints the commands of each recipe before it runs, to install it, skip it, or edit the
+	// variables it runs with.
+	Review bool
+	// AllowSudo lets an unattended install run recipes with commands that run with sudo, or as root,
+	// which an install that prompts asks to confirm.
+	AllowSudo bool
+	// ContinueOnError installs the remaining recipes when the infrastructure agent or logs recipes
+	// fail, instead of stopping the install.
+	ContinueOnError bool
+	// Target is the remote host to install onto over SSH, as user@host[:port].
+	Target string
+	// SSHKey is the path of the private key used to connect to Target.
+	SSHKey string
+	// ManifestOut is the path the discovery manifest of the host, and its processes, are exported to.
+	ManifestOut string
+	// ManifestIn is the path of an exported discovery manifest to resolve the recipes with, instead of
+	// discovering the host the CLI runs on.
+	ManifestIn string
+	// RecipeVersions are the recipe library versions that recipes are pinned to, by recipe name.
+	RecipeVersions map[string]string
+	// RecipeSHA256 are the SHA-256 checksums that recipe files fetched from a URL must have, instead
+	// of the checksum published next to them.
+	RecipeSHA256 []string
+	// RecipeChecksums are the checksums of the recipes of an applied install plan or lockfile, by
+	// recipe name, which the recipes fetched for the install must match.
+	RecipeChecksums map[string]string
+	// RequireSigned rejects recipes fetched from a URL or the recipe library without a signature
+	// from a trusted key.
+	RequireSigned bool
+	// RecipeSources are the URLs of mirrors of the open install library to fetch recipes from, in
+	// order, instead of the recipes embedded in the CLI.
+	RecipeSources []string
+	// RecipeSourceHeaders are the headers sent with each request to the RecipeSources, such as their
+	// authorization.
+	RecipeSourceHeaders map[string]string
+	// Hooks are the scripts run before and after the install and its recipes.
+	Hooks InstallHooks
+	// DiscoveryInclude are the patterns of the command lines of the processes discovery is scoped
+	// to, when there are any.
+	DiscoveryInclude []string
+	// DiscoveryExclude are the patterns of the command lines of the processes discovery ignores.
+	DiscoveryExclude []string
+	// DiscoveryTimeout is how long the discovery of the host may take, after which the probes that
+	// haven't completed are skipped, or zero for no limit besides each probe's own.
+	DiscoveryTimeout time.Duration
+	// RefreshDiscovery discovers the host again instead of reusing the manifest discovered by a recent
+	// run of the CLI.
+	RefreshDiscovery bool
+	// DiscoveryMatchers are the matchers that recommend recipes in addition to their process matches.
+	DiscoveryMatchers []DiscoveryMatcher
+	// NotifyWebhook is the URL the summary of the install is posted to once it completes.
+	NotifyWebhook string
+	// NotifySlack is the Slack incoming webhook sent a message with the outcome of the install.
+	NotifySlack string
+	// Kubernetes installs the Kubernetes integration onto a cluster with its Helm chart, instead of
+	// installing recipes onto the host.
+	Kubernetes bool
+	// ClusterName is the name the Kubernetes cluster reports its data as.
+	ClusterName string
+	// HelmValuesFile is the path the Helm chart's values are written to, instead of applying them.
+	HelmValuesFile string
+	// AWSRoleARN is the IAM role the AWS account of an EC2 host is linked to New Relic with.
+	AWSRoleARN string
+	// JSONOutput writes the events and summary of the install as JSON lines on stdout, instead of
+	// its text output.
+	JSONOutput bool
+	// UI shows the install as a full-screen view instead of line by line output.
+	UI         bool
+	deployedBy string
+	tags       []string
 }
 
 func (i *InstallerContext) RecipePathsProvided() bool {
@@ -41,14 +171,47 @@ func (i *InstallerContext) IsRecipeTargeted(name string) bool {
 	return false
 }
 
+func (i *InstallerContext) IsRecipeSkipped(name string) bool {
+	for _, r := range i.SkipRecipes {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRecipeNames sets the recipes to install, given as name or name@version to pin a recipe
+// to the version of the recipe library it was released in.
+func (i *InstallerContext) SetRecipeNames(names []string) error {
+	i.RecipeNames = []string{}
+	for _, n := range names {
+		name, version, pinned := strings.Cut(n, "@")
+		if name == "" || (pinned && version == "") {
+			return fmt.Errorf("invalid recipe %q, expected name or name@version", n)
+		}
+
+		i.RecipeNames = append(i.RecipeNames, name)
+		if pinned {
+			if i.RecipeVersions == nil {
+				i.RecipeVersions = map[string]string{}
+			}
+			i.RecipeVersions[name] = strings.TrimPrefix(version, "v")
+		}
+	}
+
+	return nil
+}
+
 func (i *InstallerContext) SetTags(tags []string) {
 	csv := ""
+	i.tags = []string{}
 	for _, value := range tags {
 		parts := strings.Split(value, TagSeparator)
 		if len(parts) == 2 {
 			if parts[0] == DeployedByTagKey {
 				i.deployedBy = parts[1]
 			}
+			i.tags = append(i.tags, value)
 			if len(csv) > 0 {
 				csv += ","
 			}
@@ -65,6 +228,39 @@ func (i *InstallerContext) SetTags(tags []string) {
 	os.Setenv(EnvInstallCustomAttributes, csv)
 }
 
+// SetCustomAttributes sets the custom attributes the infrastructure agent recipe writes to the
+// agent's configuration, over those of the NRIA_CUSTOM_ATTRIBUTES environment variable.
+func (i *InstallerContext) SetCustomAttributes(attributes map[string]string) error {
+	i.CustomAttributes = attributes
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	merged := map[string]string{}
+	if env := os.Getenv(EnvNriaCustomAttributes); env != "" {
+		if err := json.Unmarshal([]byte(env), &merged); err != nil {
+			return fmt.Errorf("invalid %s, expected a JSON object of strings: %s", EnvNriaCustomAttributes, err)
+		}
+	}
+
+	for k, v := range attributes {
+		merged[k] = v
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	os.Setenv(EnvNriaCustomAttributes, string(data))
+	return nil
+}
+
+// GetTags returns the valid key:value tags provided during install.
+func (i *InstallerContext) GetTags() []string {
+	return i.tags
+}
+
 func (i *InstallerContext) GetDeployedBy() string {
 	return i.deployedBy
 }
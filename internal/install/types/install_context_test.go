@@ -23,6 +23,14 @@ func TestIsRecipeTargeted(t *testing.T) {
 	require.False(t, ic.IsRecipeTargeted("badName"))
 }
 
+func TestIsRecipeSkipped(t *testing.T) {
+	ic := InstallerContext{}
+	ic.SkipRecipes = []string{"testName"}
+
+	require.True(t, ic.IsRecipeSkipped("testName"))
+	require.False(t, ic.IsRecipeSkipped("otherName"))
+}
+
 func TestRecipePathsProvided(t *testing.T) {
 	ic := InstallerContext{}
 	require.False(t, ic.RecipePathsProvided())
@@ -66,6 +74,13 @@ func TestSetTagsShouldNotReplaceDeployedBy(t *testing.T) {
 	require.Equal(t, "nr_deployed_by:Me,tag1:test,tag2:test", os.Getenv(EnvInstallCustomAttributes))
 }
 
+func TestSetTagsShouldKeepValidTags(t *testing.T) {
+	ic := InstallerContext{}
+	ic.SetTags([]string{"team:payments", "bad", "env:prod"})
+
+	require.Equal(t, []string{"team:payments", "env:prod"}, ic.GetTags())
+}
+
 func TestShouldGetDeployedBy(t *testing.T) {
 	t.Setenv(EnvInstallCustomAttributes, "")
 	ic := InstallerContext{}
@@ -83,3 +98,40 @@ func TestShouldGetDefaultDeployedBy(t *testing.T) {
 
 	require.Equal(t, "newrelic-cli", ic.GetDeployedBy())
 }
+
+func TestSetRecipeNamesShouldParseVersions(t *testing.T) {
+	ic := InstallerContext{}
+
+	err := ic.SetRecipeNames([]string{"mysql-open-source-integration@v0.120.0", "logs-integration"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"mysql-open-source-integration", "logs-integration"}, ic.RecipeNames)
+	require.Equal(t, map[string]string{"mysql-open-source-integration": "0.120.0"}, ic.RecipeVersions)
+}
+
+func TestSetRecipeNamesShouldRejectMissingVersion(t *testing.T) {
+	ic := InstallerContext{}
+
+	err := ic.SetRecipeNames([]string{"mysql-open-source-integration@"})
+
+	require.Error(t, err)
+}
+
+func TestSetCustomAttributesShouldMergeEnvironment(t *testing.T) {
+	t.Setenv(EnvNriaCustomAttributes, `{"team":"core","region":"eu"}`)
+	ic := InstallerContext{}
+
+	err := ic.SetCustomAttributes(map[string]string{"team": "payments"})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"team":"payments","region":"eu"}`, os.Getenv(EnvNriaCustomAttributes))
+}
+
+func TestSetCustomAttributesShouldRejectInvalidEnvironment(t *testing.T) {
+	t.Setenv(EnvNriaCustomAttributes, "team=core")
+	ic := InstallerContext{}
+
+	err := ic.SetCustomAttributes(map[string]string{"team": "payments"})
+
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package types
+
+// InstallHooks are the scripts run before and after the install, and before and after each recipe,
+// such as to open a change ticket or back up configuration.
+type InstallHooks struct {
+	// PreInstall runs once the recipes to install are known, and the install stops when it fails.
+	PreInstall string `yaml:"preInstall"`
+	// PostInstall runs once the install completes, whatever its outcome.
+	PostInstall string `yaml:"postInstall"`
+	// Recipes are the hooks of individual recipes, by recipe name.
+	Recipes map[string]RecipeHooks `yaml:"recipes"`
+}
+
+// RecipeHooks are the scripts run before and after a recipe is installed. The recipe fails when
+// its PreInstall hook fails.
+type RecipeHooks struct {
+	PreInstall  string `yaml:"preInstall"`
+	PostInstall string `yaml:"postInstall"`
+}
+
+// HasHooks returns whether any script is set.
+func (h *InstallHooks) HasHooks() bool {
+	return h.PreInstall != "" || h.PostInstall != "" || len(h.Recipes) > 0
+}
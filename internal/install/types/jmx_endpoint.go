@@ -0,0 +1,18 @@
+package types
+
+// JMXRecipeName is the recipe of the infrastructure agent's JMX integration, nri-jmx.
+const JMXRecipeName = "jmx-open-source-integration"
+
+// JMXEndpoint is the JMX remote port a Java process of the host serves, as its JVM options
+// enable it.
+type JMXEndpoint struct {
+	PID int32 `json:"pid"`
+	// Name is the jar or main class the process runs.
+	Name string `json:"name,omitempty"`
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// SSL and Authenticate are whether the port requires TLS and credentials, which the JVM does
+	// unless its options disable them.
+	SSL          bool `json:"ssl"`
+	Authenticate bool `json:"authenticate"`
+}
@@ -0,0 +1,14 @@
+package types
+
+// KubernetesHost is what discovery found about the Kubernetes cluster the host belongs to or has
+// access to.
+type KubernetesHost struct {
+	// Kubelet is set when the host is a node of the cluster.
+	Kubelet bool `json:"kubelet"`
+	// InCluster is set when the CLI runs in a pod of the cluster.
+	InCluster bool `json:"inCluster"`
+	// Kubeconfig is the path of the kubeconfig giving access to the cluster, if any.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Context is the current context of Kubeconfig.
+	Context string `json:"context,omitempty"`
+}
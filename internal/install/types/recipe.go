@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
 )
 
 const (
@@ -34,6 +38,14 @@ func (r *OpenInstallationRecipe) UnmarshalYAML(unmarshal func(interface{}) error
 		r.Dependencies = interfaceSliceToStringSlice(v.([]interface{}))
 	}
 
+	if v, ok := recipe["dependsOn"]; ok {
+		for _, d := range interfaceSliceToStringSlice(v.([]interface{})) {
+			if !utils.StringInSlice(d, r.Dependencies) {
+				r.Dependencies = append(r.Dependencies, d)
+			}
+		}
+	}
+
 	r.Description = toStringByFieldName("description", recipe)
 	r.DisplayName = toStringByFieldName("displayName", recipe)
 	r.File = toStringByFieldName("file", recipe)
@@ -46,7 +58,20 @@ func (r *OpenInstallationRecipe) UnmarshalYAML(unmarshal func(interface{}) error
 	}
 	r.Install = installAsString
 
+	uninstallAsString, err := expandUninstallMapToString(recipe)
+	if err != nil {
+		return err
+	}
+	r.Uninstall = uninstallAsString
+
+	upgradeAsString, err := expandUpgradeMapToString(recipe)
+	if err != nil {
+		return err
+	}
+	r.Upgrade = upgradeAsString
+
 	r.InstallTargets = expandInstallTargets(recipe)
+	r.InstallTimeout = toStringByFieldName("installTimeout", recipe)
 
 	if v, ok := recipe["keywords"]; ok {
 		r.Keywords = interfaceSliceToStringSlice(v.([]interface{}))
@@ -57,6 +82,10 @@ func (r *OpenInstallationRecipe) UnmarshalYAML(unmarshal func(interface{}) error
 	r.PostInstall = expandPostInstall(recipe)
 	r.PreInstall = expandPreInstall(recipe)
 
+	if v, ok := recipe["priority"].(int); ok {
+		r.Priority = v
+	}
+
 	if v, ok := recipe["processMatch"]; ok {
 		r.ProcessMatch = interfaceSliceToStringSlice(v.([]interface{}))
 	}
@@ -102,6 +131,15 @@ func (r *OpenInstallationRecipe) ToShortDisplayString() string {
 	return output
 }
 
+// InstallTargetsString returns the OS and platforms the recipe can be installed on, comma separated.
+func (r *OpenInstallationRecipe) InstallTargetsString() string {
+	targets := []string{}
+	for _, target := range r.InstallTargets {
+		targets = append(targets, getInstallTargetAsString(target))
+	}
+	return strings.Join(targets, ", ")
+}
+
 func getInstallTargetAsString(target OpenInstallationRecipeInstallTarget) string {
 	output := string(target.Os)
 	if target.Platform != "" {
@@ -204,7 +242,60 @@ func expandPreInstall(recipe map[string]interface{}) OpenInstallationPreInstallC
 		Prompt:             toStringByFieldName("prompt", infoOut),
 		RequireAtDiscovery: toStringByFieldName("requireAtDiscovery", infoOut),
 		DiscoveryMode:      expandDiscoveryMode(infoOut),
+		Prerequisites:      expandPrerequisites(infoOut),
+		InstalledCheck:     expandInstalledCheck(infoOut),
+	}
+}
+
+func expandInstalledCheck(pi map[string]interface{}) OpenInstallationInstalledCheck {
+	v, ok := pi["installedCheck"]
+	if !ok {
+		return OpenInstallationInstalledCheck{}
+	}
+
+	out := map[string]interface{}{}
+	for k, v := range v.(map[interface{}]interface{}) {
+		out[k.(string)] = v
+	}
+
+	check := OpenInstallationInstalledCheck{}
+	if v, ok := out["files"]; ok {
+		check.Files = interfaceSliceToStringSlice(v.([]interface{}))
+	}
+	if v, ok := out["services"]; ok {
+		check.Services = interfaceSliceToStringSlice(v.([]interface{}))
+	}
+	if v, ok := out["packages"]; ok {
+		check.Packages = interfaceSliceToStringSlice(v.([]interface{}))
+	}
+	check.UpgradeAvailable = toStringByFieldName("upgradeAvailable", out)
+	check.Version = toStringByFieldName("version", out)
+
+	return check
+}
+
+func expandPrerequisites(pi map[string]interface{}) []OpenInstallationPrerequisite {
+	v, ok := pi["prerequisites"]
+	if !ok {
+		return nil
+	}
+
+	prerequisites := []OpenInstallationPrerequisite{}
+	for _, p := range v.([]interface{}) {
+		pp := p.(map[interface{}]interface{})
+		out := map[string]interface{}{}
+		for k, v := range pp {
+			out[k.(string)] = v
+		}
+
+		prerequisites = append(prerequisites, OpenInstallationPrerequisite{
+			Name:        toStringByFieldName("name", out),
+			Check:       toStringByFieldName("check", out),
+			Remediation: toStringByFieldName("remediation", out),
+		})
 	}
+
+	return prerequisites
 }
 
 func expandDiscoveryMode(pi map[string]interface{}) []OpenInstallationDiscoveryMode {
@@ -360,23 +451,35 @@ func toStringByFieldName(fieldName string, data map[string]interface{}) string {
 }
 
 func expandInstalllMapToString(recipeIn map[string]interface{}) (string, error) {
-	installIn, ok := recipeIn["install"]
+	return expandTaskfileMapToString("install", recipeIn)
+}
+
+func expandUninstallMapToString(recipeIn map[string]interface{}) (string, error) {
+	return expandTaskfileMapToString("uninstall", recipeIn)
+}
+
+func expandUpgradeMapToString(recipeIn map[string]interface{}) (string, error) {
+	return expandTaskfileMapToString("upgrade", recipeIn)
+}
+
+func expandTaskfileMapToString(fieldName string, recipeIn map[string]interface{}) (string, error) {
+	taskfileIn, ok := recipeIn[fieldName]
 	if !ok {
 		return "", nil
 	}
 
-	installOut := map[string]interface{}{}
-	installMap := installIn.(map[interface{}]interface{})
-	for k, v := range installMap {
-		installOut[k.(string)] = v
+	taskfileOut := map[string]interface{}{}
+	taskfileMap := taskfileIn.(map[interface{}]interface{})
+	for k, v := range taskfileMap {
+		taskfileOut[k.(string)] = v
 	}
 
-	installAsString, err := yaml.Marshal(installOut)
+	taskfileAsString, err := yaml.Marshal(taskfileOut)
 	if err != nil {
-		return "", fmt.Errorf("error unmarshaling recipe.install to string: %s", err)
+		return "", fmt.Errorf("error unmarshaling recipe.%s to string: %s", fieldName, err)
 	}
 
-	return string(installAsString), nil
+	return string(taskfileAsString), nil
 }
 
 func interfaceSliceToStringSlice(slice []interface{}) []string {
@@ -411,6 +514,55 @@ func (r *OpenInstallationRecipe) SetRecipeVar(key string, value string) {
 	RecipeVariables[key] = value
 }
 
+// HasUninstall returns true when the recipe defines the tasks needed to remove it.
+func (r *OpenInstallationRecipe) HasUninstall() bool {
+	return strings.TrimSpace(r.Uninstall) != ""
+}
+
+// HasInstalledCheck returns true when the recipe defines what is present on a host it's already
+// installed on.
+func (r *OpenInstallationRecipe) HasInstalledCheck() bool {
+	c := r.PreInstall.InstalledCheck
+	return len(c.Files) > 0 || len(c.Services) > 0 || len(c.Packages) > 0
+}
+
+// ToUninstallRecipe returns a copy of the recipe whose install tasks are the recipe's
+// uninstall tasks, so it can be run through any RecipeExecutor.
+func (r *OpenInstallationRecipe) ToUninstallRecipe() OpenInstallationRecipe {
+	u := *r
+	u.Install = r.Uninstall
+	return u
+}
+
+// HasUpgrade returns true when the recipe defines the tasks needed to upgrade it.
+func (r *OpenInstallationRecipe) HasUpgrade() bool {
+	return strings.TrimSpace(r.Upgrade) != ""
+}
+
+// ToUpgradeRecipe returns a copy of the recipe whose install tasks are the recipe's
+// upgrade tasks, so it can be run through any RecipeExecutor.
+func (r *OpenInstallationRecipe) ToUpgradeRecipe() OpenInstallationRecipe {
+	u := *r
+	u.Install = r.Upgrade
+	return u
+}
+
+// GetInstallTimeout returns the recipe's own install timeout when it defines a valid one,
+// otherwise the provided default.
+func (r *OpenInstallationRecipe) GetInstallTimeout(defaultTimeout time.Duration) time.Duration {
+	if r.InstallTimeout == "" {
+		return defaultTimeout
+	}
+
+	timeout, err := time.ParseDuration(r.InstallTimeout)
+	if err != nil || timeout <= 0 {
+		log.Debugf("ignoring invalid installTimeout %q for recipe %s", r.InstallTimeout, r.Name)
+		return defaultTimeout
+	}
+
+	return timeout
+}
+
 func (r *OpenInstallationRecipe) IsApm() bool {
 	return r.HasKeyword("apm")
 }
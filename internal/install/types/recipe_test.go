@@ -3,8 +3,10 @@ package types
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestToStringByFieldName(t *testing.T) {
@@ -113,3 +115,127 @@ func Test_shouldExpandDiscoveryMode(t *testing.T) {
 	dm = expandDiscoveryMode(m)
 	require.Equal(t, 1, len(dm), "One good value should be parsed")
 }
+
+func Test_shouldParseUninstallSteps(t *testing.T) {
+	recipe := OpenInstallationRecipe{}
+	err := yaml.Unmarshal([]byte(`
+name: test-recipe
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo install
+uninstall:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo uninstall
+`), &recipe)
+
+	require.NoError(t, err)
+	require.True(t, recipe.HasUninstall())
+	require.Contains(t, recipe.Uninstall, "echo uninstall")
+	require.Contains(t, recipe.ToUninstallRecipe().Install, "echo uninstall")
+	require.Contains(t, recipe.Install, "echo install")
+}
+
+func Test_shouldParsePrerequisites(t *testing.T) {
+	recipe := OpenInstallationRecipe{}
+	err := yaml.Unmarshal([]byte(`
+name: test-recipe
+preInstall:
+  prerequisites:
+    - name: Java 8 or later
+      check: java -version
+      remediation: Install a Java runtime
+`), &recipe)
+
+	require.NoError(t, err)
+	require.Len(t, recipe.PreInstall.Prerequisites, 1)
+	require.Equal(t, "Java 8 or later", recipe.PreInstall.Prerequisites[0].Name)
+	require.Equal(t, "java -version", recipe.PreInstall.Prerequisites[0].Check)
+	require.Equal(t, "Install a Java runtime", recipe.PreInstall.Prerequisites[0].Remediation)
+}
+
+func Test_shouldGetInstallTimeout(t *testing.T) {
+	recipe := OpenInstallationRecipe{Name: "test-recipe"}
+	require.Equal(t, 5*time.Minute, recipe.GetInstallTimeout(5*time.Minute))
+
+	recipe.InstallTimeout = "90s"
+	require.Equal(t, 90*time.Second, recipe.GetInstallTimeout(5*time.Minute))
+
+	recipe.InstallTimeout = "soon"
+	require.Equal(t, 5*time.Minute, recipe.GetInstallTimeout(5*time.Minute))
+}
+
+func Test_shouldParseDependsOn(t *testing.T) {
+	recipe := OpenInstallationRecipe{}
+	err := yaml.Unmarshal([]byte(`
+name: nginx-open-source-integration
+dependencies:
+  - infrastructure-agent-installer
+dependsOn:
+  - infrastructure-agent-installer
+  - logs-integration
+`), &recipe)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{InfraAgentRecipeName, LoggingRecipeName}, recipe.Dependencies)
+}
+
+func Test_shouldParsePriority(t *testing.T) {
+	recipe := OpenInstallationRecipe{}
+	err := yaml.Unmarshal([]byte(`
+name: firewall-open
+priority: 10
+`), &recipe)
+
+	require.NoError(t, err)
+	require.Equal(t, 10, recipe.Priority)
+}
+
+func Test_shouldParseInstalledCheck(t *testing.T) {
+	recipe := OpenInstallationRecipe{}
+	err := yaml.Unmarshal([]byte(`
+name: infrastructure-agent-installer
+preInstall:
+  installedCheck:
+    files:
+      - /etc/newrelic-infra.yml
+    services:
+      - newrelic-infra
+    packages:
+      - newrelic-infra
+`), &recipe)
+
+	require.NoError(t, err)
+	require.True(t, recipe.HasInstalledCheck())
+	require.Equal(t, []string{"/etc/newrelic-infra.yml"}, recipe.PreInstall.InstalledCheck.Files)
+	require.Equal(t, []string{"newrelic-infra"}, recipe.PreInstall.InstalledCheck.Services)
+	require.Equal(t, []string{"newrelic-infra"}, recipe.PreInstall.InstalledCheck.Packages)
+}
+
+func Test_shouldParseUpgradeSteps(t *testing.T) {
+	recipe := OpenInstallationRecipe{}
+	err := yaml.Unmarshal([]byte(`
+name: test-recipe
+preInstall:
+  installedCheck:
+    packages:
+      - newrelic-infra
+    upgradeAvailable: apt list --upgradable 2>/dev/null | grep -q newrelic-infra
+upgrade:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - echo upgrade
+`), &recipe)
+
+	require.NoError(t, err)
+	require.True(t, recipe.HasUpgrade())
+	require.Contains(t, recipe.ToUpgradeRecipe().Install, "echo upgrade")
+	require.Equal(t, "apt list --upgradable 2>/dev/null | grep -q newrelic-infra", recipe.PreInstall.InstalledCheck.UpgradeAvailable)
+}
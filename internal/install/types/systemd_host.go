@@ -0,0 +1,18 @@
+package types
+
+// SystemdHost is what discovery found about the services systemd manages on the host.
+type SystemdHost struct {
+	Units []SystemdUnit `json:"units"`
+}
+
+// SystemdUnit is a service unit installed on a SystemdHost, which may be idle, and so absent from
+// the processes running on the host.
+type SystemdUnit struct {
+	Name string `json:"name"`
+	// ExecStart is the command line the unit starts its service with.
+	ExecStart string `json:"execStart,omitempty"`
+	// ActiveState is whether the service runs, such as active, inactive or failed.
+	ActiveState string `json:"activeState,omitempty"`
+	// UnitFileState is whether the unit starts at boot or with another unit, such as enabled or static.
+	UnitFileState string `json:"unitFileState,omitempty"`
+}
@@ -215,6 +215,34 @@ type OpenInstallationPreInstallConfiguration struct {
 	RequireAtDiscovery string `json:"requireAtDiscovery,omitempty"`
 	// Possible values, guided, targeted. Both are included if omitted.
 	DiscoveryMode []OpenInstallationDiscoveryMode `json:"discoveryMode,omitempty"`
+	// Conditions of the host checked before any recipe is installed
+	Prerequisites []OpenInstallationPrerequisite `json:"prerequisites,omitempty"`
+	// What is present on a host the recipe is already installed on, so it isn't run again
+	InstalledCheck OpenInstallationInstalledCheck `json:"installedCheck,omitempty"`
+}
+
+// OpenInstallationInstalledCheck - What is present on a host a recipe is installed on, all of which must be met
+type OpenInstallationInstalledCheck struct {
+	// Paths of files that exist once installed, such as the agent's configuration
+	Files []string `json:"files,omitempty"`
+	// Names of the services that are running once installed
+	Services []string `json:"services,omitempty"`
+	// Names of the packages that are installed
+	Packages []string `json:"packages,omitempty"`
+	// Script block exiting successfully when a newer version than the installed one is available
+	UpgradeAvailable string `json:"upgradeAvailable,omitempty"`
+	// Script block printing the installed version
+	Version string `json:"version,omitempty"`
+}
+
+// OpenInstallationPrerequisite - A condition of the host a recipe needs to install
+type OpenInstallationPrerequisite struct {
+	// Name of the prerequisite shown in the pre-flight report
+	Name string `json:"name"`
+	// Script block to be executed before installing, a successful exit status means the prerequisite is met
+	Check string `json:"check"`
+	// Hint to show the user when the prerequisite isn't met
+	Remediation string `json:"remediation,omitempty"`
 }
 
 // OpenInstallationProcessDetailInput - Process details
@@ -225,7 +253,7 @@ type OpenInstallationProcessDetailInput struct {
 
 // OpenInstallationRecipe - Installation instructions and definition of an instrumentation integration
 type OpenInstallationRecipe struct {
-	// Named list of dependencies for this recipe
+	// Named list of dependencies for this recipe, set with dependencies or dependsOn
 	Dependencies []string `json:"dependencies"`
 	// Description of the recipe
 	Description string `json:"description"`
@@ -241,6 +269,8 @@ type OpenInstallationRecipe struct {
 	Install string `json:"install"`
 	// Object representing the intended install target
 	InstallTargets []OpenInstallationRecipeInstallTarget `json:"installTargets"`
+	// Maximum time the install tasks may run for, as a duration such as "10m"
+	InstallTimeout string `json:"installTimeout,omitempty"`
 	// Tags
 	Keywords []string `json:"keywords"`
 	// # Partial list of possible Log forwarding parameters
@@ -251,12 +281,18 @@ type OpenInstallationRecipe struct {
 	PostInstall OpenInstallationPostInstallConfiguration `json:"postInstall,omitempty"`
 	// Object representing optional pre-install configuration items
 	PreInstall OpenInstallationPreInstallConfiguration `json:"preInstall,omitempty"`
+	// Recipes of a higher priority are installed first; those above 0 run before the infrastructure agent
+	Priority int `json:"priority,omitempty"`
 	// List of process definitions used to match CLI process detection
 	ProcessMatch []string `json:"processMatch"`
 	// Github repository url
 	Repository string `json:"repository"`
 	// Indicates stability level of recipe
 	Stability OpenInstallationStability `json:"stability,omitempty"`
+	// Go-task's taskfile definition used to remove what the recipe installed
+	Uninstall string `json:"uninstall,omitempty"`
+	// Go-task's taskfile definition used to upgrade what the recipe installed to the latest version
+	Upgrade string `json:"upgrade,omitempty"`
 	// Metadata to support generating a URL after installation success
 	SuccessLinkConfig OpenInstallationSuccessLinkConfig `json:"successLinkConfig,omitempty"`
 	// NRQL the newrelic-cli uses to validate this recipe
@@ -0,0 +1,26 @@
+package types
+
+// The web servers whose configuration files discovery reads.
+const (
+	WebServerNginx   = "nginx"
+	WebServerApache  = "apache"
+	WebServerHAProxy = "haproxy"
+)
+
+// WebServerStatusHints are how the status page the integration of each web server reads its
+// metrics from is enabled.
+var WebServerStatusHints = map[string]string{
+	WebServerNginx:   "add stub_status to a location of a server block",
+	WebServerApache:  "enable mod_status with SetHandler server-status in a <Location /server-status>",
+	WebServerHAProxy: "add stats enable and stats uri to a frontend or listen section",
+}
+
+// WebServerConfig is what discovery read of the configuration files of a web server installed on
+// the host.
+type WebServerConfig struct {
+	Service    string `json:"service"`
+	ConfigPath string `json:"configPath"`
+	// StatusURL is the URL of the status page the server's integration reads its metrics from, such
+	// as the stub_status of nginx. It's empty when the configuration doesn't enable one.
+	StatusURL string `json:"statusUrl,omitempty"`
+}
@@ -0,0 +1,30 @@
+package types
+
+// WindowsHost is what discovery found about the services installed on a Windows host.
+type WindowsHost struct {
+	Services []WindowsService `json:"services"`
+	// IISSites are the websites IIS serves, when it's installed.
+	IISSites []IISSite `json:"iisSites,omitempty"`
+	// SQLServerInstances are the names of the Microsoft SQL Server instances installed on the host.
+	SQLServerInstances []string `json:"sqlServerInstances,omitempty"`
+}
+
+// WindowsService is a service installed on a WindowsHost, which may be stopped.
+type WindowsService struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	// State is whether the service runs, such as Running or Stopped.
+	State string `json:"state,omitempty"`
+	// StartMode is how the service starts, such as Auto, Manual or Disabled.
+	StartMode string `json:"startMode,omitempty"`
+	// PathName is the command line the service is started with.
+	PathName string `json:"pathName,omitempty"`
+}
+
+// IISSite is a website served by IIS.
+type IISSite struct {
+	Name  string `json:"name"`
+	State string `json:"state,omitempty"`
+	// Bindings are the protocol and binding information of the site, such as http *:80:.
+	Bindings []string `json:"bindings,omitempty"`
+}
@@ -0,0 +1,49 @@
+package ux
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// CIProgress prints one timestamped line as each step starts and finishes, without colors or
+// control characters, so CI logs stay readable.
+type CIProgress struct {
+	out io.Writer
+	now func() time.Time
+}
+
+func NewCIProgress() *CIProgress {
+	p := CIProgress{
+		out: os.Stdout,
+		now: time.Now,
+	}
+
+	return &p
+}
+
+func (p *CIProgress) Start(msg string) {
+	p.printf("%s...", msg)
+}
+
+func (p *CIProgress) Success(msg string) {
+	p.printf("%s...success.", msg)
+}
+
+func (p *CIProgress) Fail(msg string) {
+	p.printf("%s...incomplete.", msg)
+}
+
+func (p *CIProgress) Canceled(msg string) {
+	p.printf("%s...canceled.", msg)
+}
+
+func (p *CIProgress) Stop() {}
+
+func (p *CIProgress) ShowSpinner(ss bool) {
+}
+
+func (p *CIProgress) printf(format string, a ...interface{}) {
+	fmt.Fprintf(p.out, "%s %s\n", p.now().UTC().Format(time.RFC3339), fmt.Sprintf(format, a...))
+}
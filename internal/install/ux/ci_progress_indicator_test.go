@@ -0,0 +1,28 @@
+package ux
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCIProgressIndicator_interface(t *testing.T) {
+	var r ProgressIndicator = NewCIProgress()
+	require.NotNil(t, r)
+}
+
+func TestCIProgressShouldPrintTimestampedLines(t *testing.T) {
+	out := &bytes.Buffer{}
+	p := NewCIProgress()
+	p.out = out
+	p.now = func() time.Time {
+		return time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	}
+
+	p.Start("Installing Logs integration")
+	p.Success("Installing Logs integration")
+
+	require.Equal(t, "2026-10-14T09:30:00Z Installing Logs integration...\n2026-10-14T09:30:00Z Installing Logs integration...success.\n", out.String())
+}
@@ -1,6 +1,9 @@
 package ux
 
 import (
+	"os"
+	"strings"
+
 	"github.com/fatih/color"
 )
 
@@ -18,3 +21,62 @@ var (
 	IconError       = color.YellowString(IconExclamation) // We display "warning"	 symbol to avoid scary "red" colors
 	IconUnsupported = color.RedString(IconCircleSlash)
 )
+
+// CIMode is set when output is written for CI logs, without spinners, colors or unicode icons.
+var CIMode bool
+
+// ASCIIMode is set when output is written as plain ASCII lines, without unicode icons, emoji,
+// box drawing or spinners, for terminals that can't show them and for screen readers.
+var ASCIIMode bool
+
+// EnableCIMode disables colors and replaces the icons with plain ASCII.
+func EnableCIMode() {
+	CIMode = true
+	color.NoColor = true
+
+	EnableASCIIMode()
+}
+
+// EnableASCIIMode replaces the icons and emoji with plain ASCII markers, and progress is printed
+// line by line instead of with spinners.
+func EnableASCIIMode() {
+	ASCIIMode = true
+
+	IconCheckmark = "OK"
+	IconMultiplication = "x"
+	IconMinus = "-"
+	IconArrowRight = "->"
+	IconCircleSlash = "x"
+
+	IconSuccess = color.GreenString(IconCheckmark)
+	IconError = color.YellowString(IconExclamation)
+	IconUnsupported = color.RedString(IconCircleSlash)
+
+	checkmark = "[OK]"
+	crossmark = "[FAILED]"
+}
+
+// IsASCIITerminal returns whether the terminal can only show ASCII, which is the case for dumb
+// terminals and locales with a character set other than UTF-8, such as en_US.ISO-8859-1.
+func IsASCIITerminal() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return true
+	}
+
+	for _, k := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		v := os.Getenv(k)
+		if v == "" {
+			continue
+		}
+
+		_, charset, ok := strings.Cut(strings.ToLower(v), ".")
+		if !ok {
+			return false
+		}
+
+		charset, _, _ = strings.Cut(charset, "@")
+		return charset != "utf-8" && charset != "utf8"
+	}
+
+	return false
+}
@@ -0,0 +1,30 @@
+package ux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsASCIITerminalShouldDetectDumbTerminals(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+
+	require.True(t, IsASCIITerminal())
+}
+
+func TestIsASCIITerminalShouldDetectCharsetOfLocale(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+
+	t.Setenv("LANG", "en_US.ISO-8859-1")
+	require.True(t, IsASCIITerminal())
+
+	t.Setenv("LANG", "de_DE.utf8@euro")
+	require.False(t, IsASCIITerminal())
+
+	t.Setenv("LC_CTYPE", "ja_JP.UTF-8")
+	t.Setenv("LANG", "C")
+	require.False(t, IsASCIITerminal())
+}
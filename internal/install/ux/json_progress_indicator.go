@@ -0,0 +1,30 @@
+package ux
+
+// JSONMode is set when the install writes its events as JSON lines on stdout instead of text, so
+// nothing else may be written there.
+var JSONMode bool
+
+// EnableJSONMode suppresses the text output of the install, including the progress and summary.
+func EnableJSONMode() {
+	JSONMode = true
+	QuietMode = true
+}
+
+// JSONProgress prints nothing, as the progress of a JSON mode install is reported by its events.
+type JSONProgress struct{}
+
+func NewJSONProgress() *JSONProgress {
+	return &JSONProgress{}
+}
+
+func (p *JSONProgress) Start(msg string) {}
+
+func (p *JSONProgress) Success(msg string) {}
+
+func (p *JSONProgress) Fail(msg string) {}
+
+func (p *JSONProgress) Canceled(msg string) {}
+
+func (p *JSONProgress) Stop() {}
+
+func (p *JSONProgress) ShowSpinner(ss bool) {}
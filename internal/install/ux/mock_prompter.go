@@ -8,12 +8,25 @@ type MockPrompter struct {
 	PromptMultiSelectVal       []string
 	PromptMultiSelectErr       error
 	PromptMultiSelectCallCount int
+	// PromptSelectVals and PromptInputVals are the answers by message, the default is used otherwise.
+	PromptSelectVals      map[string]string
+	PromptSelectCallCount int
+	PromptInputVals       map[string]string
+	PromptInputCallCount  int
+	// PromptSelectQueues and PromptInputQueues are the answers, in order, to selects and inputs
+	// asked more than once by message.
+	PromptSelectQueues map[string][]string
+	PromptInputQueues  map[string][]string
 }
 
 func NewMockPrompter() *MockPrompter {
 	return &MockPrompter{
 		PromptYesNoVal:       true,
 		PromptMultiSelectAll: true,
+		PromptSelectVals:     map[string]string{},
+		PromptSelectQueues:   map[string][]string{},
+		PromptInputVals:      map[string]string{},
+		PromptInputQueues:    map[string][]string{},
 	}
 }
 
@@ -31,3 +44,33 @@ func (p *MockPrompter) MultiSelect(msg string, options []string) ([]string, erro
 
 	return p.PromptMultiSelectVal, p.PromptMultiSelectErr
 }
+
+func (p *MockPrompter) Select(msg string, options []string, defaultOption string) (string, error) {
+	p.PromptSelectCallCount++
+
+	if queue := p.PromptSelectQueues[msg]; len(queue) > 0 {
+		p.PromptSelectQueues[msg] = queue[1:]
+		return queue[0], nil
+	}
+
+	if val, ok := p.PromptSelectVals[msg]; ok {
+		return val, nil
+	}
+
+	return defaultOption, nil
+}
+
+func (p *MockPrompter) Input(msg string, defaultValue string) (string, error) {
+	p.PromptInputCallCount++
+
+	if queue := p.PromptInputQueues[msg]; len(queue) > 0 {
+		p.PromptInputQueues[msg] = queue[1:]
+		return queue[0], nil
+	}
+
+	if val, ok := p.PromptInputVals[msg]; ok {
+		return val, nil
+	}
+
+	return defaultValue, nil
+}
@@ -2,57 +2,62 @@ package ux
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/fatih/color"
 )
 
 type PlainProgress struct {
+	out io.Writer
 }
 
 func NewPlainProgress() *PlainProgress {
-	p := PlainProgress{}
+	return NewPlainProgressTo(os.Stdout)
+}
+
+// NewPlainProgressTo returns a progress indicator that prints its lines to w.
+func NewPlainProgressTo(w io.Writer) *PlainProgress {
+	p := PlainProgress{
+		out: w,
+	}
 
 	return &p
 }
 
 func (p *PlainProgress) Start(msg string) {
-	c := color.New(color.FgCyan)
-	c.Printf("==>")
-	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
+	p.printStep(msg)
 
-	fmt.Printf("...\n")
-	fmt.Println()
+	fmt.Fprintf(p.out, "...\n")
+	fmt.Fprintln(p.out)
 }
 
 func (p *PlainProgress) Success(msg string) {
-	c := color.New(color.FgCyan)
-	c.Printf("==>")
-	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
+	p.printStep(msg)
 
-	fmt.Printf("...success.\n\n")
+	fmt.Fprintf(p.out, "...success.\n\n")
 }
 
 func (p *PlainProgress) Fail(msg string) {
-	c := color.New(color.FgCyan)
-	c.Printf("==>")
-	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
+	p.printStep(msg)
 
-	fmt.Printf("...incomplete.\n\n")
+	fmt.Fprintf(p.out, "...incomplete.\n\n")
 }
 
 func (p *PlainProgress) Canceled(msg string) {
-	c := color.New(color.FgCyan)
-	c.Printf("==>")
-	x := color.New(color.Bold)
-	x.Printf(" %s", msg)
+	p.printStep(msg)
 
-	fmt.Printf("...canceled.\n\n")
+	fmt.Fprintf(p.out, "...canceled.\n\n")
 }
 
 func (p *PlainProgress) Stop() {}
 
 func (p *PlainProgress) ShowSpinner(ss bool) {
 }
+
+func (p *PlainProgress) printStep(msg string) {
+	c := color.New(color.FgCyan)
+	c.Fprintf(p.out, "==>")
+	x := color.New(color.Bold)
+	x.Fprintf(p.out, " %s", msg)
+}
@@ -1,8 +1,10 @@
 package ux
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/require"
 )
 
@@ -10,3 +12,17 @@ func TestPlainProgressIndicator_interface(t *testing.T) {
 	var r ProgressIndicator = NewPlainProgress()
 	require.NotNil(t, r)
 }
+
+func TestPlainProgressShouldPrintToItsOutput(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	out := &bytes.Buffer{}
+	p := NewPlainProgressTo(out)
+
+	p.Start("Discovering system information")
+	p.Success("Discovering system information")
+
+	require.Equal(t, "==> Discovering system information...\n\n==> Discovering system information...success.\n\n", out.String())
+}
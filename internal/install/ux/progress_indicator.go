@@ -1,5 +1,7 @@
 package ux
 
+import "time"
+
 type ProgressIndicator interface {
 	Canceled(string)
 	Fail(string)
@@ -8,3 +10,9 @@ type ProgressIndicator interface {
 	Stop()
 	ShowSpinner(bool)
 }
+
+// ValidationProgressIndicator is implemented by progress indicators that show how long is
+// left to validate a recipe.
+type ValidationProgressIndicator interface {
+	StartValidation(displayName string, timeout time.Duration)
+}
@@ -54,3 +54,42 @@ func (p *PromptUIPrompter) MultiSelect(msg string, options []string) ([]string,
 
 	return selected, nil
 }
+
+func (p *PromptUIPrompter) Select(msg string, options []string, defaultOption string) (string, error) {
+	selected := ""
+	prompt := &survey.Select{
+		Message: msg,
+		Options: options,
+		Default: defaultOption,
+	}
+
+	err := survey.AskOne(prompt, &selected)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			return "", types.ErrInterrupt
+		}
+
+		return "", err
+	}
+
+	return selected, nil
+}
+
+func (p *PromptUIPrompter) Input(msg string, defaultValue string) (string, error) {
+	value := ""
+	prompt := &survey.Input{
+		Message: msg,
+		Default: defaultValue,
+	}
+
+	err := survey.AskOne(prompt, &value)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			return "", types.ErrInterrupt
+		}
+
+		return "", err
+	}
+
+	return value, nil
+}
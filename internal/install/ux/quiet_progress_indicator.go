@@ -0,0 +1,53 @@
+package ux
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// QuietMode is set when only warnings, errors and the install summary are printed, such as when
+// the output is scraped or embedded in the logs of other tools.
+var QuietMode bool
+
+// EnableQuietMode suppresses the welcome, progress and other informational output.
+func EnableQuietMode() {
+	QuietMode = true
+}
+
+// QuietProgress prints nothing as steps start and succeed, and one line for each step that
+// doesn't complete.
+type QuietProgress struct {
+	out io.Writer
+}
+
+func NewQuietProgress() *QuietProgress {
+	return NewQuietProgressTo(os.Stdout)
+}
+
+// NewQuietProgressTo returns a quiet progress indicator that prints the steps that don't complete
+// to w.
+func NewQuietProgressTo(w io.Writer) *QuietProgress {
+	p := QuietProgress{
+		out: w,
+	}
+
+	return &p
+}
+
+func (p *QuietProgress) Start(msg string) {}
+
+func (p *QuietProgress) Success(msg string) {}
+
+func (p *QuietProgress) Fail(msg string) {
+	fmt.Fprintf(p.out, "%s  %s...incomplete.\n", IconError, msg)
+}
+
+func (p *QuietProgress) Canceled(msg string) {
+	fmt.Fprintf(p.out, "%s  %s...canceled.\n", IconError, msg)
+}
+
+func (p *QuietProgress) Stop() {}
+
+func (p *QuietProgress) ShowSpinner(ss bool) {
+}
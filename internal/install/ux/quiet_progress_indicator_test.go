@@ -0,0 +1,26 @@
+package ux
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietProgressIndicator_interface(t *testing.T) {
+	var r ProgressIndicator = NewQuietProgress()
+	require.NotNil(t, r)
+}
+
+func TestQuietProgressShouldOnlyPrintIncompleteSteps(t *testing.T) {
+	out := &bytes.Buffer{}
+	p := NewQuietProgress()
+	p.out = out
+
+	p.Start("Installing Logs integration")
+	p.Success("Installing Logs integration")
+	p.Start("Installing MySQL integration")
+	p.Fail("Installing MySQL integration")
+
+	require.Equal(t, IconError+"  Installing MySQL integration...incomplete.\n", out.String())
+}
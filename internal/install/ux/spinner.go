@@ -2,6 +2,9 @@ package ux
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	spinnerLib "github.com/briandowns/spinner"
@@ -12,30 +15,104 @@ import (
 
 const (
 	interval    = 100 * time.Millisecond
-	checkmark   = "\u2705"
-	crossmark   = "\u274C"
 	indentation = "  "
+
+	EnvSpinner         = "NEW_RELIC_CLI_SPINNER"
+	EnvSpinnerInterval = "NEW_RELIC_CLI_SPINNER_INTERVAL"
 )
 
 var (
-	charSet = spinnerLib.CharSets[14]
+	charSet   = spinnerLib.CharSets[14]
+	checkmark = "\u2705"
+	crossmark = "\u274C"
+
+	// The configured style of spinners, which take precedence over each spinner's own.
+	spinnerCharSet  []string
+	spinnerInterval time.Duration
+	spinnerDisabled bool
 )
 
+// ConfigureSpinner sets the style of spinners, as the number of a character set of
+// github.com/briandowns/spinner or none to print progress as plain lines, and how often they're
+// redrawn. A style or interval that isn't given is read from NEW_RELIC_CLI_SPINNER and
+// NEW_RELIC_CLI_SPINNER_INTERVAL.
+func ConfigureSpinner(style string, frequency time.Duration) error {
+	if style == "" {
+		style = os.Getenv(EnvSpinner)
+	}
+
+	switch strings.ToLower(style) {
+	case "":
+	case "none", "off", "false":
+		spinnerDisabled = true
+	default:
+		n, err := strconv.Atoi(style)
+		if err != nil || spinnerLib.CharSets[n] == nil {
+			return fmt.Errorf("invalid spinner style %q, expected none or a character set from 0 to %d", style, len(spinnerLib.CharSets)-1)
+		}
+		spinnerCharSet = spinnerLib.CharSets[n]
+	}
+
+	if frequency == 0 && os.Getenv(EnvSpinnerInterval) != "" {
+		d, err := time.ParseDuration(os.Getenv(EnvSpinnerInterval))
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", EnvSpinnerInterval, err)
+		}
+		frequency = d
+	}
+
+	if frequency < 0 {
+		return fmt.Errorf("invalid spinner interval %s, expected a positive duration such as 250ms", frequency)
+	}
+	spinnerInterval = frequency
+
+	return nil
+}
+
+// spinnerStyle returns the characters and interval of a spinner, which are the configured ones
+// when set and otherwise the spinner's own.
+func spinnerStyle(defaultCharSet []string, defaultInterval time.Duration) ([]string, time.Duration) {
+	cs, d := defaultCharSet, defaultInterval
+	if spinnerCharSet != nil {
+		cs = spinnerCharSet
+	}
+
+	if spinnerInterval > 0 {
+		d = spinnerInterval
+	}
+
+	return cs, d
+}
+
+// showsSpinners returns whether progress is shown with spinners rather than as plain lines.
+func showsSpinners() bool {
+	return !ASCIIMode && !spinnerDisabled
+}
+
 type Spinner struct {
 	*spinnerLib.Spinner
 }
 
 func NewSpinner() *Spinner {
 	s := Spinner{}
-	s.Spinner = spinnerLib.New(charSet, interval)
+	s.Spinner = spinnerLib.New(spinnerStyle(charSet, interval))
 	return &s
 }
 
 func (s *Spinner) Start(msg string) {
+	// Progress is printed as plain lines, since a spinner redraws the line it's on.
+	if !showsSpinners() {
+		s.Suffix = fmt.Sprintf(" %s", msg)
+		fmt.Println()
+		fmt.Printf("%s%s...\n", indentation, msg)
+		log.Debug(msg)
+		return
+	}
+
 	// Suppress spinner output when logging at debug or trace level.
 	// Output is garbled when verbose log messages are sent during an active spinner.
 	if !config.Logger.IsLevelEnabled(log.DebugLevel) {
-		s.Spinner = spinnerLib.New(charSet, interval)
+		s.Spinner = spinnerLib.New(spinnerStyle(charSet, interval))
 		s.Prefix = indentation
 		s.Suffix = fmt.Sprintf(" %s", msg)
 
@@ -47,6 +124,13 @@ func (s *Spinner) Start(msg string) {
 }
 
 func (s *Spinner) Stop() {
+	if !showsSpinners() {
+		fmt.Printf("%s%s\n", s.FinalMSG, s.Suffix)
+		fmt.Println()
+		log.Debug(s.Suffix)
+		return
+	}
+
 	// Suppress stopping the spinner when logging at debug or trace level.
 	// See above.
 	if !config.Logger.IsLevelEnabled(log.DebugLevel) {
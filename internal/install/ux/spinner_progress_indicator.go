@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/newrelic/newrelic-cli/internal/config"
+	"github.com/newrelic/newrelic-cli/internal/install/i18n"
 )
 
 type SpinnerProgressIndicator struct {
@@ -19,7 +20,7 @@ type SpinnerProgressIndicator struct {
 
 func NewSpinnerProgressIndicator() *SpinnerProgressIndicator {
 	s := &SpinnerProgressIndicator{}
-	s.Spinner = spinnerLib.New(spinnerLib.CharSets[4], 750*time.Millisecond)
+	s.Spinner = spinnerLib.New(spinnerStyle(spinnerLib.CharSets[4], 750*time.Millisecond))
 	_ = s.Spinner.Color("green")
 	s.Spinner.HideCursor = true
 	s.showSpinner = true
@@ -87,10 +88,10 @@ func (s *SpinnerProgressIndicator) Success(msg string) {
 		fmt.Print(msg)
 	}
 
-	if strings.Contains(msg, "Complete!") {
+	if strings.Contains(msg, i18n.T("Complete!")) {
 		fmt.Println()
 		return
-	} else if strings.Contains(msg, "Installing") {
+	} else if strings.Contains(msg, strings.TrimSpace(i18n.T("Installing %s", ""))) {
 		printInstallFinalMessage("Installed", color.BgGreen)
 	} else {
 		printInstallFinalMessage("Connected", color.BgGreen)
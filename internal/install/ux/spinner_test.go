@@ -0,0 +1,59 @@
+package ux
+
+import (
+	"testing"
+	"time"
+
+	spinnerLib "github.com/briandowns/spinner"
+	"github.com/stretchr/testify/require"
+)
+
+func resetSpinnerConfig() {
+	spinnerCharSet = nil
+	spinnerInterval = 0
+	spinnerDisabled = false
+}
+
+func TestConfigureSpinnerShouldSetStyleAndInterval(t *testing.T) {
+	defer resetSpinnerConfig()
+
+	require.NoError(t, ConfigureSpinner("9", 250*time.Millisecond))
+
+	cs, d := spinnerStyle(charSet, interval)
+	require.Equal(t, spinnerLib.CharSets[9], cs)
+	require.Equal(t, 250*time.Millisecond, d)
+	require.True(t, showsSpinners())
+}
+
+func TestConfigureSpinnerShouldReadEnvironment(t *testing.T) {
+	defer resetSpinnerConfig()
+	t.Setenv(EnvSpinner, "none")
+	t.Setenv(EnvSpinnerInterval, "1s")
+
+	require.NoError(t, ConfigureSpinner("", 0))
+
+	_, d := spinnerStyle(charSet, interval)
+	require.Equal(t, time.Second, d)
+	require.False(t, showsSpinners())
+	require.IsType(t, &PlainProgress{}, NewProgressIndicator())
+}
+
+func TestConfigureSpinnerShouldKeepDefaultsWhenNotSet(t *testing.T) {
+	defer resetSpinnerConfig()
+	t.Setenv(EnvSpinner, "")
+	t.Setenv(EnvSpinnerInterval, "")
+
+	require.NoError(t, ConfigureSpinner("", 0))
+
+	cs, d := spinnerStyle(charSet, interval)
+	require.Equal(t, charSet, cs)
+	require.Equal(t, interval, d)
+}
+
+func TestConfigureSpinnerShouldRejectInvalidStyles(t *testing.T) {
+	defer resetSpinnerConfig()
+
+	require.Error(t, ConfigureSpinner("dots", 0))
+	require.Error(t, ConfigureSpinner("1000", 0))
+	require.Error(t, ConfigureSpinner("", -time.Second))
+}
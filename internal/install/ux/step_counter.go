@@ -0,0 +1,81 @@
+package ux
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// StepCounter numbers the steps of an install as they start, so progress can be shown
+// as e.g. "[3/9] Installing Logs integration". Steps may be added as they become known.
+type StepCounter struct {
+	mu      sync.Mutex
+	total   int
+	current int
+}
+
+func NewStepCounter() *StepCounter {
+	return &StepCounter{}
+}
+
+// AddSteps increases the total number of steps.
+func (c *StepCounter) AddSteps(n int) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.total += n
+}
+
+// Next starts the next step and returns its prefix, or an empty string when no steps
+// have been added.
+func (c *StepCounter) Next() string {
+	if c == nil {
+		return ""
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.total == 0 {
+		return ""
+	}
+
+	c.current++
+	if c.current > c.total {
+		c.total = c.current
+	}
+
+	return fmt.Sprintf("[%d/%d] ", c.current, c.total)
+}
+
+// NewProgressIndicator returns a spinner when stdout is a terminal, and otherwise one that
+// prints plain lines, which read better in logs and CI output.
+func NewProgressIndicator() ProgressIndicator {
+	if JSONMode {
+		return NewJSONProgress()
+	}
+
+	if QuietMode {
+		return NewQuietProgress()
+	}
+
+	if CIMode {
+		return NewCIProgress()
+	}
+
+	if !showsSpinners() {
+		return NewPlainProgress()
+	}
+
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		return NewSpinnerProgressIndicator()
+	}
+
+	return NewPlainProgress()
+}
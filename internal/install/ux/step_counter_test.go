@@ -0,0 +1,30 @@
+package ux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepCounterShouldNumberSteps(t *testing.T) {
+	c := NewStepCounter()
+	c.AddSteps(2)
+
+	require.Equal(t, "[1/2] ", c.Next())
+	require.Equal(t, "[2/2] ", c.Next())
+}
+
+func TestStepCounterShouldGrowTotalWhenStepsExceedIt(t *testing.T) {
+	c := NewStepCounter()
+	c.AddSteps(1)
+
+	require.Equal(t, "[1/1] ", c.Next())
+	require.Equal(t, "[2/2] ", c.Next())
+}
+
+func TestStepCounterShouldNotPrefixWithoutSteps(t *testing.T) {
+	var nilCounter *StepCounter
+
+	require.Equal(t, "", NewStepCounter().Next())
+	require.Equal(t, "", nilCounter.Next())
+}
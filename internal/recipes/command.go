@@ -0,0 +1,37 @@
+package recipes
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+var (
+	localRecipes string
+)
+
+var Command = &cobra.Command{
+	Use:     "recipes",
+	Short:   "Find and inspect the recipes used to install New Relic instrumentation",
+	Example: `newrelic recipes <subcommand>`,
+}
+
+// newRecipeFetcher returns a fetcher of the current release of the recipe library, failing over
+// to the recipe cache and the recipes included with the CLI, or of the local recipes when given.
+func newRecipeFetcher() recipes.RecipeFetcher {
+	if localRecipes != "" {
+		return &recipes.LocalRecipeFetcher{
+			Path: localRecipes,
+		}
+	}
+
+	return recipes.NewFailoverRecipeFetcher(
+		recipes.RecipeSource{Name: "the recipe library", Fetcher: recipes.NewLibraryRecipeFetcher("")},
+		recipes.RecipeSource{Name: "the recipe cache", Fetcher: recipes.NewCachedLibraryRecipeFetcher()},
+		recipes.RecipeSource{Name: "the recipes included with the CLI", Fetcher: recipes.NewEmbeddedRecipeFetcher()},
+	)
+}
+
+func init() {
+	Command.PersistentFlags().StringVarP(&localRecipes, "localRecipes", "", "", "a path to local recipes to use instead of the recipe library")
+}
@@ -0,0 +1,201 @@
+package recipes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var cmdDescribe = &cobra.Command{
+	Use:   "describe <name>",
+	Short: "Describe what a recipe installs and how",
+	Long: `Describe what a recipe installs and how
+
+Shows the metadata of the recipe, the variables it prompts for, the criteria
+used to detect whether it applies to a host, the tasks it runs and the NRQL
+query used to validate that it's sending data. Recipes released for several
+operating systems are described once for each of them.
+`,
+	Example: `newrelic recipes describe mysql-open-source-integration`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := newRecipeFetcher().FetchRecipes(utils.SignalCtx)
+		if err != nil {
+			return fmt.Errorf("could not fetch recipes: %w", err)
+		}
+
+		matches := []*types.OpenInstallationRecipe{}
+		for _, r := range all {
+			if r.Name == args[0] {
+				matches = append(matches, r)
+			}
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("recipe %s not found, use newrelic recipes search to find its name", args[0])
+		}
+
+		for _, r := range matches {
+			describeRecipe(os.Stdout, r)
+		}
+
+		return nil
+	},
+}
+
+// recipeTask is a go-task task of a recipe's install taskfile.
+type recipeTask struct {
+	Name string
+	Cmds []string
+}
+
+func describeRecipe(w io.Writer, r *types.OpenInstallationRecipe) {
+	fmt.Fprintf(w, "\n%s (%s)\n", recipeDisplayName(r), r.Name)
+	if description := strings.Join(strings.Fields(r.Description), " "); description != "" {
+		fmt.Fprintf(w, "  %s\n", description)
+	}
+
+	fmt.Fprintln(w)
+	describeField(w, "targets", r.InstallTargetsString())
+	describeField(w, "stability", string(r.Stability))
+	describeField(w, "keywords", strings.Join(r.Keywords, ", "))
+	describeField(w, "depends on", strings.Join(r.Dependencies, ", "))
+	describeField(w, "repository", r.Repository)
+
+	if len(r.InputVars) > 0 {
+		fmt.Fprintln(w, "\n  Variables:")
+		for _, v := range r.InputVars {
+			details := []string{}
+			if v.Default != "" && !v.Secret {
+				details = append(details, fmt.Sprintf("default %q", v.Default))
+			}
+			if v.Secret {
+				details = append(details, "secret")
+			}
+
+			line := "    " + v.Name
+			if len(details) > 0 {
+				line += " (" + strings.Join(details, ", ") + ")"
+			}
+			if v.Prompt != "" {
+				line += ": " + strings.TrimSpace(v.Prompt)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	fmt.Fprintln(w, "\n  Detection:")
+	if len(r.ProcessMatch) == 0 && r.PreInstall.RequireAtDiscovery == "" && len(r.PreInstall.Prerequisites) == 0 {
+		fmt.Fprintln(w, "    applies to any host of its targets")
+	}
+	if len(r.ProcessMatch) > 0 {
+		fmt.Fprintf(w, "    processes matching: %s\n", strings.Join(r.ProcessMatch, ", "))
+	}
+	if script := strings.TrimSpace(r.PreInstall.RequireAtDiscovery); script != "" {
+		fmt.Fprintln(w, "    when this script succeeds:")
+		describeBlock(w, script, "      ")
+	}
+	for _, p := range r.PreInstall.Prerequisites {
+		fmt.Fprintf(w, "    prerequisite %s: %s\n", p.Name, strings.TrimSpace(p.Check))
+	}
+
+	tasks := getRecipeTasks(r)
+	if len(tasks) > 0 {
+		fmt.Fprintln(w, "\n  Tasks:")
+		for _, t := range tasks {
+			fmt.Fprintf(w, "    %s\n", t.Name)
+			for _, c := range t.Cmds {
+				describeBlock(w, c, "      ")
+			}
+		}
+	}
+
+	if r.ValidationNRQL != "" {
+		fmt.Fprintln(w, "\n  Validation query:")
+		describeBlock(w, string(r.ValidationNRQL), "    ")
+	}
+
+	fmt.Fprintln(w)
+}
+
+func describeField(w io.Writer, name string, value string) {
+	if value != "" {
+		fmt.Fprintf(w, "  %-11s %s\n", name+":", value)
+	}
+}
+
+func describeBlock(w io.Writer, block string, indent string) {
+	for _, line := range strings.Split(strings.TrimSpace(block), "\n") {
+		fmt.Fprintf(w, "%s%s\n", indent, strings.TrimRight(line, " \t"))
+	}
+}
+
+func recipeDisplayName(r *types.OpenInstallationRecipe) string {
+	if r.DisplayName != "" {
+		return r.DisplayName
+	}
+
+	return r.Name
+}
+
+// getRecipeTasks returns the tasks of a recipe's install taskfile, in file order, with the commands
+// each one runs. Commands that call another task are shown as the name of that task.
+func getRecipeTasks(r *types.OpenInstallationRecipe) []recipeTask {
+	tasks := []recipeTask{}
+
+	var taskFile struct {
+		Tasks yaml.MapSlice `yaml:"tasks"`
+	}
+
+	if err := yaml.Unmarshal([]byte(r.Install), &taskFile); err != nil {
+		log.Debugf("could not parse tasks for recipe %s: %s", r.Name, err)
+		return tasks
+	}
+
+	for _, t := range taskFile.Tasks {
+		name, ok := t.Key.(string)
+		if !ok {
+			continue
+		}
+
+		task := recipeTask{Name: name, Cmds: []string{}}
+
+		var def struct {
+			Cmds []interface{} `yaml:"cmds"`
+		}
+		if data, err := yaml.Marshal(t.Value); err == nil {
+			if err := yaml.Unmarshal(data, &def); err != nil {
+				log.Debugf("could not parse task %s of recipe %s: %s", name, r.Name, err)
+			}
+		}
+
+		for _, c := range def.Cmds {
+			switch cmd := c.(type) {
+			case string:
+				task.Cmds = append(task.Cmds, cmd)
+			case map[interface{}]interface{}:
+				if v, ok := cmd["task"]; ok {
+					task.Cmds = append(task.Cmds, fmt.Sprintf("task: %v", v))
+				} else if v, ok := cmd["cmd"]; ok {
+					task.Cmds = append(task.Cmds, fmt.Sprintf("%v", v))
+				}
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func init() {
+	Command.AddCommand(cmdDescribe)
+}
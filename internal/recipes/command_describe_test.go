@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+const testDescribeRecipe = `
+name: mysql-open-source-integration
+displayName: MySQL Integration
+description: |
+  New Relic install recipe
+  for the MySQL integration
+stability: stable
+installTargets:
+  - type: host
+    os: linux
+    platform: ubuntu
+keywords:
+  - database
+dependencies:
+  - infrastructure-agent-installer
+processMatch:
+  - mysqld
+preInstall:
+  requireAtDiscovery: |
+    command -v mysql
+inputVars:
+  - name: NR_CLI_DB_USERNAME
+    prompt: MySQL Username
+    default: newrelic
+  - name: NR_CLI_DB_PASSWORD
+    prompt: MySQL Password
+    secret: true
+install:
+  version: "3"
+  tasks:
+    default:
+      cmds:
+        - task: setup
+    setup:
+      cmds:
+        - echo setup
+        - cmd: echo done
+validationNrql: "SELECT count(*) from MysqlSample"
+`
+
+func TestGetRecipeTasks(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	tasks := getRecipeTasks(r)
+	require.Equal(t, []recipeTask{
+		{Name: "default", Cmds: []string{"task: setup"}},
+		{Name: "setup", Cmds: []string{"echo setup", "echo done"}},
+	}, tasks)
+}
+
+func TestDescribeRecipe(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	describeRecipe(&out, r)
+
+	s := out.String()
+	require.Contains(t, s, "MySQL Integration (mysql-open-source-integration)")
+	require.Contains(t, s, "New Relic install recipe for the MySQL integration")
+	require.Contains(t, s, "targets:    linux/ubuntu")
+	require.Contains(t, s, "depends on: infrastructure-agent-installer")
+	require.Contains(t, s, `NR_CLI_DB_USERNAME (default "newrelic"): MySQL Username`)
+	require.Contains(t, s, "NR_CLI_DB_PASSWORD (secret): MySQL Password")
+	require.Contains(t, s, "processes matching: mysqld")
+	require.Contains(t, s, "command -v mysql")
+	require.Contains(t, s, "    setup\n      echo setup\n      echo done\n")
+	require.Contains(t, s, "SELECT count(*) from MysqlSample")
+}
@@ -0,0 +1,101 @@
+package recipes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+)
+
+var cmdLint = &cobra.Command{
+	Use:   "lint <path>...",
+	Short: "Check recipe files for mistakes before contributing them",
+	Long: `Check recipe files for mistakes before contributing them
+
+Lints the recipe files given, or the .yml and .yaml files of the directories
+given, for what would keep the CLI from installing them: missing, misspelled
+and mistyped fields, template variables that aren't input variables or task
+file variables, a missing validation NRQL query, and task file contents that
+go-task can't run. Each issue is reported with the field it was found at.
+`,
+	Example: `newrelic recipes lint recipes/newrelic/infrastructure`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := findRecipeFiles(args)
+		if err != nil {
+			return err
+		}
+
+		count := lintRecipeFiles(os.Stdout, files)
+		if count > 0 {
+			return fmt.Errorf("found %d issues in %d recipe files", count, len(files))
+		}
+
+		fmt.Printf("No issues found in %d recipe files.\n", len(files))
+		return nil
+	},
+}
+
+// findRecipeFiles returns the given files, and the recipe files of the given directories.
+func findRecipeFiles(paths []string) ([]string, error) {
+	files := []string{}
+
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+
+		err = filepath.Walk(p, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			ext := strings.ToLower(filepath.Ext(path))
+			if !info.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// lintRecipeFiles prints the issues of each recipe file, returning how many were found.
+func lintRecipeFiles(w io.Writer, files []string) int {
+	count := 0
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %s\n", f, err)
+			count++
+			continue
+		}
+
+		for _, issue := range recipes.LintRecipe(data) {
+			fmt.Fprintf(w, "%s: %s\n", f, issue)
+			count++
+		}
+	}
+
+	return count
+}
+
+func init() {
+	Command.AddCommand(cmdLint)
+}
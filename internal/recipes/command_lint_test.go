@@ -0,0 +1,31 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRecipeFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "valid.yml"), []byte(testDescribeRecipe), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("name: invalid\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# recipes\n"), 0600))
+
+	files, err := findRecipeFiles([]string{dir})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	var out bytes.Buffer
+	count := lintRecipeFiles(&out, files)
+
+	require.Equal(t, 5, count)
+	require.Contains(t, out.String(), filepath.Join(dir, "invalid.yaml")+": install: missing required field")
+	require.NotContains(t, out.String(), "valid.yml")
+}
@@ -0,0 +1,243 @@
+package recipes
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	newName   string
+	newOS     string
+	newOutput string
+)
+
+// recipeTemplate is the skeleton of a new recipe. It's delimited with [[ ]], since the recipe
+// itself is templated with {{ }}.
+const recipeTemplate = `# Generated by newrelic recipes new. Replace the TODOs, then check the recipe with
+# newrelic recipes lint and newrelic recipes test before contributing it.
+name: [[.Name]]
+displayName: [[.DisplayName]]
+description: New Relic install recipe for the [[.DisplayName]]
+repository: https://github.com/newrelic/[[.Name]]
+
+installTargets:
+  - type: host
+    os: [[.OS]]
+
+keywords:
+  - Integration
+  - [[.Keyword]]
+
+# The integration reports to the infrastructure agent, which is installed first.
+dependencies:
+  - infrastructure-agent-installer
+
+# Detection: the recipe is recommended when one of these processes is running.
+processMatch:
+  - [[.Keyword]] # TODO: the name of the process the integration monitors
+
+logMatch:
+  - name: [[.Keyword]]
+    file: [[.LogFile]] # TODO: the log files of the monitored service
+
+inputVars:
+  - name: NR_CLI_[[.VarPrefix]]_PORT
+    prompt: Port the [[.Keyword]] service listens on
+    default: "8080" # TODO: the default port of the monitored service
+
+validationNrql: "SELECT count(*) FROM [[.SampleName]] WHERE hostname LIKE '{HOSTNAME}%' SINCE 10 minutes ago" # TODO: the event type the integration reports
+
+preInstall:
+  # Detection: the recipe only applies when this script succeeds.
+  requireAtDiscovery: |
+[[- if eq .OS "windows"]]
+    powershell.exe -NoProfile -Command "if (-not (Get-Service -Name '[[.Keyword]]' -ErrorAction SilentlyContinue)) { exit 1 }"
+[[- else]]
+    command -v [[.Keyword]] >/dev/null 2>&1 || exit 1
+[[- end]]
+
+install:
+  version: "3"
+  silent: true
+
+  tasks:
+    default:
+      cmds:
+        - task: assert_pre_req
+        - task: setup
+        - task: restart
+
+    assert_pre_req:
+      cmds:
+[[- if eq .OS "windows"]]
+        - |
+          {{.POWERSHELL}} -Command 'if (-not (Get-Service -Name "newrelic-infra" -ErrorAction SilentlyContinue)) { Write-Host "The infrastructure agent is required to install this integration"; exit 1 }'
+[[- else]]
+        - |
+          if [ ! -f /etc/newrelic-infra.yml ] && [ ! -f /usr/local/etc/newrelic-infra/newrelic-infra.yml ]; then
+            echo "The infrastructure agent is required to install this integration" >&2
+            exit 1
+          fi
+[[- end]]
+
+    setup:
+      cmds:
+[[- if eq .OS "windows"]]
+        - |
+          {{.POWERSHELL}} -Command '& { $ErrorActionPreference = "Stop"; Invoke-WebRequest "{{.NEW_RELIC_DOWNLOAD_URL}}infrastructure_agent/windows/integrations/[[.Name]]/[[.Name]]-amd64.msi" -OutFile "$env:TEMP\[[.Name]].msi"; Start-Process msiexec.exe -Wait -ArgumentList "/qn /i $env:TEMP\[[.Name]].msi" }' # TODO: the package of the integration
+        - |
+          {{.POWERSHELL}} -Command 'Set-Content -Path "C:\Program Files\New Relic\newrelic-infra\integrations.d\[[.Keyword]]-config.yml" -Value @("integrations:", "  - name: [[.Name]]", "    env:", "      PORT: {{.NR_CLI_[[.VarPrefix]]_PORT}}")'
+[[- else if eq .OS "darwin"]]
+        - |
+          {{.HOMEBREW}} install [[.Name]] # TODO: the package of the integration
+        - |
+          mkdir -p /usr/local/etc/newrelic-infra/integrations.d
+          cat > /usr/local/etc/newrelic-infra/integrations.d/[[.Keyword]]-config.yml <<EOF
+          integrations:
+            - name: [[.Name]]
+              env:
+                PORT: {{.NR_CLI_[[.VarPrefix]]_PORT}}
+          EOF
+[[- else]]
+        - |
+          # TODO: the package of the integration
+          if command -v apt-get >/dev/null 2>&1; then
+            sudo apt-get install -y [[.Name]]
+          elif command -v zypper >/dev/null 2>&1; then
+            sudo zypper -n install [[.Name]]
+          else
+            sudo yum install -y [[.Name]]
+          fi
+        - |
+          sudo mkdir -p /etc/newrelic-infra/integrations.d
+          sudo tee /etc/newrelic-infra/integrations.d/[[.Keyword]]-config.yml > /dev/null <<EOF
+          integrations:
+            - name: [[.Name]]
+              env:
+                PORT: {{.NR_CLI_[[.VarPrefix]]_PORT}}
+          EOF
+[[- end]]
+
+    restart:
+      cmds:
+[[- if eq .OS "windows"]]
+        - |
+          {{.POWERSHELL}} -Command 'Restart-Service newrelic-infra'
+[[- else if eq .OS "darwin"]]
+        - |
+          {{.HOMEBREW}} services restart newrelic-infra-agent
+[[- else]]
+        - |
+          if command -v systemctl >/dev/null 2>&1; then
+            sudo systemctl restart newrelic-infra
+          else
+            sudo service newrelic-infra restart
+          fi
+[[- end]]
+
+postInstall:
+  info: |2
+      The [[.DisplayName]] configuration file can be found in [[.ConfigDir]]/[[.Keyword]]-config.yml
+`
+
+var recipeTemplateOSes = []string{"linux", "darwin", "windows"}
+
+var cmdNew = &cobra.Command{
+	Use:   "new",
+	Short: "Create the skeleton of a new recipe",
+	Long: `Create the skeleton of a new recipe
+
+Writes a recipe file for an on-host integration that targets the OS, with its
+detection criteria, log matching, install tasks and a validation NRQL query to
+start from. The parts to fill in are marked with TODO. The file is written to
+<name>.yml in the current directory, unless --output is set.
+`,
+	Example: `newrelic recipes new --name nri-foo --targetOs linux`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := newRecipeSkeleton(newName, newOS)
+		if err != nil {
+			return err
+		}
+
+		path := newOutput
+		if path == "" {
+			path = newName + ".yml"
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+
+		fmt.Printf("Created recipe %s in %s.\n", newName, path)
+		return nil
+	},
+}
+
+// newRecipeSkeleton returns the skeleton of a recipe of the given name that targets the OS.
+func newRecipeSkeleton(name string, targetOS string) ([]byte, error) {
+	targetOS = strings.ToLower(targetOS)
+	if !utils.StringInSlice(targetOS, recipeTemplateOSes) {
+		return nil, fmt.Errorf("unsupported target OS %q, expected one of %s", targetOS, strings.Join(recipeTemplateOSes, ", "))
+	}
+
+	if name == "" || strings.ContainsAny(name, " \t/\\") || strings.ToLower(name) != name {
+		return nil, fmt.Errorf("invalid recipe name %q, expected lowercase letters, numbers and dashes, such as nri-foo", name)
+	}
+
+	keyword := strings.TrimPrefix(name, "nri-")
+	words := strings.FieldsFunc(keyword, func(r rune) bool { return r == '-' || r == '_' || r == '.' })
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+
+	data := map[string]string{
+		"Name":        name,
+		"OS":          targetOS,
+		"Keyword":     keyword,
+		"DisplayName": strings.Join(words, " ") + " Integration",
+		"SampleName":  strings.Join(words, "") + "Sample",
+		"VarPrefix":   strings.ToUpper(strings.Join(words, "_")),
+		"LogFile":     fmt.Sprintf("/var/log/%s/*.log", keyword),
+		"ConfigDir":   "/etc/newrelic-infra/integrations.d",
+	}
+
+	switch targetOS {
+	case "windows":
+		data["LogFile"] = fmt.Sprintf(`C:\ProgramData\%s\logs\*.log`, keyword)
+		data["ConfigDir"] = `C:\Program Files\New Relic\newrelic-infra\integrations.d`
+	case "darwin":
+		data["LogFile"] = fmt.Sprintf("/usr/local/var/log/%s/*.log", keyword)
+		data["ConfigDir"] = "/usr/local/etc/newrelic-infra/integrations.d"
+	}
+
+	tpl, err := template.New("recipe").Delims("[[", "]]").Parse(recipeTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tpl.Execute(&b, data); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func init() {
+	Command.AddCommand(cmdNew)
+	cmdNew.Flags().StringVarP(&newName, "name", "n", "", "the name of the recipe, such as nri-foo")
+	cmdNew.Flags().StringVarP(&newOS, "targetOs", "", "linux", "the OS the recipe installs onto, one of linux, darwin or windows")
+	cmdNew.Flags().StringVarP(&newOutput, "output", "o", "", "the path to write the recipe to, instead of <name>.yml")
+	utils.LogIfError(cmdNew.MarkFlagRequired("name"))
+}
@@ -0,0 +1,48 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestNewRecipeSkeletonShouldLint(t *testing.T) {
+	for _, targetOS := range recipeTemplateOSes {
+		t.Run(targetOS, func(t *testing.T) {
+			data, err := newRecipeSkeleton("nri-foo-bar", targetOS)
+			require.NoError(t, err)
+			require.Empty(t, recipes.LintRecipe(data))
+
+			r, err := recipes.NewRecipeFile(string(data))
+			require.NoError(t, err)
+			require.Equal(t, "nri-foo-bar", r.Name)
+			require.Equal(t, "Foo Bar Integration", r.DisplayName)
+			require.Equal(t, types.OpenInstallationOperatingSystem(targetOS), r.InstallTargets[0].Os)
+			require.Equal(t, []string{"foo-bar"}, r.ProcessMatch)
+			require.Equal(t, "NR_CLI_FOO_BAR_PORT", r.InputVars[0].Name)
+			require.Contains(t, string(r.ValidationNRQL), "FROM FooBarSample")
+
+			script, err := execution.RenderRecipe(*r, types.RecipeVars{"NR_CLI_FOO_BAR_PORT": "9090"})
+			require.NoError(t, err)
+			require.Contains(t, script, "PORT: 9090")
+		})
+	}
+}
+
+func TestNewRecipeSkeletonShouldRejectInvalidInput(t *testing.T) {
+	_, err := newRecipeSkeleton("nri-foo", "solaris")
+	require.Error(t, err)
+
+	_, err = newRecipeSkeleton("NRI Foo", "linux")
+	require.Error(t, err)
+
+	_, err = newRecipeSkeleton("", "linux")
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package recipes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var cmdSearch = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Search the recipes that can be installed",
+	Long: `Search the recipes that can be installed
+
+Lists the recipes whose name, display name, description or keywords contain the
+term, with the OS and platforms each one supports. The name of a recipe is the
+value to install it with, using --recipe.
+`,
+	Example: `newrelic recipes search mysql`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := newRecipeFetcher().FetchRecipes(utils.SignalCtx)
+		if err != nil {
+			return fmt.Errorf("could not fetch recipes: %w", err)
+		}
+
+		matches := searchRecipes(all, args[0])
+		if len(matches) == 0 {
+			fmt.Printf("No recipes match %q.\n", args[0])
+			return nil
+		}
+
+		printRecipes(os.Stdout, matches)
+		return nil
+	},
+}
+
+// searchRecipes returns the recipes whose name, display name, description or keywords contain the
+// term, ignoring case, sorted by name.
+func searchRecipes(all []*types.OpenInstallationRecipe, term string) []*types.OpenInstallationRecipe {
+	term = strings.ToLower(strings.TrimSpace(term))
+	matches := []*types.OpenInstallationRecipe{}
+
+	for _, r := range all {
+		fields := append([]string{r.Name, r.DisplayName, r.Description}, r.Keywords...)
+		for _, f := range fields {
+			if strings.Contains(strings.ToLower(f), term) {
+				matches = append(matches, r)
+				break
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
+
+func printRecipes(w io.Writer, matches []*types.OpenInstallationRecipe) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleLight)
+	if ux.ASCIIMode {
+		t.SetStyle(table.StyleDefault)
+	}
+	t.AppendHeader(table.Row{"Name", "Display name", "Targets", "Description"})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 4, WidthMax: 60},
+	})
+
+	for _, r := range matches {
+		description := strings.Join(strings.Fields(r.Description), " ")
+		t.AppendRow(table.Row{r.Name, r.DisplayName, r.InstallTargetsString(), description})
+	}
+
+	t.Render()
+}
+
+func init() {
+	Command.AddCommand(cmdSearch)
+}
@@ -0,0 +1,52 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+)
+
+func TestSearchRecipes(t *testing.T) {
+	all := []*types.OpenInstallationRecipe{
+		{Name: "mysql-open-source-integration", DisplayName: "MySQL Integration"},
+		{Name: "infrastructure-agent-installer", DisplayName: "Infrastructure Agent"},
+		{Name: "apache-open-source-integration", Description: "Monitors the Apache HTTP server", Keywords: []string{"httpd"}},
+		{Name: "mariadb-open-source-integration", Keywords: []string{"mysql"}},
+	}
+
+	names := func(recipes []*types.OpenInstallationRecipe) []string {
+		out := []string{}
+		for _, r := range recipes {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+
+	require.Equal(t, []string{"mariadb-open-source-integration", "mysql-open-source-integration"}, names(searchRecipes(all, "MySQL")))
+	require.Equal(t, []string{"apache-open-source-integration"}, names(searchRecipes(all, "http")))
+	require.Equal(t, []string{"infrastructure-agent-installer"}, names(searchRecipes(all, " agent ")))
+	require.Empty(t, searchRecipes(all, "redis"))
+}
+
+func TestPrintRecipesShowsTargets(t *testing.T) {
+	r := &types.OpenInstallationRecipe{
+		Name:        "mysql-open-source-integration",
+		DisplayName: "MySQL Integration",
+		InstallTargets: []types.OpenInstallationRecipeInstallTarget{
+			{Os: types.OpenInstallationOperatingSystemTypes.LINUX, Platform: types.OpenInstallationPlatformTypes.UBUNTU},
+			{Os: types.OpenInstallationOperatingSystemTypes.WINDOWS},
+		},
+	}
+
+	var out bytes.Buffer
+	printRecipes(&out, []*types.OpenInstallationRecipe{r})
+
+	require.Contains(t, out.String(), "mysql-open-source-integration")
+	require.Contains(t, out.String(), "linux/ubuntu, windows")
+}
@@ -0,0 +1,51 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/newrelic/newrelic-cli/internal/testcobra"
+)
+
+func TestRecipesCommand(t *testing.T) {
+	assert.Equal(t, "recipes", Command.Name())
+}
+
+func TestSearchCommand(t *testing.T) {
+	assert.Equal(t, "search", cmdSearch.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdSearch)
+	testcobra.CheckCobraRequiredFlags(t, cmdSearch, []string{})
+}
+
+func TestDescribeCommand(t *testing.T) {
+	assert.Equal(t, "describe", cmdDescribe.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdDescribe)
+	testcobra.CheckCobraRequiredFlags(t, cmdDescribe, []string{})
+}
+
+func TestLintCommand(t *testing.T) {
+	assert.Equal(t, "lint", cmdLint.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdLint)
+	testcobra.CheckCobraRequiredFlags(t, cmdLint, []string{})
+}
+
+func TestTestCommand(t *testing.T) {
+	assert.Equal(t, "test", cmdTest.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdTest)
+	testcobra.CheckCobraRequiredFlags(t, cmdTest, []string{"image"})
+}
+
+func TestNewCommand(t *testing.T) {
+	assert.Equal(t, "new", cmdNew.Name())
+
+	testcobra.CheckCobraMetadata(t, cmdNew)
+	testcobra.CheckCobraRequiredFlags(t, cmdNew, []string{"name"})
+}
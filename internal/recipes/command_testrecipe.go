@@ -0,0 +1,129 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/newrelic/newrelic-cli/internal/install/discovery"
+	"github.com/newrelic/newrelic-cli/internal/install/execution"
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+	"github.com/newrelic/newrelic-cli/internal/install/types"
+	"github.com/newrelic/newrelic-cli/internal/install/ux"
+	"github.com/newrelic/newrelic-cli/internal/utils"
+)
+
+var (
+	testImage string
+	keepTest  bool
+	testVars  []string
+)
+
+var cmdTest = &cobra.Command{
+	Use:   "test <recipe.yml>",
+	Short: "Test a recipe file by installing it in a Docker container",
+	Long: `Test a recipe file by installing it in a Docker container
+
+Starts a container of the image, checks that the recipe targets the container's
+platform and that its requireAtDiscovery script passes, then runs its install
+tasks in the container with the same executor used to install onto remote
+hosts, with the variables an unattended install would use. Variables without a default
+can be set with --var. The container is
+removed once the test completes, unless --keep is set. Data isn't validated,
+since the container isn't connected to a New Relic account.
+`,
+	Example: `newrelic recipes test mysql.yml --image ubuntu:22.04`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		r, err := recipes.NewRecipeFile(string(data))
+		if err != nil {
+			return fmt.Errorf("could not parse recipe %s: %w", args[0], err)
+		}
+
+		recipeVars, err := execution.ParseRecipeVars(testVars)
+		if err != nil {
+			return err
+		}
+
+		ctx := utils.SignalCtx
+		container, err := remote.StartDockerContainer(ctx, testImage)
+		if err != nil {
+			return err
+		}
+
+		if keepTest {
+			id := container.Target().Host
+			defer fmt.Printf("Kept container %s, remove it with: docker rm --force %s\n", id, id)
+		} else {
+			defer func() {
+				if err := container.Remove(context.Background()); err != nil {
+					log.Warn(err)
+				}
+			}()
+		}
+
+		start := time.Now()
+		if err := runRecipeTest(ctx, container, r, recipeVars, os.Stdout); err != nil {
+			fmt.Printf("%s %s failed on %s after %s\n", ux.IconError, r.Name, testImage, time.Since(start).Round(time.Second))
+			return err
+		}
+
+		fmt.Printf("%s %s passed on %s in %s\n", ux.IconSuccess, r.Name, testImage, time.Since(start).Round(time.Second))
+		return nil
+	},
+}
+
+// runRecipeTest installs the recipe with the client the way an unattended install onto the host
+// of the client would, with the given variables, returning why the recipe couldn't be installed.
+func runRecipeTest(ctx context.Context, client remote.Client, r *types.OpenInstallationRecipe, recipeVars map[string]string, stdout io.Writer) error {
+	m, err := discovery.NewSSHDiscoverer(client).Discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	repo := recipes.NewRecipeRepository(func() ([]*types.OpenInstallationRecipe, error) {
+		return []*types.OpenInstallationRecipe{r}, nil
+	}, m)
+	if repo.FindRecipeByName(r.Name) == nil {
+		return fmt.Errorf("recipe %s does not target %s/%s %s", r.Name, m.OS, m.Platform, m.PlatformVersion)
+	}
+
+	vars, err := execution.NewRecipeVarProvider().
+		WithResolver(execution.NewRecipeVarResolver(recipeVars, nil)).
+		Prepare(*m, *r, true)
+	if err != nil {
+		return fmt.Errorf("could not prepare the variables of recipe %s: %w", r.Name, err)
+	}
+
+	e := execution.NewSSHRecipeExecutor(client)
+	e.Stdout = stdout
+
+	if err := e.ExecutePreInstall(ctx, *r, vars); err != nil {
+		return fmt.Errorf("the requireAtDiscovery script of recipe %s failed: %w", r.Name, err)
+	}
+
+	if err := e.Execute(ctx, *r, vars); err != nil {
+		return fmt.Errorf("recipe %s failed: %w", r.Name, err)
+	}
+
+	return nil
+}
+
+func init() {
+	Command.AddCommand(cmdTest)
+	cmdTest.Flags().StringVarP(&testImage, "image", "i", "", "the Docker image to test the recipe in, such as ubuntu:22.04")
+	cmdTest.Flags().BoolVarP(&keepTest, "keep", "", false, "keep the container once the test completes, to inspect it")
+	cmdTest.Flags().StringArrayVarP(&testVars, "var", "", []string{}, "a recipe variable to set, can be multiple. Example: --var MYSQL_PORT=3306 --var MYSQL_USERNAME=newrelic")
+	utils.LogIfError(cmdTest.MarkFlagRequired("image"))
+}
@@ -0,0 +1,91 @@
+//go:build unit
+// +build unit
+
+package recipes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/newrelic/newrelic-cli/internal/install/recipes"
+	"github.com/newrelic/newrelic-cli/internal/install/remote"
+)
+
+const testUbuntuDiscovery = `HOSTNAME=3f4e5a6b7c8d
+KERNEL_NAME=Linux
+KERNEL_ARCH=x86_64
+KERNEL_VERSION=5.15.0
+ID=ubuntu
+VERSION_ID="22.04"
+`
+
+var testRecipeVars = map[string]string{"NR_CLI_DB_PASSWORD": "secret"}
+
+func newTestRecipeClient() *remote.MockClient {
+	c := remote.NewMockClient()
+	c.Outputs["uname -s"] = testUbuntuDiscovery
+	return c
+}
+
+func TestRunRecipeTest(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	c := newTestRecipeClient()
+	err = runRecipeTest(context.Background(), c, r, testRecipeVars, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	scripts := strings.Join(c.Scripts, "\n")
+	require.Contains(t, scripts, "command -v mysql")
+	require.Contains(t, scripts, "echo setup")
+	require.Contains(t, scripts, "echo done")
+}
+
+func TestRunRecipeTestRequiresVars(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	err = runRecipeTest(context.Background(), newTestRecipeClient(), r, map[string]string{}, &bytes.Buffer{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NR_CLI_DB_PASSWORD")
+}
+
+func TestRunRecipeTestUnsupportedPlatform(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	c := remote.NewMockClient()
+	c.Outputs["uname -s"] = strings.Replace(testUbuntuDiscovery, "ID=ubuntu", "ID=centos", 1)
+
+	err = runRecipeTest(context.Background(), c, r, testRecipeVars, &bytes.Buffer{})
+	require.EqualError(t, err, "recipe mysql-open-source-integration does not target linux/centos 22.04")
+}
+
+func TestRunRecipeTestFailedDiscoveryScript(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	c := newTestRecipeClient()
+	c.Errors["command -v mysql"] = errors.New("exit status 1")
+
+	err = runRecipeTest(context.Background(), c, r, testRecipeVars, &bytes.Buffer{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the requireAtDiscovery script of recipe mysql-open-source-integration failed")
+}
+
+func TestRunRecipeTestFailedInstall(t *testing.T) {
+	r, err := recipes.NewRecipeFile(testDescribeRecipe)
+	require.NoError(t, err)
+
+	c := newTestRecipeClient()
+	c.Errors["echo setup"] = errors.New("exit status 1")
+
+	err = runRecipeTest(context.Background(), c, r, testRecipeVars, &bytes.Buffer{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "recipe mysql-open-source-integration failed")
+}
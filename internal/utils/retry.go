@@ -2,6 +2,7 @@ package utils
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	nrErrors "github.com/newrelic/newrelic-client-go/v2/pkg/errors"
@@ -26,6 +27,10 @@ type Retry struct {
 	MaxRetries   int
 	retryDelayMs int
 	RetryFunc    func() error
+	// IsRetryable, when set, stops the retries as soon as it returns false for an error.
+	IsRetryable       func(error) bool
+	backoffMultiplier int
+	jitter            bool
 }
 
 func NewRetry(maxRetries int, retryDelayMs int, retryFunc func() error) *Retry {
@@ -36,6 +41,34 @@ func NewRetry(maxRetries int, retryDelayMs int, retryFunc func() error) *Retry {
 	}
 }
 
+// WithBackoff multiplies the delay between retries by the given multiplier after each failed attempt.
+func (r *Retry) WithBackoff(multiplier int) *Retry {
+	r.backoffMultiplier = multiplier
+	return r
+}
+
+// WithJitter waits a random delay of between half and all of the delay between retries, so
+// clients that failed together don't all retry at the same time.
+func (r *Retry) WithJitter() *Retry {
+	r.jitter = true
+	return r
+}
+
+func (r *Retry) delayMs(retryCount int) int {
+	delay := r.retryDelayMs
+	if r.backoffMultiplier > 1 {
+		for i := 1; i < retryCount; i++ {
+			delay *= r.backoffMultiplier
+		}
+	}
+
+	if r.jitter && delay > 1 {
+		delay = delay/2 + rand.Intn(delay-delay/2+1)
+	}
+
+	return delay
+}
+
 func (r *Retry) ExecWithRetries(ctx context.Context) *RetryContext {
 	retryCtx := RetryContext{}
 	for !retryCtx.Success {
@@ -49,14 +82,14 @@ func (r *Retry) ExecWithRetries(ctx context.Context) *RetryContext {
 
 			retryCtx.Errors = append(retryCtx.Errors, err)
 
-			if retryCtx.RetryCount == r.MaxRetries {
+			if retryCtx.RetryCount == r.MaxRetries || (r.IsRetryable != nil && !r.IsRetryable(err)) {
 				retryCtx.Success = false
 				return &retryCtx
 			}
 
 			w := make(chan struct{}, 1)
 			go func() {
-				time.Sleep(time.Duration(r.retryDelayMs) * time.Millisecond)
+				time.Sleep(time.Duration(r.delayMs(retryCtx.RetryCount)) * time.Millisecond)
 				w <- struct{}{}
 			}()
 
@@ -67,6 +67,34 @@ func TestShouldNotRetryPaymentRequiredError(t *testing.T) {
 	require.Equal(t, 1, len(ctx.Errors))
 }
 
+func TestShouldNotRetryWhenErrorIsNotRetryable(t *testing.T) {
+	m := MockFunc{}
+	r := NewRetry(3, 0, m.testErrorFunc)
+	r.IsRetryable = func(err error) bool { return false }
+	ctx := r.ExecWithRetries(context.Background())
+	require.Equal(t, 1, m.CallCount)
+	require.False(t, ctx.Success)
+	require.Equal(t, 1, len(ctx.Errors))
+}
+
+func TestShouldIncreaseDelayWithBackoff(t *testing.T) {
+	r := NewRetry(4, 100, func() error { return nil }).WithBackoff(2)
+	require.Equal(t, 100, r.delayMs(1))
+	require.Equal(t, 200, r.delayMs(2))
+	require.Equal(t, 400, r.delayMs(3))
+
+	r = NewRetry(4, 100, func() error { return nil })
+	require.Equal(t, 100, r.delayMs(3))
+}
+
+func TestShouldRandomizeDelayWithJitter(t *testing.T) {
+	r := NewRetry(4, 100, func() error { return nil }).WithBackoff(2).WithJitter()
+	for i := 0; i < 20; i++ {
+		require.GreaterOrEqual(t, r.delayMs(3), 200)
+		require.LessOrEqual(t, r.delayMs(3), 400)
+	}
+}
+
 type MockFunc struct {
 	CallCount          int
 	CallsBeforeSuccess int